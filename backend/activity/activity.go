@@ -0,0 +1,286 @@
+// Package activity streams the ownership ledger live, for feeds and stream overlays.
+//
+// Every ledger insert notifies Channel. Each replica runs a Hub that listens on it,
+// reads the new events once and fans them out to its subscribers, so the stream
+// works no matter which replica recorded the event.
+package activity
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/karitham/waifubot/collection"
+)
+
+const (
+	// Channel is notified with the ID of every ownership event inserted.
+	Channel = "ownership_events"
+
+	// PingInterval is how often idle streams are pinged, so proxies keep them open.
+	PingInterval = 30 * time.Second
+
+	// pageSize is how many events are read from the store at once.
+	pageSize = 100
+	// subscriberBuffer is how many events a subscriber can fall behind before it is dropped.
+	subscriberBuffer = 64
+	// retryDelay is how long the hub waits before listening again after losing its connection.
+	retryDelay = 5 * time.Second
+	// lateWindow is how far below the newest event fanned out an event that
+	// committed late is still fanned out.
+	lateWindow = 1000
+)
+
+// ErrTooSlow ends a stream that fell too far behind the live events.
+// Clients resume it from the last event they got.
+var ErrTooSlow = errors.New("activity stream fell behind")
+
+// Event is an ownership event with what a feed shows about it.
+type Event struct {
+	collection.OwnershipEvent
+	CharacterName      string
+	CharacterImage     string
+	CharacterFavorites int
+	FromUsername       string
+	ToUsername         string
+	// GuildIDs are the indexed guilds either user of the event is a member of.
+	GuildIDs []uint64
+}
+
+// Filter narrows a stream down. Zero values match everything.
+type Filter struct {
+	// UserID matches events the user gave or received a character in.
+	UserID collection.UserID
+	// GuildID matches events of the guild's indexed members.
+	GuildID uint64
+}
+
+// Match reports whether e passes f.
+func (f Filter) Match(e Event) bool {
+	if f.UserID != 0 && e.FromUserID != f.UserID && e.ToUserID != f.UserID {
+		return false
+	}
+	if f.GuildID != 0 && !slices.Contains(e.GuildIDs, f.GuildID) {
+		return false
+	}
+	return true
+}
+
+// Store reads the ownership ledger.
+type Store interface {
+	// EventsAfter returns up to limit events matching f with an ID above afterID, oldest first.
+	EventsAfter(ctx context.Context, afterID int64, f Filter, limit int32) ([]Event, error)
+	// LatestEventID returns the ID of the newest event, 0 when there is none.
+	LatestEventID(ctx context.Context) (int64, error)
+}
+
+// Listener delivers database notifications, see storage.DBStore.Listen.
+type Listener interface {
+	Listen(ctx context.Context, channel string, notify func(payload string)) error
+}
+
+// Writer is where Stream sends events.
+type Writer interface {
+	Event(e Event) error
+	// Ping tells the client the stream is still open.
+	Ping() error
+}
+
+// Hub fans the events of the ledger out to streams.
+type Hub struct {
+	store    Store
+	listener Listener
+
+	mu   sync.Mutex
+	subs map[chan Event]Filter
+
+	// last is the newest event the hub fanned out. Only Run writes it, with mu
+	// held so subscribers can read it.
+	last int64
+	// fanned are the events above last-lateWindow the hub fanned out. Only Run
+	// uses it.
+	fanned map[int64]struct{}
+}
+
+// NewHub creates a Hub. Call Run to start fanning events out.
+func NewHub(store Store, listener Listener) *Hub {
+	return &Hub{
+		store:    store,
+		listener: listener,
+		subs:     make(map[chan Event]Filter),
+		fanned:   make(map[int64]struct{}),
+	}
+}
+
+// Run listens for new events until ctx is done, listening again when the connection drops.
+func (h *Hub) Run(ctx context.Context) {
+	for ctx.Err() == nil {
+		last, err := h.store.LatestEventID(ctx)
+		if err == nil {
+			h.mu.Lock()
+			h.last = last
+			h.mu.Unlock()
+			break
+		}
+		slog.Error("error reading the latest ownership event", "error", err)
+		sleep(ctx, retryDelay)
+	}
+
+	for ctx.Err() == nil {
+		err := h.listener.Listen(ctx, Channel, func(payload string) { h.notify(ctx, payload) })
+		if err != nil {
+			slog.Error("error listening for ownership events", "error", err)
+			sleep(ctx, retryDelay)
+		}
+	}
+}
+
+// notify handles a notification. The payload is the ID of the new event, or empty
+// when listening just started and events may have been missed.
+func (h *Hub) notify(ctx context.Context, payload string) {
+	id, _ := strconv.ParseInt(payload, 10, 64)
+	if id != 0 && id <= h.last {
+		// A transaction recording several events notifies once per event, and
+		// the first notification already fanned them all out. IDs are taken in
+		// insert order but committed in any order though, so an event can also
+		// show up after newer ones were fanned out.
+		if _, ok := h.fanned[id]; ok || id <= h.last-lateWindow {
+			return
+		}
+		h.fanOut(ctx, id-1, 1)
+		return
+	}
+
+	for {
+		n := h.fanOut(ctx, h.last, pageSize)
+		if n < pageSize {
+			return
+		}
+	}
+}
+
+// fanOut sends up to limit events after afterID to the subscribers, and returns how many there were.
+func (h *Hub) fanOut(ctx context.Context, afterID int64, limit int32) int32 {
+	events, err := h.store.EventsAfter(ctx, afterID, Filter{}, limit)
+	if err != nil {
+		slog.Error("error reading ownership events", "error", err)
+		return 0
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, e := range events {
+		if _, ok := h.fanned[e.ID]; ok {
+			continue
+		}
+		h.fanned[e.ID] = struct{}{}
+		h.last = max(h.last, e.ID)
+		for ch, f := range h.subs {
+			if !f.Match(e) {
+				continue
+			}
+			select {
+			case ch <- e:
+			default:
+				close(ch)
+				delete(h.subs, ch)
+			}
+		}
+	}
+	for id := range h.fanned {
+		if id <= h.last-lateWindow {
+			delete(h.fanned, id)
+		}
+	}
+	return int32(len(events))
+}
+
+// subscribe returns a channel of the live events matching f, and the newest event
+// fanned out before it. The hub closes the channel when the subscriber falls too
+// far behind.
+func (h *Hub) subscribe(f Filter) (<-chan Event, int64, func()) {
+	ch := make(chan Event, subscriberBuffer)
+
+	h.mu.Lock()
+	h.subs[ch] = f
+	last := h.last
+	h.mu.Unlock()
+
+	return ch, last, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := h.subs[ch]; ok {
+			close(ch)
+			delete(h.subs, ch)
+		}
+	}
+}
+
+// Stream writes the events matching f to w until ctx is done. With an afterID, the
+// events since then are written first, so clients can resume where they left off.
+// An afterID of 0 only streams live events.
+func (h *Hub) Stream(ctx context.Context, f Filter, afterID int64, w Writer) error {
+	// Subscribe before catching up, so no event falls between the two.
+	live, fannedOut, unsubscribe := h.subscribe(f)
+	defer unsubscribe()
+
+	// Events newer than what the hub fanned out are replayed, then arrive live
+	// too, so they are skipped the second time.
+	replayed := make(map[int64]struct{})
+	for afterID > 0 {
+		events, err := h.store.EventsAfter(ctx, afterID, f, pageSize)
+		if err != nil {
+			return err
+		}
+		for _, e := range events {
+			if err := w.Event(e); err != nil {
+				return err
+			}
+			afterID = e.ID
+			if e.ID > fannedOut {
+				replayed[e.ID] = struct{}{}
+			}
+		}
+		if len(events) < pageSize {
+			break
+		}
+	}
+
+	ping := time.NewTicker(PingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ping.C:
+			if err := w.Ping(); err != nil {
+				return err
+			}
+		case e, ok := <-live:
+			if !ok {
+				return ErrTooSlow
+			}
+			if _, ok := replayed[e.ID]; ok {
+				delete(replayed, e.ID)
+				continue
+			}
+			if err := w.Event(e); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+	case <-t.C:
+	}
+}
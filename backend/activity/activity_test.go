@@ -0,0 +1,270 @@
+package activity
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karitham/waifubot/collection"
+)
+
+type fakeStore struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (s *fakeStore) add(events ...Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, events...)
+	slices.SortFunc(s.events, func(a, b Event) int { return cmp.Compare(a.ID, b.ID) })
+}
+
+func (s *fakeStore) EventsAfter(_ context.Context, afterID int64, f Filter, limit int32) ([]Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var out []Event
+	for _, e := range s.events {
+		if e.ID > afterID && f.Match(e) && len(out) < int(limit) {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (s *fakeStore) LatestEventID(context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.events) == 0 {
+		return 0, nil
+	}
+	return s.events[len(s.events)-1].ID, nil
+}
+
+// fakeListener delivers the payloads sent on its channel.
+type fakeListener struct {
+	payloads chan string
+}
+
+func (l *fakeListener) Listen(ctx context.Context, _ string, notify func(string)) error {
+	notify("")
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case p := <-l.payloads:
+			notify(p)
+		}
+	}
+}
+
+// chanWriter hands the streamed events to the test.
+type chanWriter struct {
+	events chan Event
+}
+
+func (w *chanWriter) Event(e Event) error {
+	w.events <- e
+	return nil
+}
+
+func (w *chanWriter) Ping() error { return nil }
+
+func event(id int64, from, to collection.UserID, guilds ...uint64) Event {
+	return Event{
+		OwnershipEvent: collection.OwnershipEvent{ID: id, CharacterID: 100 + id, FromUserID: from, ToUserID: to},
+		GuildIDs:       guilds,
+	}
+}
+
+func ids(t *testing.T, events <-chan Event, n int) []int64 {
+	t.Helper()
+	var got []int64
+	for range n {
+		select {
+		case e := <-events:
+			got = append(got, e.ID)
+		case <-time.After(time.Second):
+			t.Fatalf("got %v, want %d events", got, n)
+		}
+	}
+	return got
+}
+
+func waitSubscribed(t *testing.T, h *Hub, n int) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		return len(h.subs) == n
+	}, time.Second, time.Millisecond)
+}
+
+func TestFilter_Match(t *testing.T) {
+	e := event(1, 10, 20, 7)
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   bool
+	}{
+		{name: "everything", want: true},
+		{name: "giver", filter: Filter{UserID: 10}, want: true},
+		{name: "receiver", filter: Filter{UserID: 20}, want: true},
+		{name: "other user", filter: Filter{UserID: 30}},
+		{name: "guild", filter: Filter{GuildID: 7}, want: true},
+		{name: "other guild", filter: Filter{GuildID: 8}},
+		{name: "user outside guild", filter: Filter{UserID: 10, GuildID: 8}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.Match(e))
+		})
+	}
+}
+
+func TestHub_Live(t *testing.T) {
+	store := &fakeStore{}
+	store.add(event(1, 0, 10))
+	listener := &fakeListener{payloads: make(chan string)}
+	hub := NewHub(store, listener)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	go hub.Run(ctx)
+
+	all := &chanWriter{events: make(chan Event, 8)}
+	user := &chanWriter{events: make(chan Event, 8)}
+	go func() { _ = hub.Stream(ctx, Filter{}, 0, all) }()
+	go func() { _ = hub.Stream(ctx, Filter{UserID: 20}, 0, user) }()
+	waitSubscribed(t, hub, 2)
+
+	store.add(event(2, 0, 10), event(3, 10, 20))
+	listener.payloads <- "3"
+
+	// Event 1 happened before the streams started.
+	assert.Equal(t, []int64{2, 3}, ids(t, all.events, 2))
+	assert.Equal(t, []int64{3}, ids(t, user.events, 1))
+}
+
+func TestHub_LateCommit(t *testing.T) {
+	store := &fakeStore{}
+	store.add(event(1, 0, 10), event(3, 0, 10))
+	hub := NewHub(store, nil)
+	hub.notify(t.Context(), "")
+
+	w := &chanWriter{events: make(chan Event, 8)}
+	go func() { _ = hub.Stream(t.Context(), Filter{}, 0, w) }()
+	waitSubscribed(t, hub, 1)
+
+	store.add(event(2, 0, 10))
+	hub.notify(t.Context(), "2")
+
+	assert.Equal(t, []int64{2}, ids(t, w.events, 1))
+	assert.Equal(t, int64(3), hub.last)
+}
+
+func TestHub_SameTransaction(t *testing.T) {
+	store := &fakeStore{}
+	store.add(event(1, 0, 10))
+	hub := NewHub(store, nil)
+	hub.notify(t.Context(), "")
+
+	w := &chanWriter{events: make(chan Event, 8)}
+	go func() { _ = hub.Stream(t.Context(), Filter{}, 0, w) }()
+	waitSubscribed(t, hub, 1)
+
+	// One commit notifies once per event, after all of them are visible.
+	store.add(event(2, 0, 10), event(3, 10, 20), event(4, 20, 10))
+	for _, id := range []string{"2", "3", "4"} {
+		hub.notify(t.Context(), id)
+	}
+
+	assert.Equal(t, []int64{2, 3, 4}, ids(t, w.events, 3))
+	select {
+	case e := <-w.events:
+		t.Fatalf("event %d was sent twice", e.ID)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestHub_ForgetsOldEvents(t *testing.T) {
+	store := &fakeStore{}
+	store.add(event(1, 0, 10), event(lateWindow+2, 0, 10))
+	hub := NewHub(store, nil)
+	hub.notify(t.Context(), "")
+
+	assert.Equal(t, map[int64]struct{}{lateWindow + 2: {}}, hub.fanned)
+}
+
+func TestHub_Resume(t *testing.T) {
+	store := &fakeStore{}
+	for id := range int64(pageSize + 5) {
+		store.add(event(id+1, 0, 10))
+	}
+	hub := NewHub(store, nil)
+	hub.last = pageSize
+
+	w := &chanWriter{events: make(chan Event, 2*pageSize)}
+	go func() { _ = hub.Stream(t.Context(), Filter{}, 2, w) }()
+
+	want := make([]int64, 0, pageSize+3)
+	for id := range int64(pageSize + 3) {
+		want = append(want, id+3)
+	}
+	assert.Equal(t, want, ids(t, w.events, len(want)))
+
+	// The events replayed are fanned out too, and only sent once.
+	hub.notify(t.Context(), strconv.Itoa(pageSize+5))
+	store.add(event(pageSize+6, 0, 10))
+	hub.notify(t.Context(), strconv.Itoa(pageSize+6))
+
+	assert.Equal(t, []int64{pageSize + 6}, ids(t, w.events, 1))
+}
+
+// blockedWriter holds the first event until release is closed.
+type blockedWriter struct {
+	release chan struct{}
+	once    sync.Once
+}
+
+func (w *blockedWriter) Event(Event) error {
+	w.once.Do(func() { <-w.release })
+	return nil
+}
+
+func (w *blockedWriter) Ping() error { return nil }
+
+func TestHub_TooSlow(t *testing.T) {
+	store := &fakeStore{}
+	hub := NewHub(store, nil)
+
+	w := &blockedWriter{release: make(chan struct{})}
+	errs := make(chan error, 1)
+	go func() { errs <- hub.Stream(t.Context(), Filter{}, 0, w) }()
+	waitSubscribed(t, hub, 1)
+
+	for id := range int64(subscriberBuffer + 2) {
+		store.add(event(id+1, 0, 10))
+	}
+	hub.notify(t.Context(), strconv.Itoa(subscriberBuffer+2))
+	close(w.release)
+
+	select {
+	case err := <-errs:
+		assert.ErrorIs(t, err, ErrTooSlow)
+	case <-time.After(time.Second):
+		t.Fatal("stream didn't end")
+	}
+	waitSubscribed(t, hub, 0)
+}
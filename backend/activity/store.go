@@ -0,0 +1,66 @@
+package activity
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/storage/ledgerstore"
+)
+
+type store struct {
+	q ledgerstore.Querier
+}
+
+func NewStore(q ledgerstore.Querier) Store {
+	return &store{q: q}
+}
+
+func (s *store) EventsAfter(ctx context.Context, afterID int64, f Filter, limit int32) ([]Event, error) {
+	rows, err := s.q.ListAfter(ctx, ledgerstore.ListAfterParams{
+		AfterID: afterID,
+		UserID:  optionalID(f.UserID),
+		GuildID: optionalID(f.GuildID),
+		Lim:     limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]Event, len(rows))
+	for i, r := range rows {
+		guilds := make([]uint64, len(r.GuildIds))
+		for j, g := range r.GuildIds {
+			guilds[j] = uint64(g)
+		}
+		events[i] = Event{
+			OwnershipEvent: collection.OwnershipEvent{
+				ID:          r.ID,
+				CharacterID: r.CharacterID,
+				Kind:        collection.EventKind(r.Kind),
+				FromUserID:  uint64(r.FromUserID.Int64),
+				ToUserID:    uint64(r.ToUserID.Int64),
+				Tokens:      r.Tokens,
+				ReferenceID: r.ReferenceID.Int64,
+				CreatedAt:   r.CreatedAt.Time,
+			},
+			CharacterName:      r.CharacterName,
+			CharacterImage:     r.CharacterImage,
+			CharacterFavorites: int(r.CharacterFavorites),
+			FromUsername:       r.FromUsername,
+			ToUsername:         r.ToUsername,
+			GuildIDs:           guilds,
+		}
+	}
+	return events, nil
+}
+
+func (s *store) LatestEventID(ctx context.Context) (int64, error) {
+	return s.q.LatestEventID(ctx)
+}
+
+// optionalID turns a filter's ID of 0 into NULL.
+func optionalID(id uint64) pgtype.Int8 {
+	return pgtype.Int8{Int64: int64(id), Valid: id != 0}
+}
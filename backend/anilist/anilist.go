@@ -50,12 +50,18 @@ var (
 	_ discord.TrackingService = (*Anilist)(nil)
 )
 
-// New returns a new anilist client
+// New returns a new anilist client with its own default Scheduler.
 func New() *Anilist {
+	return NewWithScheduler(NewScheduler(DefaultSchedulerConfig()))
+}
+
+// NewWithScheduler returns a new anilist client whose requests all wait for s.
+// Requests are interactive unless their context says otherwise, see WithPriority.
+func NewWithScheduler(s *Scheduler) *Anilist {
 	const graphURL = "https://graphql.anilist.co"
 
 	a := &Anilist{
-		c: graphql.NewClient(graphURL, &http.Client{Timeout: 5 * time.Second}),
+		c: graphql.NewClient(graphURL, &scheduledClient{s: s, c: &http.Client{Timeout: 5 * time.Second}}),
 	}
 
 	return a
@@ -130,7 +136,8 @@ func (a *Anilist) Character(ctx context.Context, name string) ([]collection.Medi
 	return resp, nil
 }
 
-// CharactersByIDs fetches multiple characters by their AniList IDs
+// CharactersByIDs fetches multiple characters by their AniList IDs,
+// with up to 25 of their most popular media each.
 func (a *Anilist) CharactersByIDs(ctx context.Context, ids []int64) ([]collection.MediaCharacter, error) {
 	if len(ids) == 0 {
 		return nil, nil
@@ -148,17 +155,36 @@ func (a *Anilist) CharactersByIDs(ctx context.Context, ids []int64) ([]collectio
 
 	result := make([]collection.MediaCharacter, 0, len(resp.Page.Characters))
 	for _, c := range resp.Page.Characters {
+		media := make([]collection.Media, len(c.Media.Nodes))
+		for i, m := range c.Media.Nodes {
+			title := m.Title.Romaji
+			if title == "" {
+				title = m.Title.English
+			}
+			media[i] = collection.Media{
+				ID:            m.Id,
+				Title:         title,
+				CoverImageURL: m.CoverImage.Large,
+				Type:          string(m.Type),
+				Popularity:    int(m.Popularity),
+				MalID:         m.IdMal,
+			}
+		}
+
 		mediaTitle := ""
-		if len(c.Media.Nodes) > 0 {
-			mediaTitle = c.Media.Nodes[0].Title.Romaji
+		if len(media) > 0 {
+			mediaTitle = media[0].Title
 		}
 
 		result = append(result, collection.MediaCharacter{
-			ID:         c.Id,
-			Name:       c.Name.Full,
-			ImageURL:   c.Image.Large,
-			Favorites:  int(c.Favourites),
-			MediaTitle: mediaTitle,
+			ID:          c.Id,
+			Name:        c.Name.Full,
+			ImageURL:    c.Image.Large,
+			Description: c.Description,
+			Favorites:   int(c.Favourites),
+			MediaTitle:  mediaTitle,
+			Aliases:     c.Name.Alternative,
+			Media:       media,
 		})
 	}
 
@@ -0,0 +1,336 @@
+// Package cache keeps AniList answers so repeated searches and autocompletes
+// don't all reach graphql.anilist.co.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/karitham/waifubot/anilist"
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/discord"
+)
+
+// Query is the kind of AniList request an entry answers.
+type Query string
+
+const (
+	QueryAnime           Query = "anime"
+	QueryManga           Query = "manga"
+	QueryUser            Query = "user"
+	QueryCharacter       Query = "character"
+	QuerySearchMedia     Query = "search_media"
+	QueryCharactersByIDs Query = "characters_by_ids"
+)
+
+// Queries lists every Query, for validating configuration.
+var Queries = []Query{
+	QueryAnime,
+	QueryManga,
+	QueryUser,
+	QueryCharacter,
+	QuerySearchMedia,
+	QueryCharactersByIDs,
+}
+
+// DefaultTTLs is how long each kind of answer is fresh. Character batches are
+// not cached: the sync worker asks for them to pick up changes.
+var DefaultTTLs = map[Query]time.Duration{
+	QueryAnime:           24 * time.Hour,
+	QueryManga:           24 * time.Hour,
+	QueryUser:            time.Hour,
+	QueryCharacter:       24 * time.Hour,
+	QuerySearchMedia:     6 * time.Hour,
+	QueryCharactersByIDs: 0,
+}
+
+const (
+	// DefaultSize is how many answers are kept in memory.
+	DefaultSize = 1000
+	// DefaultMaxStale is how long an expired answer can still be served while
+	// AniList is down. Older entries are pruned.
+	DefaultMaxStale = 7 * 24 * time.Hour
+)
+
+// Client is the AniList API the cache sits in front of.
+type Client interface {
+	discord.TrackingService
+	anilist.CharacterFetcher
+}
+
+// Config tunes a Cache.
+type Config struct {
+	// TTLs maps each query to how long its answers are fresh.
+	// A query without a positive TTL always goes to AniList.
+	TTLs     map[Query]time.Duration
+	Size     int
+	MaxStale time.Duration
+}
+
+// DefaultConfig returns the configuration used when nothing is overridden.
+func DefaultConfig() Config {
+	ttls := make(map[Query]time.Duration, len(DefaultTTLs))
+	for q, ttl := range DefaultTTLs {
+		ttls[q] = ttl
+	}
+	return Config{TTLs: ttls, Size: DefaultSize, MaxStale: DefaultMaxStale}
+}
+
+// Entry is a JSON-encoded answer and when it was fetched.
+type Entry struct {
+	Value     []byte
+	FetchedAt time.Time
+}
+
+// Store persists entries so they survive restarts and are shared between instances.
+type Store interface {
+	// Get reports false when there is no entry for the key.
+	Get(ctx context.Context, query Query, key string) (Entry, bool, error)
+	Put(ctx context.Context, query Query, key string, e Entry) error
+	// Prune deletes the entries fetched before before and returns how many there were.
+	Prune(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Cache decorates a Client. Answers are kept in an in-memory LRU in front of
+// Store until their query's TTL passes. Concurrent requests for the same
+// answer and of the same anilist.Priority share a single AniList call, and an
+// expired answer is served when AniList fails.
+type Cache struct {
+	client Client
+	store  Store
+	cfg    Config
+	now    func() time.Time
+
+	mu       sync.Mutex
+	lru      *lru
+	inflight map[string]*call
+}
+
+// call is an AniList request that concurrent lookups wait on.
+type call struct {
+	done  chan struct{}
+	entry Entry
+	err   error
+}
+
+var (
+	_ discord.TrackingService  = (*Cache)(nil)
+	_ anilist.CharacterFetcher = (*Cache)(nil)
+)
+
+// New returns a Cache in front of client.
+func New(client Client, store Store, cfg Config) *Cache {
+	return &Cache{
+		client:   client,
+		store:    store,
+		cfg:      cfg,
+		now:      time.Now,
+		lru:      newLRU(cfg.Size),
+		inflight: make(map[string]*call),
+	}
+}
+
+func (c *Cache) Anime(ctx context.Context, name string) ([]collection.Media, error) {
+	return lookup(ctx, c, QueryAnime, normalize(name), func(ctx context.Context) ([]collection.Media, error) {
+		return c.client.Anime(ctx, name)
+	})
+}
+
+func (c *Cache) Manga(ctx context.Context, name string) ([]collection.Media, error) {
+	return lookup(ctx, c, QueryManga, normalize(name), func(ctx context.Context) ([]collection.Media, error) {
+		return c.client.Manga(ctx, name)
+	})
+}
+
+func (c *Cache) User(ctx context.Context, name string) ([]collection.TrackerUser, error) {
+	return lookup(ctx, c, QueryUser, normalize(name), func(ctx context.Context) ([]collection.TrackerUser, error) {
+		return c.client.User(ctx, name)
+	})
+}
+
+func (c *Cache) Character(ctx context.Context, name string) ([]collection.MediaCharacter, error) {
+	return lookup(ctx, c, QueryCharacter, normalize(name), func(ctx context.Context) ([]collection.MediaCharacter, error) {
+		return c.client.Character(ctx, name)
+	})
+}
+
+func (c *Cache) SearchMedia(ctx context.Context, search string) ([]collection.Media, error) {
+	return lookup(ctx, c, QuerySearchMedia, normalize(search), func(ctx context.Context) ([]collection.Media, error) {
+		return c.client.SearchMedia(ctx, search)
+	})
+}
+
+// GetMediaCharacters always goes to AniList, collection.MediaCharacters keeps
+// the characters of a media in the catalog.
+func (c *Cache) GetMediaCharacters(ctx context.Context, mediaID int64) ([]collection.MediaCharacter, error) {
+	return c.client.GetMediaCharacters(ctx, mediaID)
+}
+
+func (c *Cache) CharactersByIDs(ctx context.Context, ids []int64) ([]collection.MediaCharacter, error) {
+	return lookup(ctx, c, QueryCharactersByIDs, idsKey(ids), func(ctx context.Context) ([]collection.MediaCharacter, error) {
+		return c.client.CharactersByIDs(ctx, ids)
+	})
+}
+
+// Run prunes the entries too old to be served every interval until ctx is done.
+func (c *Cache) Run(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+
+		n, err := c.store.Prune(ctx, c.now().Add(-c.cfg.MaxStale))
+		if err != nil {
+			slog.Error("error pruning anilist cache", "error", err)
+			continue
+		}
+		if n > 0 {
+			slog.Debug("pruned anilist cache", "entries", n)
+		}
+	}
+}
+
+// lookup answers a query from the cache when it is fresh, and from fetch otherwise.
+func lookup[T any](ctx context.Context, c *Cache, q Query, key string, fetch func(context.Context) (T, error)) (T, error) {
+	ttl := c.cfg.TTLs[q]
+	if ttl <= 0 {
+		return fetch(ctx)
+	}
+
+	cached, ok := c.cached(ctx, q, key)
+	if ok && c.now().Sub(cached.FetchedAt) < ttl {
+		return decode[T](cached)
+	}
+
+	entry, err := c.fetch(ctx, q, key, func(ctx context.Context) ([]byte, error) {
+		v, err := fetch(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(v)
+	})
+	if err != nil {
+		if ok && ctx.Err() == nil {
+			slog.Warn("anilist request failed, serving stale answer",
+				"query", q, "key", key, "age", c.now().Sub(cached.FetchedAt), "error", err)
+			return decode[T](cached)
+		}
+		var zero T
+		return zero, err
+	}
+	return decode[T](entry)
+}
+
+// cached returns the newest entry for the key that is still young enough to be served.
+// Store errors are logged and treated as a miss, the cache never fails a request.
+func (c *Cache) cached(ctx context.Context, q Query, key string) (Entry, bool) {
+	id := entryID(q, key)
+
+	c.mu.Lock()
+	e, ok := c.lru.get(id)
+	c.mu.Unlock()
+
+	if !ok {
+		var err error
+		e, ok, err = c.store.Get(ctx, q, key)
+		if err != nil {
+			slog.Warn("error reading anilist cache", "query", q, "key", key, "error", err)
+			return Entry{}, false
+		}
+		if ok {
+			c.mu.Lock()
+			c.lru.add(id, e)
+			c.mu.Unlock()
+		}
+	}
+
+	if !ok || c.now().Sub(e.FetchedAt) > c.cfg.MaxStale {
+		return Entry{}, false
+	}
+	return e, true
+}
+
+// fetch runs load once for all concurrent lookups of the key and stores its answer.
+// The shared request isn't cancelled when the lookup that started it gives up.
+// Lookups only share requests of their own priority, so a user never waits
+// behind a background request held back by the scheduler.
+func (c *Cache) fetch(ctx context.Context, q Query, key string, load func(context.Context) ([]byte, error)) (Entry, error) {
+	flight := entryID(q, key) + "\x00" + strconv.Itoa(int(anilist.PriorityFrom(ctx)))
+
+	c.mu.Lock()
+	cl, ok := c.inflight[flight]
+	if !ok {
+		cl = &call{done: make(chan struct{})}
+		c.inflight[flight] = cl
+		go c.load(context.WithoutCancel(ctx), q, key, flight, cl, load)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-cl.done:
+		return cl.entry, cl.err
+	case <-ctx.Done():
+		return Entry{}, ctx.Err()
+	}
+}
+
+func (c *Cache) load(ctx context.Context, q Query, key, flight string, cl *call, load func(context.Context) ([]byte, error)) {
+	id := entryID(q, key)
+
+	value, err := load(ctx)
+	if err == nil {
+		cl.entry = Entry{Value: value, FetchedAt: c.now()}
+	}
+	cl.err = err
+
+	c.mu.Lock()
+	if err == nil {
+		c.lru.add(id, cl.entry)
+	}
+	delete(c.inflight, flight)
+	c.mu.Unlock()
+	close(cl.done)
+
+	if err != nil {
+		return
+	}
+	if err := c.store.Put(ctx, q, key, cl.entry); err != nil {
+		slog.Warn("error writing anilist cache", "query", q, "key", key, "error", err)
+	}
+}
+
+func decode[T any](e Entry) (T, error) {
+	var v T
+	err := json.Unmarshal(e.Value, &v)
+	return v, err
+}
+
+func entryID(q Query, key string) string {
+	return string(q) + "\x00" + key
+}
+
+// normalize makes searches that only differ in case or spacing share an entry.
+func normalize(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+func idsKey(ids []int64) string {
+	sorted := slices.Clone(ids)
+	slices.Sort(sorted)
+	parts := make([]string, len(sorted))
+	for i, id := range sorted {
+		parts[i] = strconv.FormatInt(id, 10)
+	}
+	return strings.Join(parts, ",")
+}
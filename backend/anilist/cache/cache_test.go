@@ -0,0 +1,332 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karitham/waifubot/anilist"
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/collection/collectiontest"
+)
+
+type mockClient struct {
+	collectiontest.MockAnimeService
+	CharactersByIDsFunc func(ctx context.Context, ids []int64) ([]collection.MediaCharacter, error)
+}
+
+func (m *mockClient) CharactersByIDs(ctx context.Context, ids []int64) ([]collection.MediaCharacter, error) {
+	if m.CharactersByIDsFunc != nil {
+		return m.CharactersByIDsFunc(ctx, ids)
+	}
+	return nil, nil
+}
+
+type memStore struct {
+	mu      sync.Mutex
+	entries map[string]Entry
+}
+
+func newMemStore() *memStore {
+	return &memStore{entries: make(map[string]Entry)}
+}
+
+func (s *memStore) Get(_ context.Context, q Query, key string) (Entry, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[entryID(q, key)]
+	return e, ok, nil
+}
+
+func (s *memStore) Put(_ context.Context, q Query, key string, e Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[entryID(q, key)] = e
+	return nil
+}
+
+func (s *memStore) Prune(_ context.Context, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var n int64
+	for id, e := range s.entries {
+		if e.FetchedAt.Before(before) {
+			delete(s.entries, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// clock is a settable time source for Cache.now.
+type clock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func (c *clock) now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *clock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = c.t.Add(d)
+}
+
+func newTestCache(client Client, store Store, clk *clock) *Cache {
+	c := New(client, store, DefaultConfig())
+	c.now = clk.now
+	return c
+}
+
+var fate = []collection.Media{{ID: 55, Title: "Fate/Zero", Type: "ANIME"}}
+
+func TestCache_Lookup(t *testing.T) {
+	ttl := DefaultTTLs[QuerySearchMedia]
+
+	tests := []struct {
+		name      string
+		seed      *Entry // stored entry, fetchedAt relative to now
+		age       time.Duration
+		fetchErr  error
+		wantCalls int32
+		want      []collection.Media
+		wantErr   bool
+	}{
+		{name: "miss", wantCalls: 1, want: fate},
+		{name: "fresh", seed: &Entry{Value: []byte(`[{"ID":1,"Title":"Cached"}]`)}, age: ttl / 2, want: []collection.Media{{ID: 1, Title: "Cached"}}},
+		{name: "expired", seed: &Entry{Value: []byte(`[{"ID":1,"Title":"Cached"}]`)}, age: ttl + time.Minute, wantCalls: 1, want: fate},
+		{name: "expired, anilist down", seed: &Entry{Value: []byte(`[{"ID":1,"Title":"Cached"}]`)}, age: ttl + time.Minute, fetchErr: errors.New("503"), wantCalls: 1, want: []collection.Media{{ID: 1, Title: "Cached"}}},
+		{name: "too old to serve, anilist down", seed: &Entry{Value: []byte(`[{"ID":1,"Title":"Cached"}]`)}, age: DefaultMaxStale + time.Minute, fetchErr: errors.New("503"), wantCalls: 1, wantErr: true},
+		{name: "miss, anilist down", fetchErr: errors.New("503"), wantCalls: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clk := &clock{t: time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)}
+			store := newMemStore()
+			if tt.seed != nil {
+				e := *tt.seed
+				e.FetchedAt = clk.now().Add(-tt.age)
+				require.NoError(t, store.Put(t.Context(), QuerySearchMedia, "fate", e))
+			}
+
+			var calls atomic.Int32
+			client := &mockClient{MockAnimeService: collectiontest.MockAnimeService{
+				SearchMediaFunc: func(_ context.Context, search string) ([]collection.Media, error) {
+					calls.Add(1)
+					assert.Equal(t, "  Fate ", search, "the client gets the search as typed")
+					return fate, tt.fetchErr
+				},
+			}}
+
+			got, err := newTestCache(client, store, clk).SearchMedia(t.Context(), "  Fate ")
+			assert.Equal(t, tt.wantCalls, calls.Load())
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCache_StoresAnswers(t *testing.T) {
+	clk := &clock{t: time.Now()}
+	store := newMemStore()
+	var calls atomic.Int32
+	client := &mockClient{MockAnimeService: collectiontest.MockAnimeService{
+		AnimeFunc: func(context.Context, string) ([]collection.Media, error) {
+			calls.Add(1)
+			return fate, nil
+		},
+	}}
+
+	c := newTestCache(client, store, clk)
+	_, err := c.Anime(t.Context(), "Fate/Zero")
+	require.NoError(t, err)
+	got, err := c.Anime(t.Context(), "fate/zero")
+	require.NoError(t, err)
+	assert.Equal(t, fate, got)
+	assert.Equal(t, int32(1), calls.Load(), "searches differing in case share an entry")
+
+	_, ok, _ := store.Get(t.Context(), QueryAnime, "fate/zero")
+	assert.True(t, ok, "answers are written to the store")
+
+	restarted := newTestCache(client, store, clk)
+	got, err = restarted.Anime(t.Context(), "Fate/Zero")
+	require.NoError(t, err)
+	assert.Equal(t, fate, got)
+	assert.Equal(t, int32(1), calls.Load(), "a new cache reads the store before AniList")
+
+	_, err = c.Manga(t.Context(), "Fate/Zero")
+	require.NoError(t, err)
+	_, ok, _ = store.Get(t.Context(), QueryManga, "fate/zero")
+	assert.True(t, ok, "each query type has its own entries")
+}
+
+func TestCache_Coalesces(t *testing.T) {
+	release := make(chan struct{})
+	var calls atomic.Int32
+	client := &mockClient{MockAnimeService: collectiontest.MockAnimeService{
+		CharacterFunc: func(context.Context, string) ([]collection.MediaCharacter, error) {
+			calls.Add(1)
+			<-release
+			return []collection.MediaCharacter{{ID: 1, Name: "Saber"}}, nil
+		},
+	}}
+	c := newTestCache(client, newMemStore(), &clock{t: time.Now()})
+
+	const n = 10
+	var wg sync.WaitGroup
+	results := make([][]collection.MediaCharacter, n)
+	for i := range n {
+		wg.Go(func() {
+			chars, err := c.Character(t.Context(), "Saber")
+			assert.NoError(t, err)
+			results[i] = chars
+		})
+	}
+
+	// Wait for the first lookup to reach AniList before letting it answer.
+	require.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+	for _, chars := range results {
+		assert.Equal(t, []collection.MediaCharacter{{ID: 1, Name: "Saber"}}, chars)
+	}
+}
+
+func TestCache_CoalescesByPriority(t *testing.T) {
+	release := make(chan struct{})
+	client := &mockClient{MockAnimeService: collectiontest.MockAnimeService{
+		CharacterFunc: func(ctx context.Context, _ string) ([]collection.MediaCharacter, error) {
+			if anilist.PriorityFrom(ctx) == anilist.PriorityBackground {
+				<-release
+			}
+			return []collection.MediaCharacter{{ID: 1, Name: "Saber"}}, nil
+		},
+	}}
+	c := newTestCache(client, newMemStore(), &clock{t: time.Now()})
+
+	background := make(chan error, 1)
+	go func() {
+		_, err := c.Character(anilist.WithPriority(t.Context(), anilist.PriorityBackground), "Saber")
+		background <- err
+	}()
+	require.Eventually(t, func() bool {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return len(c.inflight) == 1
+	}, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+	defer cancel()
+	chars, err := c.Character(ctx, "Saber")
+	require.NoError(t, err, "an interactive lookup doesn't wait on the background request")
+	assert.Equal(t, []collection.MediaCharacter{{ID: 1, Name: "Saber"}}, chars)
+
+	close(release)
+	assert.NoError(t, <-background)
+}
+
+func TestCache_CancelledWaiter(t *testing.T) {
+	release := make(chan struct{})
+	client := &mockClient{MockAnimeService: collectiontest.MockAnimeService{
+		UserFunc: func(ctx context.Context, _ string) ([]collection.TrackerUser, error) {
+			<-release
+			return []collection.TrackerUser{{Name: "karitham"}}, ctx.Err()
+		},
+	}}
+	store := newMemStore()
+	c := newTestCache(client, store, &clock{t: time.Now()})
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	_, err := c.User(ctx, "karitham")
+	require.ErrorIs(t, err, context.Canceled)
+
+	close(release)
+	require.Eventually(t, func() bool {
+		_, ok, _ := store.Get(t.Context(), QueryUser, "karitham")
+		return ok
+	}, time.Second, time.Millisecond, "the shared request finishes for the next lookup")
+}
+
+func TestCache_Disabled(t *testing.T) {
+	store := newMemStore()
+	var calls atomic.Int32
+	client := &mockClient{CharactersByIDsFunc: func(context.Context, []int64) ([]collection.MediaCharacter, error) {
+		calls.Add(1)
+		return []collection.MediaCharacter{{ID: 1}}, nil
+	}}
+	c := newTestCache(client, store, &clock{t: time.Now()})
+
+	for range 2 {
+		_, err := c.CharactersByIDs(t.Context(), []int64{2, 1})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), calls.Load(), "character batches are not cached by default")
+	assert.Empty(t, store.entries)
+}
+
+func TestCache_Prune(t *testing.T) {
+	clk := &clock{t: time.Now()}
+	store := newMemStore()
+	require.NoError(t, store.Put(t.Context(), QueryAnime, "old", Entry{FetchedAt: clk.now().Add(-DefaultMaxStale - time.Hour)}))
+	require.NoError(t, store.Put(t.Context(), QueryAnime, "new", Entry{FetchedAt: clk.now()}))
+
+	c := newTestCache(&mockClient{}, store, clk)
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan struct{})
+	go func() {
+		c.Run(ctx, time.Millisecond)
+		close(done)
+	}()
+
+	require.Eventually(t, func() bool {
+		_, ok, _ := store.Get(t.Context(), QueryAnime, "old")
+		return !ok
+	}, time.Second, time.Millisecond)
+	cancel()
+	<-done
+
+	_, ok, _ := store.Get(t.Context(), QueryAnime, "new")
+	assert.True(t, ok)
+}
+
+func TestLRU(t *testing.T) {
+	l := newLRU(2)
+	l.add("a", Entry{Value: []byte("a")})
+	l.add("b", Entry{Value: []byte("b")})
+	_, ok := l.get("a")
+	require.True(t, ok)
+
+	l.add("c", Entry{Value: []byte("c")})
+	_, ok = l.get("b")
+	assert.False(t, ok, "the least recently used entry is evicted")
+	_, ok = l.get("a")
+	assert.True(t, ok)
+
+	l.add("a", Entry{Value: []byte("a2")})
+	e, _ := l.get("a")
+	assert.Equal(t, []byte("a2"), e.Value)
+	assert.Equal(t, 2, l.order.Len())
+
+	empty := newLRU(0)
+	empty.add("a", Entry{})
+	_, ok = empty.get("a")
+	assert.False(t, ok)
+}
@@ -0,0 +1,53 @@
+package cache
+
+import "container/list"
+
+// lru is a fixed-size map that evicts the least recently used entry.
+// It is not safe for concurrent use.
+type lru struct {
+	size  int
+	order *list.List // front is the most recently used
+	items map[string]*list.Element
+}
+
+type lruItem struct {
+	id    string
+	entry Entry
+}
+
+func newLRU(size int) *lru {
+	return &lru{
+		size:  size,
+		order: list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+func (l *lru) get(id string) (Entry, bool) {
+	el, ok := l.items[id]
+	if !ok {
+		return Entry{}, false
+	}
+	l.order.MoveToFront(el)
+	return el.Value.(*lruItem).entry, true
+}
+
+// add stores the entry, replacing the previous one for id.
+// A non-positive size keeps nothing in memory.
+func (l *lru) add(id string, e Entry) {
+	if l.size <= 0 {
+		return
+	}
+	if el, ok := l.items[id]; ok {
+		el.Value.(*lruItem).entry = e
+		l.order.MoveToFront(el)
+		return
+	}
+
+	l.items[id] = l.order.PushFront(&lruItem{id: id, entry: e})
+	if l.order.Len() > l.size {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.items, oldest.Value.(*lruItem).id)
+	}
+}
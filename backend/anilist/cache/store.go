@@ -0,0 +1,44 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/karitham/waifubot/storage/anilistcachestore"
+)
+
+type store struct {
+	q anilistcachestore.Querier
+}
+
+func NewStore(q anilistcachestore.Querier) Store {
+	return &store{q: q}
+}
+
+func (s *store) Get(ctx context.Context, query Query, key string) (Entry, bool, error) {
+	row, err := s.q.Get(ctx, anilistcachestore.GetParams{Query: string(query), Key: key})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Entry{}, false, nil
+		}
+		return Entry{}, false, err
+	}
+	return Entry{Value: row.Value, FetchedAt: row.FetchedAt.Time}, true, nil
+}
+
+func (s *store) Put(ctx context.Context, query Query, key string, e Entry) error {
+	return s.q.Put(ctx, anilistcachestore.PutParams{
+		Query:     string(query),
+		Key:       key,
+		Value:     e.Value,
+		FetchedAt: pgtype.Timestamp{Time: e.FetchedAt.UTC(), Valid: true},
+	})
+}
+
+func (s *store) Prune(ctx context.Context, before time.Time) (int64, error) {
+	return s.q.Prune(ctx, pgtype.Timestamp{Time: before.UTC(), Valid: true})
+}
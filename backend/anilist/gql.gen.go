@@ -186,6 +186,8 @@ type charactersByIdsPageCharactersCharacter struct {
 	Image charactersByIdsPageCharactersCharacterImage `json:"image"`
 	// The amount of user's who have favourited the character
 	Favourites int64 `json:"favourites"`
+	// A general description of the character
+	Description string `json:"description"`
 	// Media that includes the character
 	Media charactersByIdsPageCharactersCharacterMediaMediaConnection `json:"media"`
 }
@@ -206,6 +208,9 @@ func (v *charactersByIdsPageCharactersCharacter) GetImage() charactersByIdsPageC
 // GetFavourites returns charactersByIdsPageCharactersCharacter.Favourites, and is useful for accessing the field via an interface.
 func (v *charactersByIdsPageCharactersCharacter) GetFavourites() int64 { return v.Favourites }
 
+// GetDescription returns charactersByIdsPageCharactersCharacter.Description, and is useful for accessing the field via an interface.
+func (v *charactersByIdsPageCharactersCharacter) GetDescription() string { return v.Description }
+
 // GetMedia returns charactersByIdsPageCharactersCharacter.Media, and is useful for accessing the field via an interface.
 func (v *charactersByIdsPageCharactersCharacter) GetMedia() charactersByIdsPageCharactersCharacterMediaMediaConnection {
 	return v.Media
@@ -235,8 +240,38 @@ func (v *charactersByIdsPageCharactersCharacterMediaMediaConnection) GetNodes()
 //
 // Anime or Manga
 type charactersByIdsPageCharactersCharacterMediaMediaConnectionNodesMedia struct {
+	// The id of the media
+	Id int64 `json:"id"`
+	// The mal id of the media
+	IdMal int64 `json:"idMal"`
+	// The type of the media; anime or manga
+	Type MediaType `json:"type"`
+	// The number of users with the media on their list
+	Popularity int64 `json:"popularity"`
 	// The official titles of the media in various languages
 	Title charactersByIdsPageCharactersCharacterMediaMediaConnectionNodesMediaTitle `json:"title"`
+	// The cover images of the media
+	CoverImage charactersByIdsPageCharactersCharacterMediaMediaConnectionNodesMediaCoverImage `json:"coverImage"`
+}
+
+// GetId returns charactersByIdsPageCharactersCharacterMediaMediaConnectionNodesMedia.Id, and is useful for accessing the field via an interface.
+func (v *charactersByIdsPageCharactersCharacterMediaMediaConnectionNodesMedia) GetId() int64 {
+	return v.Id
+}
+
+// GetIdMal returns charactersByIdsPageCharactersCharacterMediaMediaConnectionNodesMedia.IdMal, and is useful for accessing the field via an interface.
+func (v *charactersByIdsPageCharactersCharacterMediaMediaConnectionNodesMedia) GetIdMal() int64 {
+	return v.IdMal
+}
+
+// GetType returns charactersByIdsPageCharactersCharacterMediaMediaConnectionNodesMedia.Type, and is useful for accessing the field via an interface.
+func (v *charactersByIdsPageCharactersCharacterMediaMediaConnectionNodesMedia) GetType() MediaType {
+	return v.Type
+}
+
+// GetPopularity returns charactersByIdsPageCharactersCharacterMediaMediaConnectionNodesMedia.Popularity, and is useful for accessing the field via an interface.
+func (v *charactersByIdsPageCharactersCharacterMediaMediaConnectionNodesMedia) GetPopularity() int64 {
+	return v.Popularity
 }
 
 // GetTitle returns charactersByIdsPageCharactersCharacterMediaMediaConnectionNodesMedia.Title, and is useful for accessing the field via an interface.
@@ -244,6 +279,22 @@ func (v *charactersByIdsPageCharactersCharacterMediaMediaConnectionNodesMedia) G
 	return v.Title
 }
 
+// GetCoverImage returns charactersByIdsPageCharactersCharacterMediaMediaConnectionNodesMedia.CoverImage, and is useful for accessing the field via an interface.
+func (v *charactersByIdsPageCharactersCharacterMediaMediaConnectionNodesMedia) GetCoverImage() charactersByIdsPageCharactersCharacterMediaMediaConnectionNodesMediaCoverImage {
+	return v.CoverImage
+}
+
+// charactersByIdsPageCharactersCharacterMediaMediaConnectionNodesMediaCoverImage includes the requested fields of the GraphQL type MediaCoverImage.
+type charactersByIdsPageCharactersCharacterMediaMediaConnectionNodesMediaCoverImage struct {
+	// The cover image url of the media at a large size
+	Large string `json:"large"`
+}
+
+// GetLarge returns charactersByIdsPageCharactersCharacterMediaMediaConnectionNodesMediaCoverImage.Large, and is useful for accessing the field via an interface.
+func (v *charactersByIdsPageCharactersCharacterMediaMediaConnectionNodesMediaCoverImage) GetLarge() string {
+	return v.Large
+}
+
 // charactersByIdsPageCharactersCharacterMediaMediaConnectionNodesMediaTitle includes the requested fields of the GraphQL type MediaTitle.
 // The GraphQL type's documentation follows.
 //
@@ -251,6 +302,8 @@ func (v *charactersByIdsPageCharactersCharacterMediaMediaConnectionNodesMedia) G
 type charactersByIdsPageCharactersCharacterMediaMediaConnectionNodesMediaTitle struct {
 	// The romanization of the native language title
 	Romaji string `json:"romaji"`
+	// The official english title
+	English string `json:"english"`
 }
 
 // GetRomaji returns charactersByIdsPageCharactersCharacterMediaMediaConnectionNodesMediaTitle.Romaji, and is useful for accessing the field via an interface.
@@ -258,6 +311,11 @@ func (v *charactersByIdsPageCharactersCharacterMediaMediaConnectionNodesMediaTit
 	return v.Romaji
 }
 
+// GetEnglish returns charactersByIdsPageCharactersCharacterMediaMediaConnectionNodesMediaTitle.English, and is useful for accessing the field via an interface.
+func (v *charactersByIdsPageCharactersCharacterMediaMediaConnectionNodesMediaTitle) GetEnglish() string {
+	return v.English
+}
+
 // charactersByIdsPageCharactersCharacterName includes the requested fields of the GraphQL type CharacterName.
 // The GraphQL type's documentation follows.
 //
@@ -265,11 +323,16 @@ func (v *charactersByIdsPageCharactersCharacterMediaMediaConnectionNodesMediaTit
 type charactersByIdsPageCharactersCharacterName struct {
 	// The character's first and last name
 	Full string `json:"full"`
+	// Other names the character might be referred to as
+	Alternative []string `json:"alternative"`
 }
 
 // GetFull returns charactersByIdsPageCharactersCharacterName.Full, and is useful for accessing the field via an interface.
 func (v *charactersByIdsPageCharactersCharacterName) GetFull() string { return v.Full }
 
+// GetAlternative returns charactersByIdsPageCharactersCharacterName.Alternative, and is useful for accessing the field via an interface.
+func (v *charactersByIdsPageCharactersCharacterName) GetAlternative() []string { return v.Alternative }
+
 // charactersByIdsResponse is returned by charactersByIds on success.
 type charactersByIdsResponse struct {
 	Page charactersByIdsPage `json:"Page"`
@@ -742,15 +805,25 @@ query charactersByIds ($ids: [Int!]!) {
 			id
 			name {
 				full
+				alternative
 			}
 			image {
 				large
 			}
 			favourites
-			media(perPage: 1, sort: POPULARITY_DESC) {
+			description
+			media(perPage: 25, sort: POPULARITY_DESC) {
 				nodes {
+					id
+					idMal
+					type
+					popularity
 					title {
 						romaji
+						english
+					}
+					coverImage {
+						large
 					}
 				}
 			}
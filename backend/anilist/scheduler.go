@@ -0,0 +1,224 @@
+package anilist
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Priority orders requests waiting for the AniList budget.
+type Priority int
+
+const (
+	// PriorityInteractive is for requests a user is waiting on. It is the default.
+	PriorityInteractive Priority = iota
+	// PriorityBackground is for requests nobody is waiting on, like the character sync.
+	PriorityBackground
+
+	priorities
+)
+
+type priorityKey struct{}
+
+// WithPriority returns a context whose AniList requests are scheduled with p.
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+// PriorityFrom returns the priority AniList requests made with ctx are scheduled with.
+func PriorityFrom(ctx context.Context) Priority {
+	if p, ok := ctx.Value(priorityKey{}).(Priority); ok && p >= 0 && p < priorities {
+		return p
+	}
+	return PriorityInteractive
+}
+
+const (
+	// DefaultRateLimit is AniList's documented limit while it runs degraded.
+	// A higher X-RateLimit-Limit from AniList replaces it.
+	DefaultRateLimit = 30
+	// DefaultInteractiveReserve is how much of the budget background requests leave to users.
+	DefaultInteractiveReserve = 20
+)
+
+// SchedulerConfig tunes a Scheduler.
+type SchedulerConfig struct {
+	// Limit is how many requests can be sent per Window.
+	Limit  int
+	Window time.Duration
+	// Reserve is how many requests of each window only interactive requests can use.
+	Reserve int
+}
+
+// DefaultSchedulerConfig returns the configuration used by New.
+func DefaultSchedulerConfig() SchedulerConfig {
+	return SchedulerConfig{Limit: DefaultRateLimit, Window: time.Minute, Reserve: DefaultInteractiveReserve}
+}
+
+// Scheduler holds the AniList request budget shared by every caller of a client.
+// Waiting requests are let through by priority, then in arrival order.
+// The budget follows AniList's X-RateLimit-Remaining header, and a Retry-After
+// header or a 429 pauses every request.
+type Scheduler struct {
+	cfg SchedulerConfig
+	now func() time.Time
+
+	mu          sync.Mutex
+	remaining   int
+	resetAt     time.Time
+	pausedUntil time.Time
+	queues      [priorities][]*waiter
+	timer       *time.Timer
+}
+
+// waiter is a request waiting for the budget.
+type waiter struct {
+	ready chan struct{}
+	// window is the resetAt of the budget the request was let through with.
+	window time.Time
+}
+
+// NewScheduler returns a Scheduler starting with a full budget.
+func NewScheduler(cfg SchedulerConfig) *Scheduler {
+	return &Scheduler{cfg: cfg, now: time.Now, remaining: cfg.Limit, resetAt: time.Now().Add(cfg.Window)}
+}
+
+// Wait blocks until a request with priority p can be sent or ctx is done.
+func (s *Scheduler) Wait(ctx context.Context, p Priority) error {
+	if p < 0 || p >= priorities {
+		p = PriorityInteractive
+	}
+	w := &waiter{ready: make(chan struct{})}
+
+	s.mu.Lock()
+	s.queues[p] = append(s.queues[p], w)
+	s.dispatch()
+	s.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.leave(p, w)
+	return ctx.Err()
+}
+
+// leave takes a request that gave up out of the queue. If it was let through
+// meanwhile, its request goes to the next in line, unless the budget it came
+// from was renewed since. It must be called with mu held.
+func (s *Scheduler) leave(p Priority, w *waiter) {
+	if i := slices.Index(s.queues[p], w); i >= 0 {
+		s.queues[p] = slices.Delete(s.queues[p], i, i+1)
+		return
+	}
+	if w.window.Equal(s.resetAt) {
+		s.remaining = min(s.remaining+1, s.cfg.Limit)
+	}
+	s.dispatch()
+}
+
+// Observe updates the budget from the rate limit headers of an AniList answer.
+func (s *Scheduler) Observe(resp *http.Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if limit, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit")); err == nil && limit > 0 {
+		s.cfg.Limit = limit
+	}
+	if remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining")); err == nil {
+		s.remaining = max(min(s.remaining, remaining), 0)
+	}
+
+	retry, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	switch {
+	case err == nil && retry >= 0:
+		s.pause(time.Duration(retry) * time.Second)
+	case resp.StatusCode == http.StatusTooManyRequests:
+		s.pause(s.cfg.Window)
+	}
+
+	s.dispatch()
+}
+
+// pause holds every request for d, after which the budget starts over.
+func (s *Scheduler) pause(d time.Duration) {
+	s.pausedUntil = s.now().Add(d)
+	s.resetAt = s.pausedUntil
+	s.remaining = 0
+}
+
+// dispatch lets waiting requests through while the budget allows. Background
+// requests don't take the part of the budget reserved for interactive ones.
+// It must be called with mu held.
+func (s *Scheduler) dispatch() {
+	now := s.now()
+	if now.Before(s.pausedUntil) {
+		s.wakeAt(s.pausedUntil)
+		return
+	}
+	if !now.Before(s.resetAt) {
+		s.remaining = s.cfg.Limit
+		s.resetAt = now.Add(s.cfg.Window)
+	}
+
+	waiting := false
+	for p := range priorities {
+		floor := 0
+		if p != PriorityInteractive {
+			floor = min(s.cfg.Reserve, s.cfg.Limit-1)
+		}
+		for len(s.queues[p]) > 0 && s.remaining > floor {
+			w := s.queues[p][0]
+			w.window = s.resetAt
+			close(w.ready)
+			s.queues[p] = s.queues[p][1:]
+			s.remaining--
+		}
+		waiting = waiting || len(s.queues[p]) > 0
+	}
+
+	if waiting {
+		s.wakeAt(s.resetAt)
+	}
+}
+
+// wakeAt dispatches again at t.
+func (s *Scheduler) wakeAt(t time.Time) {
+	d := t.Sub(s.now())
+	if s.timer == nil {
+		s.timer = time.AfterFunc(d, func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.dispatch()
+		})
+		return
+	}
+	s.timer.Reset(d)
+}
+
+// scheduledClient waits for the scheduler before each request, so the
+// client's timeout only starts once the request is let through.
+type scheduledClient struct {
+	s *Scheduler
+	c *http.Client
+}
+
+func (c *scheduledClient) Do(req *http.Request) (*http.Response, error) {
+	if err := c.s.Wait(req.Context(), PriorityFrom(req.Context())); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	c.s.Observe(resp)
+	return resp, nil
+}
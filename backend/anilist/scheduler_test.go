@@ -0,0 +1,139 @@
+package anilist
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blocked reports whether a request with priority p has to wait longer than a short while.
+func blocked(t *testing.T, s *Scheduler, p Priority) bool {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+	defer cancel()
+	return s.Wait(ctx, p) != nil
+}
+
+func TestScheduler_Reserve(t *testing.T) {
+	s := NewScheduler(SchedulerConfig{Limit: 3, Window: time.Hour, Reserve: 2})
+
+	assert.False(t, blocked(t, s, PriorityBackground))
+	assert.True(t, blocked(t, s, PriorityBackground), "background leaves the reserve alone")
+	assert.False(t, blocked(t, s, PriorityInteractive))
+	assert.False(t, blocked(t, s, PriorityInteractive))
+	assert.True(t, blocked(t, s, PriorityInteractive), "the budget is spent")
+}
+
+func TestScheduler_InteractiveFirst(t *testing.T) {
+	s := NewScheduler(SchedulerConfig{Limit: 1, Window: 100 * time.Millisecond})
+	require.NoError(t, s.Wait(t.Context(), PriorityInteractive))
+
+	order := make(chan Priority, 2)
+	wait := func(p Priority) {
+		assert.NoError(t, s.Wait(t.Context(), p))
+		order <- p
+	}
+	go wait(PriorityBackground)
+	require.Eventually(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return len(s.queues[PriorityBackground]) == 1
+	}, time.Second, time.Millisecond)
+	go wait(PriorityInteractive)
+
+	assert.Equal(t, PriorityInteractive, <-order, "the interactive request arrived later but goes first")
+	assert.Equal(t, PriorityBackground, <-order, "the background request gets the next window")
+}
+
+func TestScheduler_CancelledWaiter(t *testing.T) {
+	s := NewScheduler(SchedulerConfig{Limit: 1, Window: time.Hour})
+	require.NoError(t, s.Wait(t.Context(), PriorityInteractive))
+
+	assert.True(t, blocked(t, s, PriorityInteractive))
+	s.mu.Lock()
+	assert.Empty(t, s.queues[PriorityInteractive], "a cancelled request leaves the queue")
+	s.mu.Unlock()
+}
+
+func TestScheduler_LeaveAfterReset(t *testing.T) {
+	now := time.Now()
+	s := NewScheduler(SchedulerConfig{Limit: 2, Window: time.Minute})
+	s.now = func() time.Time { return now }
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	same := &waiter{ready: make(chan struct{})}
+	s.queues[PriorityInteractive] = append(s.queues[PriorityInteractive], same)
+	s.dispatch()
+	s.leave(PriorityInteractive, same)
+	assert.Equal(t, 2, s.remaining, "a request given up in its window goes back to the budget")
+
+	stale := &waiter{ready: make(chan struct{})}
+	s.queues[PriorityInteractive] = append(s.queues[PriorityInteractive], stale)
+	s.dispatch()
+	now = s.resetAt
+	s.dispatch()
+	s.leave(PriorityInteractive, stale)
+	assert.Equal(t, 2, s.remaining, "a request from an older window doesn't grow the new budget")
+}
+
+func TestScheduler_Observe(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		header  http.Header
+		blocked bool
+	}{
+		{name: "budget left", status: http.StatusOK, header: http.Header{"X-Ratelimit-Remaining": {"80"}}},
+		{name: "budget spent", status: http.StatusOK, header: http.Header{"X-Ratelimit-Remaining": {"0"}}, blocked: true},
+		{name: "retry after", status: http.StatusTooManyRequests, header: http.Header{"Retry-After": {"30"}}, blocked: true},
+		{name: "too many requests", status: http.StatusTooManyRequests, blocked: true},
+		{name: "retry after elapsed", status: http.StatusServiceUnavailable, header: http.Header{"Retry-After": {"0"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewScheduler(SchedulerConfig{Limit: 90, Window: time.Minute})
+			s.Observe(&http.Response{StatusCode: tt.status, Header: tt.header})
+			assert.Equal(t, tt.blocked, blocked(t, s, PriorityInteractive))
+		})
+	}
+}
+
+func TestScheduler_ObserveLimit(t *testing.T) {
+	s := NewScheduler(SchedulerConfig{Limit: 1, Window: 50 * time.Millisecond})
+	s.Observe(&http.Response{Header: http.Header{"X-Ratelimit-Limit": {"3"}, "X-Ratelimit-Remaining": {"0"}}})
+
+	time.Sleep(60 * time.Millisecond)
+	for range 3 {
+		assert.False(t, blocked(t, s, PriorityInteractive), "the next window uses AniList's limit")
+	}
+}
+
+func TestScheduledClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	s := NewScheduler(SchedulerConfig{Limit: 90, Window: time.Hour})
+	c := &scheduledClient{s: s, c: srv.Client()}
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, srv.URL, nil)
+	require.NoError(t, err)
+	resp, err := c.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+	defer cancel()
+	_, err = c.Do(req.WithContext(ctx))
+	require.ErrorIs(t, err, context.DeadlineExceeded, "the next request waits for AniList's budget")
+}
@@ -0,0 +1,242 @@
+// Package auth logs users in with Discord OAuth2 and keeps their sessions.
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/karitham/waifubot/collection"
+)
+
+const (
+	// DefaultSessionTTL is how long a session lasts when the config doesn't say.
+	DefaultSessionTTL = 30 * 24 * time.Hour
+	// DefaultReapInterval is how often expired sessions are deleted.
+	DefaultReapInterval = time.Hour
+
+	// SessionCookie is the cookie a browser keeps its session token in.
+	SessionCookie = "waifubot_session"
+	// StateCookie is the cookie that carries the OAuth2 state between login and callback.
+	StateCookie = "waifubot_oauth_state"
+)
+
+var (
+	// ErrInvalidSession is returned when a session token is unknown or expired.
+	ErrInvalidSession = errors.New("invalid or expired session")
+	// ErrInvalidState is returned when a callback's state doesn't match the one login handed out.
+	ErrInvalidState = errors.New("invalid oauth state")
+	// ErrLoginFailed is returned when the provider rejects the authorization code.
+	ErrLoginFailed = errors.New("login failed")
+)
+
+// Endpoints locates an OAuth2 provider that speaks Discord's API.
+type Endpoints struct {
+	AuthURL  string
+	TokenURL string
+	// APIURL is where the logged in user is looked up, at APIURL/users/@me.
+	APIURL string
+}
+
+// DiscordEndpoints returns the endpoints of a provider served at baseURL,
+// laid out like https://discord.com, which is what an empty baseURL means.
+// Tests and local setups point it at a fake provider.
+func DiscordEndpoints(baseURL string) Endpoints {
+	if baseURL == "" {
+		baseURL = "https://discord.com"
+	}
+	return Endpoints{
+		AuthURL:  baseURL + "/oauth2/authorize",
+		TokenURL: baseURL + "/api/oauth2/token",
+		APIURL:   baseURL + "/api/v10",
+	}
+}
+
+// Config holds the OAuth2 application credentials.
+type Config struct {
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is the callback registered with the provider.
+	RedirectURL string
+	// HomeURL is where users land once logged in.
+	HomeURL   string
+	Endpoints Endpoints
+	// SessionTTL defaults to DefaultSessionTTL.
+	SessionTTL time.Duration
+}
+
+// Session is a logged in user. Only the holder of a session knows its Token,
+// the store keeps a hash of it.
+type Session struct {
+	Token     string
+	UserID    collection.UserID
+	ExpiresAt time.Time
+}
+
+// Store keeps sessions by the hash of their token.
+type Store interface {
+	CreateSession(ctx context.Context, tokenHash []byte, userID collection.UserID, expiresAt time.Time) error
+	// Session returns the session of tokenHash if it expires after now, or collection.ErrNotFound.
+	// The returned session has no Token.
+	Session(ctx context.Context, tokenHash []byte, now time.Time) (Session, error)
+	DeleteSession(ctx context.Context, tokenHash []byte) error
+	DeleteExpiredSessions(ctx context.Context, now time.Time) (int64, error)
+}
+
+// Users is where logged in users are created and their Discord info kept up to date.
+type Users interface {
+	GetUser(ctx context.Context, userID collection.UserID) (collection.User, error)
+	CreateUser(ctx context.Context, userID collection.UserID) error
+	UpdateDiscordInfo(ctx context.Context, userID collection.UserID, username, avatar string, lastUpdated time.Time) error
+}
+
+// Service logs users in and checks their sessions.
+type Service struct {
+	config Config
+	store  Store
+	users  Users
+	client *http.Client
+}
+
+// NewService creates a Service.
+func NewService(config Config, store Store, users Users) *Service {
+	if config.SessionTTL <= 0 {
+		config.SessionTTL = DefaultSessionTTL
+	}
+	return &Service{
+		config: config,
+		store:  store,
+		users:  users,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Config returns the config of s, with its defaults applied.
+func (s *Service) Config() Config {
+	return s.config
+}
+
+// NewState returns a random OAuth2 state.
+func NewState() string {
+	return randomToken()
+}
+
+// AuthorizeURL is where users are sent to log in. The provider sends them
+// back to the redirect URL with state.
+func (s *Service) AuthorizeURL(state string) string {
+	q := url.Values{
+		"response_type": {"code"},
+		"client_id":     {s.config.ClientID},
+		"scope":         {"identify"},
+		"redirect_uri":  {s.config.RedirectURL},
+		"state":         {state},
+		"prompt":        {"none"},
+	}
+	return s.config.Endpoints.AuthURL + "?" + q.Encode()
+}
+
+// Login trades the code of a callback for a new session. want is the state
+// handed out with the authorize URL, and must match the callback's state.
+// Users logging in for the first time are created.
+func (s *Service) Login(ctx context.Context, code, state, want string) (Session, error) {
+	if want == "" || subtle.ConstantTimeCompare([]byte(state), []byte(want)) != 1 {
+		return Session{}, ErrInvalidState
+	}
+
+	accessToken, err := s.exchange(ctx, code)
+	if err != nil {
+		return Session{}, err
+	}
+
+	ident, err := s.identify(ctx, accessToken)
+	if err != nil {
+		return Session{}, err
+	}
+
+	if err := s.ensureUser(ctx, ident); err != nil {
+		return Session{}, err
+	}
+
+	session := Session{
+		Token:     randomToken(),
+		UserID:    ident.UserID,
+		ExpiresAt: time.Now().Add(s.config.SessionTTL).UTC(),
+	}
+	if err := s.store.CreateSession(ctx, hashToken(session.Token), session.UserID, session.ExpiresAt); err != nil {
+		return Session{}, err
+	}
+	return session, nil
+}
+
+// Authenticate returns the session of token, or ErrInvalidSession.
+func (s *Service) Authenticate(ctx context.Context, token string) (Session, error) {
+	if token == "" {
+		return Session{}, ErrInvalidSession
+	}
+
+	session, err := s.store.Session(ctx, hashToken(token), time.Now().UTC())
+	if err != nil {
+		if errors.Is(err, collection.ErrNotFound) {
+			return Session{}, ErrInvalidSession
+		}
+		return Session{}, err
+	}
+	session.Token = token
+	return session, nil
+}
+
+// Logout ends the session of token.
+func (s *Service) Logout(ctx context.Context, token string) error {
+	return s.store.DeleteSession(ctx, hashToken(token))
+}
+
+// Run deletes expired sessions every interval until ctx is done.
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			n, err := s.store.DeleteExpiredSessions(ctx, time.Now().UTC())
+			if err != nil {
+				slog.Error("error deleting expired sessions", "error", err)
+				continue
+			}
+			slog.Debug("deleted expired sessions", "sessions", n)
+		}
+	}
+}
+
+// ensureUser creates the user of ident if needed, and stores their Discord info.
+func (s *Service) ensureUser(ctx context.Context, ident identity) error {
+	if _, err := s.users.GetUser(ctx, ident.UserID); err != nil {
+		if !errors.Is(err, collection.ErrNotFound) {
+			return err
+		}
+		if err := s.users.CreateUser(ctx, ident.UserID); err != nil {
+			return err
+		}
+	}
+	return s.users.UpdateDiscordInfo(ctx, ident.UserID, ident.Username, ident.Avatar, time.Now())
+}
+
+func randomToken() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func hashToken(token string) []byte {
+	h := sha256.Sum256([]byte(token))
+	return h[:]
+}
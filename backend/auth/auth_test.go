@@ -0,0 +1,125 @@
+package auth_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karitham/waifubot/auth"
+	"github.com/karitham/waifubot/auth/authtest"
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/collection/collectiontest"
+)
+
+const redirectURL = "https://waifubot.example/api/v1/auth/callback"
+
+type discordInfo struct {
+	username, avatar string
+}
+
+func newService(t *testing.T) (*auth.Service, *authtest.Provider, *authtest.MemStore, map[uint64]discordInfo) {
+	t.Helper()
+
+	provider := authtest.NewProvider("client", "secret")
+	t.Cleanup(provider.Close)
+
+	users := map[uint64]discordInfo{}
+	store := &authtest.MemStore{}
+	svc := auth.NewService(auth.Config{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		RedirectURL:  redirectURL,
+		Endpoints:    provider.Endpoints(),
+	}, store, &collectiontest.MockStore{
+		GetUserFunc: func(_ context.Context, userID collection.UserID) (collection.User, error) {
+			if _, ok := users[userID]; !ok {
+				return collection.User{}, collection.ErrNotFound
+			}
+			return collection.User{UserID: userID}, nil
+		},
+		CreateUserFunc: func(_ context.Context, userID collection.UserID) error {
+			users[userID] = discordInfo{}
+			return nil
+		},
+		UpdateDiscordInfoFunc: func(_ context.Context, userID collection.UserID, username, avatar string, _ time.Time) error {
+			users[userID] = discordInfo{username, avatar}
+			return nil
+		},
+	})
+	return svc, provider, store, users
+}
+
+func TestService_Login(t *testing.T) {
+	svc, provider, store, users := newService(t)
+
+	code := provider.Code(authtest.User{ID: "42", Username: "saber", Avatar: "abc"})
+	session, err := svc.Login(t.Context(), code, "state", "state")
+	require.NoError(t, err)
+	assert.Equal(t, collection.UserID(42), session.UserID)
+	assert.NotEmpty(t, session.Token)
+	assert.WithinDuration(t, time.Now().Add(auth.DefaultSessionTTL), session.ExpiresAt, time.Minute)
+	assert.Equal(t, discordInfo{"saber", "abc"}, users[42], "new users are created with their Discord info")
+
+	got, err := svc.Authenticate(t.Context(), session.Token)
+	require.NoError(t, err)
+	assert.Equal(t, session.UserID, got.UserID)
+
+	_, err = svc.Login(t.Context(), code, "state", "state")
+	require.ErrorIs(t, err, auth.ErrLoginFailed, "codes are single use")
+
+	require.NoError(t, svc.Logout(t.Context(), session.Token))
+	_, err = svc.Authenticate(t.Context(), session.Token)
+	require.ErrorIs(t, err, auth.ErrInvalidSession)
+	assert.Zero(t, store.Len())
+}
+
+func TestService_LoginState(t *testing.T) {
+	svc, provider, _, _ := newService(t)
+	code := provider.Code(authtest.User{ID: "42"})
+
+	_, err := svc.Login(t.Context(), code, "forged", "state")
+	require.ErrorIs(t, err, auth.ErrInvalidState)
+	_, err = svc.Login(t.Context(), code, "", "")
+	require.ErrorIs(t, err, auth.ErrInvalidState, "a callback without a login has no state to match")
+}
+
+func TestService_Authenticate(t *testing.T) {
+	svc, _, store, _ := newService(t)
+
+	_, err := svc.Authenticate(t.Context(), "")
+	require.ErrorIs(t, err, auth.ErrInvalidSession)
+	_, err = svc.Authenticate(t.Context(), "unknown")
+	require.ErrorIs(t, err, auth.ErrInvalidSession)
+
+	require.NoError(t, store.CreateSession(t.Context(), []byte("expired"), 42, time.Now().Add(-time.Minute)))
+	n, err := store.DeleteExpiredSessions(t.Context(), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+}
+
+func TestService_AuthorizeURL(t *testing.T) {
+	svc, provider, _, _ := newService(t)
+	provider.LogInAs(authtest.User{ID: "7"})
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(svc.AuthorizeURL("xyz"))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	back, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, "waifubot.example", back.Host)
+	assert.Equal(t, "xyz", back.Query().Get("state"))
+
+	session, err := svc.Login(t.Context(), back.Query().Get("code"), "xyz", "xyz")
+	require.NoError(t, err)
+	assert.Equal(t, collection.UserID(7), session.UserID)
+}
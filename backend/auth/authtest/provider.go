@@ -0,0 +1,222 @@
+// Package authtest provides a fake Discord OAuth2 provider and an in-memory session store.
+package authtest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/karitham/waifubot/auth"
+	"github.com/karitham/waifubot/collection"
+)
+
+// User is a Discord user known to a Provider.
+type User struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	Avatar   string `json:"avatar"`
+}
+
+// Provider is a local fake of Discord's OAuth2 endpoints. Every code it issues
+// can be exchanged once, for an access token that identifies the user the code
+// was issued for.
+type Provider struct {
+	*httptest.Server
+	ClientID     string
+	ClientSecret string
+
+	mu sync.Mutex
+	// next is who logs in through the authorize endpoint.
+	next   User
+	codes  map[string]User
+	tokens map[string]User
+}
+
+// NewProvider starts a Provider for one OAuth2 application. Close it when done.
+func NewProvider(clientID, clientSecret string) *Provider {
+	p := &Provider{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		codes:        make(map[string]User),
+		tokens:       make(map[string]User),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /oauth2/authorize", p.authorize)
+	mux.HandleFunc("POST /api/oauth2/token", p.token)
+	mux.HandleFunc("GET /api/v10/users/@me", p.me)
+	p.Server = httptest.NewServer(mux)
+	return p
+}
+
+// Endpoints locates the provider.
+func (p *Provider) Endpoints() auth.Endpoints {
+	return auth.DiscordEndpoints(p.URL)
+}
+
+// Code issues an authorization code for u.
+func (p *Provider) Code(u User) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	code := randomHex()
+	p.codes[code] = u
+	return code
+}
+
+// LogInAs sets who the authorize endpoint logs in.
+func (p *Provider) LogInAs(u User) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.next = u
+}
+
+func (p *Provider) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("client_id") != p.ClientID || q.Get("response_type") != "code" {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	redirect, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil || redirect.Scheme == "" {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+
+	p.mu.Lock()
+	u := p.next
+	p.mu.Unlock()
+
+	back := redirect.Query()
+	back.Set("code", p.Code(u))
+	back.Set("state", q.Get("state"))
+	redirect.RawQuery = back.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (p *Provider) token(w http.ResponseWriter, r *http.Request) {
+	id, secret, ok := r.BasicAuth()
+	if !ok || id != p.ClientID || secret != p.ClientSecret {
+		http.Error(w, `{"error":"invalid_client"}`, http.StatusUnauthorized)
+		return
+	}
+	if r.FormValue("grant_type") != "authorization_code" {
+		http.Error(w, `{"error":"unsupported_grant_type"}`, http.StatusBadRequest)
+		return
+	}
+
+	p.mu.Lock()
+	u, ok := p.codes[r.FormValue("code")]
+	delete(p.codes, r.FormValue("code"))
+	var accessToken string
+	if ok {
+		accessToken = randomHex()
+		p.tokens[accessToken] = u
+	}
+	p.mu.Unlock()
+
+	if !ok {
+		http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"access_token": accessToken,
+		"token_type":   "Bearer",
+		"expires_in":   604800,
+		"scope":        "identify",
+	})
+}
+
+func (p *Provider) me(w http.ResponseWriter, r *http.Request) {
+	const prefix = "Bearer "
+	header := r.Header.Get("Authorization")
+	if len(header) <= len(prefix) || header[:len(prefix)] != prefix {
+		http.Error(w, `{"message":"401: Unauthorized"}`, http.StatusUnauthorized)
+		return
+	}
+
+	p.mu.Lock()
+	u, ok := p.tokens[header[len(prefix):]]
+	p.mu.Unlock()
+
+	if !ok {
+		http.Error(w, `{"message":"401: Unauthorized"}`, http.StatusUnauthorized)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(u)
+}
+
+func randomHex() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// MemStore is an in-memory auth.Store.
+type MemStore struct {
+	mu       sync.Mutex
+	sessions map[string]auth.Session
+}
+
+var _ auth.Store = (*MemStore)(nil)
+
+func (m *MemStore) CreateSession(_ context.Context, tokenHash []byte, userID collection.UserID, expiresAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.sessions == nil {
+		m.sessions = make(map[string]auth.Session)
+	}
+	m.sessions[string(tokenHash)] = auth.Session{UserID: userID, ExpiresAt: expiresAt}
+	return nil
+}
+
+func (m *MemStore) Session(_ context.Context, tokenHash []byte, now time.Time) (auth.Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.sessions[string(tokenHash)]
+	if !ok || !s.ExpiresAt.After(now) {
+		return auth.Session{}, collection.ErrNotFound
+	}
+	return s, nil
+}
+
+func (m *MemStore) DeleteSession(_ context.Context, tokenHash []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.sessions, string(tokenHash))
+	return nil
+}
+
+func (m *MemStore) DeleteExpiredSessions(_ context.Context, now time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var n int64
+	for k, s := range m.sessions {
+		if !s.ExpiresAt.After(now) {
+			delete(m.sessions, k)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// Len returns how many sessions are stored, expired or not.
+func (m *MemStore) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.sessions)
+}
@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/karitham/waifubot/collection"
+)
+
+// identity is the Discord user behind an access token.
+type identity struct {
+	UserID   collection.UserID
+	Username string
+	Avatar   string
+}
+
+// exchange trades an authorization code for an access token.
+func (s *Service) exchange(ctx context.Context, code string) (string, error) {
+	form := url.Values{
+		"grant_type":   {"authorization_code"},
+		"code":         {code},
+		"redirect_uri": {s.config.RedirectURL},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.config.Endpoints.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.SetBasicAuth(url.QueryEscape(s.config.ClientID), url.QueryEscape(s.config.ClientSecret))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to exchange code: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusUnauthorized {
+		return "", fmt.Errorf("%w: token endpoint returned %s", ErrLoginFailed, resp.Status)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token endpoint returned %s", resp.Status)
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("failed to decode token: %w", err)
+	}
+	if token.AccessToken == "" {
+		return "", fmt.Errorf("%w: no access token", ErrLoginFailed)
+	}
+	return token.AccessToken, nil
+}
+
+// identify looks up the user an access token belongs to.
+func (s *Service) identify(ctx context.Context, accessToken string) (identity, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", s.config.Endpoints.APIURL+"/users/@me", nil)
+	if err != nil {
+		return identity{}, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return identity{}, fmt.Errorf("failed to fetch user: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return identity{}, fmt.Errorf("user endpoint returned %s", resp.Status)
+	}
+
+	var user struct {
+		ID       string `json:"id"`
+		Username string `json:"username"`
+		Avatar   string `json:"avatar"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return identity{}, fmt.Errorf("failed to decode user: %w", err)
+	}
+
+	id, err := strconv.ParseUint(user.ID, 10, 64)
+	if err != nil || id == 0 {
+		return identity{}, fmt.Errorf("invalid user id %q", user.ID)
+	}
+	return identity{UserID: id, Username: user.Username, Avatar: user.Avatar}, nil
+}
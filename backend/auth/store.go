@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/storage/sessionstore"
+)
+
+type store struct {
+	q sessionstore.Querier
+}
+
+func NewStore(q sessionstore.Querier) Store {
+	return &store{q: q}
+}
+
+func (s *store) CreateSession(ctx context.Context, tokenHash []byte, userID collection.UserID, expiresAt time.Time) error {
+	return s.q.CreateSession(ctx, sessionstore.CreateSessionParams{
+		TokenHash: tokenHash,
+		UserID:    userID,
+		ExpiresAt: pgtype.Timestamp{Time: expiresAt.UTC(), Valid: true},
+	})
+}
+
+func (s *store) Session(ctx context.Context, tokenHash []byte, now time.Time) (Session, error) {
+	row, err := s.q.GetSession(ctx, sessionstore.GetSessionParams{
+		TokenHash: tokenHash,
+		Now:       pgtype.Timestamp{Time: now.UTC(), Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Session{}, collection.ErrNotFound
+		}
+		return Session{}, err
+	}
+	return Session{UserID: row.UserID, ExpiresAt: row.ExpiresAt.Time}, nil
+}
+
+func (s *store) DeleteSession(ctx context.Context, tokenHash []byte) error {
+	return s.q.DeleteSession(ctx, tokenHash)
+}
+
+func (s *store) DeleteExpiredSessions(ctx context.Context, now time.Time) (int64, error) {
+	return s.q.DeleteExpiredSessions(ctx, pgtype.Timestamp{Time: now.UTC(), Valid: true})
+}
@@ -0,0 +1,123 @@
+// Package backup exports accounts to files, and imports them back.
+//
+// A backup holds the collection, wishlist, tokens and profile of any number of
+// users, in JSON or CSV. Exports restore into the same or another deployment, and
+// server owners moving from another bot write the same format to bring their data in.
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/wishlist"
+)
+
+// Version is the version of the format written. Backups of other versions are rejected.
+const Version = 1
+
+var (
+	ErrUnsupportedVersion = errors.New("unsupported backup version")
+	ErrUnknownFormat      = errors.New("unknown backup format")
+)
+
+// Backup is the content of a backup file.
+type Backup struct {
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exported_at,omitzero"`
+	Users      []User    `json:"users"`
+}
+
+// User is the account of one user.
+type User struct {
+	UserID collection.UserID `json:"user_id,string"`
+	Tokens int32             `json:"tokens"`
+	Quote  string            `json:"quote,omitempty"`
+	// Favorite is the ID of the character shown on the profile, 0 for none.
+	Favorite   int64       `json:"favorite,omitempty"`
+	AnilistURL string      `json:"anilist_url,omitempty"`
+	Collection []Character `json:"collection"`
+	Wishlist   []Character `json:"wishlist"`
+}
+
+// Character is a character of a collection or wishlist. Only the ID is read back,
+// the rest comes from the catalog and is there for people reading the backup.
+type Character struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name,omitempty"`
+	Image      string `json:"image,omitempty"`
+	MediaTitle string `json:"media_title,omitempty"`
+	Favorites  int    `json:"favorites,omitempty"`
+	// Source and AcquiredAt are how and when a collected character was obtained.
+	Source     string    `json:"source,omitempty"`
+	AcquiredAt time.Time `json:"acquired_at,omitzero"`
+}
+
+// Kind is a part of a user's account.
+type Kind string
+
+const (
+	KindCollection Kind = "collection"
+	KindWishlist   Kind = "wishlist"
+	KindTokens     Kind = "tokens"
+	KindQuote      Kind = "quote"
+	KindFavorite   Kind = "favorite"
+	KindAnilistURL Kind = "anilist_url"
+)
+
+// Export backs up the accounts of userIDs.
+// It returns collection.ErrNotFound when one of them never used the bot.
+func Export(ctx context.Context, store collection.Store, wishlists wishlist.Store, userIDs ...collection.UserID) (Backup, error) {
+	b := Backup{
+		Version:    Version,
+		ExportedAt: time.Now().UTC(),
+		Users:      make([]User, 0, len(userIDs)),
+	}
+
+	for _, id := range userIDs {
+		u, err := store.GetUser(ctx, id)
+		if err != nil {
+			return Backup{}, fmt.Errorf("error getting user %d: %w", id, err)
+		}
+
+		owned, err := store.GetCollection(ctx, id)
+		if err != nil {
+			return Backup{}, fmt.Errorf("error getting collection of %d: %w", id, err)
+		}
+		chars := make([]Character, len(owned))
+		for i, c := range owned {
+			chars[i] = Character{
+				ID:         c.ID,
+				Name:       c.Name,
+				Image:      c.Image,
+				MediaTitle: c.MediaTitle,
+				Favorites:  c.Favorites,
+				Source:     c.Source,
+				AcquiredAt: c.Date.UTC(),
+			}
+		}
+
+		wished, err := wishlists.GetUserCharacterWishlist(ctx, id)
+		if err != nil {
+			return Backup{}, fmt.Errorf("error getting wishlist of %d: %w", id, err)
+		}
+		wishes := make([]Character, len(wished))
+		for i, c := range wished {
+			wishes[i] = Character{ID: c.ID, Name: c.Name, Image: c.Image, Favorites: c.Favorites}
+		}
+
+		b.Users = append(b.Users, User{
+			UserID:     id,
+			Tokens:     u.Tokens,
+			Quote:      u.Quote,
+			Favorite:   u.Favorite,
+			AnilistURL: u.AnilistURL,
+			Collection: chars,
+			Wishlist:   wishes,
+		})
+	}
+
+	return b, nil
+}
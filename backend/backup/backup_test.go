@@ -0,0 +1,252 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/collection/collectiontest"
+	"github.com/karitham/waifubot/wishlist"
+	"github.com/karitham/waifubot/wishlist/wishlisttest"
+)
+
+func sample() Backup {
+	return Backup{
+		Version:    Version,
+		ExportedAt: time.Date(2024, 2, 1, 12, 0, 0, 0, time.UTC),
+		Users: []User{
+			{
+				UserID:     42,
+				Tokens:     120,
+				Quote:      "  best girl, \"obviously\"",
+				Favorite:   1,
+				AnilistURL: "https://anilist.co/user/someone",
+				Collection: []Character{
+					{ID: 1, Name: "Rem", Image: "rem.jpg", MediaTitle: "Re:Zero", Favorites: 1000, Source: "ROLL", AcquiredAt: time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)},
+					{ID: 2, Name: "Megumin", Source: "OLD"},
+				},
+				Wishlist: []Character{{ID: 3, Name: "Emilia", Favorites: 800}},
+			},
+			{UserID: 43, Collection: []Character{{ID: 1}}, Wishlist: []Character{}},
+		},
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, f := range []Format{FormatJSON, FormatCSV} {
+		t.Run(string(f), func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, Write(&buf, sample(), f))
+
+			got, err := Read(&buf, f)
+			require.NoError(t, err)
+
+			want := sample()
+			if f == FormatCSV {
+				// CSV rows don't carry the export date, and lists are only there when they have rows.
+				want.ExportedAt = time.Time{}
+				want.Users[1].Wishlist = nil
+			}
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestRead(t *testing.T) {
+	t.Run("minimal csv", func(t *testing.T) {
+		b, err := Read(strings.NewReader("character_id,user_id\n1,42\n2, 42\n1,43\n"), FormatCSV)
+		require.NoError(t, err)
+		assert.Equal(t, []User{
+			{UserID: 42, Collection: []Character{{ID: 1}, {ID: 2}}},
+			{UserID: 43, Collection: []Character{{ID: 1}}},
+		}, b.Users)
+	})
+
+	tests := []struct {
+		name    string
+		format  Format
+		input   string
+		wantErr string
+	}{
+		{name: "json version", format: FormatJSON, input: `{"version":2,"users":[]}`, wantErr: ErrUnsupportedVersion.Error()},
+		{name: "csv version", format: FormatCSV, input: "user_id,kind,value\n,version,2\n", wantErr: ErrUnsupportedVersion.Error()},
+		{name: "no user column", format: FormatCSV, input: "character_id\n1\n", wantErr: "no user_id column"},
+		{name: "bad user", format: FormatCSV, input: "user_id,character_id\nabc,1\n", wantErr: `line 2: invalid user_id "abc"`},
+		{name: "bad character", format: FormatCSV, input: "user_id,kind\n42,wishlist\n", wantErr: `line 2: invalid character_id ""`},
+		{name: "bad kind", format: FormatCSV, input: "user_id,kind\n42,badges\n", wantErr: `line 2: invalid kind "badges"`},
+		{name: "unknown format", format: "xml", wantErr: ErrUnknownFormat.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read(strings.NewReader(tt.input), tt.format)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestFormatOf(t *testing.T) {
+	f, err := FormatOf("backup.CSV")
+	require.NoError(t, err)
+	assert.Equal(t, FormatCSV, f)
+
+	_, err = FormatOf("backup.txt")
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestExport(t *testing.T) {
+	acquired := time.Date(2023, 5, 1, 10, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	store := &collectiontest.MockStore{
+		GetUserFunc: func(_ context.Context, id collection.UserID) (collection.User, error) {
+			if id != 42 {
+				return collection.User{}, collection.ErrNotFound
+			}
+			return collection.User{UserID: 42, Tokens: 5, Quote: "hi"}, nil
+		},
+		GetCollectionFunc: func(context.Context, collection.UserID) ([]collection.OwnedCharacter, error) {
+			return []collection.OwnedCharacter{{Character: collection.Character{ID: 1, Name: "Rem"}, Source: "ROLL", Date: acquired}}, nil
+		},
+	}
+	wishlists := &wishlisttest.MockStore{
+		GetUserCharacterWishlistFunc: func(context.Context, uint64) ([]wishlist.Character, error) {
+			return []wishlist.Character{{ID: 3, Name: "Emilia"}}, nil
+		},
+	}
+
+	b, err := Export(t.Context(), store, wishlists, 42)
+	require.NoError(t, err)
+	assert.Equal(t, Version, b.Version)
+	assert.Equal(t, []User{{
+		UserID:     42,
+		Tokens:     5,
+		Quote:      "hi",
+		Collection: []Character{{ID: 1, Name: "Rem", Source: "ROLL", AcquiredAt: acquired.UTC()}},
+		Wishlist:   []Character{{ID: 3, Name: "Emilia"}},
+	}}, b.Users)
+
+	_, err = Export(t.Context(), store, wishlists, 42, 7)
+	assert.ErrorIs(t, err, collection.ErrNotFound)
+}
+
+func TestImport(t *testing.T) {
+	b := Backup{Version: Version, Users: []User{
+		{
+			UserID:     42,
+			Tokens:     50,
+			Quote:      "new quote",
+			Favorite:   2,
+			AnilistURL: "https://example.com/someone",
+			Collection: []Character{
+				{ID: 1, Source: "ROLL"},
+				{ID: 2, Source: "KAKERA"},
+				{ID: 2},
+				{ID: 5},
+				{ID: 9},
+			},
+			Wishlist: []Character{{ID: 2}, {ID: 3}, {ID: 4}, {ID: 9}},
+		},
+		{UserID: 43, Tokens: 10, Collection: []Character{{ID: 1}}},
+	}}
+
+	var (
+		imported []collection.UserID
+		added    []collection.OwnedCharacter
+		wished   = map[uint64][]int64{}
+		tokens   []collection.TokenChange
+	)
+	store := &collectiontest.MockStore{
+		GetUserFunc: func(_ context.Context, id collection.UserID) (collection.User, error) {
+			if id == 42 {
+				return collection.User{UserID: 42, Tokens: 7, Quote: "old quote"}, nil
+			}
+			return collection.User{}, collection.ErrNotFound
+		},
+		GetCollectionIDsFunc: func(_ context.Context, id collection.UserID) ([]int64, error) {
+			if id == 42 {
+				return []int64{5}, nil
+			}
+			return nil, nil
+		},
+		GetCharacterByIDFunc: func(_ context.Context, id int64) (collection.Character, error) {
+			if id == 9 {
+				return collection.Character{}, collection.ErrNotFound
+			}
+			return collection.Character{ID: id}, nil
+		},
+		CreateUserFunc: func(_ context.Context, id collection.UserID) error {
+			imported = append(imported, id)
+			return nil
+		},
+		AddToCollectionFunc: func(_ context.Context, id collection.UserID, c collection.Character, source string, at time.Time) error {
+			added = append(added, collection.OwnedCharacter{Character: c, Source: source, UserID: id})
+			return nil
+		},
+		AddTokensFunc: func(_ context.Context, _ collection.UserID, amount int32) (collection.User, error) {
+			return collection.User{Tokens: amount}, nil
+		},
+		RecordTokenChangeFunc: func(_ context.Context, c collection.TokenChange) error {
+			tokens = append(tokens, c)
+			return nil
+		},
+		AddToWishlistFunc: func(_ context.Context, id collection.UserID, ids []int64) error {
+			wished[id] = append(wished[id], ids...)
+			return nil
+		},
+	}
+	wishlists := &wishlisttest.MockStore{
+		GetUserCharacterWishlistFunc: func(_ context.Context, id uint64) ([]wishlist.Character, error) {
+			if id == 42 {
+				return []wishlist.Character{{ID: 4}}, nil
+			}
+			return nil, nil
+		},
+	}
+
+	wantUsers := []UserReport{
+		{UserID: 42, Characters: 2, Wishlist: 1, Profile: []Kind{KindFavorite}},
+		{UserID: 43, New: true, Characters: 1, Tokens: 10, Profile: []Kind{}},
+	}
+	wantConflicts := []Conflict{
+		{UserID: 42, Kind: KindCollection, CharacterID: 2, Reason: ReasonDuplicate},
+		{UserID: 42, Kind: KindCollection, CharacterID: 5, Reason: ReasonAlreadyOwned},
+		{UserID: 42, Kind: KindCollection, CharacterID: 9, Reason: ReasonUnknownCharacter},
+		{UserID: 42, Kind: KindWishlist, CharacterID: 2, Reason: ReasonAlreadyOwned},
+		{UserID: 42, Kind: KindWishlist, CharacterID: 4, Reason: ReasonAlreadyWishlisted},
+		{UserID: 42, Kind: KindWishlist, CharacterID: 9, Reason: ReasonUnknownCharacter},
+		{UserID: 42, Kind: KindTokens, Reason: ReasonDiffers},
+		{UserID: 42, Kind: KindQuote, Reason: ReasonDiffers},
+		{UserID: 42, Kind: KindAnilistURL, Reason: ReasonInvalid},
+	}
+
+	report, err := Import(t.Context(), store, wishlists, b, true)
+	require.NoError(t, err)
+	assert.Equal(t, Report{DryRun: true, Users: wantUsers, Conflicts: wantConflicts}, report)
+	assert.Empty(t, added)
+	assert.Empty(t, wished)
+
+	report, err = Import(t.Context(), store, wishlists, b, false)
+	require.NoError(t, err)
+	assert.Equal(t, Report{Users: wantUsers, Conflicts: wantConflicts}, report)
+	assert.Equal(t, []collection.OwnedCharacter{
+		{Character: collection.Character{ID: 1}, Source: "ROLL", UserID: 42},
+		{Character: collection.Character{ID: 2}, Source: "OLD", UserID: 42},
+		{Character: collection.Character{ID: 1}, Source: "OLD", UserID: 43},
+	}, added)
+	assert.Equal(t, map[uint64][]int64{42: {3}}, wished)
+	assert.Equal(t, []collection.UserID{43}, imported)
+	require.Len(t, tokens, 1)
+	assert.Equal(t, collection.TokenChange{UserID: 43, Amount: 10, Balance: 10, Reason: collection.TokenOpeningBalance, CreatedAt: tokens[0].CreatedAt}, tokens[0])
+}
+
+func TestImport_DuplicateUser(t *testing.T) {
+	b := Backup{Version: Version, Users: []User{{UserID: 42}, {UserID: 42}}}
+	_, err := Import(t.Context(), &collectiontest.MockStore{}, &wishlisttest.MockStore{}, b, true)
+	assert.ErrorContains(t, err, "user 42 is in the backup twice")
+}
@@ -0,0 +1,260 @@
+package backup
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/karitham/waifubot/collection"
+)
+
+// Format is how a backup is written.
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	// FormatCSV writes a row per character and profile field, see csvColumns.
+	FormatCSV Format = "csv"
+)
+
+// ParseFormat returns the format named s.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case FormatJSON, FormatCSV:
+		return f, nil
+	default:
+		return "", fmt.Errorf("%w %q", ErrUnknownFormat, s)
+	}
+}
+
+// FormatOf returns the format of a file from its extension.
+func FormatOf(path string) (Format, error) {
+	return ParseFormat(strings.TrimPrefix(filepath.Ext(path), "."))
+}
+
+// Write writes b to w in format f.
+func Write(w io.Writer, b Backup, f Format) error {
+	switch f {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(b)
+	case FormatCSV:
+		return writeCSV(w, b)
+	default:
+		return fmt.Errorf("%w %q", ErrUnknownFormat, f)
+	}
+}
+
+// Read reads a backup in format f from r.
+func Read(r io.Reader, f Format) (Backup, error) {
+	var b Backup
+	switch f {
+	case FormatJSON:
+		if err := json.NewDecoder(r).Decode(&b); err != nil {
+			return Backup{}, fmt.Errorf("error decoding backup: %w", err)
+		}
+	case FormatCSV:
+		var err error
+		if b, err = readCSV(r); err != nil {
+			return Backup{}, err
+		}
+	default:
+		return Backup{}, fmt.Errorf("%w %q", ErrUnknownFormat, f)
+	}
+
+	if b.Version != Version {
+		return Backup{}, fmt.Errorf("%w %d, want %d", ErrUnsupportedVersion, b.Version, Version)
+	}
+	return b, nil
+}
+
+// csvColumns are the columns of a CSV backup. Each row is a part of a user's
+// account named by kind: a collection or wishlist character, or a profile field
+// whose value is in the value column, or the favorite character's ID.
+//
+// A row of kind "version" with the version as value may come first. Rows
+// without a kind are collection characters, so a list of user and character IDs
+// is enough to import collections, and columns can be left out or reordered.
+var csvColumns = []string{"user_id", "kind", "character_id", "name", "image", "media_title", "favorites", "source", "acquired_at", "value"}
+
+const kindVersion Kind = "version"
+
+func writeCSV(w io.Writer, b Backup) error {
+	cw := csv.NewWriter(w)
+	rows := [][]string{csvColumns, {"", string(kindVersion), "", "", "", "", "", "", "", strconv.Itoa(b.Version)}}
+
+	for _, u := range b.Users {
+		id := strconv.FormatUint(u.UserID, 10)
+		field := func(k Kind, charID int64, value string) {
+			rows = append(rows, []string{id, string(k), optInt(charID), "", "", "", "", "", "", value})
+		}
+
+		field(KindTokens, 0, strconv.FormatInt(int64(u.Tokens), 10))
+		if u.Quote != "" {
+			field(KindQuote, 0, u.Quote)
+		}
+		if u.Favorite != 0 {
+			field(KindFavorite, u.Favorite, "")
+		}
+		if u.AnilistURL != "" {
+			field(KindAnilistURL, 0, u.AnilistURL)
+		}
+
+		for _, list := range []struct {
+			kind  Kind
+			chars []Character
+		}{{KindCollection, u.Collection}, {KindWishlist, u.Wishlist}} {
+			for _, c := range list.chars {
+				var acquired string
+				if !c.AcquiredAt.IsZero() {
+					acquired = c.AcquiredAt.Format(time.RFC3339)
+				}
+				rows = append(rows, []string{
+					id, string(list.kind), strconv.FormatInt(c.ID, 10), c.Name, c.Image, c.MediaTitle,
+					optInt(int64(c.Favorites)), c.Source, acquired, "",
+				})
+			}
+		}
+	}
+
+	return cw.WriteAll(rows)
+}
+
+func optInt(v int64) string {
+	if v == 0 {
+		return ""
+	}
+	return strconv.FormatInt(v, 10)
+}
+
+func readCSV(r io.Reader) (Backup, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return Backup{}, errors.New("empty backup")
+	}
+	if err != nil {
+		return Backup{}, fmt.Errorf("error reading backup: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(strings.ToLower(name))] = i
+	}
+	if _, ok := columns["user_id"]; !ok {
+		return Backup{}, errors.New("backup has no user_id column")
+	}
+
+	// Without a version row, the rows are read as the current version.
+	b := Backup{Version: Version}
+	users := map[collection.UserID]*User{}
+	var order []collection.UserID
+
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return Backup{}, fmt.Errorf("error reading backup: %w", err)
+		}
+		line, _ := cr.FieldPos(0)
+
+		col := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			if name == "value" {
+				return record[i]
+			}
+			return strings.TrimSpace(record[i])
+		}
+		invalid := func(name string) error {
+			return fmt.Errorf("line %d: invalid %s %q", line, name, col(name))
+		}
+
+		kind := Kind(col("kind"))
+		if kind == "" {
+			kind = KindCollection
+		}
+		if kind == kindVersion {
+			if b.Version, err = strconv.Atoi(strings.TrimSpace(col("value"))); err != nil {
+				return Backup{}, invalid("value")
+			}
+			continue
+		}
+
+		userID, err := strconv.ParseUint(col("user_id"), 10, 64)
+		if err != nil || userID == 0 {
+			return Backup{}, invalid("user_id")
+		}
+		u, ok := users[userID]
+		if !ok {
+			u = &User{UserID: userID}
+			users[userID] = u
+			order = append(order, userID)
+		}
+
+		var charID int64
+		if v := col("character_id"); v != "" || kind == KindCollection || kind == KindWishlist || kind == KindFavorite {
+			if charID, err = strconv.ParseInt(v, 10, 64); err != nil || charID <= 0 {
+				return Backup{}, invalid("character_id")
+			}
+		}
+
+		switch kind {
+		case KindCollection, KindWishlist:
+			c := Character{
+				ID:         charID,
+				Name:       col("name"),
+				Image:      col("image"),
+				MediaTitle: col("media_title"),
+				Source:     col("source"),
+			}
+			if v := col("favorites"); v != "" {
+				if c.Favorites, err = strconv.Atoi(v); err != nil {
+					return Backup{}, invalid("favorites")
+				}
+			}
+			if v := col("acquired_at"); v != "" {
+				if c.AcquiredAt, err = time.Parse(time.RFC3339, v); err != nil {
+					return Backup{}, invalid("acquired_at")
+				}
+			}
+			if kind == KindCollection {
+				u.Collection = append(u.Collection, c)
+			} else {
+				u.Wishlist = append(u.Wishlist, c)
+			}
+		case KindTokens:
+			tokens, err := strconv.ParseInt(strings.TrimSpace(col("value")), 10, 32)
+			if err != nil {
+				return Backup{}, invalid("value")
+			}
+			u.Tokens = int32(tokens)
+		case KindQuote:
+			u.Quote = col("value")
+		case KindFavorite:
+			u.Favorite = charID
+		case KindAnilistURL:
+			u.AnilistURL = col("value")
+		default:
+			return Backup{}, invalid("kind")
+		}
+	}
+
+	b.Users = make([]User, 0, len(order))
+	for _, id := range order {
+		b.Users = append(b.Users, *users[id])
+	}
+	return b, nil
+}
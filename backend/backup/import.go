@@ -0,0 +1,262 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/wishlist"
+)
+
+// Reason says why part of a backup isn't imported.
+type Reason string
+
+const (
+	// ReasonDuplicate is a character listed twice for the same user.
+	ReasonDuplicate Reason = "duplicate"
+	// ReasonUnknownCharacter is a character missing from the catalog.
+	ReasonUnknownCharacter Reason = "unknown_character"
+	// ReasonAlreadyOwned is a character the user owns, which can't be collected or wished for again.
+	ReasonAlreadyOwned Reason = "already_owned"
+	// ReasonAlreadyWishlisted is a character already on the user's wishlist.
+	ReasonAlreadyWishlisted Reason = "already_wishlisted"
+	// ReasonDiffers is a token balance or profile field the account already has
+	// another value for. The account keeps its own.
+	ReasonDiffers Reason = "differs"
+	// ReasonInvalid is a value the bot doesn't accept, such as a negative balance.
+	ReasonInvalid Reason = "invalid"
+)
+
+// Conflict is a part of a backup that isn't imported.
+type Conflict struct {
+	UserID      collection.UserID `json:"user_id,string"`
+	Kind        Kind              `json:"kind"`
+	CharacterID int64             `json:"character_id,omitempty"`
+	Reason      Reason            `json:"reason"`
+}
+
+// UserReport is what is imported into an account.
+type UserReport struct {
+	UserID collection.UserID `json:"user_id,string"`
+	// New is true when the user never used the bot before.
+	New        bool  `json:"new"`
+	Characters int   `json:"characters"`
+	Wishlist   int   `json:"wishlist"`
+	Tokens     int32 `json:"tokens"`
+	// Profile are the profile fields set.
+	Profile []Kind `json:"profile"`
+}
+
+// Report is the outcome of an import.
+type Report struct {
+	// DryRun is true when nothing was written, and the report is what would be imported.
+	DryRun    bool         `json:"dry_run"`
+	Users     []UserReport `json:"users"`
+	Conflicts []Conflict   `json:"conflicts"`
+}
+
+// sources are the ways a character can be acquired. Characters of a backup acquired
+// any other way are imported as OLD, like those carried over from the first bot.
+var sources = []string{"ROLL", "CLAIM", "GIVE", "OLD", "SERIES_ROLL", "TRADE"}
+
+// Import adds the accounts of b to the ones in store, resolving every character
+// against the catalog. Characters are added to collections and wishlists, while
+// tokens and profile fields are only set on accounts that don't have them yet.
+// The rest is reported as conflicts and skipped. With dryRun, nothing is written.
+func Import(ctx context.Context, store collection.Store, wishlists wishlist.Store, b Backup, dryRun bool) (Report, error) {
+	report := Report{DryRun: dryRun, Users: []UserReport{}, Conflicts: []Conflict{}}
+
+	seen := make(map[collection.UserID]bool, len(b.Users))
+	for _, u := range b.Users {
+		if u.UserID == 0 {
+			return Report{}, errors.New("backup has a user without an ID")
+		}
+		if seen[u.UserID] {
+			return Report{}, fmt.Errorf("user %d is in the backup twice", u.UserID)
+		}
+		seen[u.UserID] = true
+	}
+
+	for _, u := range b.Users {
+		p, err := plan(ctx, store, wishlists, u)
+		if err != nil {
+			return Report{}, fmt.Errorf("error checking user %d: %w", u.UserID, err)
+		}
+		report.Users = append(report.Users, p.report)
+		report.Conflicts = append(report.Conflicts, p.conflicts...)
+
+		if dryRun || p.empty() {
+			continue
+		}
+		if err := collection.ImportUser(ctx, store, u.UserID, p.imp); err != nil {
+			return report, fmt.Errorf("error importing user %d: %w", u.UserID, err)
+		}
+	}
+
+	return report, nil
+}
+
+// userPlan is what is imported into an account.
+type userPlan struct {
+	imp       collection.Import
+	report    UserReport
+	conflicts []Conflict
+}
+
+func (p userPlan) empty() bool {
+	return len(p.imp.Characters) == 0 && len(p.imp.Wishlist) == 0 && p.imp.Tokens == 0 && len(p.report.Profile) == 0
+}
+
+// plan compares u to the account it goes into.
+func plan(ctx context.Context, store collection.Store, wishlists wishlist.Store, u User) (userPlan, error) {
+	p := userPlan{report: UserReport{UserID: u.UserID, Profile: []Kind{}}}
+	conflict := func(k Kind, charID int64, r Reason) {
+		p.conflicts = append(p.conflicts, Conflict{UserID: u.UserID, Kind: k, CharacterID: charID, Reason: r})
+	}
+
+	account, err := store.GetUser(ctx, u.UserID)
+	if errors.Is(err, collection.ErrNotFound) {
+		p.report.New = true
+	} else if err != nil {
+		return userPlan{}, err
+	}
+
+	ownedIDs, err := store.GetCollectionIDs(ctx, u.UserID)
+	if err != nil {
+		return userPlan{}, err
+	}
+	owned := make(map[int64]bool, len(ownedIDs))
+	for _, id := range ownedIDs {
+		owned[id] = true
+	}
+
+	wished, err := wishlists.GetUserCharacterWishlist(ctx, u.UserID)
+	if err != nil {
+		return userPlan{}, err
+	}
+	onWishlist := make(map[int64]bool, len(wished))
+	for _, c := range wished {
+		onWishlist[c.ID] = true
+	}
+
+	catalog := map[int64]*collection.Character{}
+	lookup := func(id int64) (*collection.Character, error) {
+		if c, ok := catalog[id]; ok {
+			return c, nil
+		}
+		c, err := store.GetCharacterByID(ctx, id)
+		if errors.Is(err, collection.ErrNotFound) {
+			catalog[id] = nil
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		catalog[id] = &c
+		return &c, nil
+	}
+
+	seen := map[int64]bool{}
+	for _, c := range u.Collection {
+		if seen[c.ID] {
+			conflict(KindCollection, c.ID, ReasonDuplicate)
+			continue
+		}
+		seen[c.ID] = true
+
+		char, err := lookup(c.ID)
+		switch {
+		case err != nil:
+			return userPlan{}, err
+		case char == nil:
+			conflict(KindCollection, c.ID, ReasonUnknownCharacter)
+			continue
+		case owned[c.ID]:
+			conflict(KindCollection, c.ID, ReasonAlreadyOwned)
+			continue
+		}
+
+		source := c.Source
+		if !slices.Contains(sources, source) {
+			source = "OLD"
+		}
+		p.imp.Characters = append(p.imp.Characters, collection.OwnedCharacter{
+			Character: *char,
+			Source:    source,
+			Date:      c.AcquiredAt,
+			UserID:    u.UserID,
+		})
+		owned[c.ID] = true
+	}
+
+	clear(seen)
+	for _, c := range u.Wishlist {
+		if seen[c.ID] {
+			conflict(KindWishlist, c.ID, ReasonDuplicate)
+			continue
+		}
+		seen[c.ID] = true
+
+		char, err := lookup(c.ID)
+		switch {
+		case err != nil:
+			return userPlan{}, err
+		case char == nil:
+			conflict(KindWishlist, c.ID, ReasonUnknownCharacter)
+		case owned[c.ID]:
+			conflict(KindWishlist, c.ID, ReasonAlreadyOwned)
+		case onWishlist[c.ID]:
+			conflict(KindWishlist, c.ID, ReasonAlreadyWishlisted)
+		default:
+			p.imp.Wishlist = append(p.imp.Wishlist, c.ID)
+		}
+	}
+
+	switch {
+	case u.Tokens < 0:
+		conflict(KindTokens, 0, ReasonInvalid)
+	case u.Tokens == 0 || u.Tokens == account.Tokens:
+	case account.Tokens == 0:
+		p.imp.Tokens = u.Tokens
+	default:
+		conflict(KindTokens, 0, ReasonDiffers)
+	}
+
+	// field sets a profile field the account doesn't have yet.
+	field := func(k Kind, value, current string, valid bool, set func()) {
+		switch {
+		case value == "" || value == current:
+		case !valid:
+			conflict(k, 0, ReasonInvalid)
+		case current != "":
+			conflict(k, 0, ReasonDiffers)
+		default:
+			set()
+			p.report.Profile = append(p.report.Profile, k)
+		}
+	}
+	field(KindQuote, u.Quote, account.Quote, len(u.Quote) <= collection.MaxQuoteLength, func() { p.imp.Quote = u.Quote })
+	field(KindAnilistURL, u.AnilistURL, account.AnilistURL, collection.ValidateAnilistURL(u.AnilistURL) == nil, func() { p.imp.AnilistURL = u.AnilistURL })
+
+	if u.Favorite != 0 && u.Favorite != account.Favorite {
+		char, err := lookup(u.Favorite)
+		switch {
+		case err != nil:
+			return userPlan{}, err
+		case char == nil:
+			conflict(KindFavorite, u.Favorite, ReasonUnknownCharacter)
+		case account.Favorite != 0:
+			conflict(KindFavorite, u.Favorite, ReasonDiffers)
+		default:
+			p.imp.Favorite = u.Favorite
+			p.report.Profile = append(p.report.Profile, KindFavorite)
+		}
+	}
+
+	p.report.Characters = len(p.imp.Characters)
+	p.report.Wishlist = len(p.imp.Wishlist)
+	p.report.Tokens = p.imp.Tokens
+	return p, nil
+}
@@ -16,19 +16,83 @@ type Character struct {
 	Favorites  int
 	UpdatedAt  time.Time // for cursor tracking
 	IsActive   bool
+	Aliases    []string // alternative names from AniList, accepted by /claim
+	// Description is AniList's markdown description. Only written by UpsertCharacter,
+	// read it with Store.CharacterDescription.
+	Description string
+}
+
+// CharacterStats is how players hold a character.
+type CharacterStats struct {
+	Owners      int
+	GuildOwners int
+	Wishlisted  int
+	// FirstClaimed is when the character first entered a collection, zero if it never did.
+	FirstClaimed time.Time
+}
+
+// Media is an anime or manga characters appear in.
+type Media struct {
+	ID         int64
+	Title      string
+	Type       string // ANIME or MANGA
+	CoverImage string
+	Popularity int
+	// MalID is the media's MyAnimeList ID, 0 when AniList doesn't know it.
+	MalID     int64
+	UpdatedAt time.Time
+	// CharactersSyncedAt is when every character of the media was last linked
+	// by SetMediaCharacters. It is zero while only the characters the sync
+	// worker came across are linked, so CharactersByMedia may miss some.
+	CharactersSyncedAt time.Time
 }
 
 // Drop is a Character that appeared in a channel drop.
-type Drop = Character
+type Drop struct {
+	Character
+	// ExpiresAt is when the drop stops being claimable. The zero value never expires.
+	ExpiresAt time.Time
+}
+
+// Expired reports whether the drop can no longer be claimed at now.
+func (d Drop) Expired(now time.Time) bool {
+	return !d.ExpiresAt.IsZero() && !now.Before(d.ExpiresAt)
+}
 
 // Store provides character catalog operations.
-// Wraps collectionstore.Querier for character CRUD and guildstore.Querier for guild ownership.
+// Wraps collectionstore.Querier for character CRUD, guildstore.Querier for guild ownership
+// and catalogstore.Querier for media.
 type Store interface {
 	UpsertCharacter(ctx context.Context, char Character) error
 	GetCharacterByID(ctx context.Context, charID int64) (Character, error)
 	SearchCharacters(ctx context.Context, userID uint64, term string) ([]Character, error)
 	SearchGlobalCharacters(ctx context.Context, term string) ([]Character, error)
 	GetCharacterHoldersInGuild(ctx context.Context, guildID uint64, charID int64) ([]uint64, error)
+	// CharacterDescription returns the description of a character, empty when AniList has none.
+	CharacterDescription(ctx context.Context, charID int64) (string, error)
+	// CharacterStats counts the owners of a character, GuildOwners among the members of guildID.
+	CharacterStats(ctx context.Context, charID int64, guildID uint64) (CharacterStats, error)
+	// CharactersStats counts the owners and wishes of many characters at once, by character ID.
+	// Characters nobody holds are missing from the map.
+	CharactersStats(ctx context.Context, charIDs []int64) (map[int64]CharacterStats, error)
 	GetActiveIDs(ctx context.Context) ([]int64, error)
 	MarkCharactersInactive(ctx context.Context, ids []int64) error
+
+	UpsertMedia(ctx context.Context, media []Media) error
+	// SetCharacterMedia replaces the media a character appears in.
+	// The character and every media must already be stored.
+	// Links to media with synced characters are kept.
+	SetCharacterMedia(ctx context.Context, charID int64, mediaIDs []int64) error
+	// SetMediaCharacters replaces the characters of a media with every one of
+	// them and sets its CharactersSyncedAt. The media and characters that
+	// aren't stored yet are added, stored ones are left as they are.
+	SetMediaCharacters(ctx context.Context, media Media, chars []Character, syncedAt time.Time) error
+	GetMedia(ctx context.Context, mediaID int64) (Media, error)
+	// MediaByMalID returns the media of type mediaType with the MyAnimeList ID malID.
+	MediaByMalID(ctx context.Context, malID int64, mediaType string) (Media, error)
+	SearchMedia(ctx context.Context, term string) ([]Media, error)
+	// CharactersByMedia returns the active characters of a media, most favorited first.
+	CharactersByMedia(ctx context.Context, mediaID int64) ([]Character, error)
+	// MediaByCharacter returns the media a character appears in, most popular first.
+	MediaByCharacter(ctx context.Context, charID int64) ([]Media, error)
 }
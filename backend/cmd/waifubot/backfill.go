@@ -17,7 +17,7 @@ var BackfillCommand = &cli.Command{
 	Description: `Generates random character IDs, batch-fetches them from AniList,
 and upserts valid results into the local characters table.
 
-The process respects AniList rate limits (5 req/min) and converges on the
+The process respects AniList rate limits and converges on the
 full character set over time.`,
 	Flags: []cli.Flag{
 		dbURLFlag,
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Karitham/corde"
+	"github.com/urfave/cli/v2"
+
+	"github.com/karitham/waifubot/backup"
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/storage"
+	"github.com/karitham/waifubot/wishlist"
+)
+
+var backupFormatFlag = &cli.StringFlag{
+	Name:  "format",
+	Usage: "json or csv, guessed from the file extension when not set",
+}
+
+// backupFormat returns the format flag, or the format of path, defaulting to JSON.
+func backupFormat(c *cli.Context, path string) (backup.Format, error) {
+	if f := c.String(backupFormatFlag.Name); f != "" {
+		return backup.ParseFormat(f)
+	}
+	if path == "" || path == "-" {
+		return backup.FormatJSON, nil
+	}
+	return backup.FormatOf(path)
+}
+
+var ExportCommand = &cli.Command{
+	Name:  "export",
+	Usage: "Back up users' collection, wishlist, tokens and profile",
+	Flags: []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:     "user",
+			Usage:    "User ID to export, repeat it to export several users",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:    "output",
+			Aliases: []string{"o"},
+			Usage:   "File to write the backup to, stdout when not set",
+		},
+		backupFormatFlag,
+		dbURLFlag,
+	},
+	Action: func(c *cli.Context) error {
+		var userIDs []collection.UserID
+		for _, s := range c.StringSlice("user") {
+			userID := corde.SnowflakeFromString(s)
+			if userID == 0 {
+				return fmt.Errorf("invalid user ID: %s", s)
+			}
+			userIDs = append(userIDs, uint64(userID))
+		}
+
+		path := c.String("output")
+		format, err := backupFormat(c, path)
+		if err != nil {
+			return err
+		}
+
+		ctx := c.Context
+		store, err := storage.NewStore(ctx, c.String(dbURLFlag.Name))
+		if err != nil {
+			return fmt.Errorf("error connecting to db: %w", err)
+		}
+
+		b, err := backup.Export(ctx, newCollectionStore(store), wishlist.New(store.WishlistStore()), userIDs...)
+		if err != nil {
+			return fmt.Errorf("error exporting: %w", err)
+		}
+
+		if path == "" || path == "-" {
+			return backup.Write(os.Stdout, b, format)
+		}
+
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		if err := backup.Write(f, b, format); err != nil {
+			_ = f.Close()
+			return err
+		}
+		return f.Close()
+	},
+}
+
+var ImportCommand = &cli.Command{
+	Name:  "import",
+	Usage: "Import a backup, or the export of another bot converted to the backup format",
+	Description: "Characters are resolved against the catalog and added to collections and wishlists.\n" +
+		"Tokens and profile fields are only set on users who don't have them yet.\n" +
+		"Whatever can't be imported is reported as a conflict and skipped.",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "file",
+			Aliases:  []string{"f"},
+			Usage:    "Backup to import, - for stdin",
+			Required: true,
+		},
+		backupFormatFlag,
+		&cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Only report what would be imported and the conflicts",
+		},
+		dbURLFlag,
+	},
+	Action: func(c *cli.Context) error {
+		path := c.String("file")
+		format, err := backupFormat(c, path)
+		if err != nil {
+			return err
+		}
+
+		var r io.Reader = os.Stdin
+		if path != "-" {
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			r = f
+		}
+
+		b, err := backup.Read(r, format)
+		if err != nil {
+			return err
+		}
+
+		ctx := c.Context
+		store, err := storage.NewStore(ctx, c.String(dbURLFlag.Name))
+		if err != nil {
+			return fmt.Errorf("error connecting to db: %w", err)
+		}
+
+		report, err := backup.Import(ctx, newCollectionStore(store), wishlist.New(store.WishlistStore()), b, c.Bool("dry-run"))
+		if encErr := json.NewEncoder(os.Stdout).Encode(report); encErr != nil && err == nil {
+			err = encErr
+		}
+		if err != nil {
+			return fmt.Errorf("error importing: %w", err)
+		}
+		return nil
+	},
+}
@@ -17,4 +17,5 @@ var (
 	nameFlag           = flags.NameFlag
 	logLevelFlag       = flags.LogLevelFlag
 	apiFlag            = flags.ApiFlag
+	trackerFlag        = flags.TrackerFlag
 )
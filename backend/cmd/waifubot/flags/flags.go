@@ -81,4 +81,12 @@ var (
 		Usage: "Enable REST API server (default: true)",
 		Value: true,
 	}
+
+	// TrackerFlag is the tracker backend flag
+	TrackerFlag = &cli.StringFlag{
+		Name:    "tracker",
+		Usage:   "Tracker backend to search, anilist or mal",
+		EnvVars: []string{"TRACKER"},
+		Value:   "anilist",
+	}
 )
@@ -49,7 +49,7 @@ var GiveCommand = &cli.Command{
 			return fmt.Errorf("invalid to user ID: %s", toStr)
 		}
 
-		char, err := collection.Give(ctx, newCollectionStore(store), from, to, charID)
+		char, err := collection.AdminGive(ctx, newCollectionStore(store), from, to, charID)
 		if err != nil {
 			return fmt.Errorf("error giving: %w", err)
 		}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/storage"
+)
+
+var HistoryCommand = &cli.Command{
+	Name:  "history",
+	Usage: "Show the ownership history of a character",
+	Flags: []cli.Flag{
+		charIDFlag,
+		&cli.IntFlag{
+			Name:  "limit",
+			Usage: "Maximum number of events to show",
+			Value: collection.DefaultHistoryLimit,
+		},
+		dbURLFlag,
+	},
+	Action: func(c *cli.Context) error {
+		charID := c.Int64(charIDFlag.Name)
+		limit := c.Int("limit")
+		dbURL := c.String(dbURLFlag.Name)
+
+		ctx := c.Context
+		store, err := storage.NewStore(ctx, dbURL)
+		if err != nil {
+			return fmt.Errorf("error connecting to db: %w", err)
+		}
+
+		char, events, err := collection.CharacterHistory(ctx, newCollectionStore(store), charID, int32(limit))
+		if err != nil {
+			return fmt.Errorf("error getting history: %w", err)
+		}
+
+		result := map[string]any{
+			"character": char,
+			"events":    events,
+		}
+
+		return json.NewEncoder(os.Stdout).Encode(result)
+	},
+}
@@ -48,9 +48,13 @@ func main() {
 			ListCommand,
 			RollCommand,
 			GiveCommand,
+			HistoryCommand,
+			TokensCommand,
 			WishlistCommand,
 			UpdateCharacterCommand,
 			BackfillCommand,
+			ExportCommand,
+			ImportCommand,
 		},
 		DefaultCommand: "run",
 	}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/Karitham/corde"
 	"github.com/fxamacker/cbor/v2"
@@ -161,6 +162,7 @@ func (m *Migrator) migrateDrop(ctx context.Context, key string) error {
 		Name:       data.Name,
 		ImageURL:   data.ImageURL,
 		MediaTitle: data.MediaTitle,
+		ExpiresAt:  time.Now().Add(collection.DropLifetime),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to migrate drop for channel %s: %w", sf, err)
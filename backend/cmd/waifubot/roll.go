@@ -36,11 +36,11 @@ var RollCommand = &cli.Command{
 			return fmt.Errorf("invalid user ID: %s", userIDStr)
 		}
 
-		config := collection.RollConfig{
+		config := collection.Config{
 			RollCooldown: rollCooldown,
 		}
 		svc := collection.NewRollService(newCollectionStore(store), config)
-		char, err := svc.Roll(ctx, userID)
+		char, err := svc.Roll(ctx, 0, userID)
 		if err != nil {
 			return fmt.Errorf("error rolling: %w", err)
 		}
@@ -1,31 +1,42 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
+	"math"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Karitham/corde"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
-	"github.com/go-chi/cors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/urfave/cli/v2"
 
+	"github.com/karitham/waifubot/activity"
 	"github.com/karitham/waifubot/anilist"
+	"github.com/karitham/waifubot/anilist/cache"
+	"github.com/karitham/waifubot/auth"
+	"github.com/karitham/waifubot/collection"
 	"github.com/karitham/waifubot/discord"
 	"github.com/karitham/waifubot/guild"
+	"github.com/karitham/waifubot/jikan"
+	"github.com/karitham/waifubot/leaderboard"
 	"github.com/karitham/waifubot/rest"
 	"github.com/karitham/waifubot/rest/api"
 	"github.com/karitham/waifubot/services"
+	"github.com/karitham/waifubot/settings"
 	"github.com/karitham/waifubot/storage"
 	"github.com/karitham/waifubot/storage/commandpg"
 	"github.com/karitham/waifubot/storage/dropstore"
 	"github.com/karitham/waifubot/storage/interactionstore"
 	"github.com/karitham/waifubot/sync"
+	"github.com/karitham/waifubot/tracker"
 	"github.com/karitham/waifubot/wishlist"
 )
 
@@ -64,10 +75,128 @@ var RunCommand = &cli.Command{
 		},
 		&cli.BoolFlag{
 			Name:    "sync",
-			Usage:   "Background character sync from AniList (5 req/min)",
+			Usage:   "Background character sync from AniList, using what users leave of the rate limit",
 			EnvVars: []string{"SYNC"},
 			Value:   true,
 		},
+		&cli.DurationFlag{
+			Name:    "auction-settle-interval",
+			Usage:   "How often ended auctions are settled",
+			EnvVars: []string{"AUCTION_SETTLE_INTERVAL"},
+			Value:   time.Minute,
+		},
+		&cli.DurationFlag{
+			Name:    "drop-lifetime",
+			Usage:   "How long a drop can be claimed before it is revealed",
+			EnvVars: []string{"DROP_LIFETIME"},
+			Value:   collection.DropLifetime,
+		},
+		&cli.IntSliceFlag{
+			Name:    "drop-hint-thresholds",
+			Usage:   "Failed claims on a drop before each hint is posted (media, initials, then masked names)",
+			EnvVars: []string{"DROP_HINT_THRESHOLDS"},
+			Value:   cli.NewIntSlice(3, 6, 9, 12),
+		},
+		&cli.DurationFlag{
+			Name:    "drop-reap-interval",
+			Usage:   "How often expired drops are revealed",
+			EnvVars: []string{"DROP_REAP_INTERVAL"},
+			Value:   time.Minute,
+		},
+		&cli.DurationFlag{
+			Name:    "leaderboard-refresh-interval",
+			Usage:   "How often the leaderboard rankings are recomputed",
+			EnvVars: []string{"LEADERBOARD_REFRESH_INTERVAL"},
+			Value:   leaderboard.DefaultRefreshInterval,
+		},
+		&cli.DurationFlag{
+			Name:    "value-record-interval",
+			Usage:   "How often the value of every collection is recorded for today",
+			EnvVars: []string{"VALUE_RECORD_INTERVAL"},
+			Value:   time.Hour,
+		},
+		&cli.IntSliceFlag{
+			Name:    "daily-schedule",
+			Usage:   "Tokens paid on each day of a /daily streak; the last value repeats",
+			EnvVars: []string{"DAILY_SCHEDULE"},
+			Value:   cli.NewIntSlice(1, 1, 2, 2, 3, 3, 5),
+		},
+		&cli.DurationFlag{
+			Name:    "daily-grace",
+			Usage:   "Extra time after a missed day before a /daily streak resets",
+			EnvVars: []string{"DAILY_GRACE"},
+			Value:   collection.DefaultDailyGrace,
+		},
+		&cli.StringSliceFlag{
+			Name:    "daily-milestones",
+			Usage:   "Bonus tokens for reaching a /daily streak, as streak:tokens pairs",
+			EnvVars: []string{"DAILY_MILESTONES"},
+			Value:   cli.NewStringSlice("7:5", "30:20", "100:50"),
+		},
+		&cli.IntFlag{
+			Name:    "anilist-cache-size",
+			Usage:   "AniList answers kept in memory in front of the database cache",
+			EnvVars: []string{"ANILIST_CACHE_SIZE"},
+			Value:   cache.DefaultSize,
+		},
+		&cli.StringSliceFlag{
+			Name:    "anilist-cache-ttl",
+			Usage:   "How long AniList answers stay fresh, as query:duration pairs overriding the defaults (0 disables a query)",
+			EnvVars: []string{"ANILIST_CACHE_TTL"},
+		},
+		&cli.DurationFlag{
+			Name:    "anilist-cache-max-stale",
+			Usage:   "How long expired AniList answers are still served while AniList is down",
+			EnvVars: []string{"ANILIST_CACHE_MAX_STALE"},
+			Value:   cache.DefaultMaxStale,
+		},
+		&cli.IntFlag{
+			Name:    "anilist-rate-limit",
+			Usage:   "AniList requests per minute until AniList reports its own limit",
+			EnvVars: []string{"ANILIST_RATE_LIMIT"},
+			Value:   anilist.DefaultRateLimit,
+		},
+		&cli.IntFlag{
+			Name:    "anilist-interactive-reserve",
+			Usage:   "AniList requests per minute the background sync leaves to users",
+			EnvVars: []string{"ANILIST_INTERACTIVE_RESERVE"},
+			Value:   anilist.DefaultInteractiveReserve,
+		},
+		&cli.StringFlag{
+			Name:    "oauth-client-secret",
+			Usage:   "Client secret of the Discord application, enables logging in to the REST API",
+			EnvVars: []string{"DISCORD_CLIENT_SECRET"},
+		},
+		&cli.StringFlag{
+			Name:    "oauth-redirect-url",
+			Usage:   "Login callback registered with the Discord application",
+			EnvVars: []string{"OAUTH_REDIRECT_URL"},
+			Value:   "https://waifuapi.karitham.dev/api/v1/auth/callback",
+		},
+		&cli.StringFlag{
+			Name:    "oauth-home-url",
+			Usage:   "Where users land once logged in",
+			EnvVars: []string{"OAUTH_HOME_URL"},
+			Value:   "https://waifugui.karitham.dev",
+		},
+		&cli.StringSliceFlag{
+			Name:    "cors-origins",
+			Usage:   "Origins of the frontend, the only ones allowed to call the API with credentials",
+			EnvVars: []string{"CORS_ORIGINS"},
+			Value:   cli.NewStringSlice("https://waifugui.karitham.dev"),
+		},
+		&cli.StringFlag{
+			Name:    "oauth-base-url",
+			Usage:   "Where Discord's OAuth2 endpoints are served, to log in against a fake provider",
+			EnvVars: []string{"OAUTH_BASE_URL"},
+		},
+		&cli.DurationFlag{
+			Name:    "session-ttl",
+			Usage:   "How long a login lasts",
+			EnvVars: []string{"SESSION_TTL"},
+			Value:   auth.DefaultSessionTTL,
+		},
+		trackerFlag,
 		logLevelFlag,
 		apiFlag,
 	},
@@ -85,6 +214,21 @@ var RunCommand = &cli.Command{
 			return fmt.Errorf("error connecting to db: %w", err)
 		}
 
+		daily, err := dailyConfig(c)
+		if err != nil {
+			return err
+		}
+
+		cacheConfig, err := anilistCacheConfig(c)
+		if err != nil {
+			return err
+		}
+
+		provider, err := trackerProvider(c)
+		if err != nil {
+			return err
+		}
+
 		var guildID *corde.Snowflake
 		if gid := c.Uint64("guild-id"); gid != 0 {
 			id := corde.Snowflake(gid)
@@ -96,53 +240,76 @@ var RunCommand = &cli.Command{
 		collStore := newCollectionStore(store)
 		wishStore := wishlist.New(store.WishlistStore())
 		catalogStore := newCatalogStore(store)
+		boards := leaderboard.NewService(leaderboard.NewStore(store.LeaderboardStore()))
 
-		anilistClient := anilist.New()
+		if c.Int("anilist-rate-limit") < 1 {
+			return fmt.Errorf("invalid anilist rate limit %d, want at least 1", c.Int("anilist-rate-limit"))
+		}
+		anilistRaw := anilist.NewWithScheduler(anilist.NewScheduler(anilist.SchedulerConfig{
+			Limit:   c.Int("anilist-rate-limit"),
+			Window:  time.Minute,
+			Reserve: c.Int("anilist-interactive-reserve"),
+		}))
+		anilistClient := cache.New(anilistRaw, cache.NewStore(store.AnilistCacheStore()), cacheConfig)
+		go anilistClient.Run(ctx, time.Hour)
+
+		trackers := map[tracker.Provider]discord.TrackingService{
+			tracker.AniList:     anilistClient,
+			tracker.MyAnimeList: tracker.Map(tracker.MyAnimeList, jikan.New(), catalogStore, tracker.NewStore(store.TrackerStore())),
+		}
 
 		slog.Info("Starting WaifuBot", "port", c.String("port"), "app_id", c.String("app-id"), "api_enabled", c.Bool(apiFlag.Name))
 		router := discord.New(&discord.Router{
-			Store:             collStore,
-			Catalog:           catalogStore,
-			CommandStore:      commandpg.New(store.CommandStore()),
-			WishlistStore:     wishStore,
-			AnimeService:      anilistClient,
-			DropStore:         dropStore,
-			InterStore:        interStore,
-			GuildIndexer:      guild.NewIndexer(collStore, guild.NewDiscordFetcher(c.String(botTokenFlag.Name))),
-			GuildOps:          collStore,
-			AppID:             corde.Snowflake(c.Uint64("app-id")),
-			GuildID:           guildID,
-			BotToken:          c.String(botTokenFlag.Name),
-			PublicKey:         c.String("public-key"),
-			RollCooldown:      c.Duration(rollCooldownFlag.Name),
-			InteractionNeeded: c.Int64("interaction-needed"),
-			SeriesRollCost:    int32(c.Int(seriesRollCostFlag.Name)),
+			Store:         collStore,
+			Catalog:       catalogStore,
+			CommandStore:  commandpg.New(store.CommandStore()),
+			WishlistStore: wishStore,
+			AnimeService:  trackers[provider],
+			Tracker:       provider,
+			Trackers:      trackers,
+			MediaSource:   anilistClient,
+			DropStore:     dropStore,
+			InterStore:    interStore,
+			GuildIndexer:  guild.NewIndexer(collStore, guild.NewDiscordFetcher(c.String(botTokenFlag.Name))),
+			GuildOps:      collStore,
+			Leaderboards:  boards,
+			Settings: settings.NewService(settings.NewStore(store.SettingsStore()), collection.Config{
+				RollCooldown:      c.Duration(rollCooldownFlag.Name),
+				InteractionNeeded: c.Int64("interaction-needed"),
+				SeriesRollCost:    int32(c.Int(seriesRollCostFlag.Name)),
+			}, settings.DefaultCacheTTL),
+			DropLifetime:   c.Duration("drop-lifetime"),
+			HintThresholds: c.IntSlice("drop-hint-thresholds"),
+			Daily:          daily,
+			AppID:          corde.Snowflake(c.Uint64("app-id")),
+			GuildID:        guildID,
+			BotToken:       c.String(botTokenFlag.Name),
+			PublicKey:      c.String("public-key"),
 		})
 		mux := router.Register()
 
+		go router.RunAuctionSettler(ctx, c.Duration("auction-settle-interval"))
+		go router.RunDropReaper(ctx, c.Duration("drop-reap-interval"))
+		go boards.Run(ctx, c.Duration("leaderboard-refresh-interval"))
+		go collection.RunValueRecorder(ctx, collStore, c.Duration("value-record-interval"))
+
 		// Start background sync worker if enabled
 		if c.Bool("sync") {
 			go func() {
 				slog.Info("character sync worker started")
-				sync.NewService(catalogStore, anilistClient, anilistClient).Run(ctx)
+				sync.NewService(catalogStore, anilistClient, anilistRaw).Run(ctx)
 				slog.Info("character sync worker stopped")
 			}()
 		}
 
 		port := c.Int("port")
 
-		r := chi.NewRouter()
-		r.Use(middleware.Timeout(5 * time.Second))
-		r.Use(rest.LoggerMiddleware(slog.Default()))
-		r.Use(middleware.Compress(5))
-
-		r.Use(cors.Handler(cors.Options{
-			AllowedOrigins:   []string{"https://*", "http://*"},
-			AllowedMethods:   []string{"GET", "OPTIONS"},
-			AllowedHeaders:   []string{"Accept", "Content-Type", "If-None-Match"},
-			MaxAge:           300,
-			AllowCredentials: true,
-		}))
+		root := chi.NewRouter()
+		root.Use(rest.LoggerMiddleware(slog.Default()))
+		root.Use(rest.CORSMiddleware(c.StringSlice("cors-origins")))
+
+		// Everything but the event stream, which stays open, is timed out and compressed.
+		r := root.With(middleware.Timeout(5*time.Second), middleware.Compress(5))
 
 		r.Handle("/metrics", promhttp.Handler())
 		r.Handle("/", mux)
@@ -154,7 +321,20 @@ var RunCommand = &cli.Command{
 				discordService = services.NewDiscordService(discordToken)
 			}
 
-			restServer := rest.New(collStore, wishStore, discordService)
+			var logins *auth.Service
+			if secret := c.String("oauth-client-secret"); secret != "" {
+				logins = auth.NewService(auth.Config{
+					ClientID:     c.String(appIDFlag.Name),
+					ClientSecret: secret,
+					RedirectURL:  c.String("oauth-redirect-url"),
+					HomeURL:      c.String("oauth-home-url"),
+					Endpoints:    auth.DiscordEndpoints(c.String("oauth-base-url")),
+					SessionTTL:   c.Duration("session-ttl"),
+				}, auth.NewStore(store.SessionStore()), collStore)
+				go logins.Run(ctx, auth.DefaultReapInterval)
+			}
+
+			restServer := rest.New(collStore, wishStore, anilistClient, boards, logins, discordService)
 
 			telemetry, err := rest.SetupTelemetry(prometheus.DefaultRegisterer)
 			if err != nil {
@@ -168,6 +348,8 @@ var RunCommand = &cli.Command{
 
 			apiRouter, err := api.NewServer(
 				restServer,
+				restServer,
+				api.WithErrorHandler(rest.ErrorHandler),
 				api.WithMeterProvider(telemetry.MeterProvider()),
 			)
 			if err != nil {
@@ -175,12 +357,16 @@ var RunCommand = &cli.Command{
 			}
 
 			r.Mount("/", rest.ETagMiddleware(apiRouter))
+
+			hub := activity.NewHub(activity.NewStore(store.LedgerStore()), store)
+			go hub.Run(ctx)
+			root.Get(rest.EventsPath, rest.EventsHandler(hub).ServeHTTP)
 			slog.Info("REST API server started", "port", port)
 		}
 
 		slog.Info("Discord bot started", "port", port)
 
-		if err := http.ListenAndServe(":"+strconv.Itoa(port), r); err != nil {
+		if err := http.ListenAndServe(":"+strconv.Itoa(port), root); err != nil {
 			slog.Error("Server crashed", "error", err, "port", port)
 			return err
 		}
@@ -189,3 +375,47 @@ var RunCommand = &cli.Command{
 		return nil
 	},
 }
+
+// dailyConfig reads the /daily reward flags.
+func dailyConfig(c *cli.Context) (collection.DailyConfig, error) {
+	config := collection.DailyConfig{Grace: c.Duration("daily-grace")}
+	for _, v := range c.IntSlice("daily-schedule") {
+		if v <= 0 || v > math.MaxInt32 {
+			return collection.DailyConfig{}, fmt.Errorf("invalid daily schedule value %d, want a positive number of tokens", v)
+		}
+		config.Schedule = append(config.Schedule, int32(v))
+	}
+	if len(config.Schedule) == 0 {
+		return collection.DailyConfig{}, errors.New("daily schedule is empty")
+	}
+
+	config.Milestones = []collection.StreakMilestone{}
+	for _, pair := range c.StringSlice("daily-milestones") {
+		streak, tokens, ok := strings.Cut(pair, ":")
+		s, err1 := strconv.ParseInt(streak, 10, 32)
+		t, err2 := strconv.ParseInt(tokens, 10, 32)
+		if !ok || err1 != nil || err2 != nil || s <= 0 || t <= 0 {
+			return collection.DailyConfig{}, fmt.Errorf("invalid daily milestone %q, want streak:tokens", pair)
+		}
+		config.Milestones = append(config.Milestones, collection.StreakMilestone{Streak: int32(s), Tokens: int32(t)})
+	}
+
+	return config, nil
+}
+
+func anilistCacheConfig(c *cli.Context) (cache.Config, error) {
+	config := cache.DefaultConfig()
+	config.Size = c.Int("anilist-cache-size")
+	config.MaxStale = c.Duration("anilist-cache-max-stale")
+
+	for _, pair := range c.StringSlice("anilist-cache-ttl") {
+		query, ttl, ok := strings.Cut(pair, ":")
+		d, err := time.ParseDuration(ttl)
+		if !ok || err != nil || d < 0 || !slices.Contains(cache.Queries, cache.Query(query)) {
+			return cache.Config{}, fmt.Errorf("invalid anilist cache ttl %q, want query:duration", pair)
+		}
+		config.TTLs[cache.Query(query)] = d
+	}
+
+	return config, nil
+}
@@ -6,8 +6,6 @@ import (
 	"os"
 
 	"github.com/urfave/cli/v2"
-
-	"github.com/karitham/waifubot/anilist"
 )
 
 var SearchAnimeCommand = &cli.Command{
@@ -15,12 +13,16 @@ var SearchAnimeCommand = &cli.Command{
 	Usage: "Search for an anime by name",
 	Flags: []cli.Flag{
 		nameFlag,
+		trackerFlag,
 	},
 	Action: func(c *cli.Context) error {
 		name := c.String(nameFlag.Name)
 
 		ctx := c.Context
-		animeService := anilist.New()
+		animeService, err := searchTracker(c)
+		if err != nil {
+			return err
+		}
 		media, err := animeService.Anime(ctx, name)
 		if err != nil {
 			return fmt.Errorf("error searching anime: %w", err)
@@ -6,8 +6,6 @@ import (
 	"os"
 
 	"github.com/urfave/cli/v2"
-
-	"github.com/karitham/waifubot/anilist"
 )
 
 var SearchMangaCommand = &cli.Command{
@@ -15,12 +13,16 @@ var SearchMangaCommand = &cli.Command{
 	Usage: "Search for a manga by name",
 	Flags: []cli.Flag{
 		nameFlag,
+		trackerFlag,
 	},
 	Action: func(c *cli.Context) error {
 		name := c.String(nameFlag.Name)
 
 		ctx := c.Context
-		animeService := anilist.New()
+		animeService, err := searchTracker(c)
+		if err != nil {
+			return err
+		}
 		media, err := animeService.Manga(ctx, name)
 		if err != nil {
 			return fmt.Errorf("error searching manga: %w", err)
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/Karitham/corde"
+	"github.com/urfave/cli/v2"
+
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/storage"
+)
+
+var TokensCommand = &cli.Command{
+	Name:  "tokens",
+	Usage: "Grant tokens and audit the token ledger",
+	Subcommands: []*cli.Command{
+		{
+			Name:  "grant",
+			Usage: "Add tokens to a user, or take them away with a negative amount",
+			Flags: []cli.Flag{
+				userFlag,
+				&cli.IntFlag{
+					Name:     "amount",
+					Required: true,
+				},
+				dbURLFlag,
+			},
+			Action: func(c *cli.Context) error {
+				userID := corde.SnowflakeFromString(c.String(userFlag.Name))
+				if userID == 0 {
+					return fmt.Errorf("invalid user ID: %s", c.String(userFlag.Name))
+				}
+
+				ctx := c.Context
+				store, err := storage.NewStore(ctx, c.String(dbURLFlag.Name))
+				if err != nil {
+					return fmt.Errorf("error connecting to db: %w", err)
+				}
+
+				user, err := collection.GrantTokens(ctx, newCollectionStore(store), uint64(userID), int32(c.Int("amount")))
+				if err != nil {
+					return fmt.Errorf("error granting tokens: %w", err)
+				}
+
+				result := map[string]any{
+					"user_id": userID.String(),
+					"tokens":  user.Tokens,
+				}
+
+				return json.NewEncoder(os.Stdout).Encode(result)
+			},
+		},
+		{
+			Name:  "history",
+			Usage: "Show a user's token changes, newest first",
+			Flags: []cli.Flag{
+				userFlag,
+				&cli.IntFlag{
+					Name:  "limit",
+					Usage: "Maximum number of changes to show",
+					Value: collection.DefaultTokenHistoryLimit,
+				},
+				dbURLFlag,
+			},
+			Action: func(c *cli.Context) error {
+				userID := corde.SnowflakeFromString(c.String(userFlag.Name))
+				if userID == 0 {
+					return fmt.Errorf("invalid user ID: %s", c.String(userFlag.Name))
+				}
+
+				ctx := c.Context
+				store, err := storage.NewStore(ctx, c.String(dbURLFlag.Name))
+				if err != nil {
+					return fmt.Errorf("error connecting to db: %w", err)
+				}
+
+				changes, err := collection.TokenHistory(ctx, newCollectionStore(store), uint64(userID), int32(c.Int("limit")))
+				if err != nil {
+					return fmt.Errorf("error getting token history: %w", err)
+				}
+
+				return json.NewEncoder(os.Stdout).Encode(changes)
+			},
+		},
+		{
+			Name:  "check",
+			Usage: "Check that every balance matches the sum of its token ledger",
+			Flags: []cli.Flag{
+				dbURLFlag,
+			},
+			Action: func(c *cli.Context) error {
+				ctx := c.Context
+				store, err := storage.NewStore(ctx, c.String(dbURLFlag.Name))
+				if err != nil {
+					return fmt.Errorf("error connecting to db: %w", err)
+				}
+
+				mismatches, err := collection.CheckTokenLedger(ctx, newCollectionStore(store))
+				if err != nil {
+					return fmt.Errorf("error checking token ledger: %w", err)
+				}
+
+				if err := json.NewEncoder(os.Stdout).Encode(map[string]any{"mismatches": mismatches}); err != nil {
+					return err
+				}
+				if len(mismatches) > 0 {
+					return fmt.Errorf("%d balances don't match the token ledger", len(mismatches))
+				}
+				return nil
+			},
+		},
+	},
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/karitham/waifubot/anilist"
+	"github.com/karitham/waifubot/jikan"
+	"github.com/karitham/waifubot/tracker"
+)
+
+// trackerProvider returns the tracker picked with the tracker flag.
+func trackerProvider(c *cli.Context) (tracker.Provider, error) {
+	p := tracker.Provider(c.String(trackerFlag.Name))
+	if !slices.Contains(tracker.Providers, p) {
+		return "", fmt.Errorf("invalid tracker %q, want one of %v", p, tracker.Providers)
+	}
+	return p, nil
+}
+
+// searchTracker returns the tracker picked with the tracker flag, answering with its own IDs.
+func searchTracker(c *cli.Context) (tracker.Service, error) {
+	p, err := trackerProvider(c)
+	if err != nil {
+		return nil, err
+	}
+	if p == tracker.MyAnimeList {
+		return jikan.New(), nil
+	}
+	return anilist.New(), nil
+}
@@ -6,11 +6,27 @@ import (
 	"github.com/karitham/waifubot/catalog"
 	"github.com/karitham/waifubot/collection"
 	"github.com/karitham/waifubot/storage"
+	"github.com/karitham/waifubot/storage/achievementpg"
+	"github.com/karitham/waifubot/storage/achievementstore"
+	"github.com/karitham/waifubot/storage/auctionpg"
+	"github.com/karitham/waifubot/storage/auctionstore"
 	"github.com/karitham/waifubot/storage/catalogpg"
+	"github.com/karitham/waifubot/storage/catalogstore"
 	"github.com/karitham/waifubot/storage/collectionpg"
+	"github.com/karitham/waifubot/storage/collectionstore"
 	"github.com/karitham/waifubot/storage/droppg"
+	"github.com/karitham/waifubot/storage/dropstore"
 	"github.com/karitham/waifubot/storage/guildpg"
+	"github.com/karitham/waifubot/storage/guildstore"
+	"github.com/karitham/waifubot/storage/ledgerpg"
+	"github.com/karitham/waifubot/storage/ledgerstore"
+	"github.com/karitham/waifubot/storage/tradepg"
+	"github.com/karitham/waifubot/storage/tradestore"
 	"github.com/karitham/waifubot/storage/userpg"
+	"github.com/karitham/waifubot/storage/userstore"
+	"github.com/karitham/waifubot/storage/valuepg"
+	"github.com/karitham/waifubot/storage/valuestore"
+	"github.com/karitham/waifubot/storage/wishliststore"
 )
 
 // newCollectionStore wires adapters into a collection.Store.
@@ -21,13 +37,21 @@ func newCollectionStore(s storage.Store) collection.Store {
 func newStoreFromStorage(s storage.Store) *collection.PostgresStore {
 	catQ := s.CollectionStore()
 
+	// txFn binds every adapter to the transaction so multi-step
+	// operations (claim, give, trade) commit or roll back together.
 	txFn := func(tx pgx.Tx) collection.Store {
+		txCatQ := collectionstore.New(tx)
 		return collection.NewPostgresStore(
-			userpg.New(s.UserStore()),
-			collectionpg.New(catQ, s.WishlistStore()),
-			droppg.New(s.DropStore()),
-			guildpg.New(s.GuildStore()),
-			catalogpg.New(catQ, s.GuildStore()),
+			userpg.New(userstore.New(tx)),
+			collectionpg.New(txCatQ, wishliststore.New(tx)),
+			droppg.New(dropstore.New(tx)),
+			guildpg.New(guildstore.New(tx)),
+			tradepg.New(tradestore.New(tx)),
+			auctionpg.New(auctionstore.New(tx)),
+			ledgerpg.New(ledgerstore.New(tx)),
+			achievementpg.New(achievementstore.New(tx)),
+			valuepg.New(valuestore.New(tx)),
+			catalogpg.New(txCatQ, guildstore.New(tx), catalogstore.New(tx)),
 			tx,
 			nil,
 		)
@@ -38,7 +62,12 @@ func newStoreFromStorage(s storage.Store) *collection.PostgresStore {
 		collectionpg.New(catQ, s.WishlistStore()),
 		droppg.New(s.DropStore()),
 		guildpg.New(s.GuildStore()),
-		catalogpg.New(catQ, s.GuildStore()),
+		tradepg.New(s.TradeStore()),
+		auctionpg.New(s.AuctionStore()),
+		ledgerpg.New(s.LedgerStore()),
+		achievementpg.New(s.AchievementStore()),
+		valuepg.New(s.ValueStore()),
+		catalogpg.New(catQ, s.GuildStore(), s.CatalogStore()),
 		s.DB(),
 		txFn,
 	)
@@ -46,5 +75,5 @@ func newStoreFromStorage(s storage.Store) *collection.PostgresStore {
 
 // newCatalogStore creates a catalog.Store from the underlying storage.
 func newCatalogStore(s storage.Store) catalog.Store {
-	return catalogpg.New(s.CollectionStore(), s.GuildStore())
+	return catalogpg.New(s.CollectionStore(), s.GuildStore(), s.CatalogStore())
 }
@@ -0,0 +1,182 @@
+package collection
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// SeriesMinCharacters is how many active characters a media needs before
+// owning all of them counts as completing a series.
+const SeriesMinCharacters = 5
+
+// AchievementMetric is a per-user statistic achievements are unlocked on.
+type AchievementMetric string
+
+const (
+	MetricOwned           AchievementMetric = "owned"
+	MetricLegendary       AchievementMetric = "legendary"
+	MetricCompletedSeries AchievementMetric = "completed_series"
+	MetricClaims          AchievementMetric = "claims"
+)
+
+// Achievement is a badge unlocked once Metric reaches Goal.
+// IDs are stored with each unlock, so they must never change.
+type Achievement struct {
+	ID          string
+	Name        string
+	Description string
+	Metric      AchievementMetric
+	Goal        int64
+}
+
+// Achievements are the rules evaluated by CheckAchievements, in display order.
+var Achievements = []Achievement{
+	{ID: "owned_10", Name: "Collector", Description: "Own 10 characters", Metric: MetricOwned, Goal: 10},
+	{ID: "owned_100", Name: "Curator", Description: "Own 100 characters", Metric: MetricOwned, Goal: 100},
+	{ID: "owned_1000", Name: "Archivist", Description: "Own 1000 characters", Metric: MetricOwned, Goal: 1000},
+	{ID: "legendary_1", Name: "Touched by Legend", Description: "Own a Legendary character", Metric: MetricLegendary, Goal: 1},
+	{ID: "legendary_5", Name: "Hall of Legends", Description: "Own 5 Legendary characters", Metric: MetricLegendary, Goal: 5},
+	{ID: "series_1", Name: "Completionist", Description: "Complete a series", Metric: MetricCompletedSeries, Goal: 1},
+	{ID: "series_10", Name: "Box Set", Description: "Complete 10 series", Metric: MetricCompletedSeries, Goal: 10},
+	{ID: "claims_10", Name: "Quick Draw", Description: "Claim 10 drops", Metric: MetricClaims, Goal: 10},
+	{ID: "claims_100", Name: "Fastest in the West", Description: "Claim 100 drops", Metric: MetricClaims, Goal: 100},
+}
+
+// AchievementUnlock records when a user unlocked an achievement.
+type AchievementUnlock struct {
+	ID         string
+	UnlockedAt time.Time
+}
+
+// UnlockedAchievement is an achievement a user has, with its unlock time.
+type UnlockedAchievement struct {
+	Achievement
+	UnlockedAt time.Time
+}
+
+// AchievementRepository stores unlocked achievements and the statistics that
+// aren't available from the other repositories.
+type AchievementRepository interface {
+	// UnlockAchievement records an unlock. It reports false if the user already had it.
+	UnlockAchievement(ctx context.Context, userID UserID, id string, at time.Time) (bool, error)
+	ListAchievements(ctx context.Context, userID UserID) ([]AchievementUnlock, error)
+	// CountCompletedSeries counts the media with at least minCharacters
+	// active characters, all of which the user owns. Only media whose
+	// characters were synced count, see catalog.Media.CharactersSyncedAt.
+	CountCompletedSeries(ctx context.Context, userID UserID, minCharacters int32) (int64, error)
+	// CountCompletedSeriesOf is CountCompletedSeries among the media of charID.
+	CountCompletedSeriesOf(ctx context.Context, userID UserID, charID int64, minCharacters int32) (int64, error)
+	// CountOwnedFromFavorites counts the characters the user owns with at least minFavorites.
+	CountOwnedFromFavorites(ctx context.Context, userID UserID, minFavorites int32) (int64, error)
+}
+
+// AchievementTrigger is what a user just did: acquire Character through Kind.
+type AchievementTrigger struct {
+	Kind      EventKind
+	Character Character
+}
+
+// raises reports whether t can raise metric.
+func (t AchievementTrigger) raises(metric AchievementMetric) bool {
+	switch metric {
+	case MetricLegendary:
+		return RarityFromFavorites(t.Character.Favorites) == RarityLegendary
+	case MetricClaims:
+		return t.Kind == EventClaim
+	default:
+		return true
+	}
+}
+
+// UserAchievements returns the achievements a user unlocked, in display order.
+// Unlocks of achievements that no longer exist are skipped.
+func UserAchievements(ctx context.Context, store Store, userID UserID) ([]UnlockedAchievement, error) {
+	unlocks, err := store.ListAchievements(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	at := make(map[string]time.Time, len(unlocks))
+	for _, u := range unlocks {
+		at[u.ID] = u.UnlockedAt
+	}
+
+	var unlocked []UnlockedAchievement
+	for _, a := range Achievements {
+		if t, ok := at[a.ID]; ok {
+			unlocked = append(unlocked, UnlockedAchievement{Achievement: a, UnlockedAt: t})
+		}
+	}
+	return unlocked, nil
+}
+
+// CheckAchievements evaluates the achievements the user doesn't have yet and that
+// t can make progress on, and unlocks those whose goal is met. It returns the
+// newly unlocked ones. It is called after a user acquires a character, while
+// answering them, so only the statistics t can raise are computed.
+func CheckAchievements(ctx context.Context, store Store, userID UserID, t AchievementTrigger) ([]Achievement, error) {
+	unlocks, err := store.ListAchievements(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var locked []Achievement
+	for _, a := range Achievements {
+		if t.raises(a.Metric) && !slices.ContainsFunc(unlocks, func(u AchievementUnlock) bool { return u.ID == a.ID }) {
+			locked = append(locked, a)
+		}
+	}
+
+	// Only compute the statistics a locked achievement still depends on.
+	stats := make(map[AchievementMetric]int64)
+	for _, a := range locked {
+		if _, ok := stats[a.Metric]; ok {
+			continue
+		}
+		v, err := achievementStat(ctx, store, userID, a.Metric, t)
+		if err != nil {
+			return nil, fmt.Errorf("error computing %s: %w", a.Metric, err)
+		}
+		stats[a.Metric] = v
+	}
+
+	now := time.Now()
+	var unlocked []Achievement
+	for _, a := range locked {
+		if stats[a.Metric] < a.Goal {
+			continue
+		}
+		ok, err := store.UnlockAchievement(ctx, userID, a.ID, now)
+		if err != nil {
+			return nil, fmt.Errorf("error unlocking %s: %w", a.ID, err)
+		}
+		// A concurrent check may have unlocked it first; only announce it once.
+		if ok {
+			unlocked = append(unlocked, a)
+		}
+	}
+	return unlocked, nil
+}
+
+func achievementStat(ctx context.Context, store Store, userID UserID, metric AchievementMetric, t AchievementTrigger) (int64, error) {
+	switch metric {
+	case MetricOwned:
+		return store.CountCollection(ctx, userID)
+	case MetricLegendary:
+		minFavorites, _ := RarityLegendary.FavoritesRange()
+		return store.CountOwnedFromFavorites(ctx, userID, int32(minFavorites))
+	case MetricCompletedSeries:
+		// Only the series of the new character can have been completed.
+		n, err := store.CountCompletedSeriesOf(ctx, userID, t.Character.ID, SeriesMinCharacters)
+		if err != nil || n == 0 {
+			return 0, err
+		}
+		return store.CountCompletedSeries(ctx, userID, SeriesMinCharacters)
+	case MetricClaims:
+		return store.CountReceivedEvents(ctx, userID, EventClaim)
+	default:
+		return 0, fmt.Errorf("unknown achievement metric %q", metric)
+	}
+}
@@ -0,0 +1,175 @@
+package collection_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/collection/collectiontest"
+)
+
+func achievementIDs(as []collection.Achievement) []string {
+	var ids []string
+	for _, a := range as {
+		ids = append(ids, a.ID)
+	}
+	return ids
+}
+
+func TestCheckAchievements(t *testing.T) {
+	legendary := collection.Character{ID: 1, Favorites: 5000}
+	rare := collection.Character{ID: 2, Favorites: 4999}
+
+	tests := []struct {
+		name      string
+		trigger   collection.AchievementTrigger
+		owned     int64
+		legendary int64
+		seriesOf  int64
+		series    int64
+		claims    int64
+		unlocked  []collection.AchievementUnlock
+		raced     string
+		want      []string
+	}{
+		{name: "nothing yet", trigger: collection.AchievementTrigger{Kind: collection.EventRoll, Character: rare}, owned: 3},
+		{name: "owned", trigger: collection.AchievementTrigger{Kind: collection.EventRoll, Character: rare}, owned: 100, want: []string{"owned_10", "owned_100"}},
+		{
+			name:      "legendary",
+			trigger:   collection.AchievementTrigger{Kind: collection.EventGive, Character: legendary},
+			owned:     7,
+			legendary: 5,
+			want:      []string{"legendary_1", "legendary_5"},
+		},
+		{name: "legendary needs a legendary character", trigger: collection.AchievementTrigger{Kind: collection.EventRoll, Character: rare}, legendary: 5},
+		{name: "series", trigger: collection.AchievementTrigger{Kind: collection.EventRoll, Character: rare}, seriesOf: 1, series: 3, want: []string{"series_1"}},
+		{name: "series of another character", trigger: collection.AchievementTrigger{Kind: collection.EventRoll, Character: rare}, series: 3},
+		{name: "claims", trigger: collection.AchievementTrigger{Kind: collection.EventClaim, Character: rare}, claims: 10, want: []string{"claims_10"}},
+		{name: "claims need a claim", trigger: collection.AchievementTrigger{Kind: collection.EventRoll, Character: rare}, claims: 10},
+		{
+			name:     "already unlocked",
+			trigger:  collection.AchievementTrigger{Kind: collection.EventRoll, Character: rare},
+			owned:    100,
+			unlocked: []collection.AchievementUnlock{{ID: "owned_10"}},
+			want:     []string{"owned_100"},
+		},
+		{name: "unlocked concurrently", trigger: collection.AchievementTrigger{Kind: collection.EventRoll, Character: rare}, owned: 10, raced: "owned_10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stored []string
+			store := &collectiontest.MockStore{
+				ListAchievementsFunc: func(context.Context, collection.UserID) ([]collection.AchievementUnlock, error) {
+					return tt.unlocked, nil
+				},
+				CountCollectionFunc: func(context.Context, collection.UserID) (int64, error) {
+					return tt.owned, nil
+				},
+				CountOwnedFromFavoritesFunc: func(_ context.Context, _ collection.UserID, minFavorites int32) (int64, error) {
+					assert.Equal(t, int32(5000), minFavorites)
+					return tt.legendary, nil
+				},
+				CountCompletedSeriesOfFunc: func(_ context.Context, _ collection.UserID, charID int64, minCharacters int32) (int64, error) {
+					assert.Equal(t, tt.trigger.Character.ID, charID)
+					assert.Equal(t, int32(collection.SeriesMinCharacters), minCharacters)
+					return tt.seriesOf, nil
+				},
+				CountCompletedSeriesFunc: func(_ context.Context, _ collection.UserID, minCharacters int32) (int64, error) {
+					assert.Equal(t, int32(collection.SeriesMinCharacters), minCharacters)
+					return tt.series, nil
+				},
+				CountReceivedEventsFunc: func(_ context.Context, _ collection.UserID, kind collection.EventKind) (int64, error) {
+					assert.Equal(t, collection.EventClaim, kind)
+					return tt.claims, nil
+				},
+				UnlockAchievementFunc: func(_ context.Context, _ collection.UserID, id string, at time.Time) (bool, error) {
+					assert.False(t, at.IsZero())
+					stored = append(stored, id)
+					return id != tt.raced, nil
+				},
+			}
+
+			got, err := collection.CheckAchievements(t.Context(), store, 1, tt.trigger)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, achievementIDs(got))
+			if tt.raced != "" {
+				assert.Contains(t, stored, tt.raced)
+			}
+		})
+	}
+}
+
+func TestCheckAchievements_SkipsFinishedMetrics(t *testing.T) {
+	var unlocked []collection.AchievementUnlock
+	for _, a := range collection.Achievements {
+		if a.Metric == collection.MetricLegendary {
+			unlocked = append(unlocked, collection.AchievementUnlock{ID: a.ID})
+		}
+	}
+
+	store := &collectiontest.MockStore{
+		ListAchievementsFunc: func(context.Context, collection.UserID) ([]collection.AchievementUnlock, error) {
+			return unlocked, nil
+		},
+		CountOwnedFromFavoritesFunc: func(context.Context, collection.UserID, int32) (int64, error) {
+			t.Fatal("legendary characters are only counted for legendary achievements")
+			return 0, nil
+		},
+	}
+
+	_, err := collection.CheckAchievements(t.Context(), store, 1, collection.AchievementTrigger{Kind: collection.EventRoll, Character: collection.Character{Favorites: 9000}})
+	require.NoError(t, err)
+}
+
+func TestCheckAchievements_SeriesOnlyWhenCompleted(t *testing.T) {
+	store := &collectiontest.MockStore{
+		CountCompletedSeriesFunc: func(context.Context, collection.UserID, int32) (int64, error) {
+			t.Fatal("series are only counted when the new character completed one")
+			return 0, nil
+		},
+	}
+
+	_, err := collection.CheckAchievements(t.Context(), store, 1, collection.AchievementTrigger{Kind: collection.EventRoll, Character: collection.Character{ID: 1}})
+	require.NoError(t, err)
+}
+
+func TestCheckAchievements_Error(t *testing.T) {
+	store := &collectiontest.MockStore{
+		CountCollectionFunc: func(context.Context, collection.UserID) (int64, error) {
+			return 0, errors.New("database on fire")
+		},
+		UnlockAchievementFunc: func(context.Context, collection.UserID, string, time.Time) (bool, error) {
+			t.Fatal("nothing is unlocked when a statistic fails")
+			return false, nil
+		},
+	}
+
+	_, err := collection.CheckAchievements(t.Context(), store, 1, collection.AchievementTrigger{Kind: collection.EventRoll})
+	require.Error(t, err)
+}
+
+func TestUserAchievements(t *testing.T) {
+	at := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	store := &collectiontest.MockStore{
+		ListAchievementsFunc: func(context.Context, collection.UserID) ([]collection.AchievementUnlock, error) {
+			return []collection.AchievementUnlock{
+				{ID: "claims_10", UnlockedAt: at},
+				{ID: "retired", UnlockedAt: at},
+				{ID: "owned_10", UnlockedAt: at.Add(time.Hour)},
+			}, nil
+		},
+	}
+
+	got, err := collection.UserAchievements(t.Context(), store, 1)
+	require.NoError(t, err)
+	require.Len(t, got, 2, "unknown achievements are skipped")
+	assert.Equal(t, "owned_10", got[0].ID, "achievements are in display order")
+	assert.Equal(t, at.Add(time.Hour), got[0].UnlockedAt)
+	assert.Equal(t, "Quick Draw", got[1].Name)
+}
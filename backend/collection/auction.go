@@ -0,0 +1,360 @@
+package collection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// MinAuctionDuration is the shortest an auction may run.
+	MinAuctionDuration = 10 * time.Minute
+	// MaxAuctionDuration is the longest an auction may run.
+	MaxAuctionDuration = 7 * 24 * time.Hour
+)
+
+// ErrAuctionNotFound is returned when an auction doesn't exist.
+var ErrAuctionNotFound = errors.New("auction not found")
+
+// ErrAuctionClosed is returned when bidding on or settling an auction that already ended.
+var ErrAuctionClosed = errors.New("auction is closed")
+
+// ErrAuctionExists is returned when the seller already has an open auction for the character.
+var ErrAuctionExists = errors.New("character is already up for auction")
+
+// ErrBidTooLow is returned when a bid doesn't beat the start price or the current high bid.
+var ErrBidTooLow = errors.New("bid is too low")
+
+// ErrOwnAuction is returned when a seller bids on their own auction.
+var ErrOwnAuction = errors.New("cannot bid on your own auction")
+
+// ErrInvalidDuration is returned when an auction duration is out of bounds.
+var ErrInvalidDuration = errors.New("invalid auction duration")
+
+// AuctionStatus is the lifecycle state of an auction.
+type AuctionStatus string
+
+const (
+	AuctionOpen AuctionStatus = "open"
+	// AuctionSold means the character went to the high bidder and the seller got paid.
+	AuctionSold AuctionStatus = "sold"
+	// AuctionUnsold means the auction ended without bids.
+	AuctionUnsold AuctionStatus = "unsold"
+	// AuctionVoid means the trade couldn't happen at settlement (the seller no longer
+	// owns the character, or the winner already does) and every bid was refunded.
+	AuctionVoid AuctionStatus = "void"
+)
+
+// Auction is a timed sale of a character for tokens.
+type Auction struct {
+	ID           int64
+	SellerID     UserID
+	CharacterID  int64
+	ChannelID    uint64
+	StartPrice   int32
+	HighBid      int32
+	HighBidderID UserID // 0 while there are no bids
+	Status       AuctionStatus
+	CreatedAt    time.Time
+	EndsAt       time.Time
+}
+
+// AuctionEscrow is the amount of tokens a bidder has locked in an auction.
+type AuctionEscrow struct {
+	UserID UserID
+	Amount int32
+}
+
+// AuctionRepository handles auction and bid escrow persistence.
+type AuctionRepository interface {
+	CreateAuction(ctx context.Context, a Auction) (Auction, error)
+	// GetAuctionForUpdate locks the auction row for the rest of the transaction.
+	GetAuctionForUpdate(ctx context.Context, id int64) (Auction, error)
+	// ListOpenAuctions returns open auctions, ending soonest first.
+	ListOpenAuctions(ctx context.Context, limit int32) ([]Auction, error)
+	// ListDueAuctionIDs returns open auctions whose end is at or before now.
+	ListDueAuctionIDs(ctx context.Context, now time.Time) ([]int64, error)
+	SetAuctionHighBid(ctx context.Context, id int64, bidderID UserID, amount int32) error
+	SetAuctionStatus(ctx context.Context, id int64, status AuctionStatus) error
+	// GetEscrow returns ErrNotFound when the user hasn't bid on the auction.
+	GetEscrow(ctx context.Context, auctionID int64, userID UserID) (int32, error)
+	UpsertEscrow(ctx context.Context, auctionID int64, userID UserID, amount int32) error
+	DeleteEscrow(ctx context.Context, auctionID int64, userID UserID) error
+	ListEscrows(ctx context.Context, auctionID int64) ([]AuctionEscrow, error)
+	DeleteEscrows(ctx context.Context, auctionID int64) error
+}
+
+// CreateAuction puts one of the seller's characters up for auction.
+// The character stays in the seller's collection until the auction settles.
+func CreateAuction(ctx context.Context, store Store, seller UserID, charID int64, channelID uint64, startPrice int32, duration time.Duration) (Auction, error) {
+	if startPrice < 0 {
+		return Auction{}, ErrInvalidAmount
+	}
+	if duration < MinAuctionDuration || duration > MaxAuctionDuration {
+		return Auction{}, fmt.Errorf("%w: must be between %s and %s", ErrInvalidDuration, MinAuctionDuration, MaxAuctionDuration)
+	}
+
+	if _, err := store.GetOwnedCharacter(ctx, seller, charID); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return Auction{}, fmt.Errorf("%w %d", ErrUserDoesNotOwnCharacter, charID)
+		}
+		return Auction{}, fmt.Errorf("error checking ownership: %w", err)
+	}
+
+	now := time.Now()
+	return store.CreateAuction(ctx, Auction{
+		SellerID:    seller,
+		CharacterID: charID,
+		ChannelID:   channelID,
+		StartPrice:  startPrice,
+		Status:      AuctionOpen,
+		CreatedAt:   now,
+		EndsAt:      now.Add(duration),
+	})
+}
+
+// PlaceBid bids amount tokens on an auction.
+// Tokens move into escrow right away; raising your own bid only escrows the difference.
+// The bidder outbid gets their escrow back in the same transaction, so only the
+// high bidder ever has tokens locked in an auction.
+func PlaceBid(ctx context.Context, store Store, bidder UserID, auctionID int64, amount int32) (Auction, error) {
+	now := time.Now()
+
+	var auction Auction
+	err := withTx(ctx, store, func(tx Store) error {
+		var err error
+		auction, err = tx.GetAuctionForUpdate(ctx, auctionID)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				return ErrAuctionNotFound
+			}
+			return fmt.Errorf("error getting auction: %w", err)
+		}
+		if auction.Status != AuctionOpen || !now.Before(auction.EndsAt) {
+			return ErrAuctionClosed
+		}
+		if auction.SellerID == bidder {
+			return ErrOwnAuction
+		}
+		if amount < auction.StartPrice || amount <= auction.HighBid {
+			return ErrBidTooLow
+		}
+
+		_, err = tx.GetOwnedCharacter(ctx, bidder, auction.CharacterID)
+		if err == nil {
+			return ErrAlreadyOwned
+		}
+		if !errors.Is(err, ErrNotFound) {
+			return fmt.Errorf("error checking ownership: %w", err)
+		}
+
+		held, err := tx.GetEscrow(ctx, auctionID, bidder)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return fmt.Errorf("error getting escrow: %w", err)
+		}
+
+		if _, err := changeTokens(ctx, tx, TokenChange{
+			UserID:         bidder,
+			Amount:         -(amount - held),
+			Reason:         TokenAuctionBid,
+			CounterpartyID: auction.SellerID,
+			ReferenceID:    auctionID,
+			CreatedAt:      now,
+		}); err != nil {
+			if errors.Is(err, ErrInsufficientTokens) {
+				return err
+			}
+			return fmt.Errorf("failed to escrow tokens: %w", err)
+		}
+		if err := tx.UpsertEscrow(ctx, auctionID, bidder, amount); err != nil {
+			return fmt.Errorf("failed to escrow tokens: %w", err)
+		}
+		if err := tx.SetAuctionHighBid(ctx, auctionID, bidder, amount); err != nil {
+			return fmt.Errorf("failed to record bid: %w", err)
+		}
+		if auction.HighBidderID != 0 && auction.HighBidderID != bidder {
+			if err := refundEscrow(ctx, tx, auction, auction.HighBidderID, now); err != nil {
+				return err
+			}
+		}
+
+		auction.HighBid = amount
+		auction.HighBidderID = bidder
+		return nil
+	})
+	if err != nil {
+		return Auction{}, err
+	}
+	return auction, nil
+}
+
+// refundEscrow gives a bidder the tokens they have locked in an auction back.
+func refundEscrow(ctx context.Context, tx Store, auction Auction, userID UserID, now time.Time) error {
+	held, err := tx.GetEscrow(ctx, auction.ID, userID)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error getting escrow: %w", err)
+	}
+
+	if _, err := changeTokens(ctx, tx, TokenChange{
+		UserID:         userID,
+		Amount:         held,
+		Reason:         TokenAuctionRefund,
+		CounterpartyID: auction.SellerID,
+		ReferenceID:    auction.ID,
+		CreatedAt:      now,
+	}); err != nil {
+		return fmt.Errorf("error refunding bidder %d: %w", userID, err)
+	}
+	if err := tx.DeleteEscrow(ctx, auction.ID, userID); err != nil {
+		return fmt.Errorf("error releasing escrow: %w", err)
+	}
+	return nil
+}
+
+// ListAuctions returns open auctions, ending soonest first.
+func ListAuctions(ctx context.Context, store Store, limit int32) ([]Auction, error) {
+	return store.ListOpenAuctions(ctx, limit)
+}
+
+// SettleAuction closes an ended auction in a single transaction.
+// The character goes to the high bidder, the seller gets the winning bid and any escrow
+// still held for another bidder is refunded. If the seller no longer owns the character, or the winner already
+// does, the auction is voided and every bidder is refunded instead.
+func SettleAuction(ctx context.Context, store Store, auctionID int64) (Auction, error) {
+	now := time.Now()
+
+	var auction Auction
+	err := withTx(ctx, store, func(tx Store) error {
+		var err error
+		auction, err = tx.GetAuctionForUpdate(ctx, auctionID)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				return ErrAuctionNotFound
+			}
+			return fmt.Errorf("error getting auction: %w", err)
+		}
+		if auction.Status != AuctionOpen || now.Before(auction.EndsAt) {
+			return ErrAuctionClosed
+		}
+
+		escrows, err := tx.ListEscrows(ctx, auctionID)
+		if err != nil {
+			return fmt.Errorf("error listing escrow: %w", err)
+		}
+
+		auction.Status, err = settleOutcome(ctx, tx, auction)
+		if err != nil {
+			return err
+		}
+
+		if auction.Status == AuctionSold {
+			if _, err := tx.GiveCharacter(ctx, auction.SellerID, auction.HighBidderID, auction.CharacterID); err != nil {
+				return fmt.Errorf("error giving char: %w", err)
+			}
+			if err := tx.RemoveFromWishlist(ctx, auction.HighBidderID, auction.CharacterID); err != nil {
+				return fmt.Errorf("error removing char from wishlist: %w", err)
+			}
+			if _, err := changeTokens(ctx, tx, TokenChange{
+				UserID:         auction.SellerID,
+				Amount:         auction.HighBid,
+				Reason:         TokenAuctionSale,
+				CounterpartyID: auction.HighBidderID,
+				ReferenceID:    auction.ID,
+				CreatedAt:      now,
+			}); err != nil {
+				return fmt.Errorf("error paying seller: %w", err)
+			}
+			if err := tx.RecordOwnershipEvent(ctx, OwnershipEvent{
+				CharacterID: auction.CharacterID,
+				Kind:        EventAuction,
+				FromUserID:  auction.SellerID,
+				ToUserID:    auction.HighBidderID,
+				Tokens:      auction.HighBid,
+				ReferenceID: auction.ID,
+				CreatedAt:   now,
+			}); err != nil {
+				return fmt.Errorf("error recording ownership event: %w", err)
+			}
+		}
+
+		for _, e := range escrows {
+			if auction.Status == AuctionSold && e.UserID == auction.HighBidderID {
+				continue
+			}
+			if _, err := changeTokens(ctx, tx, TokenChange{
+				UserID:         e.UserID,
+				Amount:         e.Amount,
+				Reason:         TokenAuctionRefund,
+				CounterpartyID: auction.SellerID,
+				ReferenceID:    auction.ID,
+				CreatedAt:      now,
+			}); err != nil {
+				return fmt.Errorf("error refunding bidder %d: %w", e.UserID, err)
+			}
+		}
+
+		if err := tx.DeleteEscrows(ctx, auctionID); err != nil {
+			return fmt.Errorf("error releasing escrow: %w", err)
+		}
+		return tx.SetAuctionStatus(ctx, auctionID, auction.Status)
+	})
+	if err != nil {
+		return Auction{}, err
+	}
+	return auction, nil
+}
+
+// settleOutcome decides how an ended auction closes.
+func settleOutcome(ctx context.Context, tx Store, a Auction) (AuctionStatus, error) {
+	if a.HighBidderID == 0 {
+		return AuctionUnsold, nil
+	}
+
+	_, err := tx.GetOwnedCharacter(ctx, a.SellerID, a.CharacterID)
+	if errors.Is(err, ErrNotFound) {
+		return AuctionVoid, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("error checking seller ownership: %w", err)
+	}
+
+	_, err = tx.GetOwnedCharacter(ctx, a.HighBidderID, a.CharacterID)
+	if err == nil {
+		return AuctionVoid, nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return "", fmt.Errorf("error checking winner ownership: %w", err)
+	}
+
+	return AuctionSold, nil
+}
+
+// SettleDueAuctions settles every auction that has ended.
+// Each auction settles in its own transaction, so one failure doesn't hold back the others.
+func SettleDueAuctions(ctx context.Context, store Store) ([]Auction, error) {
+	ids, err := store.ListDueAuctionIDs(ctx, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("error listing due auctions: %w", err)
+	}
+
+	var (
+		settled []Auction
+		errs    []error
+	)
+	for _, id := range ids {
+		a, err := SettleAuction(ctx, store, id)
+		if errors.Is(err, ErrAuctionClosed) {
+			continue // settled concurrently
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("auction %d: %w", id, err))
+			continue
+		}
+		settled = append(settled, a)
+	}
+	return settled, errors.Join(errs...)
+}
@@ -0,0 +1,318 @@
+package collection_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/collection/collectiontest"
+)
+
+func TestCreateAuction(t *testing.T) {
+	tests := []struct {
+		name     string
+		owned    map[uint64][]int64
+		start    int32
+		duration time.Duration
+		wantErr  error
+	}{
+		{name: "success", owned: map[uint64][]int64{1: {10}}, start: 5, duration: time.Hour},
+		{name: "negative_start", start: -1, duration: time.Hour, wantErr: collection.ErrInvalidAmount},
+		{name: "too_short", duration: time.Minute, wantErr: collection.ErrInvalidDuration},
+		{name: "too_long", duration: 8 * 24 * time.Hour, wantErr: collection.ErrInvalidDuration},
+		{name: "not_owned", owned: map[uint64][]int64{}, duration: time.Hour, wantErr: collection.ErrUserDoesNotOwnCharacter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created collection.Auction
+			store := &collectiontest.MockStore{
+				GetOwnedCharacterFunc: ownership(tt.owned),
+				CreateAuctionFunc: func(_ context.Context, a collection.Auction) (collection.Auction, error) {
+					created = a
+					a.ID = 3
+					return a, nil
+				},
+			}
+
+			a, err := collection.CreateAuction(t.Context(), store, 1, 10, 99, tt.start, tt.duration)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(3), a.ID)
+			assert.Equal(t, uint64(99), created.ChannelID)
+			assert.Equal(t, collection.AuctionOpen, created.Status)
+			assert.WithinDuration(t, time.Now().Add(tt.duration), created.EndsAt, time.Second)
+		})
+	}
+}
+
+func TestPlaceBid(t *testing.T) {
+	open := collection.Auction{ID: 1, SellerID: 1, CharacterID: 10, StartPrice: 5, Status: collection.AuctionOpen, EndsAt: time.Now().Add(time.Hour)}
+
+	tests := []struct {
+		name      string
+		auction   collection.Auction
+		bidder    uint64
+		amount    int32
+		held      int32
+		owned     map[uint64][]int64
+		wantErr   error
+		wantSpent int32
+	}{
+		{name: "first_bid", auction: open, bidder: 2, amount: 5, wantSpent: 5},
+		{
+			name:      "raise_own_bid",
+			auction:   func() collection.Auction { a := open; a.HighBid, a.HighBidderID = 6, 2; return a }(),
+			bidder:    2,
+			amount:    9,
+			held:      6,
+			wantSpent: 3,
+		},
+		{name: "below_start", auction: open, bidder: 2, amount: 4, wantErr: collection.ErrBidTooLow},
+		{
+			name:    "not_above_high_bid",
+			auction: func() collection.Auction { a := open; a.HighBid, a.HighBidderID = 8, 3; return a }(),
+			bidder:  2,
+			amount:  8,
+			wantErr: collection.ErrBidTooLow,
+		},
+		{name: "seller", auction: open, bidder: 1, amount: 10, wantErr: collection.ErrOwnAuction},
+		{name: "already_owned", auction: open, bidder: 2, amount: 10, owned: map[uint64][]int64{2: {10}}, wantErr: collection.ErrAlreadyOwned},
+		{
+			name:    "ended",
+			auction: func() collection.Auction { a := open; a.EndsAt = time.Now().Add(-time.Second); return a }(),
+			bidder:  2,
+			amount:  10,
+			wantErr: collection.ErrAuctionClosed,
+		},
+		{
+			name:    "settled",
+			auction: func() collection.Auction { a := open; a.Status = collection.AuctionSold; return a }(),
+			bidder:  2,
+			amount:  10,
+			wantErr: collection.ErrAuctionClosed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var spent, escrowed int32
+			store := &collectiontest.MockStore{
+				GetAuctionForUpdateFunc: func(context.Context, int64) (collection.Auction, error) { return tt.auction, nil },
+				GetOwnedCharacterFunc:   ownership(tt.owned),
+				GetEscrowFunc: func(context.Context, int64, uint64) (int32, error) {
+					if tt.held == 0 {
+						return 0, collection.ErrNotFound
+					}
+					return tt.held, nil
+				},
+				SpendTokensFunc: func(_ context.Context, _ uint64, amount int32) (collection.User, error) {
+					spent = amount
+					return collection.User{}, nil
+				},
+				UpsertEscrowFunc: func(_ context.Context, _ int64, _ uint64, amount int32) error {
+					escrowed = amount
+					return nil
+				},
+			}
+
+			a, err := collection.PlaceBid(t.Context(), store, tt.bidder, 1, tt.amount)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Zero(t, spent)
+				assert.Equal(t, 1, store.RollbackCalls)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSpent, spent)
+			assert.Equal(t, tt.amount, escrowed)
+			assert.Equal(t, tt.amount, a.HighBid)
+			assert.Equal(t, tt.bidder, a.HighBidderID)
+			assert.Equal(t, 1, store.CommitCalls)
+		})
+	}
+}
+
+func TestPlaceBid_RefundsOutbid(t *testing.T) {
+	escrows := map[uint64]int32{3: 8}
+	credits := map[uint64]int32{}
+	store := &collectiontest.MockStore{
+		GetAuctionForUpdateFunc: func(context.Context, int64) (collection.Auction, error) {
+			return collection.Auction{ID: 1, SellerID: 1, CharacterID: 10, StartPrice: 5, HighBid: 8, HighBidderID: 3, Status: collection.AuctionOpen, EndsAt: time.Now().Add(time.Hour)}, nil
+		},
+		GetOwnedCharacterFunc: ownership(nil),
+		GetEscrowFunc: func(_ context.Context, _ int64, userID uint64) (int32, error) {
+			held, ok := escrows[userID]
+			if !ok {
+				return 0, collection.ErrNotFound
+			}
+			return held, nil
+		},
+		UpsertEscrowFunc: func(_ context.Context, _ int64, userID uint64, amount int32) error {
+			escrows[userID] = amount
+			return nil
+		},
+		DeleteEscrowFunc: func(_ context.Context, _ int64, userID uint64) error {
+			delete(escrows, userID)
+			return nil
+		},
+		AddTokensFunc: func(_ context.Context, userID uint64, amount int32) (collection.User, error) {
+			credits[userID] += amount
+			return collection.User{}, nil
+		},
+	}
+
+	_, err := collection.PlaceBid(t.Context(), store, 2, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, map[uint64]int32{3: 8}, credits, "the outbid bidder gets their tokens back")
+	assert.Equal(t, map[uint64]int32{2: 10}, escrows, "only the high bidder has tokens locked")
+	assert.Equal(t, 1, store.CommitCalls)
+}
+
+func TestPlaceBid_InsufficientTokens(t *testing.T) {
+	store := &collectiontest.MockStore{
+		GetAuctionForUpdateFunc: func(context.Context, int64) (collection.Auction, error) {
+			return collection.Auction{ID: 1, SellerID: 1, CharacterID: 10, Status: collection.AuctionOpen, EndsAt: time.Now().Add(time.Hour)}, nil
+		},
+		GetOwnedCharacterFunc: ownership(nil),
+		SpendTokensFunc: func(context.Context, uint64, int32) (collection.User, error) {
+			return collection.User{}, collection.ErrInsufficientTokens
+		},
+	}
+
+	_, err := collection.PlaceBid(t.Context(), store, 2, 1, 10)
+	require.ErrorIs(t, err, collection.ErrInsufficientTokens)
+}
+
+func TestSettleAuction(t *testing.T) {
+	ended := collection.Auction{ID: 1, SellerID: 1, CharacterID: 10, HighBid: 9, HighBidderID: 2, Status: collection.AuctionOpen, EndsAt: time.Now().Add(-time.Minute)}
+	escrows := []collection.AuctionEscrow{{UserID: 2, Amount: 9}, {UserID: 3, Amount: 7}}
+
+	tests := []struct {
+		name        string
+		auction     collection.Auction
+		owned       map[uint64][]int64
+		wantStatus  collection.AuctionStatus
+		wantGiven   bool
+		wantCredits map[uint64]int32
+		wantErr     error
+	}{
+		{
+			name:        "sold",
+			auction:     ended,
+			owned:       map[uint64][]int64{1: {10}},
+			wantStatus:  collection.AuctionSold,
+			wantGiven:   true,
+			wantCredits: map[uint64]int32{1: 9, 3: 7},
+		},
+		{
+			name:        "seller_lost_character",
+			auction:     ended,
+			owned:       map[uint64][]int64{},
+			wantStatus:  collection.AuctionVoid,
+			wantCredits: map[uint64]int32{2: 9, 3: 7},
+		},
+		{
+			name:        "winner_already_owns",
+			auction:     ended,
+			owned:       map[uint64][]int64{1: {10}, 2: {10}},
+			wantStatus:  collection.AuctionVoid,
+			wantCredits: map[uint64]int32{2: 9, 3: 7},
+		},
+		{
+			name:        "no_bids",
+			auction:     func() collection.Auction { a := ended; a.HighBid, a.HighBidderID = 0, 0; return a }(),
+			wantStatus:  collection.AuctionUnsold,
+			wantCredits: map[uint64]int32{},
+		},
+		{
+			name:    "not_ended",
+			auction: func() collection.Auction { a := ended; a.EndsAt = time.Now().Add(time.Hour); return a }(),
+			wantErr: collection.ErrAuctionClosed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				given    bool
+				status   collection.AuctionStatus
+				released bool
+				credits  = map[uint64]int32{}
+			)
+			store := &collectiontest.MockStore{
+				GetAuctionForUpdateFunc: func(context.Context, int64) (collection.Auction, error) { return tt.auction, nil },
+				GetOwnedCharacterFunc:   ownership(tt.owned),
+				ListEscrowsFunc: func(context.Context, int64) ([]collection.AuctionEscrow, error) {
+					if tt.auction.HighBidderID == 0 {
+						return nil, nil
+					}
+					return escrows, nil
+				},
+				GiveCharacterFunc: func(_ context.Context, from, to uint64, _ int64) (collection.OwnedCharacter, error) {
+					given = from == 1 && to == 2
+					return collection.OwnedCharacter{}, nil
+				},
+				AddTokensFunc: func(_ context.Context, userID uint64, amount int32) (collection.User, error) {
+					credits[userID] += amount
+					return collection.User{}, nil
+				},
+				DeleteEscrowsFunc: func(context.Context, int64) error {
+					released = true
+					return nil
+				},
+				SetAuctionStatusFunc: func(_ context.Context, _ int64, s collection.AuctionStatus) error {
+					status = s
+					return nil
+				},
+			}
+
+			a, err := collection.SettleAuction(t.Context(), store, 1)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, credits)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, a.Status)
+			assert.Equal(t, tt.wantStatus, status)
+			assert.Equal(t, tt.wantGiven, given)
+			assert.Equal(t, tt.wantCredits, credits)
+			assert.True(t, released)
+		})
+	}
+}
+
+func TestSettleDueAuctions(t *testing.T) {
+	store := &collectiontest.MockStore{
+		ListDueAuctionIDsFunc: func(context.Context, time.Time) ([]int64, error) { return []int64{1, 2, 3}, nil },
+		GetAuctionForUpdateFunc: func(_ context.Context, id int64) (collection.Auction, error) {
+			switch id {
+			case 2:
+				return collection.Auction{}, errors.New("database on fire")
+			case 3:
+				return collection.Auction{ID: id, Status: collection.AuctionSold}, nil
+			}
+			return collection.Auction{ID: id, Status: collection.AuctionOpen, EndsAt: time.Now().Add(-time.Minute)}, nil
+		},
+	}
+
+	settled, err := collection.SettleDueAuctions(t.Context(), store)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "auction 2")
+	require.Len(t, settled, 1)
+	assert.Equal(t, int64(1), settled[0].ID)
+	assert.Equal(t, collection.AuctionUnsold, settled[0].Status)
+}
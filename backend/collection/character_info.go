@@ -0,0 +1,76 @@
+package collection
+
+import (
+	"context"
+
+	"github.com/karitham/waifubot/catalog"
+)
+
+// CharacterInfo is a catalog character with its appearances and how players hold it.
+// Its Description is filled.
+type CharacterInfo struct {
+	Character
+	// Media lists the media the character appears in, most popular first.
+	Media []catalog.Media
+	Stats catalog.CharacterStats
+}
+
+// Rarity returns the rarity tier of the character.
+func (c CharacterInfo) Rarity() RarityTier {
+	return RarityFromFavorites(c.Favorites)
+}
+
+// CharacterDetails gathers what the catalog and the collections know about a character.
+// Owners in guildID are counted separately.
+func CharacterDetails(ctx context.Context, store Store, charID int64, guildID uint64) (CharacterInfo, error) {
+	char, err := store.GetCharacterByID(ctx, charID)
+	if err != nil {
+		return CharacterInfo{}, err
+	}
+
+	char.Description, err = store.CharacterDescription(ctx, charID)
+	if err != nil {
+		return CharacterInfo{}, err
+	}
+
+	media, err := store.MediaByCharacter(ctx, charID)
+	if err != nil {
+		return CharacterInfo{}, err
+	}
+
+	stats, err := store.CharacterStats(ctx, charID, guildID)
+	if err != nil {
+		return CharacterInfo{}, err
+	}
+
+	return CharacterInfo{
+		Character: char,
+		Media:     media,
+		Stats:     stats,
+	}, nil
+}
+
+// SearchCharacterInfo searches the active characters of the catalog by name or ID prefix,
+// with how many players own and wish for each. Media, Description and the guild and
+// first-claim stats are left empty.
+func SearchCharacterInfo(ctx context.Context, store Store, term string) ([]CharacterInfo, error) {
+	chars, err := store.SearchGlobalCharacters(ctx, term)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, len(chars))
+	for i, c := range chars {
+		ids[i] = c.ID
+	}
+	stats, err := store.CharactersStats(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]CharacterInfo, len(chars))
+	for i, c := range chars {
+		infos[i] = CharacterInfo{Character: c, Stats: stats[c.ID]}
+	}
+	return infos, nil
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/karitham/waifubot/namematch"
 )
 
 // ErrNoDropInChannel is returned when there is no drop in the channel.
@@ -14,7 +16,14 @@ var ErrNoDropInChannel = errors.New("no drop in this channel")
 // ErrWrongCharacterName is returned when the character name is wrong.
 var ErrWrongCharacterName = errors.New("wrong character name")
 
+// ErrDropExpired is returned when the drop in the channel is past its expiry.
+var ErrDropExpired = errors.New("drop expired")
+
+// DropLifetime is how long a drop stays claimable before it is revealed.
+const DropLifetime = 30 * time.Minute
+
 // Claim claims a dropped character for a user.
+// The guess is matched leniently, see namematch.Match.
 func Claim(ctx context.Context, store Store, userID, channelID uint64, charName string) (Character, error) {
 	tx, err := store.WithTx(ctx)
 	if err != nil {
@@ -35,7 +44,12 @@ func Claim(ctx context.Context, store Store, userID, channelID uint64, charName
 		return Character{}, fmt.Errorf("failed to get drop: %w", err)
 	}
 
-	if !strings.EqualFold(SanitizeName(drop.Name), charName) {
+	now := time.Now()
+	if drop.Expired(now) {
+		return Character{}, ErrDropExpired
+	}
+
+	if !namematch.Match(charName, drop.Name, drop.Aliases...) {
 		return Character{}, ErrWrongCharacterName
 	}
 
@@ -62,8 +76,7 @@ func Claim(ctx context.Context, store Store, userID, channelID uint64, charName
 		return Character{}, fmt.Errorf("failed to upsert character: %w", err)
 	}
 
-	now := time.Now()
-	err = tx.AddToCollection(ctx, userID, Character(drop), "CLAIM", now)
+	err = tx.AddToCollection(ctx, userID, drop.Character, "CLAIM", now)
 	if err != nil {
 		if errors.Is(err, ErrAlreadyOwned) {
 			return Character{}, ErrAlreadyOwned
@@ -71,6 +84,16 @@ func Claim(ctx context.Context, store Store, userID, channelID uint64, charName
 		return Character{}, fmt.Errorf("failed to insert character into collection: %w", err)
 	}
 
+	err = tx.RecordOwnershipEvent(ctx, OwnershipEvent{
+		CharacterID: drop.ID,
+		Kind:        EventClaim,
+		ToUserID:    userID,
+		CreatedAt:   now,
+	})
+	if err != nil {
+		return Character{}, fmt.Errorf("failed to record ownership event: %w", err)
+	}
+
 	_ = tx.RemoveFromWishlist(ctx, userID, drop.ID)
 
 	err = tx.DeleteDrop(ctx, channelID)
@@ -84,7 +107,7 @@ func Claim(ctx context.Context, store Store, userID, channelID uint64, charName
 		return Character{}, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	return Character(drop), nil
+	return drop.Character, nil
 }
 
 // SanitizeName collapses whitespace in a character name to a single space.
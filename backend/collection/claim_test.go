@@ -29,7 +29,7 @@ func TestClaim(t *testing.T) {
 			name: "success",
 			setup: func(m *collectiontest.MockStore) {
 				m.GetDropForUpdateFunc = func(_ context.Context, _ uint64) (collection.Drop, error) {
-					return collection.Drop{ID: 1, Name: "Test Character", Image: "test.jpg", MediaTitle: "Test Anime"}, nil
+					return collection.Drop{Character: collection.Character{ID: 1, Name: "Test Character", Image: "test.jpg", MediaTitle: "Test Anime"}}, nil
 				}
 				m.GetUserFunc = func(_ context.Context, userID uint64) (collection.User, error) {
 					return collection.User{UserID: userID}, nil
@@ -58,11 +58,26 @@ func TestClaim(t *testing.T) {
 			charName:  "Test",
 			wantErr:   collection.ErrNoDropInChannel,
 		},
+		{
+			name: "expired_drop",
+			setup: func(m *collectiontest.MockStore) {
+				m.GetDropForUpdateFunc = func(_ context.Context, _ uint64) (collection.Drop, error) {
+					return collection.Drop{
+						Character: collection.Character{ID: 1, Name: "Test Character"},
+						ExpiresAt: time.Now().Add(-time.Minute),
+					}, nil
+				}
+			},
+			userID:    123,
+			channelID: 456,
+			charName:  "Test Character",
+			wantErr:   collection.ErrDropExpired,
+		},
 		{
 			name: "wrong_name",
 			setup: func(m *collectiontest.MockStore) {
 				m.GetDropForUpdateFunc = func(_ context.Context, _ uint64) (collection.Drop, error) {
-					return collection.Drop{ID: 1, Name: "Test Character"}, nil
+					return collection.Drop{Character: collection.Character{ID: 1, Name: "Test Character"}}, nil
 				}
 			},
 			userID:    123,
@@ -70,11 +85,41 @@ func TestClaim(t *testing.T) {
 			charName:  "Wrong Name",
 			wantErr:   collection.ErrWrongCharacterName,
 		},
+		{
+			name: "alias_and_word_order",
+			setup: func(m *collectiontest.MockStore) {
+				m.GetDropForUpdateFunc = func(_ context.Context, _ uint64) (collection.Drop, error) {
+					return collection.Drop{Character: collection.Character{ID: 7, Name: "Eren Yeager", Aliases: []string{"Eren Jaeger"}}}, nil
+				}
+				m.GetUserFunc = func(_ context.Context, userID uint64) (collection.User, error) {
+					return collection.User{UserID: userID}, nil
+				}
+			},
+			userID:     123,
+			channelID:  456,
+			charName:   "jaeger eren",
+			wantCharID: 7,
+		},
+		{
+			name: "diacritics_and_typo",
+			setup: func(m *collectiontest.MockStore) {
+				m.GetDropForUpdateFunc = func(_ context.Context, _ uint64) (collection.Drop, error) {
+					return collection.Drop{Character: collection.Character{ID: 8, Name: "Kōsei Arima"}}, nil
+				}
+				m.GetUserFunc = func(_ context.Context, userID uint64) (collection.User, error) {
+					return collection.User{UserID: userID}, nil
+				}
+			},
+			userID:     123,
+			channelID:  456,
+			charName:   "Kosei Arma",
+			wantCharID: 8,
+		},
 		{
 			name: "already_owned",
 			setup: func(m *collectiontest.MockStore) {
 				m.GetDropForUpdateFunc = func(_ context.Context, _ uint64) (collection.Drop, error) {
-					return collection.Drop{ID: 1, Name: "Test Character"}, nil
+					return collection.Drop{Character: collection.Character{ID: 1, Name: "Test Character"}}, nil
 				}
 				m.GetUserFunc = func(_ context.Context, userID uint64) (collection.User, error) {
 					return collection.User{UserID: userID}, nil
@@ -93,7 +138,7 @@ func TestClaim(t *testing.T) {
 			name: "new_user",
 			setup: func(m *collectiontest.MockStore) {
 				m.GetDropForUpdateFunc = func(_ context.Context, _ uint64) (collection.Drop, error) {
-					return collection.Drop{ID: 1, Name: "Test Character", Image: "test.jpg"}, nil
+					return collection.Drop{Character: collection.Character{ID: 1, Name: "Test Character", Image: "test.jpg"}}, nil
 				}
 				m.GetUserFunc = func(_ context.Context, _ uint64) (collection.User, error) {
 					return collection.User{}, collection.ErrNotFound
@@ -143,6 +188,7 @@ func TestExchange(t *testing.T) {
 		charID     int64
 		wantErr    error
 		wantCharID int64
+		wantEvent  collection.OwnershipEvent
 	}{
 		{
 			name: "success",
@@ -166,6 +212,7 @@ func TestExchange(t *testing.T) {
 			userID:     123,
 			charID:     1,
 			wantCharID: 1,
+			wantEvent:  collection.OwnershipEvent{CharacterID: 1, Kind: collection.EventSell, FromUserID: 123, Tokens: 1},
 		},
 		{
 			name: "not_owned",
@@ -190,16 +237,25 @@ func TestExchange(t *testing.T) {
 				tt.setup(store)
 			}
 
+			var events []collection.OwnershipEvent
+			store.RecordOwnershipEventFunc = func(_ context.Context, e collection.OwnershipEvent) error {
+				e.CreatedAt = time.Time{}
+				events = append(events, e)
+				return nil
+			}
+
 			char, err := collection.Exchange(t.Context(), store, tt.userID, tt.charID)
 
 			if tt.wantErr != nil {
 				require.ErrorIs(t, err, tt.wantErr)
 				assert.Equal(t, 1, store.RollbackCalls)
+				assert.Empty(t, events)
 				return
 			}
 
 			require.NoError(t, err)
 			assert.Equal(t, tt.wantCharID, char.ID)
+			assert.Equal(t, []collection.OwnershipEvent{tt.wantEvent}, events)
 			assert.Equal(t, 1, store.CommitCalls)
 		})
 	}
@@ -216,6 +272,8 @@ func TestGive(t *testing.T) {
 		errContains string
 		wantSource  string
 		wantUserID  uint64
+		admin       bool
+		wantEvent   collection.OwnershipEvent
 	}{
 		{
 			name: "success",
@@ -242,6 +300,28 @@ func TestGive(t *testing.T) {
 			charID:     1,
 			wantSource: "TRADE",
 			wantUserID: 456,
+			wantEvent:  collection.OwnershipEvent{CharacterID: 1, Kind: collection.EventGive, FromUserID: 123, ToUserID: 456},
+		},
+		{
+			name: "admin",
+			setup: func(m *collectiontest.MockStore) {
+				m.GetOwnedCharacterFunc = func(_ context.Context, userID uint64, _ int64) (collection.OwnedCharacter, error) {
+					if userID == 123 {
+						return collection.OwnedCharacter{Character: collection.Character{ID: 1}}, nil
+					}
+					return collection.OwnedCharacter{}, collection.ErrNotFound
+				}
+				m.GiveCharacterFunc = func(_ context.Context, _, _ uint64, _ int64) (collection.OwnedCharacter, error) {
+					return collection.OwnedCharacter{Character: collection.Character{ID: 1}, Source: "TRADE", UserID: 456}, nil
+				}
+			},
+			from:       123,
+			to:         456,
+			charID:     1,
+			admin:      true,
+			wantSource: "TRADE",
+			wantUserID: 456,
+			wantEvent:  collection.OwnershipEvent{CharacterID: 1, Kind: collection.EventAdmin, FromUserID: 123, ToUserID: 456},
 		},
 		{
 			name: "not_owned",
@@ -280,18 +360,31 @@ func TestGive(t *testing.T) {
 				tt.setup(store)
 			}
 
-			char, err := collection.Give(t.Context(), store, tt.from, tt.to, tt.charID)
+			var events []collection.OwnershipEvent
+			store.RecordOwnershipEventFunc = func(_ context.Context, e collection.OwnershipEvent) error {
+				e.CreatedAt = time.Time{}
+				events = append(events, e)
+				return nil
+			}
+
+			give := collection.Give
+			if tt.admin {
+				give = collection.AdminGive
+			}
+			char, err := give(t.Context(), store, tt.from, tt.to, tt.charID)
 
 			if tt.wantErr {
 				require.Error(t, err)
 				assert.Contains(t, err.Error(), tt.errContains)
 				assert.Equal(t, 1, store.RollbackCalls)
+				assert.Empty(t, events)
 				return
 			}
 
 			require.NoError(t, err)
 			assert.Equal(t, tt.wantSource, char.Source)
 			assert.Equal(t, tt.wantUserID, char.UserID)
+			assert.Equal(t, []collection.OwnershipEvent{tt.wantEvent}, events)
 			assert.Equal(t, 1, store.CommitCalls)
 		})
 	}
@@ -362,15 +455,27 @@ func TestTransferTokens(t *testing.T) {
 				tt.setup(store)
 			}
 
+			var changes []collection.TokenChange
+			store.RecordTokenChangeFunc = func(_ context.Context, c collection.TokenChange) error {
+				c.CreatedAt = time.Time{}
+				changes = append(changes, c)
+				return nil
+			}
+
 			err := collection.TransferTokens(t.Context(), store, tt.from, tt.to, tt.amount)
 
 			if tt.wantErr != nil {
 				require.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, changes)
 				return
 			}
 
 			require.NoError(t, err)
 			assert.Equal(t, 1, store.CommitCalls)
+			assert.Equal(t, []collection.TokenChange{
+				{UserID: tt.from, Amount: -tt.amount, Balance: 50, Reason: collection.TokenTransfer, CounterpartyID: tt.to},
+				{UserID: tt.to, Amount: tt.amount, Balance: 50, Reason: collection.TokenTransfer, CounterpartyID: tt.from},
+			}, changes)
 		})
 	}
 }
@@ -20,8 +20,11 @@ type MockStore struct {
 	UpdateQuoteFunc              func(ctx context.Context, userID collection.UserID, quote string) error
 	UpdateAnilistURLFunc         func(ctx context.Context, userID collection.UserID, url string) error
 	UpdateDiscordInfoFunc        func(ctx context.Context, userID collection.UserID, username, avatar string, lastUpdated time.Time) error
+	UpdateDailyStreakFunc        func(ctx context.Context, userID collection.UserID, streak int32, previous, claimedAt time.Time) (bool, error)
 
 	GetCollectionFunc        func(ctx context.Context, userID collection.UserID) ([]collection.OwnedCharacter, error)
+	ListCollectionPageFunc   func(ctx context.Context, userID collection.UserID, q collection.CollectionPageQuery) ([]collection.OwnedCharacter, error)
+	CountCollectionPageFunc  func(ctx context.Context, userID collection.UserID, f collection.CollectionFilter) (int64, error)
 	GetCollectionIDsFunc     func(ctx context.Context, userID collection.UserID) ([]int64, error)
 	GetOwnedCharacterFunc    func(ctx context.Context, userID collection.UserID, charID int64) (collection.OwnedCharacter, error)
 	AddToCollectionFunc      func(ctx context.Context, userID collection.UserID, char collection.Character, source string, acquiredAt time.Time) error
@@ -29,6 +32,7 @@ type MockStore struct {
 	GiveCharacterFunc        func(ctx context.Context, from, to collection.UserID, charID int64) (collection.OwnedCharacter, error)
 	CountCollectionFunc      func(ctx context.Context, userID collection.UserID) (int64, error)
 	RemoveFromWishlistFunc   func(ctx context.Context, userID collection.UserID, charID int64) error
+	AddToWishlistFunc        func(ctx context.Context, userID collection.UserID, charIDs []int64) error
 
 	GetDropForUpdateFunc func(ctx context.Context, channelID uint64) (collection.Drop, error)
 	DeleteDropFunc       func(ctx context.Context, channelID uint64) error
@@ -39,6 +43,42 @@ type MockStore struct {
 	UpsertGuildMembersFunc      func(ctx context.Context, guildID uint64, memberIDs []uint64, indexedAt time.Time) error
 	DeleteGuildMembersNotInFunc func(ctx context.Context, guildID uint64, memberIDs []uint64) error
 
+	CreateTradeOfferFunc       func(ctx context.Context, offer collection.TradeOffer) (collection.TradeOffer, error)
+	GetTradeOfferForUpdateFunc func(ctx context.Context, id int64) (collection.TradeOffer, error)
+	ListTradeOffersFunc        func(ctx context.Context, userID collection.UserID, now time.Time) ([]collection.TradeOffer, error)
+	SetTradeOfferStatusFunc    func(ctx context.Context, id int64, status collection.TradeStatus) error
+	ExpireTradeOffersFunc      func(ctx context.Context, now time.Time) (int64, error)
+
+	CreateAuctionFunc       func(ctx context.Context, a collection.Auction) (collection.Auction, error)
+	GetAuctionForUpdateFunc func(ctx context.Context, id int64) (collection.Auction, error)
+	ListOpenAuctionsFunc    func(ctx context.Context, limit int32) ([]collection.Auction, error)
+	ListDueAuctionIDsFunc   func(ctx context.Context, now time.Time) ([]int64, error)
+	SetAuctionHighBidFunc   func(ctx context.Context, id int64, bidderID collection.UserID, amount int32) error
+	SetAuctionStatusFunc    func(ctx context.Context, id int64, status collection.AuctionStatus) error
+	GetEscrowFunc           func(ctx context.Context, auctionID int64, userID collection.UserID) (int32, error)
+	UpsertEscrowFunc        func(ctx context.Context, auctionID int64, userID collection.UserID, amount int32) error
+	ListEscrowsFunc         func(ctx context.Context, auctionID int64) ([]collection.AuctionEscrow, error)
+	DeleteEscrowsFunc       func(ctx context.Context, auctionID int64) error
+	DeleteEscrowFunc        func(ctx context.Context, auctionID int64, userID collection.UserID) error
+
+	RecordOwnershipEventFunc func(ctx context.Context, event collection.OwnershipEvent) error
+	ListOwnershipEventsFunc  func(ctx context.Context, charID int64, limit int32) ([]collection.OwnershipEvent, error)
+	CountReceivedEventsFunc  func(ctx context.Context, userID collection.UserID, kind collection.EventKind) (int64, error)
+
+	RecordTokenChangeFunc      func(ctx context.Context, change collection.TokenChange) error
+	ListTokenChangesFunc       func(ctx context.Context, userID collection.UserID, limit int32) ([]collection.TokenChange, error)
+	TokenBalanceMismatchesFunc func(ctx context.Context) ([]collection.TokenMismatch, error)
+
+	UnlockAchievementFunc       func(ctx context.Context, userID collection.UserID, id string, at time.Time) (bool, error)
+	ListAchievementsFunc        func(ctx context.Context, userID collection.UserID) ([]collection.AchievementUnlock, error)
+	CountCompletedSeriesFunc    func(ctx context.Context, userID collection.UserID, minCharacters int32) (int64, error)
+	CountCompletedSeriesOfFunc  func(ctx context.Context, userID collection.UserID, charID int64, minCharacters int32) (int64, error)
+	CountOwnedFromFavoritesFunc func(ctx context.Context, userID collection.UserID, minFavorites int32) (int64, error)
+
+	CollectionValueFunc        func(ctx context.Context, userID collection.UserID) (int64, error)
+	RecordCollectionValuesFunc func(ctx context.Context, day time.Time) (int64, error)
+	CollectionValueHistoryFunc func(ctx context.Context, userID collection.UserID, since time.Time) ([]collection.ValuePoint, error)
+
 	UpsertCharacterFunc            func(ctx context.Context, char catalog.Character) error
 	GetCharacterByIDFunc           func(ctx context.Context, charID int64) (catalog.Character, error)
 	SearchCharactersFunc           func(ctx context.Context, userID uint64, term string) ([]catalog.Character, error)
@@ -46,6 +86,17 @@ type MockStore struct {
 	GetCharacterHoldersInGuildFunc func(ctx context.Context, guildID uint64, charID int64) ([]uint64, error)
 	GetActiveIDsFunc               func(ctx context.Context) ([]int64, error)
 	MarkCharactersInactiveFunc     func(ctx context.Context, ids []int64) error
+	UpsertMediaFunc                func(ctx context.Context, media []catalog.Media) error
+	SetCharacterMediaFunc          func(ctx context.Context, charID int64, mediaIDs []int64) error
+	SetMediaCharactersFunc         func(ctx context.Context, media catalog.Media, chars []catalog.Character, syncedAt time.Time) error
+	CharacterDescriptionFunc       func(ctx context.Context, charID int64) (string, error)
+	CharacterStatsFunc             func(ctx context.Context, charID int64, guildID uint64) (catalog.CharacterStats, error)
+	CharactersStatsFunc            func(ctx context.Context, charIDs []int64) (map[int64]catalog.CharacterStats, error)
+	GetMediaFunc                   func(ctx context.Context, mediaID int64) (catalog.Media, error)
+	MediaByMalIDFunc               func(ctx context.Context, malID int64, mediaType string) (catalog.Media, error)
+	SearchMediaFunc                func(ctx context.Context, term string) ([]catalog.Media, error)
+	CharactersByMediaFunc          func(ctx context.Context, mediaID int64) ([]catalog.Character, error)
+	MediaByCharacterFunc           func(ctx context.Context, charID int64) ([]catalog.Media, error)
 
 	RandomCharNotOwnedFunc func(ctx context.Context, userID collection.UserID, weightExponent float64) (catalog.Character, error)
 	RandomActiveCharFunc   func(ctx context.Context, weightExponent float64) (catalog.Character, error)
@@ -135,6 +186,13 @@ func (m *MockStore) UpdateDiscordInfo(ctx context.Context, userID collection.Use
 	return nil
 }
 
+func (m *MockStore) UpdateDailyStreak(ctx context.Context, userID collection.UserID, streak int32, previous, claimedAt time.Time) (bool, error) {
+	if m.UpdateDailyStreakFunc != nil {
+		return m.UpdateDailyStreakFunc(ctx, userID, streak, previous, claimedAt)
+	}
+	return true, nil
+}
+
 func (m *MockStore) GetCollection(ctx context.Context, userID collection.UserID) ([]collection.OwnedCharacter, error) {
 	if m.GetCollectionFunc != nil {
 		return m.GetCollectionFunc(ctx, userID)
@@ -142,6 +200,20 @@ func (m *MockStore) GetCollection(ctx context.Context, userID collection.UserID)
 	return nil, nil
 }
 
+func (m *MockStore) ListCollectionPage(ctx context.Context, userID collection.UserID, q collection.CollectionPageQuery) ([]collection.OwnedCharacter, error) {
+	if m.ListCollectionPageFunc != nil {
+		return m.ListCollectionPageFunc(ctx, userID, q)
+	}
+	return nil, nil
+}
+
+func (m *MockStore) CountCollectionPage(ctx context.Context, userID collection.UserID, f collection.CollectionFilter) (int64, error) {
+	if m.CountCollectionPageFunc != nil {
+		return m.CountCollectionPageFunc(ctx, userID, f)
+	}
+	return 0, nil
+}
+
 func (m *MockStore) GetCollectionIDs(ctx context.Context, userID collection.UserID) ([]int64, error) {
 	if m.GetCollectionIDsFunc != nil {
 		return m.GetCollectionIDsFunc(ctx, userID)
@@ -191,6 +263,13 @@ func (m *MockStore) RemoveFromWishlist(ctx context.Context, userID collection.Us
 	return nil
 }
 
+func (m *MockStore) AddToWishlist(ctx context.Context, userID collection.UserID, charIDs []int64) error {
+	if m.AddToWishlistFunc != nil {
+		return m.AddToWishlistFunc(ctx, userID, charIDs)
+	}
+	return nil
+}
+
 func (m *MockStore) GetDropForUpdate(ctx context.Context, channelID uint64) (collection.Drop, error) {
 	if m.GetDropForUpdateFunc != nil {
 		return m.GetDropForUpdateFunc(ctx, channelID)
@@ -240,6 +319,118 @@ func (m *MockStore) DeleteGuildMembersNotIn(ctx context.Context, guildID uint64,
 	return nil
 }
 
+func (m *MockStore) CreateTradeOffer(ctx context.Context, offer collection.TradeOffer) (collection.TradeOffer, error) {
+	if m.CreateTradeOfferFunc != nil {
+		return m.CreateTradeOfferFunc(ctx, offer)
+	}
+	return offer, nil
+}
+
+func (m *MockStore) GetTradeOfferForUpdate(ctx context.Context, id int64) (collection.TradeOffer, error) {
+	if m.GetTradeOfferForUpdateFunc != nil {
+		return m.GetTradeOfferForUpdateFunc(ctx, id)
+	}
+	return collection.TradeOffer{}, nil
+}
+
+func (m *MockStore) ListTradeOffers(ctx context.Context, userID collection.UserID, now time.Time) ([]collection.TradeOffer, error) {
+	if m.ListTradeOffersFunc != nil {
+		return m.ListTradeOffersFunc(ctx, userID, now)
+	}
+	return nil, nil
+}
+
+func (m *MockStore) SetTradeOfferStatus(ctx context.Context, id int64, status collection.TradeStatus) error {
+	if m.SetTradeOfferStatusFunc != nil {
+		return m.SetTradeOfferStatusFunc(ctx, id, status)
+	}
+	return nil
+}
+
+func (m *MockStore) ExpireTradeOffers(ctx context.Context, now time.Time) (int64, error) {
+	if m.ExpireTradeOffersFunc != nil {
+		return m.ExpireTradeOffersFunc(ctx, now)
+	}
+	return 0, nil
+}
+
+func (m *MockStore) CreateAuction(ctx context.Context, a collection.Auction) (collection.Auction, error) {
+	if m.CreateAuctionFunc != nil {
+		return m.CreateAuctionFunc(ctx, a)
+	}
+	return a, nil
+}
+
+func (m *MockStore) GetAuctionForUpdate(ctx context.Context, id int64) (collection.Auction, error) {
+	if m.GetAuctionForUpdateFunc != nil {
+		return m.GetAuctionForUpdateFunc(ctx, id)
+	}
+	return collection.Auction{}, nil
+}
+
+func (m *MockStore) ListOpenAuctions(ctx context.Context, limit int32) ([]collection.Auction, error) {
+	if m.ListOpenAuctionsFunc != nil {
+		return m.ListOpenAuctionsFunc(ctx, limit)
+	}
+	return nil, nil
+}
+
+func (m *MockStore) ListDueAuctionIDs(ctx context.Context, now time.Time) ([]int64, error) {
+	if m.ListDueAuctionIDsFunc != nil {
+		return m.ListDueAuctionIDsFunc(ctx, now)
+	}
+	return nil, nil
+}
+
+func (m *MockStore) SetAuctionHighBid(ctx context.Context, id int64, bidderID collection.UserID, amount int32) error {
+	if m.SetAuctionHighBidFunc != nil {
+		return m.SetAuctionHighBidFunc(ctx, id, bidderID, amount)
+	}
+	return nil
+}
+
+func (m *MockStore) SetAuctionStatus(ctx context.Context, id int64, status collection.AuctionStatus) error {
+	if m.SetAuctionStatusFunc != nil {
+		return m.SetAuctionStatusFunc(ctx, id, status)
+	}
+	return nil
+}
+
+func (m *MockStore) GetEscrow(ctx context.Context, auctionID int64, userID collection.UserID) (int32, error) {
+	if m.GetEscrowFunc != nil {
+		return m.GetEscrowFunc(ctx, auctionID, userID)
+	}
+	return 0, nil
+}
+
+func (m *MockStore) UpsertEscrow(ctx context.Context, auctionID int64, userID collection.UserID, amount int32) error {
+	if m.UpsertEscrowFunc != nil {
+		return m.UpsertEscrowFunc(ctx, auctionID, userID, amount)
+	}
+	return nil
+}
+
+func (m *MockStore) ListEscrows(ctx context.Context, auctionID int64) ([]collection.AuctionEscrow, error) {
+	if m.ListEscrowsFunc != nil {
+		return m.ListEscrowsFunc(ctx, auctionID)
+	}
+	return nil, nil
+}
+
+func (m *MockStore) DeleteEscrow(ctx context.Context, auctionID int64, userID collection.UserID) error {
+	if m.DeleteEscrowFunc != nil {
+		return m.DeleteEscrowFunc(ctx, auctionID, userID)
+	}
+	return nil
+}
+
+func (m *MockStore) DeleteEscrows(ctx context.Context, auctionID int64) error {
+	if m.DeleteEscrowsFunc != nil {
+		return m.DeleteEscrowsFunc(ctx, auctionID)
+	}
+	return nil
+}
+
 func (m *MockStore) UpsertCharacter(ctx context.Context, char catalog.Character) error {
 	if m.UpsertCharacterFunc != nil {
 		return m.UpsertCharacterFunc(ctx, char)
@@ -289,6 +480,83 @@ func (m *MockStore) MarkCharactersInactive(ctx context.Context, ids []int64) err
 	return nil
 }
 
+func (m *MockStore) UpsertMedia(ctx context.Context, media []catalog.Media) error {
+	if m.UpsertMediaFunc != nil {
+		return m.UpsertMediaFunc(ctx, media)
+	}
+	return nil
+}
+
+func (m *MockStore) SetCharacterMedia(ctx context.Context, charID int64, mediaIDs []int64) error {
+	if m.SetCharacterMediaFunc != nil {
+		return m.SetCharacterMediaFunc(ctx, charID, mediaIDs)
+	}
+	return nil
+}
+
+func (m *MockStore) SetMediaCharacters(ctx context.Context, media catalog.Media, chars []catalog.Character, syncedAt time.Time) error {
+	if m.SetMediaCharactersFunc != nil {
+		return m.SetMediaCharactersFunc(ctx, media, chars, syncedAt)
+	}
+	return nil
+}
+
+func (m *MockStore) CharacterDescription(ctx context.Context, charID int64) (string, error) {
+	if m.CharacterDescriptionFunc != nil {
+		return m.CharacterDescriptionFunc(ctx, charID)
+	}
+	return "", nil
+}
+
+func (m *MockStore) CharacterStats(ctx context.Context, charID int64, guildID uint64) (catalog.CharacterStats, error) {
+	if m.CharacterStatsFunc != nil {
+		return m.CharacterStatsFunc(ctx, charID, guildID)
+	}
+	return catalog.CharacterStats{}, nil
+}
+
+func (m *MockStore) CharactersStats(ctx context.Context, charIDs []int64) (map[int64]catalog.CharacterStats, error) {
+	if m.CharactersStatsFunc != nil {
+		return m.CharactersStatsFunc(ctx, charIDs)
+	}
+	return nil, nil
+}
+
+func (m *MockStore) GetMedia(ctx context.Context, mediaID int64) (catalog.Media, error) {
+	if m.GetMediaFunc != nil {
+		return m.GetMediaFunc(ctx, mediaID)
+	}
+	return catalog.Media{}, collection.ErrNotFound
+}
+
+func (m *MockStore) MediaByMalID(ctx context.Context, malID int64, mediaType string) (catalog.Media, error) {
+	if m.MediaByMalIDFunc != nil {
+		return m.MediaByMalIDFunc(ctx, malID, mediaType)
+	}
+	return catalog.Media{}, collection.ErrNotFound
+}
+
+func (m *MockStore) SearchMedia(ctx context.Context, term string) ([]catalog.Media, error) {
+	if m.SearchMediaFunc != nil {
+		return m.SearchMediaFunc(ctx, term)
+	}
+	return nil, nil
+}
+
+func (m *MockStore) CharactersByMedia(ctx context.Context, mediaID int64) ([]catalog.Character, error) {
+	if m.CharactersByMediaFunc != nil {
+		return m.CharactersByMediaFunc(ctx, mediaID)
+	}
+	return nil, nil
+}
+
+func (m *MockStore) MediaByCharacter(ctx context.Context, charID int64) ([]catalog.Media, error) {
+	if m.MediaByCharacterFunc != nil {
+		return m.MediaByCharacterFunc(ctx, charID)
+	}
+	return nil, nil
+}
+
 func (m *MockStore) RandomCharNotOwned(ctx context.Context, userID collection.UserID, weightExponent float64) (catalog.Character, error) {
 	if m.RandomCharNotOwnedFunc != nil {
 		return m.RandomCharNotOwnedFunc(ctx, userID, weightExponent)
@@ -303,6 +571,104 @@ func (m *MockStore) RandomActiveChar(ctx context.Context, weightExponent float64
 	return catalog.Character{}, nil
 }
 
+func (m *MockStore) RecordOwnershipEvent(ctx context.Context, event collection.OwnershipEvent) error {
+	if m.RecordOwnershipEventFunc != nil {
+		return m.RecordOwnershipEventFunc(ctx, event)
+	}
+	return nil
+}
+
+func (m *MockStore) ListOwnershipEvents(ctx context.Context, charID int64, limit int32) ([]collection.OwnershipEvent, error) {
+	if m.ListOwnershipEventsFunc != nil {
+		return m.ListOwnershipEventsFunc(ctx, charID, limit)
+	}
+	return nil, nil
+}
+
+func (m *MockStore) CountReceivedEvents(ctx context.Context, userID collection.UserID, kind collection.EventKind) (int64, error) {
+	if m.CountReceivedEventsFunc != nil {
+		return m.CountReceivedEventsFunc(ctx, userID, kind)
+	}
+	return 0, nil
+}
+
+func (m *MockStore) RecordTokenChange(ctx context.Context, change collection.TokenChange) error {
+	if m.RecordTokenChangeFunc != nil {
+		return m.RecordTokenChangeFunc(ctx, change)
+	}
+	return nil
+}
+
+func (m *MockStore) ListTokenChanges(ctx context.Context, userID collection.UserID, limit int32) ([]collection.TokenChange, error) {
+	if m.ListTokenChangesFunc != nil {
+		return m.ListTokenChangesFunc(ctx, userID, limit)
+	}
+	return nil, nil
+}
+
+func (m *MockStore) TokenBalanceMismatches(ctx context.Context) ([]collection.TokenMismatch, error) {
+	if m.TokenBalanceMismatchesFunc != nil {
+		return m.TokenBalanceMismatchesFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockStore) UnlockAchievement(ctx context.Context, userID collection.UserID, id string, at time.Time) (bool, error) {
+	if m.UnlockAchievementFunc != nil {
+		return m.UnlockAchievementFunc(ctx, userID, id, at)
+	}
+	return true, nil
+}
+
+func (m *MockStore) ListAchievements(ctx context.Context, userID collection.UserID) ([]collection.AchievementUnlock, error) {
+	if m.ListAchievementsFunc != nil {
+		return m.ListAchievementsFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockStore) CountCompletedSeries(ctx context.Context, userID collection.UserID, minCharacters int32) (int64, error) {
+	if m.CountCompletedSeriesFunc != nil {
+		return m.CountCompletedSeriesFunc(ctx, userID, minCharacters)
+	}
+	return 0, nil
+}
+
+func (m *MockStore) CountCompletedSeriesOf(ctx context.Context, userID collection.UserID, charID int64, minCharacters int32) (int64, error) {
+	if m.CountCompletedSeriesOfFunc != nil {
+		return m.CountCompletedSeriesOfFunc(ctx, userID, charID, minCharacters)
+	}
+	return 0, nil
+}
+
+func (m *MockStore) CountOwnedFromFavorites(ctx context.Context, userID collection.UserID, minFavorites int32) (int64, error) {
+	if m.CountOwnedFromFavoritesFunc != nil {
+		return m.CountOwnedFromFavoritesFunc(ctx, userID, minFavorites)
+	}
+	return 0, nil
+}
+
+func (m *MockStore) CollectionValue(ctx context.Context, userID collection.UserID) (int64, error) {
+	if m.CollectionValueFunc != nil {
+		return m.CollectionValueFunc(ctx, userID)
+	}
+	return 0, nil
+}
+
+func (m *MockStore) RecordCollectionValues(ctx context.Context, day time.Time) (int64, error) {
+	if m.RecordCollectionValuesFunc != nil {
+		return m.RecordCollectionValuesFunc(ctx, day)
+	}
+	return 0, nil
+}
+
+func (m *MockStore) CollectionValueHistory(ctx context.Context, userID collection.UserID, since time.Time) ([]collection.ValuePoint, error) {
+	if m.CollectionValueHistoryFunc != nil {
+		return m.CollectionValueHistoryFunc(ctx, userID, since)
+	}
+	return nil, nil
+}
+
 func (m *MockStore) WithTx(ctx context.Context) (collection.Store, error) {
 	if m.WithTxFunc != nil {
 		return m.WithTxFunc(ctx)
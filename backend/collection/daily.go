@@ -0,0 +1,165 @@
+package collection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DailyCooldown is how long a user waits between two daily rewards.
+const DailyCooldown = 24 * time.Hour
+
+// DefaultDailyGrace is how late a user may be, past the end of the next day,
+// and still keep their streak.
+const DefaultDailyGrace = 6 * time.Hour
+
+// DefaultDailySchedule is the payout for each day of a streak. Streaks longer
+// than the schedule keep getting its last entry.
+var DefaultDailySchedule = []int32{1, 1, 2, 2, 3, 3, 5}
+
+// DefaultStreakMilestones are the bonuses paid when a streak reaches a given length.
+var DefaultStreakMilestones = []StreakMilestone{
+	{Streak: 7, Tokens: 5},
+	{Streak: 30, Tokens: 20},
+	{Streak: 100, Tokens: 50},
+}
+
+// StreakMilestone is a one-off bonus paid on the day a streak reaches Streak.
+type StreakMilestone struct {
+	Streak int32
+	Tokens int32
+}
+
+// DailyConfig holds the daily reward tunables.
+type DailyConfig struct {
+	Schedule   []int32
+	Grace      time.Duration
+	Milestones []StreakMilestone
+}
+
+// DefaultDailyConfig returns the default daily reward tunables.
+func DefaultDailyConfig() DailyConfig {
+	return DailyConfig{
+		Schedule:   DefaultDailySchedule,
+		Grace:      DefaultDailyGrace,
+		Milestones: DefaultStreakMilestones,
+	}
+}
+
+// Payout returns the tokens paid on the given day of a streak.
+func (c DailyConfig) Payout(streak int32) int32 {
+	if len(c.Schedule) == 0 || streak <= 0 {
+		return 0
+	}
+	return c.Schedule[min(int(streak), len(c.Schedule))-1]
+}
+
+// Bonus returns the milestone bonus paid on the given day of a streak, if any.
+func (c DailyConfig) Bonus(streak int32) int32 {
+	var bonus int32
+	for _, m := range c.Milestones {
+		if m.Streak == streak {
+			bonus += m.Tokens
+		}
+	}
+	return bonus
+}
+
+// StreakExpiry returns when a streak whose last claim was at last is lost.
+// The next claim opens DailyCooldown after the last one and stays open for a
+// day, plus the grace window.
+func (c DailyConfig) StreakExpiry(last time.Time) time.Time {
+	return last.Add(2*DailyCooldown + c.Grace)
+}
+
+// ErrDailyCooldown is returned when a user already claimed their daily reward.
+type ErrDailyCooldown struct {
+	Until time.Time
+}
+
+func (e ErrDailyCooldown) Error() string {
+	return fmt.Sprintf("You can claim your daily reward again in %s.", time.Until(e.Until).Round(time.Second))
+}
+
+// DailyReward is the outcome of a daily claim.
+type DailyReward struct {
+	Streak int32
+	Tokens int32
+	// Bonus is the milestone bonus paid on top of Tokens, 0 if none.
+	Bonus   int32
+	Balance int32
+	// StreakLost is set when a previous streak ran out before this claim.
+	StreakLost bool
+	Next       time.Time
+	ExpiresAt  time.Time
+}
+
+// ClaimDaily pays the user's daily reward and advances their streak. The
+// streak, the payout and any milestone bonus are committed together.
+func ClaimDaily(ctx context.Context, store Store, userID UserID, config DailyConfig) (DailyReward, error) {
+	now := time.Now()
+
+	var reward DailyReward
+	err := withTx(ctx, store, func(tx Store) error {
+		user, err := tx.GetUser(ctx, userID)
+		if errors.Is(err, ErrNotFound) {
+			if err := tx.CreateUser(ctx, userID); err != nil {
+				return err
+			}
+			user, err = tx.GetUser(ctx, userID)
+		}
+		if err != nil {
+			return err
+		}
+
+		if until := user.LastDaily.Add(DailyCooldown); now.Before(until) {
+			return ErrDailyCooldown{Until: until}
+		}
+
+		streak := int32(1)
+		if user.DailyStreak > 0 && now.Before(config.StreakExpiry(user.LastDaily)) {
+			streak = user.DailyStreak + 1
+		}
+
+		ok, err := tx.UpdateDailyStreak(ctx, userID, streak, user.LastDaily, now)
+		if err != nil {
+			return fmt.Errorf("error updating daily streak: %w", err)
+		}
+		if !ok {
+			return ErrDailyCooldown{Until: now.Add(DailyCooldown)}
+		}
+
+		reward = DailyReward{
+			Streak:     streak,
+			Tokens:     config.Payout(streak),
+			Bonus:      config.Bonus(streak),
+			StreakLost: user.DailyStreak > 0 && streak == 1,
+			Next:       now.Add(DailyCooldown),
+			ExpiresAt:  config.StreakExpiry(now),
+		}
+
+		u, err := changeTokens(ctx, tx, TokenChange{UserID: userID, Amount: reward.Tokens, Reason: TokenDaily, CreatedAt: now})
+		if err != nil {
+			return fmt.Errorf("error paying daily reward: %w", err)
+		}
+		if reward.Bonus > 0 {
+			u, err = changeTokens(ctx, tx, TokenChange{
+				UserID:      userID,
+				Amount:      reward.Bonus,
+				Reason:      TokenStreakBonus,
+				ReferenceID: int64(streak),
+				CreatedAt:   now,
+			})
+			if err != nil {
+				return fmt.Errorf("error paying streak bonus: %w", err)
+			}
+		}
+		reward.Balance = u.Tokens
+		return nil
+	})
+	if err != nil {
+		return DailyReward{}, err
+	}
+	return reward, nil
+}
@@ -0,0 +1,123 @@
+package collection_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/collection/collectiontest"
+)
+
+func TestDailyConfig(t *testing.T) {
+	config := collection.DefaultDailyConfig()
+
+	assert.Equal(t, int32(0), config.Payout(0))
+	assert.Equal(t, int32(1), config.Payout(1))
+	assert.Equal(t, int32(2), config.Payout(3))
+	assert.Equal(t, int32(5), config.Payout(7))
+	assert.Equal(t, int32(5), config.Payout(365), "the last entry repeats")
+
+	assert.Equal(t, int32(0), config.Bonus(6))
+	assert.Equal(t, int32(5), config.Bonus(7))
+	assert.Equal(t, int32(20), config.Bonus(30))
+	assert.Equal(t, int32(0), config.Bonus(31))
+
+	last := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, last.Add(54*time.Hour), config.StreakExpiry(last))
+}
+
+func TestClaimDaily(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name       string
+		user       collection.User
+		newUser    bool
+		raced      bool
+		wantErr    bool
+		wantStreak int32
+		wantTokens int32
+		wantBonus  int32
+		wantLost   bool
+	}{
+		{name: "first claim", newUser: true, wantStreak: 1, wantTokens: 1},
+		{name: "next day", user: collection.User{DailyStreak: 2, LastDaily: now.Add(-25 * time.Hour)}, wantStreak: 3, wantTokens: 2},
+		{name: "within grace", user: collection.User{DailyStreak: 2, LastDaily: now.Add(-50 * time.Hour)}, wantStreak: 3, wantTokens: 2},
+		{name: "missed a day", user: collection.User{DailyStreak: 5, LastDaily: now.Add(-55 * time.Hour)}, wantStreak: 1, wantTokens: 1, wantLost: true},
+		{name: "milestone", user: collection.User{DailyStreak: 6, LastDaily: now.Add(-30 * time.Hour)}, wantStreak: 7, wantTokens: 5, wantBonus: 5},
+		{name: "already claimed", user: collection.User{DailyStreak: 3, LastDaily: now.Add(-time.Hour)}, wantErr: true},
+		{name: "concurrent claim", user: collection.User{DailyStreak: 3, LastDaily: now.Add(-25 * time.Hour)}, raced: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created bool
+			var balance int32 = 10
+			var streak int32
+			var changes []collection.TokenChange
+			store := &collectiontest.MockStore{
+				GetUserFunc: func(_ context.Context, userID collection.UserID) (collection.User, error) {
+					if tt.newUser && !created {
+						return collection.User{}, collection.ErrNotFound
+					}
+					u := tt.user
+					u.UserID = userID
+					return u, nil
+				},
+				CreateUserFunc: func(context.Context, collection.UserID) error {
+					created = true
+					return nil
+				},
+				UpdateDailyStreakFunc: func(_ context.Context, _ collection.UserID, s int32, previous, _ time.Time) (bool, error) {
+					assert.Equal(t, tt.user.LastDaily, previous)
+					streak = s
+					return !tt.raced, nil
+				},
+				AddTokensFunc: func(_ context.Context, userID collection.UserID, amount int32) (collection.User, error) {
+					balance += amount
+					return collection.User{UserID: userID, Tokens: balance}, nil
+				},
+				RecordTokenChangeFunc: func(_ context.Context, c collection.TokenChange) error {
+					changes = append(changes, c)
+					return nil
+				},
+			}
+
+			reward, err := collection.ClaimDaily(t.Context(), store, 1, collection.DefaultDailyConfig())
+			if tt.wantErr {
+				var cd collection.ErrDailyCooldown
+				require.ErrorAs(t, err, &cd)
+				assert.True(t, cd.Until.After(now))
+				assert.Empty(t, changes)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.newUser, created)
+			assert.Equal(t, tt.wantStreak, reward.Streak)
+			assert.Equal(t, tt.wantStreak, streak)
+			assert.Equal(t, tt.wantTokens, reward.Tokens)
+			assert.Equal(t, tt.wantBonus, reward.Bonus)
+			assert.Equal(t, tt.wantLost, reward.StreakLost)
+			assert.Equal(t, 10+tt.wantTokens+tt.wantBonus, reward.Balance)
+			assert.True(t, reward.ExpiresAt.After(reward.Next))
+
+			require.NotEmpty(t, changes)
+			assert.Equal(t, collection.TokenDaily, changes[0].Reason)
+			assert.Equal(t, tt.wantTokens, changes[0].Amount)
+			if tt.wantBonus == 0 {
+				assert.Len(t, changes, 1)
+				return
+			}
+			require.Len(t, changes, 2)
+			assert.Equal(t, collection.TokenStreakBonus, changes[1].Reason)
+			assert.Equal(t, tt.wantBonus, changes[1].Amount)
+			assert.Equal(t, int64(tt.wantStreak), changes[1].ReferenceID)
+			assert.Equal(t, reward.Balance, changes[1].Balance)
+		})
+	}
+}
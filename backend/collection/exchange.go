@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 )
 
 // Exchange sells a character for 1 token.
@@ -38,11 +39,22 @@ func Exchange(ctx context.Context, store Store, userID UserID, charID int64) (Ow
 		return OwnedCharacter{}, err
 	}
 
-	_, err = tx.AddTokens(ctx, userID, 1)
+	_, err = changeTokens(ctx, tx, TokenChange{UserID: userID, Amount: 1, Reason: TokenSell})
 	if err != nil {
 		return OwnedCharacter{}, err
 	}
 
+	err = tx.RecordOwnershipEvent(ctx, OwnershipEvent{
+		CharacterID: charID,
+		Kind:        EventSell,
+		FromUserID:  userID,
+		Tokens:      1,
+		CreatedAt:   time.Now(),
+	})
+	if err != nil {
+		return OwnedCharacter{}, fmt.Errorf("error recording ownership event: %w", err)
+	}
+
 	err = tx.Commit(ctx)
 	committed = err == nil
 
@@ -4,10 +4,21 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 )
 
 // Give executes the give logic from one user to another.
 func Give(ctx context.Context, store Store, from, to UserID, charID int64) (OwnedCharacter, error) {
+	return give(ctx, store, from, to, charID, EventGive)
+}
+
+// AdminGive moves a character on behalf of an operator. It is recorded as an
+// admin event in the ownership ledger instead of a give.
+func AdminGive(ctx context.Context, store Store, from, to UserID, charID int64) (OwnedCharacter, error) {
+	return give(ctx, store, from, to, charID, EventAdmin)
+}
+
+func give(ctx context.Context, store Store, from, to UserID, charID int64, kind EventKind) (OwnedCharacter, error) {
 	tx, err := store.WithTx(ctx)
 	if err != nil {
 		return OwnedCharacter{}, err
@@ -39,6 +50,17 @@ func Give(ctx context.Context, store Store, from, to UserID, charID int64) (Owne
 
 	_ = tx.RemoveFromWishlist(ctx, to, charID)
 
+	err = tx.RecordOwnershipEvent(ctx, OwnershipEvent{
+		CharacterID: charID,
+		Kind:        kind,
+		FromUserID:  from,
+		ToUserID:    to,
+		CreatedAt:   time.Now(),
+	})
+	if err != nil {
+		return OwnedCharacter{}, fmt.Errorf("error recording ownership event: %w", err)
+	}
+
 	err = tx.Commit(ctx)
 	committed = err == nil
 	return given, err
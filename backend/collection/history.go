@@ -0,0 +1,67 @@
+package collection
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultHistoryLimit is how many ownership events CharacterHistory returns when no limit is given.
+const DefaultHistoryLimit = 25
+
+// EventKind says how a character changed hands.
+type EventKind string
+
+const (
+	EventRoll       EventKind = "roll"
+	EventClaim      EventKind = "claim"
+	EventSeriesRoll EventKind = "series_roll"
+	EventGive       EventKind = "give"
+	EventSell       EventKind = "sell"
+	EventTrade      EventKind = "trade"
+	EventAuction    EventKind = "auction"
+	EventAdmin      EventKind = "admin"
+)
+
+// OwnershipEvent is one entry of the append-only ownership ledger.
+// FromUserID is 0 when the character entered the game, ToUserID is 0 when it left it.
+type OwnershipEvent struct {
+	ID          int64
+	CharacterID int64
+	Kind        EventKind
+	FromUserID  UserID
+	ToUserID    UserID
+	// Tokens paid for the character, if any.
+	Tokens int32
+	// ReferenceID is the trade or auction the event belongs to, 0 otherwise.
+	ReferenceID int64
+	CreatedAt   time.Time
+}
+
+// OwnershipLedgerRepository records ownership changes. Events are written inside the
+// transaction that moves the character so the ledger never disagrees with it.
+type OwnershipLedgerRepository interface {
+	RecordOwnershipEvent(ctx context.Context, event OwnershipEvent) error
+	// ListOwnershipEvents returns up to limit events for a character, newest first.
+	ListOwnershipEvents(ctx context.Context, charID int64, limit int32) ([]OwnershipEvent, error)
+	// CountReceivedEvents counts the events of a kind that gave a character to the user.
+	CountReceivedEvents(ctx context.Context, userID UserID, kind EventKind) (int64, error)
+}
+
+// CharacterHistory returns the character and its most recent ownership events, newest first.
+func CharacterHistory(ctx context.Context, store Store, charID int64, limit int32) (Character, []OwnershipEvent, error) {
+	if limit <= 0 {
+		limit = DefaultHistoryLimit
+	}
+
+	char, err := store.GetCharacterByID(ctx, charID)
+	if err != nil {
+		return Character{}, nil, err
+	}
+
+	events, err := store.ListOwnershipEvents(ctx, charID, limit)
+	if err != nil {
+		return Character{}, nil, err
+	}
+
+	return char, events, nil
+}
@@ -0,0 +1,127 @@
+package collection_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karitham/waifubot/catalog"
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/collection/collectiontest"
+)
+
+func TestCharacterHistory(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     int32
+		charErr   error
+		wantLimit int32
+		wantErr   error
+	}{
+		{name: "default limit", wantLimit: collection.DefaultHistoryLimit},
+		{name: "explicit limit", limit: 5, wantLimit: 5},
+		{name: "unknown character", charErr: collection.ErrNotFound, wantErr: collection.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotLimit int32
+			store := &collectiontest.MockStore{
+				GetCharacterByIDFunc: func(_ context.Context, id int64) (catalog.Character, error) {
+					return catalog.Character{ID: id, Name: "Rem"}, tt.charErr
+				},
+				ListOwnershipEventsFunc: func(_ context.Context, charID int64, limit int32) ([]collection.OwnershipEvent, error) {
+					gotLimit = limit
+					return []collection.OwnershipEvent{{CharacterID: charID, Kind: collection.EventRoll, ToUserID: 1}}, nil
+				},
+			}
+
+			char, events, err := collection.CharacterHistory(t.Context(), store, 42, tt.limit)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "Rem", char.Name)
+			assert.Len(t, events, 1)
+			assert.Equal(t, tt.wantLimit, gotLimit)
+		})
+	}
+}
+
+func TestLedgerFailureRollsBack(t *testing.T) {
+	store := &collectiontest.MockStore{
+		GetOwnedCharacterFunc: func(_ context.Context, userID uint64, _ int64) (collection.OwnedCharacter, error) {
+			if userID == 1 {
+				return collection.OwnedCharacter{Date: time.Now()}, nil
+			}
+			return collection.OwnedCharacter{}, collection.ErrNotFound
+		},
+		RecordOwnershipEventFunc: func(context.Context, collection.OwnershipEvent) error {
+			return errors.New("db down")
+		},
+	}
+
+	_, err := collection.Give(t.Context(), store, 1, 2, 42)
+	require.Error(t, err)
+	assert.Equal(t, 0, store.CommitCalls)
+	assert.Equal(t, 1, store.RollbackCalls)
+}
+
+func TestCharacterDetails(t *testing.T) {
+	var gotGuild uint64
+	store := &collectiontest.MockStore{
+		GetCharacterByIDFunc: func(_ context.Context, id int64) (catalog.Character, error) {
+			return catalog.Character{ID: id, Name: "Rem", Favorites: 6000}, nil
+		},
+		CharacterDescriptionFunc: func(context.Context, int64) (string, error) {
+			return "Maid of the Roswaal mansion.", nil
+		},
+		MediaByCharacterFunc: func(context.Context, int64) ([]catalog.Media, error) {
+			return []catalog.Media{{ID: 1, Title: "Re:Zero"}}, nil
+		},
+		CharacterStatsFunc: func(_ context.Context, _ int64, guildID uint64) (catalog.CharacterStats, error) {
+			gotGuild = guildID
+			return catalog.CharacterStats{Owners: 3, GuildOwners: 1}, nil
+		},
+	}
+
+	info, err := collection.CharacterDetails(t.Context(), store, 42, 7)
+	require.NoError(t, err)
+	assert.Equal(t, "Maid of the Roswaal mansion.", info.Description)
+	assert.Len(t, info.Media, 1)
+	assert.Equal(t, 3, info.Stats.Owners)
+	assert.Equal(t, uint64(7), gotGuild)
+	assert.Equal(t, collection.RarityLegendary, info.Rarity())
+
+	store.CharacterStatsFunc = func(context.Context, int64, uint64) (catalog.CharacterStats, error) {
+		return catalog.CharacterStats{}, errors.New("db down")
+	}
+	_, err = collection.CharacterDetails(t.Context(), store, 42, 7)
+	require.Error(t, err)
+}
+
+func TestSearchCharacterInfo(t *testing.T) {
+	var gotIDs []int64
+	store := &collectiontest.MockStore{
+		SearchGlobalCharactersFunc: func(context.Context, string) ([]catalog.Character, error) {
+			return []catalog.Character{{ID: 1, Name: "Rem"}, {ID: 2, Name: "Ram"}}, nil
+		},
+		CharactersStatsFunc: func(_ context.Context, ids []int64) (map[int64]catalog.CharacterStats, error) {
+			gotIDs = ids
+			return map[int64]catalog.CharacterStats{1: {Owners: 2, Wishlisted: 5}}, nil
+		},
+	}
+
+	infos, err := collection.SearchCharacterInfo(t.Context(), store, "r")
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, gotIDs, "stats are counted in one go")
+	require.Len(t, infos, 2)
+	assert.Equal(t, catalog.CharacterStats{Owners: 2, Wishlisted: 5}, infos[0].Stats)
+	assert.Zero(t, infos[1].Stats, "characters nobody holds have empty stats")
+}
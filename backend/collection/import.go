@@ -0,0 +1,92 @@
+package collection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Import is what ImportUser adds to an account.
+type Import struct {
+	// Characters are added with their source and acquisition date.
+	Characters []OwnedCharacter
+	// Wishlist are character IDs added to the wishlist.
+	Wishlist []int64
+	// Tokens are added as an opening balance.
+	Tokens int32
+	// Quote, AnilistURL and Favorite are set when not empty.
+	Quote      string
+	AnilistURL string
+	Favorite   int64
+}
+
+// ImportUser adds imp to a user on behalf of an operator, creating the user if needed.
+// The characters are recorded as admin events in the ownership ledger and leave the
+// user's wishlist, while imp.Wishlist is added to it. Nothing is imported if any part fails, such as a character the
+// user already owns.
+func ImportUser(ctx context.Context, store Store, userID UserID, imp Import) error {
+	return withTx(ctx, store, func(tx Store) error {
+		if _, err := tx.GetUser(ctx, userID); err != nil {
+			if !errors.Is(err, ErrNotFound) {
+				return err
+			}
+			if err := tx.CreateUser(ctx, userID); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		for _, c := range imp.Characters {
+			acquired := c.Date
+			if acquired.IsZero() {
+				acquired = now
+			}
+			if err := tx.AddToCollection(ctx, userID, c.Character, c.Source, acquired); err != nil {
+				return fmt.Errorf("error adding char %d: %w", c.ID, err)
+			}
+			if err := tx.RemoveFromWishlist(ctx, userID, c.ID); err != nil {
+				return fmt.Errorf("error removing char %d from wishlist: %w", c.ID, err)
+			}
+
+			err := tx.RecordOwnershipEvent(ctx, OwnershipEvent{
+				CharacterID: c.ID,
+				Kind:        EventAdmin,
+				ToUserID:    userID,
+				CreatedAt:   now,
+			})
+			if err != nil {
+				return fmt.Errorf("error recording ownership event: %w", err)
+			}
+		}
+
+		if len(imp.Wishlist) > 0 {
+			if err := tx.AddToWishlist(ctx, userID, imp.Wishlist); err != nil {
+				return fmt.Errorf("error adding to wishlist: %w", err)
+			}
+		}
+
+		if imp.Tokens > 0 {
+			if _, err := changeTokens(ctx, tx, TokenChange{UserID: userID, Amount: imp.Tokens, Reason: TokenOpeningBalance, CreatedAt: now}); err != nil {
+				return err
+			}
+		}
+
+		if imp.Quote != "" {
+			if err := SetQuote(ctx, tx, userID, imp.Quote); err != nil {
+				return err
+			}
+		}
+		if imp.AnilistURL != "" {
+			if err := SetAnilistURL(ctx, tx, userID, imp.AnilistURL); err != nil {
+				return err
+			}
+		}
+		if imp.Favorite != 0 {
+			if err := SetFavorite(ctx, tx, userID, imp.Favorite); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
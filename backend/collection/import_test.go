@@ -0,0 +1,129 @@
+package collection_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/collection/collectiontest"
+)
+
+func TestImportUser(t *testing.T) {
+	acquired := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	var (
+		created  bool
+		added    []collection.OwnedCharacter
+		events   []collection.OwnershipEvent
+		changes  []collection.TokenChange
+		quote    string
+		anilist  string
+		favorite int64
+		wished   []int64
+	)
+	store := &collectiontest.MockStore{
+		GetUserFunc: func(context.Context, collection.UserID) (collection.User, error) {
+			return collection.User{}, collection.ErrNotFound
+		},
+		CreateUserFunc: func(context.Context, collection.UserID) error {
+			created = true
+			return nil
+		},
+		AddToCollectionFunc: func(_ context.Context, userID collection.UserID, char collection.Character, source string, at time.Time) error {
+			added = append(added, collection.OwnedCharacter{Character: char, Source: source, Date: at, UserID: userID})
+			return nil
+		},
+		RecordOwnershipEventFunc: func(_ context.Context, e collection.OwnershipEvent) error {
+			events = append(events, e)
+			return nil
+		},
+		AddToWishlistFunc: func(_ context.Context, _ collection.UserID, ids []int64) error {
+			wished = append(wished, ids...)
+			return nil
+		},
+		AddTokensFunc: func(_ context.Context, _ collection.UserID, amount int32) (collection.User, error) {
+			return collection.User{Tokens: amount}, nil
+		},
+		RecordTokenChangeFunc: func(_ context.Context, c collection.TokenChange) error {
+			changes = append(changes, c)
+			return nil
+		},
+		UpdateQuoteFunc: func(_ context.Context, _ collection.UserID, q string) error {
+			quote = q
+			return nil
+		},
+		UpdateAnilistURLFunc: func(_ context.Context, _ collection.UserID, url string) error {
+			anilist = url
+			return nil
+		},
+		UpdateFavoriteFunc: func(_ context.Context, _ collection.UserID, charID int64) error {
+			favorite = charID
+			return nil
+		},
+	}
+
+	err := collection.ImportUser(t.Context(), store, 7, collection.Import{
+		Characters: []collection.OwnedCharacter{
+			{Character: collection.Character{ID: 1}, Source: "ROLL", Date: acquired},
+			{Character: collection.Character{ID: 2}, Source: "OLD"},
+		},
+		Wishlist:   []int64{3},
+		Tokens:     50,
+		Quote:      "hello",
+		AnilistURL: "https://anilist.co/user/someone",
+		Favorite:   2,
+	})
+	require.NoError(t, err)
+
+	assert.True(t, created)
+	require.Len(t, added, 2)
+	assert.Equal(t, acquired, added[0].Date)
+	assert.Equal(t, "ROLL", added[0].Source)
+	assert.False(t, added[1].Date.IsZero())
+	require.Len(t, events, 2)
+	assert.Equal(t, collection.EventAdmin, events[0].Kind)
+	assert.Equal(t, collection.UserID(7), events[0].ToUserID)
+	assert.Equal(t, []int64{3}, wished)
+	require.Len(t, changes, 1)
+	assert.Equal(t, collection.TokenOpeningBalance, changes[0].Reason)
+	assert.Equal(t, int32(50), changes[0].Balance)
+	assert.Equal(t, "hello", quote)
+	assert.Equal(t, "https://anilist.co/user/someone", anilist)
+	assert.Equal(t, int64(2), favorite)
+	assert.Equal(t, 1, store.CommitCalls)
+}
+
+func TestImportUser_RollsBack(t *testing.T) {
+	store := &collectiontest.MockStore{
+		AddToCollectionFunc: func(context.Context, collection.UserID, collection.Character, string, time.Time) error {
+			return collection.ErrAlreadyOwned
+		},
+	}
+
+	err := collection.ImportUser(t.Context(), store, 7, collection.Import{
+		Characters: []collection.OwnedCharacter{{Character: collection.Character{ID: 1}}},
+	})
+	require.ErrorIs(t, err, collection.ErrAlreadyOwned)
+	assert.Zero(t, store.CommitCalls)
+	assert.Equal(t, 1, store.RollbackCalls)
+}
+
+func TestImportUser_WishlistError(t *testing.T) {
+	store := &collectiontest.MockStore{
+		RemoveFromWishlistFunc: func(context.Context, collection.UserID, int64) error {
+			return errors.New("tx aborted")
+		},
+	}
+
+	err := collection.ImportUser(t.Context(), store, 7, collection.Import{
+		Characters: []collection.OwnedCharacter{{Character: collection.Character{ID: 1}}},
+	})
+	require.ErrorContains(t, err, "tx aborted")
+	assert.Zero(t, store.CommitCalls)
+	assert.Equal(t, 1, store.RollbackCalls)
+}
@@ -3,25 +3,43 @@
 package collection_test
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"slices"
+	"strconv"
 	"testing"
 	"time"
 
+	"github.com/Karitham/corde"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
 	"github.com/testcontainers/testcontainers-go/wait"
 
+	"github.com/karitham/waifubot/activity"
+	"github.com/karitham/waifubot/auth"
+	"github.com/karitham/waifubot/backup"
+	"github.com/karitham/waifubot/catalog"
 	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/leaderboard"
+	"github.com/karitham/waifubot/settings"
 	"github.com/karitham/waifubot/storage"
+	"github.com/karitham/waifubot/storage/achievementpg"
+	"github.com/karitham/waifubot/storage/auctionpg"
 	"github.com/karitham/waifubot/storage/catalogpg"
 	"github.com/karitham/waifubot/storage/collectionpg"
 	"github.com/karitham/waifubot/storage/droppg"
+	"github.com/karitham/waifubot/storage/dropstore"
 	"github.com/karitham/waifubot/storage/guildpg"
+	"github.com/karitham/waifubot/storage/ledgerpg"
+	"github.com/karitham/waifubot/storage/tradepg"
 	"github.com/karitham/waifubot/storage/userpg"
 	"github.com/karitham/waifubot/storage/userstore"
+	"github.com/karitham/waifubot/storage/valuepg"
+	"github.com/karitham/waifubot/storage/wishliststore"
+	"github.com/karitham/waifubot/wishlist"
 )
 
 var testDBURL string
@@ -65,13 +83,19 @@ func buildStore(s storage.Store) collection.Store {
 		collectionpg.New(s.CollectionStore(), s.WishlistStore()),
 		droppg.New(s.DropStore()),
 		guildpg.New(s.GuildStore()),
-		catalogpg.New(s.CollectionStore(), s.GuildStore()),
+		tradepg.New(s.TradeStore()),
+		auctionpg.New(s.AuctionStore()),
+		ledgerpg.New(s.LedgerStore()),
+		achievementpg.New(s.AchievementStore()),
+		valuepg.New(s.ValueStore()),
+		catalogpg.New(s.CollectionStore(), s.GuildStore(), s.CatalogStore()),
 		s.DB(),
 		nil,
 	)
 }
 
-func setupStore(t *testing.T) collection.Store {
+// setupTx returns a store in a transaction rolled back when the test ends.
+func setupTx(t *testing.T) storage.Store {
 	t.Helper()
 
 	dbStore, err := storage.NewStore(t.Context(), testDBURL)
@@ -84,7 +108,12 @@ func setupStore(t *testing.T) collection.Store {
 		_ = txStore.Rollback(t.Context())
 	})
 
-	return buildStore(txStore)
+	return txStore
+}
+
+func setupStore(t *testing.T) collection.Store {
+	t.Helper()
+	return buildStore(setupTx(t))
 }
 
 func setupStoreWithSeed(t *testing.T, userIDs ...uint64) collection.Store {
@@ -312,6 +341,96 @@ func TestIntegration_DropOperations(t *testing.T) {
 	require.ErrorIs(t, err, collection.ErrNotFound)
 }
 
+func TestIntegration_DropExpiry(t *testing.T) {
+	ctx := t.Context()
+	txStore := setupTx(t)
+
+	drops := dropstore.NewPostgresStore(txStore.DropStore())
+	now := time.Now().Truncate(time.Second)
+	const fresh, stale corde.Snowflake = 888101, 888102
+
+	require.NoError(t, drops.Set(ctx, fresh, dropstore.Drop{ID: 4101, Name: "Fresh", ExpiresAt: now.Add(time.Hour)}))
+	require.NoError(t, drops.Set(ctx, stale, dropstore.Drop{ID: 4102, Name: "Stale", ExpiresAt: now.Add(-time.Minute)}))
+	require.NoError(t, drops.SetMessage(ctx, stale, 4102, 777))
+
+	drop, err := droppg.New(txStore.DropStore()).GetDropForUpdate(ctx, uint64(stale))
+	require.NoError(t, err)
+	assert.True(t, drop.Expired(now))
+
+	expired, err := drops.Expired(ctx, now, 10)
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+	assert.Equal(t, stale, expired[0].ChannelID)
+	assert.Equal(t, corde.Snowflake(777), expired[0].MessageID)
+	assert.Equal(t, "Stale", expired[0].Name)
+
+	deleted, err := drops.DeleteExpired(ctx, fresh, now)
+	require.NoError(t, err)
+	assert.False(t, deleted, "unexpired drops are kept")
+
+	deleted, err = drops.DeleteExpired(ctx, stale, now)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+
+	expired, err = drops.Expired(ctx, now, 10)
+	require.NoError(t, err)
+	assert.Empty(t, expired)
+}
+
+func TestIntegration_DropHints(t *testing.T) {
+	ctx := t.Context()
+	txStore := setupTx(t)
+
+	drops := dropstore.NewPostgresStore(txStore.DropStore())
+	const channel corde.Snowflake = 888301
+	require.NoError(t, drops.Set(ctx, channel, dropstore.Drop{ID: 4301, Name: "Hinted", MediaTitle: "Show", ExpiresAt: time.Now().Add(time.Hour)}))
+
+	_, err := drops.RecordMiss(ctx, channel)
+	require.NoError(t, err)
+	miss, err := drops.RecordMiss(ctx, channel)
+	require.NoError(t, err)
+	assert.Equal(t, 2, miss.Attempts)
+	assert.Equal(t, 0, miss.HintsShown)
+	assert.Equal(t, "Hinted", miss.Name)
+	assert.Equal(t, "Show", miss.MediaTitle)
+
+	advanced, err := drops.AdvanceHint(ctx, channel, 1)
+	require.NoError(t, err)
+	assert.True(t, advanced)
+	advanced, err = drops.AdvanceHint(ctx, channel, 1)
+	require.NoError(t, err)
+	assert.False(t, advanced, "a hint is only posted once")
+
+	// A new drop in the channel starts over.
+	require.NoError(t, drops.Set(ctx, channel, dropstore.Drop{ID: 4302, Name: "Next", ExpiresAt: time.Now().Add(time.Hour)}))
+	miss, err = drops.RecordMiss(ctx, channel)
+	require.NoError(t, err)
+	assert.Equal(t, 1, miss.Attempts)
+	assert.Equal(t, 0, miss.HintsShown)
+
+	_, err = drops.RecordMiss(ctx, 888399)
+	require.Error(t, err)
+}
+
+func TestIntegration_CharacterAliases(t *testing.T) {
+	ctx := t.Context()
+	txStore := setupTx(t)
+
+	store := buildStore(txStore)
+	aliases := []string{"Eren Jaeger", "エレン・イェーガー"}
+	require.NoError(t, store.UpsertCharacter(ctx, collection.Character{ID: 4201, Name: "Eren Yeager", Aliases: aliases}))
+
+	// Upserts that don't know the aliases keep the stored ones.
+	require.NoError(t, store.UpsertCharacter(ctx, collection.Character{ID: 4201, Name: "Eren Yeager", Favorites: 10}))
+
+	drops := dropstore.NewPostgresStore(txStore.DropStore())
+	require.NoError(t, drops.Set(ctx, 888201, dropstore.Drop{ID: 4201, Name: "Eren Yeager", ExpiresAt: time.Now().Add(time.Hour)}))
+
+	drop, err := store.GetDropForUpdate(ctx, 888201)
+	require.NoError(t, err)
+	assert.Equal(t, aliases, drop.Aliases)
+}
+
 func TestIntegration_GetUserByAnilist(t *testing.T) {
 	const uid uint64 = 900010
 	store := setupStore(t)
@@ -360,6 +479,43 @@ func TestIntegration_GetCharacterHoldersInGuild(t *testing.T) {
 	assert.Empty(t, holders)
 }
 
+func TestIntegration_CharacterStats(t *testing.T) {
+	const u1, u2, gid uint64 = 900015, 900016, 900017
+	store := setupStoreWithSeed(t, u1, u2)
+	ctx := t.Context()
+
+	require.NoError(t, store.StartIndexingJob(ctx, gid))
+	require.NoError(t, store.UpsertGuildMembers(ctx, gid, []uint64{u1}, time.Now()))
+	require.NoError(t, store.CompleteIndexingJob(ctx, gid))
+
+	require.NoError(t, store.UpsertCharacter(ctx, collection.Character{ID: 5002, Name: "StatChar", Description: "A __bold__ lead."}))
+	stats, err := store.CharacterStats(ctx, 5002, gid)
+	require.NoError(t, err)
+	assert.Zero(t, stats.Owners)
+	assert.True(t, stats.FirstClaimed.IsZero(), "unclaimed characters have no first claim")
+
+	first := time.Now().Add(-time.Hour).Truncate(time.Second)
+	require.NoError(t, store.AddToCollection(ctx, u1, collection.Character{ID: 5002}, "ROLL", time.Now()))
+	require.NoError(t, store.AddToCollection(ctx, u2, collection.Character{ID: 5002}, "CLAIM", first))
+
+	stats, err = store.CharacterStats(ctx, 5002, gid)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.Owners)
+	assert.Equal(t, 1, stats.GuildOwners)
+	assert.True(t, first.Equal(stats.FirstClaimed), "first claimed is %s", stats.FirstClaimed)
+
+	desc, err := store.CharacterDescription(ctx, 5002)
+	require.NoError(t, err)
+	assert.Equal(t, "A __bold__ lead.", desc)
+
+	require.NoError(t, store.UpsertCharacter(ctx, collection.Character{ID: 5002, Name: "StatChar"}))
+	desc, _ = store.CharacterDescription(ctx, 5002)
+	assert.Equal(t, "A __bold__ lead.", desc, "upserts without a description keep the stored one")
+
+	_, err = store.CharacterDescription(ctx, 9999)
+	require.ErrorIs(t, err, collection.ErrNotFound)
+}
+
 func TestIntegration_RandomCharNotOwned_ExcludesInactive(t *testing.T) {
 	store := setupStore(t)
 	ctx := t.Context()
@@ -382,3 +538,907 @@ func TestIntegration_RandomCharNotOwned_ExcludesInactive(t *testing.T) {
 			"inactive character %d was rolled", char.ID)
 	}
 }
+
+func TestIntegration_TradeOffers(t *testing.T) {
+	const u1, u2, u3 uint64 = 910001, 910002, 910003
+	store := setupStoreWithSeed(t, u1, u2, u3)
+	ctx := t.Context()
+	now := time.Now()
+
+	live, err := store.CreateTradeOffer(ctx, collection.TradeOffer{
+		FromUserID: u1, ToUserID: u2, OfferedCharacters: []int64{1, 2}, RequestedTokens: 3, ExpiresAt: now.Add(time.Hour),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, collection.TradePending, live.Status)
+	assert.Equal(t, []int64{1, 2}, live.OfferedCharacters)
+	assert.Empty(t, live.RequestedCharacters)
+
+	stale, err := store.CreateTradeOffer(ctx, collection.TradeOffer{
+		FromUserID: u3, ToUserID: u2, OfferedTokens: 1, ExpiresAt: now.Add(-time.Hour),
+	})
+	require.NoError(t, err)
+
+	offers, err := store.ListTradeOffers(ctx, u2, now)
+	require.NoError(t, err)
+	require.Len(t, offers, 1)
+	assert.Equal(t, live.ID, offers[0].ID)
+
+	n, err := store.ExpireTradeOffers(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	got, err := store.GetTradeOfferForUpdate(ctx, stale.ID)
+	require.NoError(t, err)
+	assert.Equal(t, collection.TradeExpired, got.Status)
+
+	require.NoError(t, store.SetTradeOfferStatus(ctx, live.ID, collection.TradeDeclined))
+	offers, _ = store.ListTradeOffers(ctx, u1, now)
+	assert.Empty(t, offers)
+
+	_, err = store.GetTradeOfferForUpdate(ctx, -1)
+	assert.ErrorIs(t, err, collection.ErrNotFound)
+}
+
+func TestIntegration_Auctions(t *testing.T) {
+	const seller, bidder, outbid uint64 = 920001, 920002, 920003
+	store := setupStoreWithSeed(t, seller, bidder, outbid)
+	ctx := t.Context()
+	now := time.Now()
+
+	a, err := store.CreateAuction(ctx, collection.Auction{
+		SellerID: seller, CharacterID: 1, ChannelID: 42, StartPrice: 5, EndsAt: now.Add(-time.Minute),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, collection.AuctionOpen, a.Status)
+	assert.Zero(t, a.HighBidderID)
+
+	_, err = store.CreateAuction(ctx, collection.Auction{
+		SellerID: seller, CharacterID: 1, ChannelID: 42, EndsAt: now.Add(time.Hour),
+	})
+	assert.ErrorIs(t, err, collection.ErrAuctionExists)
+
+	_, err = store.GetEscrow(ctx, a.ID, bidder)
+	assert.ErrorIs(t, err, collection.ErrNotFound)
+
+	require.NoError(t, store.UpsertEscrow(ctx, a.ID, outbid, 6))
+	require.NoError(t, store.DeleteEscrow(ctx, a.ID, outbid))
+	_, err = store.GetEscrow(ctx, a.ID, outbid)
+	assert.ErrorIs(t, err, collection.ErrNotFound)
+
+	require.NoError(t, store.UpsertEscrow(ctx, a.ID, bidder, 5))
+	require.NoError(t, store.UpsertEscrow(ctx, a.ID, bidder, 8))
+	held, err := store.GetEscrow(ctx, a.ID, bidder)
+	require.NoError(t, err)
+	assert.Equal(t, int32(8), held)
+
+	require.NoError(t, store.SetAuctionHighBid(ctx, a.ID, bidder, 8))
+	got, err := store.GetAuctionForUpdate(ctx, a.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int32(8), got.HighBid)
+	assert.Equal(t, bidder, got.HighBidderID)
+
+	due, err := store.ListDueAuctionIDs(ctx, now)
+	require.NoError(t, err)
+	assert.Contains(t, due, a.ID)
+
+	escrows, err := store.ListEscrows(ctx, a.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []collection.AuctionEscrow{{UserID: bidder, Amount: 8}}, escrows)
+
+	require.NoError(t, store.DeleteEscrows(ctx, a.ID))
+	require.NoError(t, store.SetAuctionStatus(ctx, a.ID, collection.AuctionSold))
+
+	due, _ = store.ListDueAuctionIDs(ctx, now)
+	assert.NotContains(t, due, a.ID)
+	escrows, _ = store.ListEscrows(ctx, a.ID)
+	assert.Empty(t, escrows)
+
+	_, err = store.GetAuctionForUpdate(ctx, -1)
+	assert.ErrorIs(t, err, collection.ErrNotFound)
+}
+
+func TestIntegration_GuildSettings(t *testing.T) {
+	ctx := t.Context()
+	txStore := setupTx(t)
+
+	store := settings.NewStore(txStore.SettingsStore())
+	const guildID uint64 = 930001
+
+	o, err := store.GetGuildSettings(ctx, guildID)
+	require.NoError(t, err)
+	assert.Equal(t, settings.Overrides{}, o)
+
+	cooldown := 90 * time.Minute
+	cost := int32(0)
+	require.NoError(t, store.UpsertGuildSettings(ctx, guildID, settings.Overrides{RollCooldown: &cooldown, SeriesRollCost: &cost}))
+
+	o, err = store.GetGuildSettings(ctx, guildID)
+	require.NoError(t, err)
+	require.NotNil(t, o.RollCooldown)
+	assert.Equal(t, cooldown, *o.RollCooldown)
+	require.NotNil(t, o.SeriesRollCost)
+	assert.Equal(t, cost, *o.SeriesRollCost)
+	assert.Nil(t, o.InteractionNeeded)
+	assert.Nil(t, o.DropChannelID)
+
+	dropChannel := uint64(930101)
+	require.NoError(t, store.UpsertGuildSettings(ctx, guildID, settings.Overrides{DropChannelID: &dropChannel}))
+	o, err = store.GetGuildSettings(ctx, guildID)
+	require.NoError(t, err)
+	require.NotNil(t, o.DropChannelID)
+	assert.Equal(t, dropChannel, *o.DropChannelID)
+
+	require.NoError(t, store.AddDropChannel(ctx, guildID, 930102))
+	require.NoError(t, store.AddDropChannel(ctx, guildID, 930102))
+	channels, err := store.ListDropChannels(ctx, guildID)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{930102}, channels)
+
+	removed, err := store.RemoveDropChannel(ctx, guildID, 930102)
+	require.NoError(t, err)
+	assert.True(t, removed)
+	removed, err = store.RemoveDropChannel(ctx, guildID, 930102)
+	require.NoError(t, err)
+	assert.False(t, removed)
+
+	require.NoError(t, store.DeleteGuildSettings(ctx, guildID))
+	o, err = store.GetGuildSettings(ctx, guildID)
+	require.NoError(t, err)
+	assert.Equal(t, settings.Overrides{}, o)
+}
+
+func TestIntegration_OwnershipLedger(t *testing.T) {
+	const u1, u2 uint64 = 940001, 940002
+	ctx := t.Context()
+	txStore := setupTx(t)
+
+	store := buildStore(txStore)
+	require.NoError(t, store.UpsertCharacter(ctx, collection.Character{ID: 940101, Name: "LedgerChar"}))
+
+	now := time.Now()
+	require.NoError(t, store.RecordOwnershipEvent(ctx, collection.OwnershipEvent{
+		CharacterID: 940101, Kind: collection.EventClaim, ToUserID: u1, CreatedAt: now,
+	}))
+	require.NoError(t, store.RecordOwnershipEvent(ctx, collection.OwnershipEvent{
+		CharacterID: 940101, Kind: collection.EventTrade, FromUserID: u1, ToUserID: u2, ReferenceID: 77, CreatedAt: now,
+	}))
+	require.NoError(t, store.RecordOwnershipEvent(ctx, collection.OwnershipEvent{
+		CharacterID: 940101, Kind: collection.EventSell, FromUserID: u2, Tokens: 1, CreatedAt: now,
+	}))
+
+	events, err := store.ListOwnershipEvents(ctx, 940101, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	assert.Equal(t, collection.EventSell, events[0].Kind)
+	assert.Equal(t, u2, events[0].FromUserID)
+	assert.Zero(t, events[0].ToUserID)
+	assert.Equal(t, int32(1), events[0].Tokens)
+	assert.Equal(t, collection.EventTrade, events[1].Kind)
+	assert.Equal(t, int64(77), events[1].ReferenceID)
+	assert.Equal(t, collection.EventClaim, events[2].Kind)
+	assert.Zero(t, events[2].FromUserID)
+	assert.WithinDuration(t, now, events[2].CreatedAt, time.Second)
+
+	limited, err := store.ListOwnershipEvents(ctx, 940101, 1)
+	require.NoError(t, err)
+	require.Len(t, limited, 1)
+	assert.Equal(t, events[0].ID, limited[0].ID)
+
+	// Each rejected statement aborts the transaction, so run them under a savepoint.
+	rejected := func(stmt string, args ...any) error {
+		_, err := txStore.DB().Exec(ctx, "SAVEPOINT ledger")
+		require.NoError(t, err)
+		_, stmtErr := txStore.DB().Exec(ctx, stmt, args...)
+		_, err = txStore.DB().Exec(ctx, "ROLLBACK TO SAVEPOINT ledger")
+		require.NoError(t, err)
+		return stmtErr
+	}
+
+	assert.Error(t, rejected("UPDATE ownership_events SET to_user_id = $1 WHERE id = $2", u1, events[0].ID), "ledger is append-only")
+	assert.Error(t, rejected("DELETE FROM ownership_events WHERE id = $1", events[0].ID), "ledger is append-only")
+	assert.Error(t, rejected("INSERT INTO ownership_events (character_id, kind) VALUES ($1, 'admin')", int64(940101)), "an event needs a user")
+}
+
+func TestIntegration_TokenLedger(t *testing.T) {
+	const u1, u2 uint64 = 950001, 950002
+	store := setupStoreWithSeed(t, u1, u2)
+	ctx := t.Context()
+
+	mismatchesFor := func(ids ...uint64) []collection.TokenMismatch {
+		t.Helper()
+		all, err := store.TokenBalanceMismatches(ctx)
+		require.NoError(t, err)
+		var ours []collection.TokenMismatch
+		for _, m := range all {
+			if slices.Contains(ids, m.UserID) {
+				ours = append(ours, m)
+			}
+		}
+		return ours
+	}
+
+	user, err := store.AddTokens(ctx, u1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []collection.TokenMismatch{{UserID: u1, Balance: 10, LedgerTotal: 0}}, mismatchesFor(u1, u2))
+
+	now := time.Now()
+	require.NoError(t, store.RecordTokenChange(ctx, collection.TokenChange{
+		UserID: u1, Amount: 10, Balance: user.Tokens, Reason: collection.TokenAdmin, CreatedAt: now,
+	}))
+	assert.Empty(t, mismatchesFor(u1, u2))
+
+	user, err = store.SpendTokens(ctx, u1, 4)
+	require.NoError(t, err)
+	require.NoError(t, store.RecordTokenChange(ctx, collection.TokenChange{
+		UserID: u1, Amount: -4, Balance: user.Tokens, Reason: collection.TokenTrade, CounterpartyID: u2, ReferenceID: 3, CreatedAt: now,
+	}))
+	user, err = store.AddTokens(ctx, u2, 4)
+	require.NoError(t, err)
+	require.NoError(t, store.RecordTokenChange(ctx, collection.TokenChange{
+		UserID: u2, Amount: 4, Balance: user.Tokens, Reason: collection.TokenTrade, CounterpartyID: u1, ReferenceID: 3, CreatedAt: now,
+	}))
+	assert.Empty(t, mismatchesFor(u1, u2))
+
+	changes, err := store.ListTokenChanges(ctx, u1, 10)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	assert.Equal(t, int32(-4), changes[0].Amount)
+	assert.Equal(t, int32(6), changes[0].Balance)
+	assert.Equal(t, collection.TokenTrade, changes[0].Reason)
+	assert.Equal(t, u2, changes[0].CounterpartyID)
+	assert.Equal(t, int64(3), changes[0].ReferenceID)
+	assert.Equal(t, collection.TokenAdmin, changes[1].Reason)
+	assert.Zero(t, changes[1].CounterpartyID)
+	assert.WithinDuration(t, now, changes[1].CreatedAt, time.Second)
+}
+
+func TestIntegration_DailyStreak(t *testing.T) {
+	const u1 uint64 = 960001
+	store := setupStoreWithSeed(t, u1)
+	ctx := t.Context()
+
+	user, err := store.GetUser(ctx, u1)
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), user.DailyStreak)
+
+	claimed := time.Now().UTC().Truncate(time.Microsecond)
+	ok, err := store.UpdateDailyStreak(ctx, u1, 1, user.LastDaily, claimed)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = store.UpdateDailyStreak(ctx, u1, 1, user.LastDaily, claimed.Add(time.Second))
+	require.NoError(t, err)
+	assert.False(t, ok, "a stale previous claim must not overwrite the streak")
+
+	user, err = store.GetUser(ctx, u1)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), user.DailyStreak)
+	assert.True(t, claimed.Equal(user.LastDaily.UTC()))
+}
+
+func TestIntegration_Achievements(t *testing.T) {
+	const u1, u2 uint64 = 970001, 970002
+	store := setupStoreWithSeed(t, u1, u2)
+	ctx := t.Context()
+	now := time.Now()
+
+	require.NoError(t, store.UpsertMedia(ctx, []catalog.Media{
+		{ID: 970201, Title: "Integration Achievement Series"},
+		{ID: 970202, Title: "Integration Other Series"},
+	}))
+	for id := int64(970101); id <= 970105; id++ {
+		require.NoError(t, store.UpsertCharacter(ctx, collection.Character{ID: id, Name: "SeriesChar"}))
+		require.NoError(t, store.SetCharacterMedia(ctx, id, []int64{970201}))
+	}
+	require.NoError(t, store.UpsertCharacter(ctx, collection.Character{ID: 970106, Name: "OtherChar"}))
+	require.NoError(t, store.SetCharacterMedia(ctx, 970106, []int64{970202}))
+
+	for id := int64(970101); id <= 970104; id++ {
+		require.NoError(t, store.AddToCollection(ctx, u1, collection.Character{ID: id}, "ROLL", now))
+	}
+	require.NoError(t, store.AddToCollection(ctx, u2, collection.Character{ID: 970105}, "ROLL", now))
+
+	completed, err := store.CountCompletedSeries(ctx, u1, 5)
+	require.NoError(t, err)
+	assert.Zero(t, completed, "another user owns the last character")
+
+	_, err = store.GiveCharacter(ctx, u2, u1, 970105)
+	require.NoError(t, err)
+	completed, err = store.CountCompletedSeries(ctx, u1, 5)
+	require.NoError(t, err)
+	assert.Zero(t, completed, "series whose characters were never synced may miss some")
+
+	var series []catalog.Character
+	for id := int64(970101); id <= 970105; id++ {
+		series = append(series, catalog.Character{ID: id})
+	}
+	require.NoError(t, store.SetMediaCharacters(ctx, catalog.Media{ID: 970201}, series, now))
+	require.NoError(t, store.SetMediaCharacters(ctx, catalog.Media{ID: 970202}, []catalog.Character{{ID: 970106}}, now))
+	completed, err = store.CountCompletedSeries(ctx, u1, 5)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), completed)
+	completed, err = store.CountCompletedSeries(ctx, u1, 6)
+	require.NoError(t, err)
+	assert.Zero(t, completed, "series smaller than the minimum don't count")
+	completed, err = store.CountCompletedSeriesOf(ctx, u1, 970105, 5)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), completed)
+	completed, err = store.CountCompletedSeriesOf(ctx, u1, 970106, 5)
+	require.NoError(t, err)
+	assert.Zero(t, completed, "only the series of the character count")
+
+	require.NoError(t, store.UpsertCharacter(ctx, collection.Character{ID: 970107, Name: "LegendChar", Favorites: 6000}))
+	require.NoError(t, store.AddToCollection(ctx, u1, collection.Character{ID: 970107}, "ROLL", now))
+	legendary, err := store.CountOwnedFromFavorites(ctx, u1, 5000)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), legendary)
+
+	for id := int64(970101); id <= 970103; id++ {
+		require.NoError(t, store.RecordOwnershipEvent(ctx, collection.OwnershipEvent{
+			CharacterID: id, Kind: collection.EventClaim, ToUserID: u1, CreatedAt: now,
+		}))
+	}
+	require.NoError(t, store.RecordOwnershipEvent(ctx, collection.OwnershipEvent{
+		CharacterID: 970104, Kind: collection.EventRoll, ToUserID: u1, CreatedAt: now,
+	}))
+	claims, err := store.CountReceivedEvents(ctx, u1, collection.EventClaim)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), claims)
+
+	ok, err := store.UnlockAchievement(ctx, u1, "series_1", now)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = store.UnlockAchievement(ctx, u1, "series_1", now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.False(t, ok, "an achievement is only unlocked once")
+
+	unlocks, err := store.ListAchievements(ctx, u1)
+	require.NoError(t, err)
+	require.Len(t, unlocks, 1)
+	assert.Equal(t, "series_1", unlocks[0].ID)
+	assert.WithinDuration(t, now, unlocks[0].UnlockedAt, time.Second)
+
+	unlocks, err = store.ListAchievements(ctx, u2)
+	require.NoError(t, err)
+	assert.Empty(t, unlocks)
+}
+
+func TestIntegration_MediaCatalog(t *testing.T) {
+	store := setupStore(t)
+	ctx := t.Context()
+
+	_, err := store.GetMedia(ctx, 990001)
+	require.ErrorIs(t, err, collection.ErrNotFound)
+
+	require.NoError(t, store.UpsertMedia(ctx, []catalog.Media{
+		{ID: 990001, Title: "Integration Catalog Anime", Type: "ANIME", CoverImage: "https://example.com/a.png", Popularity: 100, MalID: 880001},
+		{ID: 990002, Title: "Integration Catalog Manga", Type: "MANGA", Popularity: 300},
+	}))
+	require.NoError(t, store.UpsertCharacter(ctx, collection.Character{ID: 990101, Name: "Lead", Favorites: 10}))
+	require.NoError(t, store.UpsertCharacter(ctx, collection.Character{ID: 990102, Name: "Rival", Favorites: 20}))
+	require.NoError(t, store.SetCharacterMedia(ctx, 990101, []int64{990001, 990002}))
+	require.NoError(t, store.SetCharacterMedia(ctx, 990102, []int64{990001}))
+
+	media, err := store.GetMedia(ctx, 990001)
+	require.NoError(t, err)
+	assert.Equal(t, "Integration Catalog Anime", media.Title)
+	assert.Equal(t, "ANIME", media.Type)
+	assert.Equal(t, "https://example.com/a.png", media.CoverImage)
+
+	media, err = store.MediaByMalID(ctx, 880001, "ANIME")
+	require.NoError(t, err)
+	assert.Equal(t, int64(990001), media.ID)
+	_, err = store.MediaByMalID(ctx, 880001, "MANGA")
+	require.ErrorIs(t, err, collection.ErrNotFound, "anime and manga MyAnimeList IDs are distinct")
+	media, err = store.GetMedia(ctx, 990002)
+	require.NoError(t, err)
+	assert.Zero(t, media.MalID, "unknown MyAnimeList IDs stay empty")
+
+	found, err := store.SearchMedia(ctx, "integration catalog")
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	assert.Equal(t, int64(990002), found[0].ID, "most popular first")
+
+	chars, err := store.CharactersByMedia(ctx, 990001)
+	require.NoError(t, err)
+	require.Len(t, chars, 2)
+	assert.Equal(t, "Rival", chars[0].Name, "most favorited first")
+
+	linked, err := store.MediaByCharacter(ctx, 990101)
+	require.NoError(t, err)
+	require.Len(t, linked, 2)
+	assert.Equal(t, int64(990002), linked[0].ID)
+
+	require.NoError(t, store.SetCharacterMedia(ctx, 990101, []int64{990002}))
+	chars, err = store.CharactersByMedia(ctx, 990001)
+	require.NoError(t, err)
+	require.Len(t, chars, 1, "relinking drops the media the character left")
+	assert.Equal(t, int64(990102), chars[0].ID)
+
+	require.NoError(t, store.MarkCharactersInactive(ctx, []int64{990102}))
+	chars, err = store.CharactersByMedia(ctx, 990001)
+	require.NoError(t, err)
+	assert.Empty(t, chars, "inactive characters are left out")
+
+	synced := time.Now().Truncate(time.Microsecond)
+	require.NoError(t, store.SetMediaCharacters(ctx, catalog.Media{ID: 990003, Title: "Integration Synced"}, []catalog.Character{
+		{ID: 990101, Name: "Renamed"},
+		{ID: 990103, Name: "Newcomer", Favorites: 5},
+	}, synced))
+	media, err = store.GetMedia(ctx, 990003)
+	require.NoError(t, err)
+	assert.Equal(t, "Integration Synced", media.Title)
+	assert.WithinDuration(t, synced, media.CharactersSyncedAt, time.Second)
+	chars, err = store.CharactersByMedia(ctx, 990003)
+	require.NoError(t, err)
+	require.Len(t, chars, 2)
+	assert.Equal(t, "Lead", chars[0].Name, "stored characters are left as they are")
+	assert.Equal(t, "Newcomer", chars[1].Name, "missing characters are added")
+
+	require.NoError(t, store.SetCharacterMedia(ctx, 990103, nil))
+	chars, err = store.CharactersByMedia(ctx, 990003)
+	require.NoError(t, err)
+	assert.Len(t, chars, 2, "relinking a character keeps its synced media")
+
+	require.NoError(t, store.SetMediaCharacters(ctx, catalog.Media{ID: 990003}, []catalog.Character{{ID: 990103}}, synced))
+	chars, err = store.CharactersByMedia(ctx, 990003)
+	require.NoError(t, err)
+	require.Len(t, chars, 1, "syncing again drops the characters the media lost")
+	assert.Equal(t, int64(990103), chars[0].ID)
+}
+
+func TestIntegration_Leaderboard(t *testing.T) {
+	ctx := t.Context()
+	txStore := setupTx(t)
+
+	store := buildStore(txStore)
+	boards := leaderboard.NewStore(txStore.LeaderboardStore())
+	const u1, u2, u3, gid uint64 = 940001, 940002, 940003, 940004
+	for _, uid := range []uint64{u1, u2, u3} {
+		require.NoError(t, store.CreateUser(ctx, uid))
+	}
+	require.NoError(t, store.StartIndexingJob(ctx, gid))
+	require.NoError(t, store.UpsertGuildMembers(ctx, gid, []uint64{u1, u3}, time.Now()))
+	require.NoError(t, store.CompleteIndexingJob(ctx, gid))
+
+	require.NoError(t, store.UpsertMedia(ctx, []catalog.Media{{ID: 940101, Title: "Leaderboard Series", Type: "ANIME"}}))
+	require.NoError(t, store.UpsertCharacter(ctx, collection.Character{ID: 940201, Name: "Legend", Favorites: 9000}))
+	require.NoError(t, store.SetCharacterMedia(ctx, 940201, []int64{940101}))
+	require.NoError(t, store.AddToCollection(ctx, u1, collection.Character{ID: 940201}, "ROLL", time.Now()))
+	for i := range int64(collection.SeriesMinCharacters - 1) {
+		id := 940202 + i
+		require.NoError(t, store.UpsertCharacter(ctx, collection.Character{ID: id, Name: fmt.Sprintf("Extra %d", i), Favorites: 10}))
+		require.NoError(t, store.SetCharacterMedia(ctx, id, []int64{940101}))
+		require.NoError(t, store.AddToCollection(ctx, u1, collection.Character{ID: id}, "ROLL", time.Now()))
+	}
+	series, err := store.CharactersByMedia(ctx, 940101)
+	require.NoError(t, err)
+	require.NoError(t, store.SetMediaCharacters(ctx, catalog.Media{ID: 940101}, series, time.Now()))
+	require.NoError(t, store.AddToCollection(ctx, u2, collection.Character{ID: 940202}, "ROLL", time.Now()))
+	_, err = store.AddTokens(ctx, u3, 50)
+	require.NoError(t, err)
+
+	require.NoError(t, boards.Refresh(ctx))
+	refreshed, err := boards.RefreshedAt(ctx)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), refreshed, time.Minute)
+
+	rank := func(c leaderboard.Category, guildID, userID uint64) leaderboard.Entry {
+		t.Helper()
+		e, err := boards.Rank(ctx, c, guildID, userID)
+		require.NoError(t, err)
+		return e
+	}
+	assert.Equal(t, leaderboard.Entry{Rank: 1, UserID: u1, Value: 10 + collection.SeriesMinCharacters - 1}, rank(leaderboard.Rarity, 0, u1))
+	assert.Equal(t, int64(1), rank(leaderboard.Legendary, 0, u1).Value)
+	assert.Equal(t, int64(1), rank(leaderboard.Series, 0, u1).Value)
+	assert.Zero(t, rank(leaderboard.Series, 0, u2).Value, "owning part of a series doesn't complete it")
+	assert.Equal(t, int64(50), rank(leaderboard.Tokens, gid, u3).Value)
+
+	top, err := boards.Top(ctx, leaderboard.Collection, gid, 10)
+	require.NoError(t, err)
+	var ids []uint64
+	for _, e := range top {
+		if e.UserID >= u1 && e.UserID <= u3 {
+			ids = append(ids, e.UserID)
+		}
+	}
+	assert.Equal(t, []uint64{u1}, ids, "guild boards only rank members, and leave out empty collections")
+
+	_, err = boards.Rank(ctx, leaderboard.Collection, 0, 949999)
+	require.ErrorIs(t, err, collection.ErrNotFound)
+}
+
+func TestIntegration_RarityTier(t *testing.T) {
+	dbStore, err := storage.NewStore(t.Context(), testDBURL)
+	require.NoError(t, err)
+
+	for _, favorites := range []int{0, 99, 100, 999, 1000, 4999, 5000, 20000} {
+		var tier int
+		require.NoError(t, dbStore.DB().QueryRow(t.Context(), "SELECT rarity_tier($1)", favorites).Scan(&tier))
+		assert.Equal(t, collection.RarityFromFavorites(favorites), collection.RarityTier(tier), "%d favorites", favorites)
+	}
+}
+
+func TestIntegration_CollectionValue(t *testing.T) {
+	ctx := t.Context()
+	txStore := setupTx(t)
+
+	store := buildStore(txStore)
+	const uid uint64 = 950001
+	require.NoError(t, store.CreateUser(ctx, uid))
+
+	favorites := []int{0, 99, 5000}
+	var want int64
+	for i, f := range favorites {
+		id := 950101 + int64(i)
+		require.NoError(t, store.UpsertCharacter(ctx, collection.Character{ID: id, Name: fmt.Sprintf("Valued %d", i), Favorites: f}))
+		require.NoError(t, store.AddToCollection(ctx, uid, collection.Character{ID: id}, "ROLL", time.Now()))
+		want += collection.CharacterValue(f)
+	}
+
+	value, err := store.CollectionValue(ctx, uid)
+	require.NoError(t, err)
+	assert.Equal(t, want, value, "the database and CharacterValue must agree")
+
+	yesterday := time.Now().UTC().AddDate(0, 0, -1)
+	_, err = store.RecordCollectionValues(ctx, yesterday)
+	require.NoError(t, err)
+	require.NoError(t, store.RemoveFromCollection(ctx, uid, 950103))
+	for range 2 {
+		_, err = store.RecordCollectionValues(ctx, time.Now())
+		require.NoError(t, err)
+	}
+
+	history, err := collection.ValueHistory(ctx, store, uid, 7, time.Now())
+	require.NoError(t, err)
+	require.Len(t, history, 2, "recording a day again replaces it")
+	assert.Equal(t, yesterday.Format(time.DateOnly), history[0].Day.Format(time.DateOnly))
+	assert.Equal(t, want, history[0].Value)
+	assert.Equal(t, want-collection.CharacterValue(5000), history[1].Value)
+}
+
+func TestIntegration_Sessions(t *testing.T) {
+	ctx := t.Context()
+	txStore := setupTx(t)
+
+	sessions := auth.NewStore(txStore.SessionStore())
+	now := time.Now().UTC()
+	require.NoError(t, sessions.CreateSession(ctx, []byte("live"), 960001, now.Add(time.Hour)))
+	require.NoError(t, sessions.CreateSession(ctx, []byte("dead"), 960001, now.Add(-time.Hour)))
+
+	s, err := sessions.Session(ctx, []byte("live"), now)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(960001), s.UserID)
+	assert.WithinDuration(t, now.Add(time.Hour), s.ExpiresAt, time.Second)
+
+	_, err = sessions.Session(ctx, []byte("dead"), now)
+	require.ErrorIs(t, err, collection.ErrNotFound, "expired sessions are not returned")
+
+	n, err := sessions.DeleteExpiredSessions(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	require.NoError(t, sessions.DeleteSession(ctx, []byte("live")))
+	_, err = sessions.Session(ctx, []byte("live"), now)
+	require.ErrorIs(t, err, collection.ErrNotFound)
+}
+
+func TestIntegration_ListCollectionPage(t *testing.T) {
+	ctx := t.Context()
+	const uid uint64 = 970001
+	store := setupStoreWithSeed(t, uid)
+
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	owned := []struct {
+		char   collection.Character
+		source string
+		date   time.Time
+	}{
+		{collection.Character{ID: 970101, Name: "Rem", MediaTitle: "Re:Zero", Favorites: 6000}, "ROLL", day(1)},
+		{collection.Character{ID: 970102, Name: "emilia", MediaTitle: "Re:Zero", Favorites: 1500}, "CLAIM", day(3)},
+		{collection.Character{ID: 970103, Name: "Aqua", MediaTitle: "KonoSuba", Favorites: 50}, "ROLL", day(2)},
+		{collection.Character{ID: 970104, Name: "Megumin", MediaTitle: "KonoSuba", Favorites: 1500}, "TRADE", day(2)},
+		{collection.Character{ID: 970105, Name: "Darkness", MediaTitle: "KonoSuba", Favorites: 1500}, "ROLL", day(4)},
+	}
+	for _, o := range owned {
+		require.NoError(t, store.UpsertCharacter(ctx, o.char))
+		require.NoError(t, store.AddToCollection(ctx, uid, o.char, o.source, o.date))
+	}
+
+	list := func(q collection.CollectionQuery) []int64 {
+		t.Helper()
+		var got []int64
+		for {
+			page, err := collection.ListCollection(ctx, store, uid, q)
+			require.NoError(t, err)
+			for _, c := range page.Characters {
+				got = append(got, c.ID)
+			}
+			if page.NextCursor == "" {
+				return got
+			}
+			q.Cursor = page.NextCursor
+		}
+	}
+
+	asc := false
+	sorts := []struct {
+		name  string
+		query collection.CollectionQuery
+		want  []int64
+	}{
+		{"date", collection.CollectionQuery{Sort: collection.SortByDate}, []int64{970105, 970102, 970104, 970103, 970101}},
+		{"date ascending", collection.CollectionQuery{Sort: collection.SortByDate, Descending: &asc}, []int64{970101, 970103, 970104, 970102, 970105}},
+		{"rarity", collection.CollectionQuery{Sort: collection.SortByRarity}, []int64{970101, 970105, 970104, 970102, 970103}},
+		{"media", collection.CollectionQuery{Sort: collection.SortByMedia}, []int64{970103, 970105, 970104, 970102, 970101}},
+		{"name", collection.CollectionQuery{Sort: collection.SortByName}, []int64{970103, 970105, 970102, 970104, 970101}},
+		{"id", collection.CollectionQuery{Sort: collection.SortByID}, []int64{970101, 970102, 970103, 970104, 970105}},
+	}
+	for _, tt := range sorts {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, list(tt.query), "one page")
+			tt.query.Limit = 2
+			assert.Equal(t, tt.want, list(tt.query), "pages of 2")
+		})
+	}
+
+	rare := collection.RarityRare
+	page, err := collection.ListCollection(ctx, store, uid, collection.CollectionQuery{
+		Filter: collection.CollectionFilter{Rarity: &rare, Media: "konosuba", AcquiredFrom: day(2), AcquiredTo: day(4)},
+		Limit:  1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), page.Total)
+	assert.Empty(t, page.NextCursor)
+	require.Len(t, page.Characters, 1)
+	assert.Equal(t, int64(970104), page.Characters[0].ID)
+
+	assert.Equal(t, []int64{970101, 970103, 970105}, list(collection.CollectionQuery{
+		Filter: collection.CollectionFilter{Source: "ROLL"},
+		Sort:   collection.SortByID,
+	}))
+	assert.Equal(t, []int64{970102, 970104}, list(collection.CollectionQuery{
+		Filter: collection.CollectionFilter{Search: "MI"},
+		Sort:   collection.SortByID,
+		Limit:  1,
+	}), "search matches names case-insensitively")
+	assert.Equal(t, []int64{970105}, list(collection.CollectionQuery{
+		Filter: collection.CollectionFilter{Search: "970105"},
+	}), "search matches IDs")
+	assert.Empty(t, list(collection.CollectionQuery{
+		Filter: collection.CollectionFilter{Search: "_"},
+	}), "search wildcards match literally")
+	assert.Empty(t, list(collection.CollectionQuery{
+		Filter: collection.CollectionFilter{Media: "Re%Zero"},
+	}), "media wildcards match literally")
+}
+
+func TestIntegration_CharactersStats(t *testing.T) {
+	ctx := t.Context()
+	txStore := setupTx(t)
+
+	store := buildStore(txStore)
+	const u1, u2 uint64 = 980001, 980002
+	for _, id := range []int64{980101, 980102, 980103} {
+		require.NoError(t, store.UpsertCharacter(ctx, collection.Character{ID: id, Name: fmt.Sprintf("Stats %d", id)}))
+	}
+	require.NoError(t, store.AddToCollection(ctx, u1, collection.Character{ID: 980101}, "ROLL", time.Now()))
+	require.NoError(t, store.AddToCollection(ctx, u2, collection.Character{ID: 980101}, "ROLL", time.Now()))
+	require.NoError(t, txStore.WishlistStore().AddCharactersToWishlist(ctx, wishliststore.AddCharactersToWishlistParams{
+		UserID:  u1,
+		Column2: []int64{980102},
+	}))
+
+	stats, err := store.CharactersStats(ctx, []int64{980101, 980102, 980103})
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]catalog.CharacterStats{
+		980101: {Owners: 2},
+		980102: {Wishlisted: 1},
+	}, stats)
+
+	char, err := store.GetCharacterByID(ctx, 980103)
+	require.NoError(t, err)
+	assert.True(t, char.IsActive)
+	require.NoError(t, store.MarkCharactersInactive(ctx, []int64{980103}))
+	char, err = store.GetCharacterByID(ctx, 980103)
+	require.NoError(t, err)
+	assert.False(t, char.IsActive)
+}
+
+func TestIntegration_ActivityStream(t *testing.T) {
+	ctx := t.Context()
+	dbStore, err := storage.NewStore(ctx, testDBURL)
+	require.NoError(t, err)
+
+	// Notifications are only sent on commit, so the events are written outside a transaction.
+	store := buildStore(dbStore)
+	events := activity.NewStore(dbStore.LedgerStore())
+	const u1, u2 uint64 = 990001, 990002
+	const gid uint64 = 990201
+	require.NoError(t, store.UpsertCharacter(ctx, collection.Character{ID: 990101, Name: "Activity", Image: "activity.jpg", Favorites: 42}))
+	require.NoError(t, store.UpsertGuildMembers(ctx, gid, []uint64{u1}, time.Now()))
+
+	before, err := events.LatestEventID(ctx)
+	require.NoError(t, err)
+	require.NoError(t, store.RecordOwnershipEvent(ctx, collection.OwnershipEvent{
+		CharacterID: 990101, Kind: collection.EventClaim, ToUserID: u1, CreatedAt: time.Now(),
+	}))
+	require.NoError(t, store.RecordOwnershipEvent(ctx, collection.OwnershipEvent{
+		CharacterID: 990101, Kind: collection.EventGive, FromUserID: u1, ToUserID: u2, CreatedAt: time.Now(),
+	}))
+	require.NoError(t, store.RecordOwnershipEvent(ctx, collection.OwnershipEvent{
+		CharacterID: 990101, Kind: collection.EventSell, FromUserID: u2, CreatedAt: time.Now(),
+	}))
+
+	all, err := events.EventsAfter(ctx, before, activity.Filter{}, 10)
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, "Activity", all[0].CharacterName)
+	assert.Equal(t, 42, all[0].CharacterFavorites)
+	assert.Equal(t, []uint64{gid}, all[0].GuildIDs)
+	assert.Empty(t, all[2].GuildIDs)
+
+	eventIDs := func(f activity.Filter) []int64 {
+		got, err := events.EventsAfter(ctx, before, f, 10)
+		require.NoError(t, err)
+		var ids []int64
+		for _, e := range got {
+			ids = append(ids, e.ID)
+		}
+		return ids
+	}
+	assert.Equal(t, []int64{all[1].ID, all[2].ID}, eventIDs(activity.Filter{UserID: u2}))
+	assert.Equal(t, []int64{all[0].ID, all[1].ID}, eventIDs(activity.Filter{GuildID: gid}))
+	assert.Equal(t, []int64{all[1].ID}, eventIDs(activity.Filter{UserID: u2, GuildID: gid}))
+
+	listenCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	payloads := make(chan string, 4)
+	done := make(chan error, 1)
+	go func() { done <- dbStore.Listen(listenCtx, activity.Channel, func(p string) { payloads <- p }) }()
+	require.Equal(t, "", <-payloads)
+
+	require.NoError(t, store.RecordOwnershipEvent(ctx, collection.OwnershipEvent{
+		CharacterID: 990101, Kind: collection.EventClaim, ToUserID: u1, CreatedAt: time.Now(),
+	}))
+	latest, err := events.LatestEventID(ctx)
+	require.NoError(t, err)
+	select {
+	case p := <-payloads:
+		assert.Equal(t, strconv.FormatInt(latest, 10), p)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no notification for the new event")
+	}
+
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+// activityWriter hands streamed events to a test.
+type activityWriter chan activity.Event
+
+func (w activityWriter) Event(e activity.Event) error {
+	w <- e
+	return nil
+}
+
+func (w activityWriter) Ping() error { return nil }
+
+func TestIntegration_ActivityHub(t *testing.T) {
+	ctx := t.Context()
+	dbStore, err := storage.NewStore(ctx, testDBURL)
+	require.NoError(t, err)
+
+	store := buildStore(dbStore)
+	const u1, u2 uint64 = 990011, 990012
+	require.NoError(t, store.UpsertCharacter(ctx, collection.Character{ID: 990111, Name: "Ping"}))
+	require.NoError(t, store.UpsertCharacter(ctx, collection.Character{ID: 990112, Name: "Traded"}))
+
+	hubCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	hub := activity.NewHub(activity.NewStore(dbStore.LedgerStore()), dbStore)
+	go hub.Run(hubCtx)
+	w := make(activityWriter, 64)
+	go func() { _ = hub.Stream(hubCtx, activity.Filter{}, 0, w) }()
+
+	// Events only stream once the hub listens, record some until one arrives.
+	require.Eventually(t, func() bool {
+		assert.NoError(t, store.RecordOwnershipEvent(ctx, collection.OwnershipEvent{
+			CharacterID: 990111, Kind: collection.EventClaim, ToUserID: u1, CreatedAt: time.Now(),
+		}))
+		select {
+		case <-w:
+			return true
+		case <-time.After(100 * time.Millisecond):
+			return false
+		}
+	}, 10*time.Second, time.Millisecond)
+
+	tx, err := store.WithTx(ctx)
+	require.NoError(t, err)
+	for range 3 {
+		require.NoError(t, tx.RecordOwnershipEvent(ctx, collection.OwnershipEvent{
+			CharacterID: 990112, Kind: collection.EventTrade, FromUserID: u1, ToUserID: u2, CreatedAt: time.Now(),
+		}))
+	}
+	require.NoError(t, tx.Commit(ctx))
+
+	seen := make(map[int64]int)
+	timeout := time.After(5 * time.Second)
+	for settled := false; !settled; {
+		select {
+		case e := <-w:
+			if e.CharacterID == 990112 {
+				seen[e.ID]++
+			}
+		case <-timeout:
+			t.Fatalf("got %v, want 3 events", seen)
+		case <-time.After(500 * time.Millisecond):
+			settled = len(seen) == 3
+		}
+	}
+	for id, n := range seen {
+		assert.Equal(t, 1, n, "event %d", id)
+	}
+}
+
+func TestIntegration_Backup(t *testing.T) {
+	ctx := t.Context()
+	txStore := setupTx(t)
+
+	store := buildStore(txStore)
+	wishlists := wishlist.New(txStore.WishlistStore())
+	const from, to uint64 = 995001, 995002
+	for _, id := range []int64{995101, 995102, 995103} {
+		require.NoError(t, store.UpsertCharacter(ctx, collection.Character{ID: id, Name: fmt.Sprintf("Backup %d", id)}))
+	}
+	acquired := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	require.NoError(t, store.CreateUser(ctx, from))
+	require.NoError(t, store.AddToCollection(ctx, from, collection.Character{ID: 995101}, "ROLL", acquired))
+	require.NoError(t, store.AddToCollection(ctx, from, collection.Character{ID: 995102}, "TRADE", acquired))
+	require.NoError(t, wishlists.AddCharactersToWishlist(ctx, from, []int64{995103}))
+	_, err := collection.GrantTokens(ctx, store, from, 40)
+	require.NoError(t, err)
+	require.NoError(t, collection.SetQuote(ctx, store, from, "backed up"))
+
+	b, err := backup.Export(ctx, store, wishlists, from)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, backup.Write(&buf, b, backup.FormatCSV))
+	b, err = backup.Read(&buf, backup.FormatCSV)
+	require.NoError(t, err)
+
+	// Restore into another account that already has one of the characters.
+	b.Users[0].UserID = to
+	require.NoError(t, store.CreateUser(ctx, to))
+	require.NoError(t, store.AddToCollection(ctx, to, collection.Character{ID: 995102}, "ROLL", time.Now()))
+
+	report, err := backup.Import(ctx, store, wishlists, b, true)
+	require.NoError(t, err)
+	assert.Equal(t, []backup.Conflict{{UserID: to, Kind: backup.KindCollection, CharacterID: 995102, Reason: backup.ReasonAlreadyOwned}}, report.Conflicts)
+	ids, err := store.GetCollectionIDs(ctx, to)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{995102}, ids, "dry run writes nothing")
+
+	report, err = backup.Import(ctx, store, wishlists, b, false)
+	require.NoError(t, err)
+	assert.Equal(t, []backup.UserReport{{UserID: to, Characters: 1, Wishlist: 1, Tokens: 40, Profile: []backup.Kind{backup.KindQuote}}}, report.Users)
+
+	restored, err := store.GetOwnedCharacter(ctx, to, 995101)
+	require.NoError(t, err)
+	assert.Equal(t, "ROLL", restored.Source)
+	assert.True(t, acquired.Equal(restored.Date))
+	user, err := store.GetUser(ctx, to)
+	require.NoError(t, err)
+	assert.Equal(t, int32(40), user.Tokens)
+	assert.Equal(t, "backed up", user.Quote)
+	wished, err := wishlists.GetUserCharacterWishlist(ctx, to)
+	require.NoError(t, err)
+	require.Len(t, wished, 1)
+	assert.Equal(t, int64(995103), wished[0].ID)
+	changes, err := collection.TokenHistory(ctx, store, to, 0)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, collection.TokenOpeningBalance, changes[0].Reason)
+}
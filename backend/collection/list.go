@@ -1,10 +1,236 @@
 package collection
 
 import (
+	"cmp"
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // Characters retrieves a user's character collection.
 func Characters(ctx context.Context, store Store, userID UserID) ([]OwnedCharacter, error) {
 	return store.GetCollection(ctx, userID)
 }
+
+// CollectionSort selects the order in which a collection is listed.
+type CollectionSort string
+
+const (
+	SortByDate   CollectionSort = "date"   // most recently acquired first
+	SortByRarity CollectionSort = "rarity" // most favorites first
+	SortByMedia  CollectionSort = "media"  // media title, then character name
+	SortByName   CollectionSort = "name"   // character name
+	SortByID     CollectionSort = "id"     // character ID
+)
+
+// Descending reports whether s lists in descending order unless told otherwise.
+func (s CollectionSort) Descending() bool {
+	return s == SortByDate || s == SortByRarity
+}
+
+// CollectionFilter narrows down a listed collection. Zero values match everything.
+type CollectionFilter struct {
+	Rarity *RarityTier
+	// Media matches case-insensitively anywhere in the media title.
+	Media string
+	// Source matches the acquisition source exactly, like ROLL or TRADE.
+	Source string
+	// AcquiredFrom and AcquiredTo bound the acquisition date. From is inclusive, To exclusive.
+	AcquiredFrom time.Time
+	AcquiredTo   time.Time
+	// Search matches the start of the character ID, or case-insensitively anywhere in the name.
+	Search string
+}
+
+// FilterCharacters returns the characters matching f, keeping their order.
+func FilterCharacters(chars []OwnedCharacter, f CollectionFilter) []OwnedCharacter {
+	media := strings.ToLower(strings.TrimSpace(f.Media))
+	search := strings.ToLower(strings.TrimSpace(f.Search))
+	out := make([]OwnedCharacter, 0, len(chars))
+	for _, c := range chars {
+		if f.Rarity != nil && RarityFromFavorites(c.Favorites) != *f.Rarity {
+			continue
+		}
+		if media != "" && !strings.Contains(strings.ToLower(c.MediaTitle), media) {
+			continue
+		}
+		if f.Source != "" && c.Source != f.Source {
+			continue
+		}
+		if !f.AcquiredFrom.IsZero() && c.Date.Before(f.AcquiredFrom) {
+			continue
+		}
+		if !f.AcquiredTo.IsZero() && !c.Date.Before(f.AcquiredTo) {
+			continue
+		}
+		if search != "" && !strings.HasPrefix(strconv.FormatInt(c.ID, 10), search) &&
+			!strings.Contains(strings.ToLower(c.Name), search) {
+			continue
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// SortCharacters sorts chars in place. Ties fall back to character ID for a stable paging order.
+func SortCharacters(chars []OwnedCharacter, by CollectionSort) {
+	slices.SortStableFunc(chars, func(a, b OwnedCharacter) int {
+		var c int
+		switch by {
+		case SortByRarity:
+			c = cmp.Compare(b.Favorites, a.Favorites)
+		case SortByMedia:
+			c = cmp.Or(
+				cmp.Compare(strings.ToLower(a.MediaTitle), strings.ToLower(b.MediaTitle)),
+				cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)),
+			)
+		case SortByName:
+			c = cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		case SortByID:
+			// ordered by the ID tie-break alone
+		default:
+			c = b.Date.Compare(a.Date)
+		}
+		return cmp.Or(c, cmp.Compare(a.ID, b.ID))
+	})
+}
+
+// MaxPageLimit is the most characters a collection page holds.
+const MaxPageLimit = 500
+
+// ErrInvalidCursor is returned when a page cursor is malformed or was issued for another sort.
+var ErrInvalidCursor = errors.New("invalid page cursor")
+
+// CollectionQuery selects one page of a collection.
+type CollectionQuery struct {
+	Filter CollectionFilter
+	Sort   CollectionSort
+	// Descending is the sort direction, the sort's own when nil.
+	Descending *bool
+	// Cursor is the NextCursor of the previous page, empty for the first page.
+	Cursor string
+	// Limit caps the page size at MaxPageLimit. 0 lists the whole collection in one page.
+	Limit int
+}
+
+// CollectionPageQuery is a CollectionQuery with its defaults applied and its cursor decoded,
+// as handed to the store.
+type CollectionPageQuery struct {
+	Filter     CollectionFilter
+	Sort       CollectionSort
+	Descending bool
+	// After is the last character of the previous page, nil for the first page.
+	// Only the fields the sort orders by are set.
+	After *OwnedCharacter
+	Limit int
+}
+
+// CollectionPage is one page of a collection.
+type CollectionPage struct {
+	Characters []OwnedCharacter
+	// Total is how many characters match the filter, across every page.
+	Total int64
+	// NextCursor continues after this page, empty on the last one.
+	NextCursor string
+}
+
+// ListCollection returns a page of a user's collection, filtered and sorted by the database.
+func ListCollection(ctx context.Context, store Store, userID UserID, q CollectionQuery) (CollectionPage, error) {
+	pq := CollectionPageQuery{
+		Filter: q.Filter,
+		Sort:   cmp.Or(q.Sort, SortByDate),
+		Limit:  min(q.Limit, MaxPageLimit),
+	}
+	pq.Descending = pq.Sort.Descending()
+	if q.Descending != nil {
+		pq.Descending = *q.Descending
+	}
+	if pq.Limit <= 0 {
+		pq.Limit = math.MaxInt32
+	}
+
+	if q.Cursor != "" {
+		after, err := decodeCursor(q.Cursor, pq.Sort, pq.Descending)
+		if err != nil {
+			return CollectionPage{}, err
+		}
+		pq.After = &after
+	}
+
+	// Ask for one more character than the page holds, to know whether there is a next page.
+	fetch := pq
+	if fetch.Limit < math.MaxInt32 {
+		fetch.Limit++
+	}
+	chars, err := store.ListCollectionPage(ctx, userID, fetch)
+	if err != nil {
+		return CollectionPage{}, err
+	}
+
+	total, err := store.CountCollectionPage(ctx, userID, pq.Filter)
+	if err != nil {
+		return CollectionPage{}, err
+	}
+
+	page := CollectionPage{Characters: chars, Total: total}
+	if len(chars) > pq.Limit {
+		page.Characters = chars[:pq.Limit]
+		page.NextCursor = encodeCursor(page.Characters[pq.Limit-1], pq.Sort, pq.Descending)
+	}
+	return page, nil
+}
+
+// pageCursor is the position of a page, encoded as base64 JSON so clients treat it as opaque.
+type pageCursor struct {
+	Sort       CollectionSort `json:"s"`
+	Descending bool           `json:"d,omitempty"`
+	ID         int64          `json:"i"`
+	Date       time.Time      `json:"t,omitzero"`
+	Name       string         `json:"n,omitempty"`
+	Media      string         `json:"m,omitempty"`
+	Favorites  int            `json:"f,omitempty"`
+}
+
+func encodeCursor(last OwnedCharacter, sort CollectionSort, descending bool) string {
+	c := pageCursor{Sort: sort, Descending: descending, ID: last.ID}
+	switch sort {
+	case SortByDate:
+		c.Date = last.Date
+	case SortByRarity:
+		c.Favorites = last.Favorites
+	case SortByMedia:
+		c.Media, c.Name = last.MediaTitle, last.Name
+	case SortByName:
+		c.Name = last.Name
+	}
+
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeCursor returns the character a cursor points after. It rejects cursors
+// of another sort or direction, since they point at a different position.
+func decodeCursor(cursor string, sort CollectionSort, descending bool) (OwnedCharacter, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return OwnedCharacter{}, ErrInvalidCursor
+	}
+	var c pageCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return OwnedCharacter{}, ErrInvalidCursor
+	}
+	if c.Sort != sort || c.Descending != descending {
+		return OwnedCharacter{}, ErrInvalidCursor
+	}
+
+	return OwnedCharacter{
+		Character: Character{ID: c.ID, Name: c.Name, MediaTitle: c.Media, Favorites: c.Favorites},
+		Date:      c.Date,
+	}, nil
+}
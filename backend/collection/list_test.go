@@ -0,0 +1,128 @@
+package collection_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/collection/collectiontest"
+)
+
+func listFixture() []collection.OwnedCharacter {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	return []collection.OwnedCharacter{
+		{Character: collection.Character{ID: 1, Name: "Rem", MediaTitle: "Re:Zero", Favorites: 6000}, Date: day(1), Source: "ROLL"},
+		{Character: collection.Character{ID: 2, Name: "Emilia", MediaTitle: "Re:Zero", Favorites: 1500}, Date: day(3), Source: "CLAIM"},
+		{Character: collection.Character{ID: 3, Name: "Aqua", MediaTitle: "KonoSuba", Favorites: 50}, Date: day(2), Source: "ROLL"},
+		{Character: collection.Character{ID: 4, Name: "Megumin", MediaTitle: "KonoSuba", Favorites: 1500}, Date: day(2), Source: "TRADE"},
+	}
+}
+
+func ids(chars []collection.OwnedCharacter) []int64 {
+	out := make([]int64, len(chars))
+	for i, c := range chars {
+		out[i] = c.ID
+	}
+	return out
+}
+
+func TestSortCharacters(t *testing.T) {
+	tests := []struct {
+		by   collection.CollectionSort
+		want []int64
+	}{
+		{collection.SortByDate, []int64{2, 3, 4, 1}},
+		{collection.SortByRarity, []int64{1, 2, 4, 3}},
+		{collection.SortByMedia, []int64{3, 4, 2, 1}},
+		{collection.SortByName, []int64{3, 2, 4, 1}},
+		{collection.SortByID, []int64{1, 2, 3, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.by), func(t *testing.T) {
+			chars := listFixture()
+			collection.SortCharacters(chars, tt.by)
+			assert.Equal(t, tt.want, ids(chars))
+		})
+	}
+}
+
+func TestFilterCharacters(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	rare := collection.RarityRare
+
+	tests := []struct {
+		name   string
+		filter collection.CollectionFilter
+		want   []int64
+	}{
+		{"no filter", collection.CollectionFilter{}, []int64{1, 2, 3, 4}},
+		{"rarity", collection.CollectionFilter{Rarity: &rare}, []int64{2, 4}},
+		{"media case insensitive", collection.CollectionFilter{Media: " konosuba"}, []int64{3, 4}},
+		{"rarity and media", collection.CollectionFilter{Rarity: &rare, Media: "zero"}, []int64{2}},
+		{"no match", collection.CollectionFilter{Media: "bleach"}, []int64{}},
+		{"source", collection.CollectionFilter{Source: "ROLL"}, []int64{1, 3}},
+		{"date range", collection.CollectionFilter{AcquiredFrom: day(2), AcquiredTo: day(3)}, []int64{3, 4}},
+		{"search name", collection.CollectionFilter{Search: "MI"}, []int64{2, 4}},
+		{"search id", collection.CollectionFilter{Search: "3"}, []int64{3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ids(collection.FilterCharacters(listFixture(), tt.filter)))
+		})
+	}
+}
+
+func TestListCollection(t *testing.T) {
+	var queries []collection.CollectionPageQuery
+	store := &collectiontest.MockStore{
+		ListCollectionPageFunc: func(_ context.Context, _ collection.UserID, q collection.CollectionPageQuery) ([]collection.OwnedCharacter, error) {
+			queries = append(queries, q)
+			chars := listFixture()
+			collection.SortCharacters(chars, q.Sort)
+			if q.After != nil {
+				i := slices.IndexFunc(chars, func(c collection.OwnedCharacter) bool { return c.ID == q.After.ID })
+				chars = chars[i+1:]
+			}
+			return chars[:min(q.Limit, len(chars))], nil
+		},
+		CountCollectionPageFunc: func(context.Context, collection.UserID, collection.CollectionFilter) (int64, error) {
+			return 4, nil
+		},
+	}
+
+	q := collection.CollectionQuery{Sort: collection.SortByRarity, Limit: 3}
+	page, err := collection.ListCollection(t.Context(), store, 1, q)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2, 4}, ids(page.Characters))
+	assert.Equal(t, int64(4), page.Total)
+	require.NotEmpty(t, page.NextCursor)
+	assert.True(t, queries[0].Descending, "rarity lists the most favorites first")
+	assert.Equal(t, 4, queries[0].Limit, "one more than the page, to find the next one")
+
+	q.Cursor = page.NextCursor
+	page, err = collection.ListCollection(t.Context(), store, 1, q)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3}, ids(page.Characters))
+	assert.Empty(t, page.NextCursor)
+	require.NotNil(t, queries[1].After)
+	assert.Equal(t, int64(4), queries[1].After.ID)
+	assert.Equal(t, 1500, queries[1].After.Favorites)
+
+	asc := false
+	_, err = collection.ListCollection(t.Context(), store, 1, collection.CollectionQuery{Sort: collection.SortByRarity, Descending: &asc, Cursor: q.Cursor})
+	require.ErrorIs(t, err, collection.ErrInvalidCursor, "a cursor only continues its own order")
+	_, err = collection.ListCollection(t.Context(), store, 1, collection.CollectionQuery{Cursor: "not a cursor"})
+	require.ErrorIs(t, err, collection.ErrInvalidCursor)
+
+	page, err = collection.ListCollection(t.Context(), store, 1, collection.CollectionQuery{})
+	require.NoError(t, err)
+	assert.Len(t, page.Characters, 4, "no limit lists everything")
+	assert.Empty(t, page.NextCursor)
+}
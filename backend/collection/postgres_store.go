@@ -25,6 +25,11 @@ type PostgresStore struct {
 	CollectionRepository
 	DropRepository
 	GuildQuerier
+	TradeRepository
+	AuctionRepository
+	LedgerRepository
+	AchievementRepository
+	ValueRepository
 	catalog.Store
 
 	db   pooler // connection pool (non-tx) or pgx.Tx (tx)
@@ -37,18 +42,28 @@ func NewPostgresStore(
 	coll CollectionRepository,
 	drop DropRepository,
 	guild GuildQuerier,
+	trade TradeRepository,
+	auction AuctionRepository,
+	ledger LedgerRepository,
+	achievement AchievementRepository,
+	value ValueRepository,
 	cat catalog.Store,
 	db pooler,
 	txFn TxFn,
 ) *PostgresStore {
 	return &PostgresStore{
-		UserRepository:       user,
-		CollectionRepository: coll,
-		DropRepository:       drop,
-		GuildQuerier:         guild,
-		Store:                cat,
-		db:                   db,
-		txFn:                 txFn,
+		UserRepository:        user,
+		CollectionRepository:  coll,
+		DropRepository:        drop,
+		GuildQuerier:          guild,
+		TradeRepository:       trade,
+		AuctionRepository:     auction,
+		LedgerRepository:      ledger,
+		AchievementRepository: achievement,
+		ValueRepository:       value,
+		Store:                 cat,
+		db:                    db,
+		txFn:                  txFn,
 	}
 }
 
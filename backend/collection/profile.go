@@ -3,9 +3,21 @@ package collection
 import (
 	"context"
 	"errors"
+	"net/url"
+	"strings"
 	"time"
 )
 
+// MaxQuoteLength is the longest profile quote a user can set.
+const MaxQuoteLength = 1024
+
+var (
+	// ErrQuoteTooLong is returned when a quote is longer than MaxQuoteLength.
+	ErrQuoteTooLong = errors.New("quote is too long")
+	// ErrInvalidAnilistURL is returned when a URL is not an Anilist user page.
+	ErrInvalidAnilistURL = errors.New("invalid Anilist URL")
+)
+
 // AnimeService defines the interface for anime operations.
 type AnimeService interface {
 	GetMediaCharacters(ctx context.Context, mediaId int64) ([]MediaCharacter, error)
@@ -17,13 +29,19 @@ type AnimeService interface {
 
 // Config holds configuration values.
 type Config struct {
-	RollCooldown   time.Duration
-	SeriesRollCost int32
+	RollCooldown      time.Duration
+	InteractionNeeded int64
+	SeriesRollCost    int32
 }
 
-// RollConfig holds configuration for roll operations.
-type RollConfig struct {
-	RollCooldown time.Duration
+// ConfigSource resolves the configuration that applies to a guild.
+type ConfigSource interface {
+	GuildConfig(ctx context.Context, guildID uint64) (Config, error)
+}
+
+// GuildConfig implements ConfigSource by applying c to every guild.
+func (c Config) GuildConfig(context.Context, uint64) (Config, error) {
+	return c, nil
 }
 
 // MediaCharacter represents a character from the anime service.
@@ -35,6 +53,10 @@ type MediaCharacter struct {
 	Description string
 	MediaTitle  string
 	Favorites   int
+	Aliases     []string
+	// Media lists the media the character appears in, most popular first.
+	// Only filled by CharacterFetcher.CharactersByIDs.
+	Media []Media
 }
 
 // Media represents an anime or manga.
@@ -47,6 +69,9 @@ type Media struct {
 	CoverImageColor uint32
 	Description     string
 	Type            string
+	Popularity      int
+	// MalID is the MyAnimeList ID of the media, 0 when unknown.
+	MalID int64
 }
 
 // TrackerUser represents an anime tracker user.
@@ -57,11 +82,14 @@ type TrackerUser struct {
 	About    string
 }
 
-// Profile represents a complete user profile with character count and favorite.
+// Profile represents a complete user profile with character count, value, favorite and badges.
 type Profile struct {
 	User
 	CharacterCount int
-	Favorite       Character
+	// Value is the sum of the CharacterValue of every character in the collection.
+	Value        int64
+	Favorite     Character
+	Achievements []UnlockedAchievement
 }
 
 // UserProfile retrieves a user's profile.
@@ -94,9 +122,55 @@ func UserProfile(ctx context.Context, store Store, userID UserID) (Profile, erro
 		return Profile{}, err
 	}
 
+	value, err := store.CollectionValue(ctx, userID)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	achievements, err := UserAchievements(ctx, store, userID)
+	if err != nil {
+		return Profile{}, err
+	}
+
 	return Profile{
 		User:           u,
 		Favorite:       favorite,
 		CharacterCount: int(count),
+		Value:          value,
+		Achievements:   achievements,
 	}, nil
 }
+
+// SetQuote sets the quote shown on a user's profile.
+func SetQuote(ctx context.Context, store Store, userID UserID, quote string) error {
+	if len(quote) > MaxQuoteLength {
+		return ErrQuoteTooLong
+	}
+	return store.UpdateQuote(ctx, userID, quote)
+}
+
+// SetAnilistURL links a user's profile to their Anilist user page.
+func SetAnilistURL(ctx context.Context, store Store, userID UserID, anilistURL string) error {
+	if err := ValidateAnilistURL(anilistURL); err != nil {
+		return err
+	}
+	return store.UpdateAnilistURL(ctx, userID, anilistURL)
+}
+
+// ValidateAnilistURL returns ErrInvalidAnilistURL unless anilistURL is an Anilist user page.
+func ValidateAnilistURL(anilistURL string) error {
+	u, err := url.Parse(anilistURL)
+	if err != nil || u.Host != "anilist.co" || !strings.HasPrefix(u.Path, "/user/") {
+		return ErrInvalidAnilistURL
+	}
+	return nil
+}
+
+// SetFavorite sets the character shown on a user's profile.
+// The character must be in the catalog, but doesn't have to be owned.
+func SetFavorite(ctx context.Context, store Store, userID UserID, charID int64) error {
+	if _, err := store.GetCharacterByID(ctx, charID); err != nil {
+		return err
+	}
+	return store.UpdateFavorite(ctx, userID, charID)
+}
@@ -33,6 +33,7 @@ func (r RarityTier) String() string {
 }
 
 // RarityFromFavorites classifies a favorites count into a tier.
+// The rarity_tier SQL function classifies them for the leaderboard and must agree.
 func RarityFromFavorites(favorites int) RarityTier {
 	switch {
 	case favorites >= 5000:
@@ -46,6 +47,21 @@ func RarityFromFavorites(favorites int) RarityTier {
 	}
 }
 
+// FavoritesRange returns the favorites counts classified as r, from min inclusive
+// to max exclusive. A max of 0 means there is no upper bound.
+func (r RarityTier) FavoritesRange() (min, max int) {
+	switch r {
+	case RarityLegendary:
+		return 5000, 0
+	case RarityRare:
+		return 1000, 5000
+	case RarityUncommon:
+		return 100, 1000
+	default:
+		return 0, 100
+	}
+}
+
 // GradientColor returns the Discord embed color using gradient interpolation based on favorites count.
 func GradientColor(favorites int) uint32 {
 	hex := getRarityHex(favorites)
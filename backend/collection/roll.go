@@ -36,16 +36,21 @@ func withTx(ctx context.Context, store Store, fn func(tx Store) error) error {
 // RollService orchestrates roll operations.
 type RollService struct {
 	store  Store
-	config RollConfig
+	config ConfigSource
 }
 
-func NewRollService(store Store, config RollConfig) *RollService {
+func NewRollService(store Store, config ConfigSource) *RollService {
 	return &RollService{store: store, config: config}
 }
 
-// Roll executes the free roll for a user, enforcing the cooldown constraint.
-func (s *RollService) Roll(ctx context.Context, userID UserID) (MediaCharacter, error) {
+// Roll executes the free roll for a user, enforcing the cooldown of the guild they roll in.
+func (s *RollService) Roll(ctx context.Context, guildID uint64, userID UserID) (MediaCharacter, error) {
 	// --- GATHER ---
+	config, err := s.config.GuildConfig(ctx, guildID)
+	if err != nil {
+		return MediaCharacter{}, err
+	}
+
 	user, err := s.store.GetUser(ctx, userID)
 	if err != nil {
 		if !errors.Is(err, ErrNotFound) {
@@ -54,7 +59,7 @@ func (s *RollService) Roll(ctx context.Context, userID UserID) (MediaCharacter,
 		// User is new — skip cooldown check
 	} else {
 		now := time.Now()
-		cooldownUntil := user.Date.Add(s.config.RollCooldown)
+		cooldownUntil := user.Date.Add(config.RollCooldown)
 		if now.Before(cooldownUntil) {
 			return MediaCharacter{}, ErrRollCooldown{Until: cooldownUntil}
 		}
@@ -92,7 +97,7 @@ func (s *RollService) Roll(ctx context.Context, userID UserID) (MediaCharacter,
 		} else {
 			// Re-check cooldown inside the transaction — a concurrent request
 			// may have already updated last_roll since our earlier check.
-			cooldownUntil := user.Date.Add(s.config.RollCooldown)
+			cooldownUntil := user.Date.Add(config.RollCooldown)
 			if now.Before(cooldownUntil) {
 				return ErrRollCooldown{Until: cooldownUntil}
 			}
@@ -107,6 +112,15 @@ func (s *RollService) Roll(ctx context.Context, userID UserID) (MediaCharacter,
 			return err
 		}
 
+		if err := tx.RecordOwnershipEvent(ctx, OwnershipEvent{
+			CharacterID: char.ID,
+			Kind:        EventRoll,
+			ToUserID:    userID,
+			CreatedAt:   now,
+		}); err != nil {
+			return err
+		}
+
 		if err := tx.RemoveFromWishlist(ctx, userID, char.ID); err != nil {
 			return err
 		}
@@ -15,7 +15,7 @@ import (
 )
 
 func TestRoll(t *testing.T) {
-	config := collection.RollConfig{RollCooldown: time.Hour}
+	config := collection.Config{RollCooldown: time.Hour}
 
 	tests := []struct {
 		name       string
@@ -118,7 +118,7 @@ func TestRoll(t *testing.T) {
 			}
 
 			svc := collection.NewRollService(store, config)
-			got, err := svc.Roll(t.Context(), tt.userID)
+			got, err := svc.Roll(t.Context(), 0, tt.userID)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -136,3 +136,35 @@ func TestRoll(t *testing.T) {
 		})
 	}
 }
+
+// guildConfigs is a ConfigSource with a config per guild.
+type guildConfigs map[uint64]collection.Config
+
+func (g guildConfigs) GuildConfig(_ context.Context, guildID uint64) (collection.Config, error) {
+	c, ok := g[guildID]
+	if !ok {
+		return collection.Config{}, errors.New("unknown guild")
+	}
+	return c, nil
+}
+
+func TestRoll_GuildCooldown(t *testing.T) {
+	store := &collectiontest.MockStore{
+		GetUserFunc: func(_ context.Context, userID uint64) (collection.User, error) {
+			return collection.User{UserID: userID, Date: time.Now().Add(-30 * time.Minute)}, nil
+		},
+	}
+	svc := collection.NewRollService(store, guildConfigs{
+		1: {RollCooldown: time.Hour},
+		2: {RollCooldown: 10 * time.Minute},
+	})
+
+	_, err := svc.Roll(t.Context(), 1, 123)
+	assert.ErrorAs(t, err, &collection.ErrRollCooldown{})
+
+	_, err = svc.Roll(t.Context(), 2, 123)
+	assert.NoError(t, err)
+
+	_, err = svc.Roll(t.Context(), 3, 123)
+	assert.ErrorContains(t, err, "unknown guild")
+}
@@ -0,0 +1,172 @@
+package collection
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/karitham/waifubot/catalog"
+)
+
+// CharactersSyncTTL is how long the characters of a media synced into the
+// catalog are trusted, before they are fetched and synced again.
+const CharactersSyncTTL = 7 * 24 * time.Hour
+
+// MediaCharacterSource lists the characters of a media.
+type MediaCharacterSource interface {
+	GetMediaCharacters(ctx context.Context, mediaID int64) ([]MediaCharacter, error)
+}
+
+// CatalogMedia is every character of a media, as synced into the catalog at SyncedAt.
+type CatalogMedia struct {
+	MediaID    int64
+	Title      string
+	Characters []MediaCharacter
+	SyncedAt   time.Time
+}
+
+// MediaCharacters returns every character of a media. The catalog only answers
+// for media whose characters were synced less than CharactersSyncTTL ago, the sync
+// worker alone links some characters of a media but not all. Other media are
+// fetched from source and synced into the catalog, and stale characters are
+// still served when source fails.
+func MediaCharacters(ctx context.Context, store Store, source MediaCharacterSource, mediaID int64) (CatalogMedia, error) {
+	local, synced, err := catalogMedia(ctx, store, mediaID)
+	if err != nil {
+		return CatalogMedia{}, err
+	}
+	if synced && time.Since(local.SyncedAt) < CharactersSyncTTL {
+		return local, nil
+	}
+
+	chars, err := source.GetMediaCharacters(ctx, mediaID)
+	if err != nil {
+		if synced {
+			return local, nil
+		}
+		return CatalogMedia{}, err
+	}
+	if len(chars) == 0 {
+		return CatalogMedia{}, ErrMediaNotFound
+	}
+
+	media := CatalogMedia{MediaID: mediaID, Title: local.Title, SyncedAt: time.Now()}
+	seen := make(map[int64]bool, len(chars))
+	for _, c := range chars {
+		if media.Title == "" {
+			media.Title = c.MediaTitle
+		}
+		// A character listed under several roles is only counted once.
+		if !seen[c.ID] {
+			seen[c.ID] = true
+			media.Characters = append(media.Characters, c)
+		}
+	}
+
+	catalogChars := make([]catalog.Character, len(media.Characters))
+	for i, c := range media.Characters {
+		catalogChars[i] = catalog.Character{
+			ID:         c.ID,
+			Name:       c.Name,
+			Image:      c.ImageURL,
+			MediaTitle: c.MediaTitle,
+			Favorites:  c.Favorites,
+		}
+	}
+	if err := withTx(ctx, store, func(tx Store) error {
+		return tx.SetMediaCharacters(ctx, catalog.Media{ID: mediaID, Title: media.Title}, catalogChars, media.SyncedAt)
+	}); err != nil {
+		return CatalogMedia{}, fmt.Errorf("error syncing media characters: %w", err)
+	}
+	return media, nil
+}
+
+// catalogMedia returns a media and its characters from the catalog, and whether
+// every character of the media is linked. SyncedAt is when they were synced.
+// Characters are only read for synced media.
+func catalogMedia(ctx context.Context, store Store, mediaID int64) (CatalogMedia, bool, error) {
+	media, err := store.GetMedia(ctx, mediaID)
+	if errors.Is(err, ErrNotFound) {
+		return CatalogMedia{MediaID: mediaID}, false, nil
+	}
+	if err != nil {
+		return CatalogMedia{}, false, err
+	}
+
+	local := CatalogMedia{MediaID: media.ID, Title: media.Title, SyncedAt: media.CharactersSyncedAt}
+	if media.CharactersSyncedAt.IsZero() {
+		return local, false, nil
+	}
+
+	chars, err := store.CharactersByMedia(ctx, mediaID)
+	if err != nil {
+		return CatalogMedia{}, false, err
+	}
+	for _, c := range chars {
+		local.Characters = append(local.Characters, MediaCharacter{
+			ID:         c.ID,
+			Name:       c.Name,
+			ImageURL:   c.Image,
+			MediaTitle: media.Title,
+			Favorites:  c.Favorites,
+			Aliases:    c.Aliases,
+		})
+	}
+	return local, true, nil
+}
+
+// SeriesProgress is how much of a series a user owns.
+type SeriesProgress struct {
+	MediaID int64
+	Title   string
+	Total   int
+	Owned   int
+	// Missing are the characters the user doesn't own, most favorited first.
+	Missing []MediaCharacter
+}
+
+// Percent returns the share of the series owned, from 0 to 100.
+func (p SeriesProgress) Percent() float64 {
+	if p.Total == 0 {
+		return 0
+	}
+	return float64(p.Owned) * 100 / float64(p.Total)
+}
+
+// SeriesCompletion compares a user's collection against every character of a media.
+func SeriesCompletion(ctx context.Context, store Store, source MediaCharacterSource, userID UserID, mediaID int64) (SeriesProgress, error) {
+	media, err := MediaCharacters(ctx, store, source, mediaID)
+	if err != nil {
+		return SeriesProgress{}, err
+	}
+
+	ownedIDs, err := store.GetCollectionIDs(ctx, userID)
+	if err != nil {
+		return SeriesProgress{}, err
+	}
+	owned := make(map[int64]bool, len(ownedIDs))
+	for _, id := range ownedIDs {
+		owned[id] = true
+	}
+
+	progress := SeriesProgress{
+		MediaID: media.MediaID,
+		Title:   media.Title,
+		Total:   len(media.Characters),
+	}
+	for _, c := range media.Characters {
+		if owned[c.ID] {
+			progress.Owned++
+		} else {
+			progress.Missing = append(progress.Missing, c)
+		}
+	}
+	slices.SortStableFunc(progress.Missing, func(a, b MediaCharacter) int {
+		return cmp.Compare(b.Favorites, a.Favorites)
+	})
+
+	return progress, nil
+}
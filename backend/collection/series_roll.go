@@ -8,7 +8,7 @@ import (
 )
 
 // SeriesRoll executes a paid, series-specific roll for a user, deducting tokens.
-func (s *RollService) SeriesRoll(ctx context.Context, userID UserID, mediaID int64, seriesRollCost int32, anime AnimeService) (MediaCharacter, error) {
+func (s *RollService) SeriesRoll(ctx context.Context, userID UserID, mediaID int64, seriesRollCost int32, source MediaCharacterSource) (MediaCharacter, error) {
 	// --- GATHER ---
 	user, err := s.store.GetUser(ctx, userID)
 	if err != nil {
@@ -33,14 +33,11 @@ func (s *RollService) SeriesRoll(ctx context.Context, userID UserID, mediaID int
 		return MediaCharacter{}, err
 	}
 
-	allChars, err := anime.GetMediaCharacters(ctx, mediaID)
+	media, err := MediaCharacters(ctx, s.store, source, mediaID)
 	if err != nil {
 		return MediaCharacter{}, err
 	}
-
-	if len(allChars) == 0 {
-		return MediaCharacter{}, ErrMediaNotFound
-	}
+	allChars := media.Characters
 
 	// --- PROCESS ---
 	owned := make(map[int64]struct{}, len(ownedIDs))
@@ -92,11 +89,21 @@ func (s *RollService) SeriesRoll(ctx context.Context, userID UserID, mediaID int
 			return err
 		}
 
+		if err := tx.RecordOwnershipEvent(ctx, OwnershipEvent{
+			CharacterID: char.ID,
+			Kind:        EventSeriesRoll,
+			ToUserID:    userID,
+			Tokens:      seriesRollCost,
+			CreatedAt:   now,
+		}); err != nil {
+			return err
+		}
+
 		if err := tx.RemoveFromWishlist(ctx, userID, char.ID); err != nil {
 			return err
 		}
 
-		if _, err := tx.SpendTokens(ctx, userID, seriesRollCost); err != nil {
+		if _, err := changeTokens(ctx, tx, TokenChange{UserID: userID, Amount: -seriesRollCost, Reason: TokenSeriesRoll, CreatedAt: now}); err != nil {
 			return err
 		}
 		return nil
@@ -15,7 +15,7 @@ import (
 
 func TestSeriesRoll(t *testing.T) {
 	config := collection.Config{SeriesRollCost: 20}
-	rollConfig := collection.RollConfig{}
+	rollConfig := collection.Config{}
 
 	tests := []struct {
 		name       string
@@ -0,0 +1,137 @@
+package collection_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karitham/waifubot/catalog"
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/collection/collectiontest"
+)
+
+var onePiece = []collection.MediaCharacter{
+	{ID: 40, Name: "Monkey D. Luffy", MediaTitle: "One Piece", Favorites: 50},
+	{ID: 62, Name: "Roronoa Zoro", MediaTitle: "One Piece", Favorites: 90},
+	{ID: 723, Name: "Nami", MediaTitle: "One Piece", Favorites: 30},
+}
+
+func TestMediaCharacters(t *testing.T) {
+	fresh := &catalog.Media{ID: 21, Title: "One Piece (catalog)", CharactersSyncedAt: time.Now().Add(-time.Hour)}
+	stale := &catalog.Media{ID: 21, Title: "One Piece (catalog)", CharactersSyncedAt: time.Now().Add(-collection.CharactersSyncTTL - time.Hour)}
+	linked := &catalog.Media{ID: 21, Title: "One Piece (catalog)"}
+	localChars := []catalog.Character{{ID: 62, Name: "Roronoa Zoro", MediaTitle: "Other"}, {ID: 40, Name: "Monkey D. Luffy"}}
+
+	tests := []struct {
+		name      string
+		local     *catalog.Media
+		fetched   []collection.MediaCharacter
+		fetchErr  error
+		wantTitle string
+		wantLen   int
+		wantFetch bool
+		wantSync  bool
+		wantErr   error
+	}{
+		{name: "synced", local: fresh, wantTitle: "One Piece (catalog)", wantLen: 2},
+		{name: "partially linked", local: linked, fetched: onePiece, wantTitle: "One Piece (catalog)", wantLen: 3, wantFetch: true, wantSync: true},
+		{name: "stale", local: stale, fetched: onePiece, wantTitle: "One Piece (catalog)", wantLen: 3, wantFetch: true, wantSync: true},
+		{name: "not in catalog", fetched: onePiece, wantTitle: "One Piece", wantLen: 3, wantFetch: true, wantSync: true},
+		{name: "duplicate roles", fetched: append(onePiece, onePiece[0]), wantTitle: "One Piece", wantLen: 3, wantFetch: true, wantSync: true},
+		{name: "stale, source down", local: stale, fetchErr: errors.New("anilist down"), wantTitle: "One Piece (catalog)", wantLen: 2, wantFetch: true},
+		{name: "partially linked, source down", local: linked, fetchErr: errors.New("anilist down"), wantFetch: true, wantErr: errors.New("anilist down")},
+		{name: "no characters", fetched: nil, wantFetch: true, wantErr: collection.ErrMediaNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				fetched     bool
+				synced      *catalog.Media
+				syncedChars []catalog.Character
+			)
+			store := &collectiontest.MockStore{
+				GetMediaFunc: func(context.Context, int64) (catalog.Media, error) {
+					if tt.local == nil {
+						return catalog.Media{}, collection.ErrNotFound
+					}
+					return *tt.local, nil
+				},
+				CharactersByMediaFunc: func(context.Context, int64) ([]catalog.Character, error) {
+					return localChars, nil
+				},
+				SetMediaCharactersFunc: func(_ context.Context, media catalog.Media, chars []catalog.Character, _ time.Time) error {
+					synced, syncedChars = &media, chars
+					return nil
+				},
+			}
+			source := &collectiontest.MockAnimeService{
+				GetMediaCharactersFunc: func(_ context.Context, mediaID int64) ([]collection.MediaCharacter, error) {
+					fetched = true
+					assert.Equal(t, int64(21), mediaID)
+					return tt.fetched, tt.fetchErr
+				},
+			}
+
+			media, err := collection.MediaCharacters(t.Context(), store, source, 21)
+			assert.Equal(t, tt.wantFetch, fetched)
+			assert.Equal(t, tt.wantSync, synced != nil)
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTitle, media.Title)
+			assert.Len(t, media.Characters, tt.wantLen)
+			if !tt.wantFetch || tt.fetchErr != nil {
+				for _, c := range media.Characters {
+					assert.Equal(t, media.Title, c.MediaTitle, "catalog characters are titled after the media asked for")
+				}
+			}
+			if synced != nil {
+				assert.Equal(t, catalog.Media{ID: 21, Title: media.Title}, *synced)
+				require.Len(t, syncedChars, len(media.Characters))
+				for i, c := range media.Characters {
+					assert.Equal(t, c.ID, syncedChars[i].ID)
+				}
+				assert.Equal(t, 1, store.CommitCalls)
+			}
+		})
+	}
+}
+
+func TestSeriesCompletion(t *testing.T) {
+	store := &collectiontest.MockStore{
+		GetMediaFunc: func(context.Context, int64) (catalog.Media, error) {
+			return catalog.Media{ID: 21, Title: "One Piece", CharactersSyncedAt: time.Now()}, nil
+		},
+		CharactersByMediaFunc: func(context.Context, int64) ([]catalog.Character, error) {
+			chars := make([]catalog.Character, len(onePiece))
+			for i, c := range onePiece {
+				chars[i] = catalog.Character{ID: c.ID, Name: c.Name, Favorites: c.Favorites}
+			}
+			return chars, nil
+		},
+		GetCollectionIDsFunc: func(context.Context, collection.UserID) ([]int64, error) {
+			return []int64{40, 1, 2}, nil
+		},
+	}
+
+	progress, err := collection.SeriesCompletion(t.Context(), store, &collectiontest.MockAnimeService{}, 1, 21)
+	require.NoError(t, err)
+	assert.Equal(t, "One Piece", progress.Title)
+	assert.Equal(t, 3, progress.Total)
+	assert.Equal(t, 1, progress.Owned)
+	assert.InDelta(t, 33.33, progress.Percent(), 0.01)
+	require.Len(t, progress.Missing, 2)
+	assert.Equal(t, "Roronoa Zoro", progress.Missing[0].Name, "most favorited first")
+	assert.Equal(t, "Nami", progress.Missing[1].Name)
+
+	assert.Zero(t, collection.SeriesProgress{}.Percent())
+}
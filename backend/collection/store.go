@@ -46,6 +46,8 @@ type User struct {
 	DiscordUsername string
 	DiscordAvatar   string
 	LastUpdated     time.Time
+	DailyStreak     int32
+	LastDaily       time.Time
 }
 
 type IndexingStatus int
@@ -81,11 +83,17 @@ type UserRepository interface {
 	UpdateQuote(ctx context.Context, userID UserID, quote string) error
 	UpdateAnilistURL(ctx context.Context, userID UserID, url string) error
 	UpdateDiscordInfo(ctx context.Context, userID UserID, username, avatar string, lastUpdated time.Time) error
+	// UpdateDailyStreak stores a daily claim, but only if the user's last claim is still previous.
+	// It reports false when a concurrent claim got there first.
+	UpdateDailyStreak(ctx context.Context, userID UserID, streak int32, previous, claimedAt time.Time) (bool, error)
 }
 
 // CollectionRepository handles owned character operations.
 type CollectionRepository interface {
 	GetCollection(ctx context.Context, userID UserID) ([]OwnedCharacter, error)
+	// ListCollectionPage returns up to q.Limit characters matching q.Filter, in q's order, after q.After.
+	ListCollectionPage(ctx context.Context, userID UserID, q CollectionPageQuery) ([]OwnedCharacter, error)
+	CountCollectionPage(ctx context.Context, userID UserID, f CollectionFilter) (int64, error)
 	GetCollectionIDs(ctx context.Context, userID UserID) ([]int64, error)
 	GetOwnedCharacter(ctx context.Context, userID UserID, charID int64) (OwnedCharacter, error)
 	AddToCollection(ctx context.Context, userID UserID, char Character, source string, acquiredAt time.Time) error
@@ -94,6 +102,8 @@ type CollectionRepository interface {
 	CountCollection(ctx context.Context, userID UserID) (int64, error)
 	// RemoveFromWishlist is called inside roll/claim/give transactions.
 	RemoveFromWishlist(ctx context.Context, userID UserID, charID int64) error
+	// AddToWishlist is called inside import transactions. Characters already wished for are skipped.
+	AddToWishlist(ctx context.Context, userID UserID, charIDs []int64) error
 	// RandomCharNotOwned returns a random active character not owned by the user,
 	// weighted by favorites^weightExponent. Does NOT filter the default AniList image
 	// (rolls/direct rolls use this path and the image isn't publicly embedded).
@@ -138,6 +148,11 @@ type Store interface {
 	CollectionRepository
 	DropRepository
 	GuildQuerier
+	TradeRepository
+	AuctionRepository
+	LedgerRepository
+	AchievementRepository
+	ValueRepository
 	catalog.Store
 
 	WithTx(ctx context.Context) (Store, error)
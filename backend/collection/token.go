@@ -4,8 +4,98 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 )
 
+// DefaultTokenHistoryLimit is how many token changes TokenHistory returns when no limit is given.
+const DefaultTokenHistoryLimit = 25
+
+// TokenReason says why a user's token balance changed.
+type TokenReason string
+
+const (
+	TokenOpeningBalance TokenReason = "opening_balance"
+	TokenTransfer       TokenReason = "transfer"
+	TokenTrade          TokenReason = "trade"
+	TokenSell           TokenReason = "sell"
+	TokenSeriesRoll     TokenReason = "series_roll"
+	TokenAuctionBid     TokenReason = "auction_bid"
+	TokenAuctionRefund  TokenReason = "auction_refund"
+	TokenAuctionSale    TokenReason = "auction_sale"
+	TokenAdmin          TokenReason = "admin"
+	TokenDaily          TokenReason = "daily"
+	TokenStreakBonus    TokenReason = "streak_bonus"
+)
+
+// TokenChange is one entry of the append-only token ledger.
+type TokenChange struct {
+	ID     int64
+	UserID UserID
+	// Amount is signed: negative when tokens were spent.
+	Amount int32
+	// Balance is the user's balance right after the change.
+	Balance int32
+	Reason  TokenReason
+	// CounterpartyID is the other user involved, 0 if none.
+	CounterpartyID UserID
+	// ReferenceID is the trade or auction the change belongs to, 0 otherwise.
+	// Streak bonuses use it for the streak length.
+	ReferenceID int64
+	CreatedAt   time.Time
+}
+
+// TokenMismatch is a user whose balance disagrees with the sum of their ledger entries.
+type TokenMismatch struct {
+	UserID      UserID
+	Balance     int32
+	LedgerTotal int32
+}
+
+// TokenLedgerRepository records token balance changes.
+type TokenLedgerRepository interface {
+	RecordTokenChange(ctx context.Context, change TokenChange) error
+	// ListTokenChanges returns up to limit changes for a user, newest first.
+	ListTokenChanges(ctx context.Context, userID UserID, limit int32) ([]TokenChange, error)
+	// TokenBalanceMismatches returns every user whose balance differs from their ledger total.
+	TokenBalanceMismatches(ctx context.Context) ([]TokenMismatch, error)
+}
+
+// LedgerRepository holds the append-only ownership and token ledgers.
+type LedgerRepository interface {
+	OwnershipLedgerRepository
+	TokenLedgerRepository
+}
+
+// changeTokens applies c.Amount to the user's balance and records it in the ledger.
+// Spending fails with ErrInsufficientTokens rather than going negative.
+// It must run inside a transaction so the balance and the ledger stay in step.
+// A zero amount records nothing.
+func changeTokens(ctx context.Context, tx Store, c TokenChange) (User, error) {
+	if c.Amount == 0 {
+		return tx.GetUser(ctx, c.UserID)
+	}
+
+	var u User
+	var err error
+	if c.Amount < 0 {
+		u, err = tx.SpendTokens(ctx, c.UserID, -c.Amount)
+	} else {
+		u, err = tx.AddTokens(ctx, c.UserID, c.Amount)
+	}
+	if err != nil {
+		return User{}, err
+	}
+
+	c.Balance = u.Tokens
+	if c.CreatedAt.IsZero() {
+		c.CreatedAt = time.Now()
+	}
+	if err := tx.RecordTokenChange(ctx, c); err != nil {
+		return User{}, fmt.Errorf("error recording token change: %w", err)
+	}
+	return u, nil
+}
+
 // TransferTokens transfers tokens between users.
 func TransferTokens(ctx context.Context, store Store, from, to UserID, amount int32) (err error) {
 	if amount <= 0 {
@@ -25,7 +115,7 @@ func TransferTokens(ctx context.Context, store Store, from, to UserID, amount in
 		}
 	}()
 
-	_, err = tx.SpendTokens(ctx, from, amount)
+	_, err = changeTokens(ctx, tx, TokenChange{UserID: from, Amount: -amount, Reason: TokenTransfer, CounterpartyID: to})
 	if err != nil {
 		if errors.Is(err, ErrInsufficientTokens) {
 			return err
@@ -33,10 +123,52 @@ func TransferTokens(ctx context.Context, store Store, from, to UserID, amount in
 		return fmt.Errorf("failed to update source token count: %w", err)
 	}
 
-	_, err = tx.AddTokens(ctx, to, amount)
+	_, err = changeTokens(ctx, tx, TokenChange{UserID: to, Amount: amount, Reason: TokenTransfer, CounterpartyID: from})
 	if err != nil {
 		return err
 	}
 
 	return tx.Commit(ctx)
 }
+
+// GrantTokens adds amount tokens to a user on behalf of an operator, creating
+// the user if needed. A negative amount takes tokens away.
+func GrantTokens(ctx context.Context, store Store, userID UserID, amount int32) (User, error) {
+	if amount == 0 {
+		return User{}, ErrInvalidAmount
+	}
+
+	var user User
+	err := withTx(ctx, store, func(tx Store) error {
+		if _, err := tx.GetUser(ctx, userID); err != nil {
+			if !errors.Is(err, ErrNotFound) {
+				return err
+			}
+			if err := tx.CreateUser(ctx, userID); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		user, err = changeTokens(ctx, tx, TokenChange{UserID: userID, Amount: amount, Reason: TokenAdmin})
+		return err
+	})
+	if err != nil {
+		return User{}, err
+	}
+	return user, nil
+}
+
+// TokenHistory returns the user's most recent token changes, newest first.
+func TokenHistory(ctx context.Context, store Store, userID UserID, limit int32) ([]TokenChange, error) {
+	if limit <= 0 {
+		limit = DefaultTokenHistoryLimit
+	}
+	return store.ListTokenChanges(ctx, userID, limit)
+}
+
+// CheckTokenLedger returns the users whose balance doesn't match their token ledger.
+// An empty result means every balance is accounted for.
+func CheckTokenLedger(ctx context.Context, store Store) ([]TokenMismatch, error) {
+	return store.TokenBalanceMismatches(ctx)
+}
@@ -0,0 +1,84 @@
+package collection_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/collection/collectiontest"
+)
+
+func TestGrantTokens(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  int32
+		newUser bool
+		wantErr error
+	}{
+		{name: "grant", amount: 10},
+		{name: "new user", amount: 10, newUser: true},
+		{name: "take away", amount: -3},
+		{name: "zero", amount: 0, wantErr: collection.ErrInvalidAmount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created bool
+			var changes []collection.TokenChange
+			store := &collectiontest.MockStore{
+				GetUserFunc: func(context.Context, collection.UserID) (collection.User, error) {
+					if tt.newUser && !created {
+						return collection.User{}, collection.ErrNotFound
+					}
+					return collection.User{UserID: 1}, nil
+				},
+				CreateUserFunc: func(context.Context, collection.UserID) error {
+					created = true
+					return nil
+				},
+				AddTokensFunc: func(_ context.Context, _ collection.UserID, amount int32) (collection.User, error) {
+					return collection.User{UserID: 1, Tokens: 5 + amount}, nil
+				},
+				SpendTokensFunc: func(_ context.Context, _ collection.UserID, amount int32) (collection.User, error) {
+					return collection.User{UserID: 1, Tokens: 5 - amount}, nil
+				},
+				RecordTokenChangeFunc: func(_ context.Context, c collection.TokenChange) error {
+					changes = append(changes, c)
+					return nil
+				},
+			}
+
+			user, err := collection.GrantTokens(t.Context(), store, 1, tt.amount)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, changes)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.newUser, created)
+			assert.Equal(t, 5+tt.amount, user.Tokens)
+			require.Len(t, changes, 1)
+			assert.Equal(t, collection.TokenAdmin, changes[0].Reason)
+			assert.Equal(t, tt.amount, changes[0].Amount)
+			assert.Equal(t, user.Tokens, changes[0].Balance)
+			assert.False(t, changes[0].CreatedAt.IsZero())
+		})
+	}
+}
+
+func TestCheckTokenLedger(t *testing.T) {
+	want := []collection.TokenMismatch{{UserID: 1, Balance: 10, LedgerTotal: 7}}
+	store := &collectiontest.MockStore{
+		TokenBalanceMismatchesFunc: func(context.Context) ([]collection.TokenMismatch, error) {
+			return want, nil
+		},
+	}
+
+	got, err := collection.CheckTokenLedger(t.Context(), store)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,281 @@
+package collection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// TradeOfferTTL is how long a trade offer stays pending before it expires.
+const TradeOfferTTL = 24 * time.Hour
+
+// MaxTradeCharacters caps how many characters each side of a trade may include.
+const MaxTradeCharacters = 10
+
+// ErrTradeNotFound is returned when a trade offer doesn't exist or doesn't involve the user.
+var ErrTradeNotFound = errors.New("trade offer not found")
+
+// ErrTradeNotPending is returned when a trade offer was already accepted, declined, cancelled or expired.
+var ErrTradeNotPending = errors.New("trade offer is no longer pending")
+
+// ErrTradeExpired is returned when accepting a trade offer past its expiry.
+var ErrTradeExpired = errors.New("trade offer has expired")
+
+// ErrEmptyTrade is returned when neither side of a trade offers anything.
+var ErrEmptyTrade = errors.New("trade offer is empty")
+
+// ErrTradeTooLarge is returned when a side of a trade has more than MaxTradeCharacters characters.
+var ErrTradeTooLarge = errors.New("too many characters in trade offer")
+
+// TradeStatus is the lifecycle state of a trade offer.
+type TradeStatus string
+
+const (
+	TradePending   TradeStatus = "pending"
+	TradeAccepted  TradeStatus = "accepted"
+	TradeDeclined  TradeStatus = "declined"
+	TradeCancelled TradeStatus = "cancelled"
+	TradeExpired   TradeStatus = "expired"
+)
+
+// TradeOffer is a two-sided swap proposed by FromUserID to ToUserID.
+// Offered* is what the proposer gives, Requested* is what they get back.
+type TradeOffer struct {
+	ID                  int64
+	FromUserID          UserID
+	ToUserID            UserID
+	OfferedCharacters   []int64
+	RequestedCharacters []int64
+	OfferedTokens       int32
+	RequestedTokens     int32
+	Status              TradeStatus
+	CreatedAt           time.Time
+	ExpiresAt           time.Time
+}
+
+// TradeRepository handles pending trade offer persistence.
+type TradeRepository interface {
+	CreateTradeOffer(ctx context.Context, offer TradeOffer) (TradeOffer, error)
+	// GetTradeOfferForUpdate locks the offer row for the rest of the transaction.
+	GetTradeOfferForUpdate(ctx context.Context, id int64) (TradeOffer, error)
+	// ListTradeOffers returns the unexpired pending offers sent or received by the user.
+	ListTradeOffers(ctx context.Context, userID UserID, now time.Time) ([]TradeOffer, error)
+	SetTradeOfferStatus(ctx context.Context, id int64, status TradeStatus) error
+	// ExpireTradeOffers marks every pending offer past its expiry as expired.
+	ExpireTradeOffers(ctx context.Context, now time.Time) (int64, error)
+}
+
+// OfferTrade validates and records a pending trade offer.
+// Ownership is checked here for early feedback and again on accept.
+func OfferTrade(ctx context.Context, store Store, offer TradeOffer) (TradeOffer, error) {
+	if offer.FromUserID == offer.ToUserID {
+		return TradeOffer{}, ErrSameUserTransfer
+	}
+	if offer.OfferedTokens < 0 || offer.RequestedTokens < 0 {
+		return TradeOffer{}, ErrInvalidAmount
+	}
+	if len(offer.OfferedCharacters) == 0 && len(offer.RequestedCharacters) == 0 &&
+		offer.OfferedTokens == 0 && offer.RequestedTokens == 0 {
+		return TradeOffer{}, ErrEmptyTrade
+	}
+	if len(offer.OfferedCharacters) > MaxTradeCharacters || len(offer.RequestedCharacters) > MaxTradeCharacters {
+		return TradeOffer{}, ErrTradeTooLarge
+	}
+
+	offer.OfferedCharacters = dedupIDs(offer.OfferedCharacters)
+	offer.RequestedCharacters = dedupIDs(offer.RequestedCharacters)
+
+	if err := checkTradeSide(ctx, store, offer.FromUserID, offer.ToUserID, offer.OfferedCharacters); err != nil {
+		return TradeOffer{}, err
+	}
+	if err := checkTradeSide(ctx, store, offer.ToUserID, offer.FromUserID, offer.RequestedCharacters); err != nil {
+		return TradeOffer{}, err
+	}
+
+	if offer.OfferedTokens > 0 {
+		u, err := store.GetUser(ctx, offer.FromUserID)
+		if err != nil {
+			return TradeOffer{}, fmt.Errorf("error getting user: %w", err)
+		}
+		if u.Tokens < offer.OfferedTokens {
+			return TradeOffer{}, ErrInsufficientTokens
+		}
+	}
+
+	now := time.Now()
+	offer.Status = TradePending
+	offer.CreatedAt = now
+	offer.ExpiresAt = now.Add(TradeOfferTTL)
+
+	return store.CreateTradeOffer(ctx, offer)
+}
+
+// AcceptTrade executes a pending trade offer in a single transaction.
+// Ownership and balances are re-checked since they may have changed after the offer was made.
+func AcceptTrade(ctx context.Context, store Store, userID UserID, offerID int64) (TradeOffer, error) {
+	now := time.Now()
+	var offer TradeOffer
+	err := withTx(ctx, store, func(tx Store) error {
+		var err error
+		offer, err = pendingOffer(ctx, tx, offerID, func(o TradeOffer) bool { return o.ToUserID == userID })
+		if err != nil {
+			return err
+		}
+		if !now.Before(offer.ExpiresAt) {
+			return ErrTradeExpired
+		}
+
+		if err := checkTradeSide(ctx, tx, offer.FromUserID, offer.ToUserID, offer.OfferedCharacters); err != nil {
+			return err
+		}
+		if err := checkTradeSide(ctx, tx, offer.ToUserID, offer.FromUserID, offer.RequestedCharacters); err != nil {
+			return err
+		}
+
+		if err := moveTokens(ctx, tx, offer.ID, offer.FromUserID, offer.ToUserID, offer.OfferedTokens, now); err != nil {
+			return err
+		}
+		if err := moveTokens(ctx, tx, offer.ID, offer.ToUserID, offer.FromUserID, offer.RequestedTokens, now); err != nil {
+			return err
+		}
+
+		if err := moveCharacters(ctx, tx, offer.ID, offer.FromUserID, offer.ToUserID, offer.OfferedCharacters, now); err != nil {
+			return err
+		}
+		if err := moveCharacters(ctx, tx, offer.ID, offer.ToUserID, offer.FromUserID, offer.RequestedCharacters, now); err != nil {
+			return err
+		}
+
+		offer.Status = TradeAccepted
+		return tx.SetTradeOfferStatus(ctx, offer.ID, TradeAccepted)
+	})
+	if err != nil {
+		return TradeOffer{}, err
+	}
+	return offer, nil
+}
+
+// DeclineTrade rejects a pending trade offer received by the user.
+func DeclineTrade(ctx context.Context, store Store, userID UserID, offerID int64) (TradeOffer, error) {
+	return closeTrade(ctx, store, offerID, TradeDeclined, func(o TradeOffer) bool { return o.ToUserID == userID })
+}
+
+// CancelTrade withdraws a pending trade offer sent by the user.
+func CancelTrade(ctx context.Context, store Store, userID UserID, offerID int64) (TradeOffer, error) {
+	return closeTrade(ctx, store, offerID, TradeCancelled, func(o TradeOffer) bool { return o.FromUserID == userID })
+}
+
+// ListTrades expires stale offers and returns the user's pending ones.
+func ListTrades(ctx context.Context, store Store, userID UserID) ([]TradeOffer, error) {
+	now := time.Now()
+	if _, err := store.ExpireTradeOffers(ctx, now); err != nil {
+		return nil, fmt.Errorf("error expiring trade offers: %w", err)
+	}
+	return store.ListTradeOffers(ctx, userID, now)
+}
+
+func closeTrade(ctx context.Context, store Store, offerID int64, status TradeStatus, allowed func(TradeOffer) bool) (TradeOffer, error) {
+	var offer TradeOffer
+	err := withTx(ctx, store, func(tx Store) error {
+		var err error
+		offer, err = pendingOffer(ctx, tx, offerID, allowed)
+		if err != nil {
+			return err
+		}
+		offer.Status = status
+		return tx.SetTradeOfferStatus(ctx, offer.ID, status)
+	})
+	if err != nil {
+		return TradeOffer{}, err
+	}
+	return offer, nil
+}
+
+// pendingOffer locks an offer and checks it is still pending and visible to the caller.
+func pendingOffer(ctx context.Context, tx Store, offerID int64, allowed func(TradeOffer) bool) (TradeOffer, error) {
+	offer, err := tx.GetTradeOfferForUpdate(ctx, offerID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return TradeOffer{}, ErrTradeNotFound
+		}
+		return TradeOffer{}, fmt.Errorf("error getting trade offer: %w", err)
+	}
+	if !allowed(offer) {
+		return TradeOffer{}, ErrTradeNotFound
+	}
+	if offer.Status != TradePending {
+		return TradeOffer{}, ErrTradeNotPending
+	}
+	return offer, nil
+}
+
+// checkTradeSide verifies owner has every character and recipient has none of them.
+func checkTradeSide(ctx context.Context, store Store, owner, recipient UserID, charIDs []int64) error {
+	for _, id := range charIDs {
+		_, err := store.GetOwnedCharacter(ctx, owner, id)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				return fmt.Errorf("%w %d", ErrUserDoesNotOwnCharacter, id)
+			}
+			return fmt.Errorf("error checking ownership: %w", err)
+		}
+
+		_, err = store.GetOwnedCharacter(ctx, recipient, id)
+		if err == nil {
+			return fmt.Errorf("%w: %d", ErrAlreadyOwned, id)
+		}
+		if !errors.Is(err, ErrNotFound) {
+			return fmt.Errorf("error checking ownership: %w", err)
+		}
+	}
+	return nil
+}
+
+func moveTokens(ctx context.Context, tx Store, tradeID int64, from, to UserID, amount int32, now time.Time) error {
+	if amount == 0 {
+		return nil
+	}
+	debit := TokenChange{UserID: from, Amount: -amount, Reason: TokenTrade, CounterpartyID: to, ReferenceID: tradeID, CreatedAt: now}
+	if _, err := changeTokens(ctx, tx, debit); err != nil {
+		if errors.Is(err, ErrInsufficientTokens) {
+			return err
+		}
+		return fmt.Errorf("failed to update source token count: %w", err)
+	}
+	credit := TokenChange{UserID: to, Amount: amount, Reason: TokenTrade, CounterpartyID: from, ReferenceID: tradeID, CreatedAt: now}
+	if _, err := changeTokens(ctx, tx, credit); err != nil {
+		return fmt.Errorf("failed to update target token count: %w", err)
+	}
+	return nil
+}
+
+// moveCharacters transfers charIDs and records each move in the ownership ledger under the trade.
+func moveCharacters(ctx context.Context, tx Store, tradeID int64, from, to UserID, charIDs []int64, now time.Time) error {
+	for _, id := range charIDs {
+		if _, err := tx.GiveCharacter(ctx, from, to, id); err != nil {
+			return fmt.Errorf("error giving char %d: %w", id, err)
+		}
+		if err := tx.RemoveFromWishlist(ctx, to, id); err != nil {
+			return fmt.Errorf("error removing char %d from wishlist: %w", id, err)
+		}
+		if err := tx.RecordOwnershipEvent(ctx, OwnershipEvent{
+			CharacterID: id,
+			Kind:        EventTrade,
+			FromUserID:  from,
+			ToUserID:    to,
+			ReferenceID: tradeID,
+			CreatedAt:   now,
+		}); err != nil {
+			return fmt.Errorf("error recording trade of char %d: %w", id, err)
+		}
+	}
+	return nil
+}
+
+func dedupIDs(ids []int64) []int64 {
+	out := slices.Clone(ids)
+	slices.Sort(out)
+	return slices.Compact(out)
+}
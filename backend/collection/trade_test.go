@@ -0,0 +1,307 @@
+package collection_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/collection/collectiontest"
+)
+
+// ownership returns a GetOwnedCharacterFunc backed by a user -> character set map.
+func ownership(owned map[uint64][]int64) func(context.Context, uint64, int64) (collection.OwnedCharacter, error) {
+	return func(_ context.Context, userID uint64, charID int64) (collection.OwnedCharacter, error) {
+		for _, id := range owned[userID] {
+			if id == charID {
+				return collection.OwnedCharacter{Character: collection.Character{ID: charID}, UserID: userID}, nil
+			}
+		}
+		return collection.OwnedCharacter{}, collection.ErrNotFound
+	}
+}
+
+func TestOfferTrade(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(m *collectiontest.MockStore)
+		offer   collection.TradeOffer
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func(m *collectiontest.MockStore) {
+				m.GetOwnedCharacterFunc = ownership(map[uint64][]int64{1: {10}, 2: {20}})
+				m.GetUserFunc = func(_ context.Context, userID uint64) (collection.User, error) {
+					return collection.User{UserID: userID, Tokens: 5}, nil
+				}
+			},
+			offer: collection.TradeOffer{FromUserID: 1, ToUserID: 2, OfferedCharacters: []int64{10, 10}, RequestedCharacters: []int64{20}, OfferedTokens: 3},
+		},
+		{
+			name:    "self_trade",
+			offer:   collection.TradeOffer{FromUserID: 1, ToUserID: 1, OfferedTokens: 1},
+			wantErr: collection.ErrSameUserTransfer,
+		},
+		{
+			name:    "empty",
+			offer:   collection.TradeOffer{FromUserID: 1, ToUserID: 2},
+			wantErr: collection.ErrEmptyTrade,
+		},
+		{
+			name:    "negative_tokens",
+			offer:   collection.TradeOffer{FromUserID: 1, ToUserID: 2, RequestedTokens: -1},
+			wantErr: collection.ErrInvalidAmount,
+		},
+		{
+			name:    "too_large",
+			offer:   collection.TradeOffer{FromUserID: 1, ToUserID: 2, OfferedCharacters: make([]int64, collection.MaxTradeCharacters+1)},
+			wantErr: collection.ErrTradeTooLarge,
+		},
+		{
+			name: "offered_not_owned",
+			setup: func(m *collectiontest.MockStore) {
+				m.GetOwnedCharacterFunc = ownership(map[uint64][]int64{})
+			},
+			offer:   collection.TradeOffer{FromUserID: 1, ToUserID: 2, OfferedCharacters: []int64{10}},
+			wantErr: collection.ErrUserDoesNotOwnCharacter,
+		},
+		{
+			name: "requested_already_owned_by_proposer",
+			setup: func(m *collectiontest.MockStore) {
+				m.GetOwnedCharacterFunc = ownership(map[uint64][]int64{1: {20}, 2: {20}})
+			},
+			offer:   collection.TradeOffer{FromUserID: 1, ToUserID: 2, RequestedCharacters: []int64{20}},
+			wantErr: collection.ErrAlreadyOwned,
+		},
+		{
+			name: "insufficient_tokens",
+			setup: func(m *collectiontest.MockStore) {
+				m.GetUserFunc = func(_ context.Context, userID uint64) (collection.User, error) {
+					return collection.User{UserID: userID, Tokens: 1}, nil
+				}
+			},
+			offer:   collection.TradeOffer{FromUserID: 1, ToUserID: 2, OfferedTokens: 3},
+			wantErr: collection.ErrInsufficientTokens,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &collectiontest.MockStore{}
+			if tt.setup != nil {
+				tt.setup(store)
+			}
+			var created collection.TradeOffer
+			store.CreateTradeOfferFunc = func(_ context.Context, offer collection.TradeOffer) (collection.TradeOffer, error) {
+				created = offer
+				offer.ID = 7
+				return offer, nil
+			}
+
+			offer, err := collection.OfferTrade(t.Context(), store, tt.offer)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, int64(7), offer.ID)
+			assert.Equal(t, collection.TradePending, created.Status)
+			assert.Equal(t, []int64{10}, created.OfferedCharacters)
+			assert.WithinDuration(t, time.Now().Add(collection.TradeOfferTTL), created.ExpiresAt, time.Minute)
+		})
+	}
+}
+
+func TestAcceptTrade(t *testing.T) {
+	pending := collection.TradeOffer{
+		ID:                  7,
+		FromUserID:          1,
+		ToUserID:            2,
+		OfferedCharacters:   []int64{10},
+		RequestedCharacters: []int64{20},
+		OfferedTokens:       3,
+		Status:              collection.TradePending,
+	}
+
+	tests := []struct {
+		name    string
+		offer   collection.TradeOffer
+		userID  uint64
+		setup   func(m *collectiontest.MockStore)
+		wantErr error
+	}{
+		{
+			name:   "success",
+			offer:  pending,
+			userID: 2,
+		},
+		{
+			name:    "not_recipient",
+			offer:   pending,
+			userID:  1,
+			wantErr: collection.ErrTradeNotFound,
+		},
+		{
+			name: "not_pending",
+			offer: func() collection.TradeOffer {
+				o := pending
+				o.Status = collection.TradeCancelled
+				return o
+			}(),
+			userID:  2,
+			wantErr: collection.ErrTradeNotPending,
+		},
+		{
+			name: "expired",
+			offer: func() collection.TradeOffer {
+				o := pending
+				o.ExpiresAt = time.Now().Add(-time.Minute)
+				return o
+			}(),
+			userID:  2,
+			wantErr: collection.ErrTradeExpired,
+		},
+		{
+			name:   "character_given_away_since_offer",
+			offer:  pending,
+			userID: 2,
+			setup: func(m *collectiontest.MockStore) {
+				m.GetOwnedCharacterFunc = ownership(map[uint64][]int64{2: {20}})
+			},
+			wantErr: collection.ErrUserDoesNotOwnCharacter,
+		},
+		{
+			name:   "tokens_spent_since_offer",
+			offer:  pending,
+			userID: 2,
+			setup: func(m *collectiontest.MockStore) {
+				m.SpendTokensFunc = func(_ context.Context, _ uint64, _ int32) (collection.User, error) {
+					return collection.User{}, collection.ErrInsufficientTokens
+				}
+			},
+			wantErr: collection.ErrInsufficientTokens,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.offer.ExpiresAt.IsZero() {
+				tt.offer.ExpiresAt = time.Now().Add(time.Hour)
+			}
+
+			type move struct {
+				from, to uint64
+				charID   int64
+			}
+			var moves []move
+			var wishlistCleared []int64
+			var status collection.TradeStatus
+
+			store := &collectiontest.MockStore{
+				GetTradeOfferForUpdateFunc: func(_ context.Context, _ int64) (collection.TradeOffer, error) {
+					return tt.offer, nil
+				},
+				GetOwnedCharacterFunc: ownership(map[uint64][]int64{1: {10}, 2: {20}}),
+				GiveCharacterFunc: func(_ context.Context, from, to uint64, charID int64) (collection.OwnedCharacter, error) {
+					moves = append(moves, move{from, to, charID})
+					return collection.OwnedCharacter{}, nil
+				},
+				RemoveFromWishlistFunc: func(_ context.Context, _ uint64, charID int64) error {
+					wishlistCleared = append(wishlistCleared, charID)
+					return nil
+				},
+				SetTradeOfferStatusFunc: func(_ context.Context, _ int64, s collection.TradeStatus) error {
+					status = s
+					return nil
+				},
+			}
+			if tt.setup != nil {
+				tt.setup(store)
+			}
+
+			offer, err := collection.AcceptTrade(t.Context(), store, tt.userID, tt.offer.ID)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, 1, store.RollbackCalls)
+				assert.Equal(t, 0, store.CommitCalls)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, collection.TradeAccepted, offer.Status)
+			assert.Equal(t, collection.TradeAccepted, status)
+			assert.Equal(t, []move{{1, 2, 10}, {2, 1, 20}}, moves)
+			assert.ElementsMatch(t, []int64{10, 20}, wishlistCleared)
+			assert.Equal(t, 1, store.CommitCalls)
+		})
+	}
+}
+
+func TestCloseTrade(t *testing.T) {
+	offer := collection.TradeOffer{ID: 7, FromUserID: 1, ToUserID: 2, Status: collection.TradePending}
+
+	tests := []struct {
+		name       string
+		fn         func(context.Context, collection.Store, collection.UserID, int64) (collection.TradeOffer, error)
+		userID     uint64
+		wantStatus collection.TradeStatus
+		wantErr    error
+	}{
+		{name: "decline_by_recipient", fn: collection.DeclineTrade, userID: 2, wantStatus: collection.TradeDeclined},
+		{name: "decline_by_proposer", fn: collection.DeclineTrade, userID: 1, wantErr: collection.ErrTradeNotFound},
+		{name: "cancel_by_proposer", fn: collection.CancelTrade, userID: 1, wantStatus: collection.TradeCancelled},
+		{name: "cancel_by_recipient", fn: collection.CancelTrade, userID: 2, wantErr: collection.ErrTradeNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var status collection.TradeStatus
+			store := &collectiontest.MockStore{
+				GetTradeOfferForUpdateFunc: func(_ context.Context, _ int64) (collection.TradeOffer, error) {
+					return offer, nil
+				},
+				SetTradeOfferStatusFunc: func(_ context.Context, _ int64, s collection.TradeStatus) error {
+					status = s
+					return nil
+				},
+			}
+
+			_, err := tt.fn(t.Context(), store, tt.userID, offer.ID)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, status)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, status)
+			assert.Equal(t, 1, store.CommitCalls)
+		})
+	}
+}
+
+func TestListTrades_ExpiresStaleOffers(t *testing.T) {
+	expired := false
+	store := &collectiontest.MockStore{
+		ExpireTradeOffersFunc: func(_ context.Context, _ time.Time) (int64, error) {
+			expired = true
+			return 1, nil
+		},
+		ListTradeOffersFunc: func(_ context.Context, userID uint64, _ time.Time) ([]collection.TradeOffer, error) {
+			assert.True(t, expired, "stale offers should be expired before listing")
+			return []collection.TradeOffer{{ID: 1, FromUserID: userID}}, nil
+		},
+	}
+
+	offers, err := collection.ListTrades(t.Context(), store, 1)
+	require.NoError(t, err)
+	assert.Len(t, offers, 1)
+}
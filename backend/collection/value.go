@@ -0,0 +1,69 @@
+package collection
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"time"
+)
+
+// DefaultValueHistoryDays is how far back a value history goes when no range is given.
+const DefaultValueHistoryDays = 90
+
+// ValuePoint is the value of a collection at the end of a day.
+type ValuePoint struct {
+	Day   time.Time
+	Value int64
+}
+
+// ValueRepository computes collection values and keeps their daily history.
+// Values are computed by the database and must agree with CharacterValue.
+type ValueRepository interface {
+	CollectionValue(ctx context.Context, userID UserID) (int64, error)
+	// RecordCollectionValues stores the value of every collection for day,
+	// replacing values already stored for it. It returns how many it stored.
+	RecordCollectionValues(ctx context.Context, day time.Time) (int64, error)
+	// CollectionValueHistory returns the recorded values since day, oldest first.
+	CollectionValueHistory(ctx context.Context, userID UserID, since time.Time) ([]ValuePoint, error)
+}
+
+// CharacterValue is what a character adds to the value of a collection.
+// Every character is worth at least 1, and each favorite adds less than the
+// previous one: 100 favorites are worth 11, 10000 are worth 101.
+func CharacterValue(favorites int) int64 {
+	if favorites <= 0 {
+		return 1
+	}
+	return 1 + int64(math.Sqrt(float64(favorites)))
+}
+
+// ValueHistory returns the daily values of a user's collection over the last days days.
+func ValueHistory(ctx context.Context, store ValueRepository, userID UserID, days int, now time.Time) ([]ValuePoint, error) {
+	if days <= 0 {
+		days = DefaultValueHistoryDays
+	}
+	since := now.UTC().Truncate(24*time.Hour).AddDate(0, 0, -days+1)
+	return store.CollectionValueHistory(ctx, userID, since)
+}
+
+// RunValueRecorder records the value of every collection for the current day
+// every interval until ctx is done, so the last record of a day is its value.
+func RunValueRecorder(ctx context.Context, store ValueRepository, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		n, err := store.RecordCollectionValues(ctx, time.Now().UTC())
+		if err != nil {
+			slog.Error("error recording collection values", "error", err)
+		} else {
+			slog.Debug("recorded collection values", "collections", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
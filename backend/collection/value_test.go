@@ -0,0 +1,66 @@
+package collection_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/collection/collectiontest"
+)
+
+func TestCharacterValue(t *testing.T) {
+	tests := []struct {
+		favorites int
+		want      int64
+	}{
+		{-3, 1},
+		{0, 1},
+		{1, 2},
+		{99, 10},
+		{100, 11},
+		{5000, 71},
+		{10000, 101},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			assert.Equal(t, tt.want, collection.CharacterValue(tt.favorites))
+		})
+	}
+}
+
+func TestValueHistory(t *testing.T) {
+	now := time.Date(2026, 10, 17, 15, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		days      int
+		wantSince time.Time
+	}{
+		{"one day is today", 1, time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)},
+		{"a week", 7, time.Date(2026, 10, 11, 0, 0, 0, 0, time.UTC)},
+		{"default range", 0, time.Date(2026, 7, 20, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var since time.Time
+			store := &collectiontest.MockStore{
+				CollectionValueHistoryFunc: func(_ context.Context, userID collection.UserID, s time.Time) ([]collection.ValuePoint, error) {
+					assert.Equal(t, collection.UserID(1), userID)
+					since = s
+					return []collection.ValuePoint{{Day: s, Value: 12}}, nil
+				},
+			}
+
+			points, err := collection.ValueHistory(t.Context(), store, 1, tt.days, now)
+			require.NoError(t, err)
+			assert.Len(t, points, 1)
+			assert.Equal(t, tt.wantSince, since)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/Karitham/corde"
+
+	"github.com/karitham/waifubot/collection"
+)
+
+// achievementColor is the gold used for achievement embeds.
+const achievementColor = 0xf1c40f
+
+// unlockedAchievements checks the user's achievements after they acquired a character
+// and returns an embed announcing the new ones, if any. The event itself already
+// succeeded, so a failed check is logged and announces nothing.
+func unlockedAchievements(ctx context.Context, store collection.Store, userID uint64, kind collection.EventKind, char collection.Character) []corde.Embedder {
+	unlocked, err := collection.CheckAchievements(ctx, store, userID, collection.AchievementTrigger{Kind: kind, Character: char})
+	if err != nil {
+		slog.Error("error checking achievements", "error", err, "user_id", userID)
+		return nil
+	}
+	if len(unlocked) == 0 {
+		return nil
+	}
+	return []corde.Embedder{achievementEmbed(userID, unlocked)}
+}
+
+func achievementEmbed(userID uint64, unlocked []collection.Achievement) corde.Embed {
+	title := "🏆 Achievement unlocked"
+	if len(unlocked) > 1 {
+		title = "🏆 Achievements unlocked"
+	}
+
+	var sb strings.Builder
+	for _, a := range unlocked {
+		fmt.Fprintf(&sb, "<@%d> earned **%s**: %s\n", userID, a.Name, a.Description)
+	}
+
+	return corde.NewEmbed().
+		Title(title).
+		Description(sb.String()).
+		Color(achievementColor).
+		Embed()
+}
+
+// badgesText lists a user's badges for their profile.
+func badgesText(unlocked []collection.UnlockedAchievement) string {
+	if len(unlocked) == 0 {
+		return "None yet"
+	}
+
+	names := make([]string, len(unlocked))
+	for i, a := range unlocked {
+		names[i] = "🏅 " + a.Name
+	}
+	return strings.Join(names, "\n")
+}
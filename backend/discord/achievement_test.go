@@ -0,0 +1,80 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/collection/collectiontest"
+)
+
+func TestUnlockedAchievements(t *testing.T) {
+	tests := []struct {
+		name      string
+		store     *collectiontest.MockStore
+		wantTitle string
+		wantDesc  []string
+	}{
+		{
+			name:  "nothing unlocked",
+			store: &collectiontest.MockStore{},
+		},
+		{
+			name: "one unlocked",
+			store: &collectiontest.MockStore{
+				CountCollectionFunc: func(ctx context.Context, userID collection.UserID) (int64, error) {
+					return 10, nil
+				},
+			},
+			wantTitle: "🏆 Achievement unlocked",
+			wantDesc:  []string{"<@7> earned **Collector**: Own 10 characters"},
+		},
+		{
+			name: "several unlocked",
+			store: &collectiontest.MockStore{
+				CountReceivedEventsFunc: func(ctx context.Context, userID collection.UserID, kind collection.EventKind) (int64, error) {
+					return 100, nil
+				},
+			},
+			wantTitle: "🏆 Achievements unlocked",
+			wantDesc:  []string{"**Quick Draw**", "**Fastest in the West**"},
+		},
+		{
+			name: "check fails",
+			store: &collectiontest.MockStore{
+				ListAchievementsFunc: func(ctx context.Context, userID collection.UserID) ([]collection.AchievementUnlock, error) {
+					return nil, errors.New("database on fire")
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			embeds := unlockedAchievements(t.Context(), tt.store, 7, collection.EventClaim, collection.Character{ID: 1})
+			if tt.wantTitle == "" {
+				assert.Empty(t, embeds)
+				return
+			}
+
+			require.Len(t, embeds, 1)
+			e := embeds[0].Embed()
+			assert.Equal(t, tt.wantTitle, e.Title)
+			for _, want := range tt.wantDesc {
+				assert.Contains(t, e.Description, want)
+			}
+		})
+	}
+}
+
+func TestBadgesText(t *testing.T) {
+	assert.Equal(t, "None yet", badgesText(nil))
+	assert.Equal(t, "🏅 Collector\n🏅 Quick Draw", badgesText([]collection.UnlockedAchievement{
+		{Achievement: collection.Achievement{Name: "Collector"}},
+		{Achievement: collection.Achievement{Name: "Quick Draw"}},
+	}))
+}
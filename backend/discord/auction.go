@@ -0,0 +1,236 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/Karitham/corde"
+
+	"github.com/karitham/waifubot/catalog"
+	"github.com/karitham/waifubot/collection"
+)
+
+// auctionListLimit caps how many open auctions /auction list shows.
+const auctionListLimit = 15
+
+// AuctionHandler handles the /auction command and its subcommands.
+type AuctionHandler struct {
+	store collection.Store
+}
+
+// Register wires the auction sub-routes on the mux.
+func (h *AuctionHandler) Register(m *corde.Mux) {
+	m.Route("create", func(m *corde.Mux) {
+		m.SlashCommand("", wrap(wrapCtx(h.Create), trace[corde.SlashCommandInteractionData]))
+		m.Autocomplete("id", h.userCollectionAutocomplete)
+	})
+	m.SlashCommand("bid", wrap(wrapCtx(h.Bid), trace[corde.SlashCommandInteractionData]))
+	m.SlashCommand("list", wrap(wrapCtx(h.List), trace[corde.SlashCommandInteractionData]))
+}
+
+// auctionCreateOptions holds the parsed options for the auction create command.
+type auctionCreateOptions struct {
+	charID     int64
+	startPrice int32
+	duration   time.Duration
+}
+
+// parseAuctionCreateOptions parses the auction create options.
+func parseAuctionCreateOptions(cmd CommandContext) (auctionCreateOptions, error) {
+	charID, err := cmd.OptInt64("id")
+	if err != nil {
+		return auctionCreateOptions{}, fmt.Errorf("select a character to auction: %w", err)
+	}
+
+	start, _ := cmd.OptInt("start")
+
+	raw, err := cmd.OptString("duration")
+	if err != nil {
+		return auctionCreateOptions{}, fmt.Errorf("specify how long the auction runs: %w", err)
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(raw))
+	if err != nil {
+		return auctionCreateOptions{}, fmt.Errorf("%q is not a duration, try something like 30m or 12h", raw)
+	}
+
+	return auctionCreateOptions{charID: charID, startPrice: int32(start), duration: d}, nil
+}
+
+// Create puts one of the user's characters up for auction in the current channel.
+func (h *AuctionHandler) Create(ctx context.Context, w corde.ResponseWriter, cmd CommandContext) {
+	logger := slog.With("user_id", cmd.UserID(), "guild_id", cmd.GuildID())
+
+	opts, err := parseAuctionCreateOptions(cmd)
+	if err != nil {
+		w.Respond(rspErr(err.Error()))
+		return
+	}
+
+	auction, err := collection.CreateAuction(ctx, h.store, cmd.UserID(), opts.charID, cmd.ChannelID(), opts.startPrice, opts.duration)
+	if err != nil {
+		if msg, ok := auctionErrMessage(err); ok {
+			w.Respond(rspErr(msg))
+			return
+		}
+		logger.Error("error creating auction", "error", err, "character_id", opts.charID)
+		w.Respond(rspErr("Failed to create auction"))
+		return
+	}
+
+	w.Respond(corde.NewResp().Contentf(
+		"<@%d> put %s up for auction (#%d), starting at %d tokens. Ends <t:%d:R>.\nUse `/auction bid id:%d amount:<tokens>` to bid.",
+		auction.SellerID, describeCharacter(ctx, h.store, auction.CharacterID), auction.ID,
+		auction.StartPrice, auction.EndsAt.Unix(), auction.ID,
+	))
+}
+
+// Bid places a bid on an open auction.
+func (h *AuctionHandler) Bid(ctx context.Context, w corde.ResponseWriter, cmd CommandContext) {
+	logger := slog.With("user_id", cmd.UserID(), "guild_id", cmd.GuildID())
+
+	id, err := cmd.OptInt64("id")
+	if err != nil {
+		w.Respond(rspErr("specify the auction ID"))
+		return
+	}
+	amount, err := cmd.OptInt("amount")
+	if err != nil {
+		w.Respond(rspErr("specify how many tokens to bid"))
+		return
+	}
+
+	auction, err := collection.PlaceBid(ctx, h.store, cmd.UserID(), id, int32(amount))
+	if err != nil {
+		if msg, ok := auctionErrMessage(err); ok {
+			w.Respond(rspErr(msg))
+			return
+		}
+		logger.Error("error placing bid", "error", err, "auction_id", id, "amount", amount)
+		w.Respond(rspErr("Failed to place bid"))
+		return
+	}
+
+	w.Respond(corde.NewResp().Contentf(
+		"<@%d> bid %d tokens on %s (auction #%d). Ends <t:%d:R>.",
+		auction.HighBidderID, auction.HighBid, describeCharacter(ctx, h.store, auction.CharacterID),
+		auction.ID, auction.EndsAt.Unix(),
+	))
+}
+
+// List shows the open auctions ending soonest.
+func (h *AuctionHandler) List(ctx context.Context, w corde.ResponseWriter, cmd CommandContext) {
+	logger := slog.With("user_id", cmd.UserID(), "guild_id", cmd.GuildID())
+
+	auctions, err := collection.ListAuctions(ctx, h.store, auctionListLimit)
+	if err != nil {
+		logger.Error("error listing auctions", "error", err)
+		w.Respond(rspErr("Failed to list auctions"))
+		return
+	}
+	if len(auctions) == 0 {
+		w.Respond(Privf("There are no open auctions"))
+		return
+	}
+
+	var sb strings.Builder
+	for _, a := range auctions {
+		bid := fmt.Sprintf("starts at %d tokens", a.StartPrice)
+		if a.HighBidderID != 0 {
+			bid = fmt.Sprintf("%d tokens by <@%d>", a.HighBid, a.HighBidderID)
+		}
+		fmt.Fprintf(&sb, "**#%d** %s from <@%d>, %s, ends <t:%d:R>\n",
+			a.ID, describeCharacter(ctx, h.store, a.CharacterID), a.SellerID, bid, a.EndsAt.Unix())
+	}
+
+	w.Respond(corde.NewResp().Embeds(corde.NewEmbed().
+		Title("Open auctions").
+		Description(sb.String()).
+		Color(AnilistColor),
+	).Ephemeral())
+}
+
+// userCollectionAutocomplete provides character suggestions for the auction create command.
+func (h *AuctionHandler) userCollectionAutocomplete(ctx context.Context, w corde.ResponseWriter, i *corde.Interaction[corde.AutocompleteInteractionData]) {
+	autocomplete(ctx, w, i, "id", func(ctx context.Context, input string) ([]catalog.Character, error) {
+		return h.store.SearchCharacters(ctx, uint64(i.Member.User.ID), input)
+	}, formatCharacterChoice)
+}
+
+// RunAuctionSettler settles ended auctions every interval and announces the
+// outcome in the channel each auction was created in. It blocks until ctx is done.
+func (r *Router) RunAuctionSettler(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+
+		settled, err := collection.SettleDueAuctions(ctx, r.Store)
+		if err != nil {
+			slog.Error("error settling auctions", "error", err)
+		}
+		for _, a := range settled {
+			_, err := r.mux.CreateMessage(corde.Snowflake(a.ChannelID), corde.Message{Content: auctionResult(ctx, r.Store, a)})
+			if err != nil {
+				slog.Error("failed to announce auction result", "error", err, "auction_id", a.ID, "channel_id", a.ChannelID)
+			}
+		}
+	}
+}
+
+// auctionResult renders the announcement for a settled auction.
+func auctionResult(ctx context.Context, store collection.Store, a collection.Auction) string {
+	char := describeCharacter(ctx, store, a.CharacterID)
+	switch a.Status {
+	case collection.AuctionSold:
+		return fmt.Sprintf("Auction #%d is over: <@%d> won %s from <@%d> for %d tokens.", a.ID, a.HighBidderID, char, a.SellerID, a.HighBid)
+	case collection.AuctionVoid:
+		return fmt.Sprintf("Auction #%d for %s was called off, all bids were refunded.", a.ID, char)
+	default:
+		return fmt.Sprintf("Auction #%d for %s ended without bids, <@%d> keeps it.", a.ID, char, a.SellerID)
+	}
+}
+
+// describeCharacter renders a character as "Name (id)", falling back to the bare ID.
+func describeCharacter(ctx context.Context, store collection.Store, id int64) string {
+	char, err := store.GetCharacterByID(ctx, id)
+	if err != nil || char.Name == "" {
+		return fmt.Sprintf("character %d", id)
+	}
+	return fmt.Sprintf("%s (%d)", char.Name, id)
+}
+
+// auctionErrMessage maps expected auction errors to a user-facing message.
+func auctionErrMessage(err error) (string, bool) {
+	switch {
+	case errors.Is(err, collection.ErrAuctionNotFound):
+		return "That auction doesn't exist", true
+	case errors.Is(err, collection.ErrAuctionClosed):
+		return "That auction has ended", true
+	case errors.Is(err, collection.ErrAuctionExists):
+		return "That character is already up for auction", true
+	case errors.Is(err, collection.ErrBidTooLow):
+		return "Your bid must meet the start price and beat the current high bid", true
+	case errors.Is(err, collection.ErrOwnAuction):
+		return "You cannot bid on your own auction", true
+	case errors.Is(err, collection.ErrInvalidDuration):
+		return fmt.Sprintf("Auctions must run between %s and %s", collection.MinAuctionDuration, collection.MaxAuctionDuration), true
+	case errors.Is(err, collection.ErrInvalidAmount):
+		return "The start price cannot be negative", true
+	case errors.Is(err, collection.ErrUserDoesNotOwnCharacter):
+		return "You don't own that character", true
+	case errors.Is(err, collection.ErrAlreadyOwned):
+		return "You already own that character", true
+	case errors.Is(err, collection.ErrInsufficientTokens):
+		return "You don't have enough tokens for that bid", true
+	}
+	return "", false
+}
@@ -0,0 +1,159 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/karitham/waifubot/catalog"
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/collection/collectiontest"
+	"github.com/karitham/waifubot/discord/cordetest"
+)
+
+func TestAuctionHandler_Create(t *testing.T) {
+	tests := []struct {
+		name        string
+		cmd         CommandContext
+		store       *collectiontest.MockStore
+		wantContent string
+	}{
+		{
+			name:        "bad duration",
+			cmd:         &MockCommandContext{UserIDVal: 1, OptInt64Vals: map[string]int64{"id": 10}, OptStringVals: map[string]string{"duration": "soon"}},
+			store:       &collectiontest.MockStore{},
+			wantContent: "not a duration",
+		},
+		{
+			name:        "duration out of bounds",
+			cmd:         &MockCommandContext{UserIDVal: 1, OptInt64Vals: map[string]int64{"id": 10}, OptStringVals: map[string]string{"duration": "1m"}},
+			store:       &collectiontest.MockStore{},
+			wantContent: "must run between",
+		},
+		{
+			name: "already listed",
+			cmd:  &MockCommandContext{UserIDVal: 1, OptInt64Vals: map[string]int64{"id": 10}, OptStringVals: map[string]string{"duration": "1h"}},
+			store: &collectiontest.MockStore{
+				CreateAuctionFunc: func(context.Context, collection.Auction) (collection.Auction, error) {
+					return collection.Auction{}, collection.ErrAuctionExists
+				},
+			},
+			wantContent: "already up for auction",
+		},
+		{
+			name: "success",
+			cmd: &MockCommandContext{
+				UserIDVal:     1,
+				ChannelIDVal:  5,
+				OptInt64Vals:  map[string]int64{"id": 10},
+				OptIntVals:    map[string]int{"start": 20},
+				OptStringVals: map[string]string{"duration": "2h"},
+			},
+			store: &collectiontest.MockStore{
+				CreateAuctionFunc: func(_ context.Context, a collection.Auction) (collection.Auction, error) {
+					a.ID = 4
+					return a, nil
+				},
+				GetCharacterByIDFunc: func(_ context.Context, charID int64) (catalog.Character, error) {
+					return catalog.Character{ID: charID, Name: "Sakura"}, nil
+				},
+			},
+			wantContent: "Sakura (10) up for auction (#4), starting at 20 tokens",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &cordetest.MockResponseWriter{}
+			h := &AuctionHandler{store: tt.store}
+
+			h.Create(t.Context(), w, tt.cmd)
+
+			assert.True(t, w.RespondCalled)
+			w.AssertContains(t, tt.wantContent)
+		})
+	}
+}
+
+func TestAuctionHandler_Bid(t *testing.T) {
+	open := func(context.Context, int64) (collection.Auction, error) {
+		return collection.Auction{ID: 4, SellerID: 1, CharacterID: 10, StartPrice: 20, Status: collection.AuctionOpen, EndsAt: time.Now().Add(time.Hour)}, nil
+	}
+
+	tests := []struct {
+		name        string
+		amount      int
+		store       *collectiontest.MockStore
+		wantContent string
+	}{
+		{
+			name:   "not found",
+			amount: 25,
+			store: &collectiontest.MockStore{GetAuctionForUpdateFunc: func(context.Context, int64) (collection.Auction, error) {
+				return collection.Auction{}, collection.ErrNotFound
+			}},
+			wantContent: "doesn't exist",
+		},
+		{
+			name:        "too low",
+			amount:      10,
+			store:       &collectiontest.MockStore{GetAuctionForUpdateFunc: open},
+			wantContent: "must meet the start price",
+		},
+		{
+			name:   "store error",
+			amount: 25,
+			store: &collectiontest.MockStore{
+				GetAuctionForUpdateFunc: open,
+				GetOwnedCharacterFunc: func(context.Context, uint64, int64) (collection.OwnedCharacter, error) {
+					return collection.OwnedCharacter{}, errors.New("database on fire")
+				},
+			},
+			wantContent: "Failed to place bid",
+		},
+		{
+			name:   "success",
+			amount: 25,
+			store: &collectiontest.MockStore{
+				GetAuctionForUpdateFunc: open,
+				GetOwnedCharacterFunc: func(context.Context, uint64, int64) (collection.OwnedCharacter, error) {
+					return collection.OwnedCharacter{}, collection.ErrNotFound
+				},
+			},
+			wantContent: "<@2> bid 25 tokens on character 10 (auction #4)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &cordetest.MockResponseWriter{}
+			h := &AuctionHandler{store: tt.store}
+
+			h.Bid(t.Context(), w, &MockCommandContext{
+				UserIDVal:    2,
+				OptInt64Vals: map[string]int64{"id": 4},
+				OptIntVals:   map[string]int{"amount": tt.amount},
+			})
+
+			assert.True(t, w.RespondCalled)
+			w.AssertContains(t, tt.wantContent)
+		})
+	}
+}
+
+func TestAuctionResult(t *testing.T) {
+	store := &collectiontest.MockStore{}
+	a := collection.Auction{ID: 4, SellerID: 1, CharacterID: 10, HighBid: 25, HighBidderID: 2}
+
+	a.Status = collection.AuctionSold
+	assert.Contains(t, auctionResult(t.Context(), store, a), "<@2> won character 10 from <@1> for 25 tokens")
+
+	a.Status = collection.AuctionVoid
+	assert.Contains(t, auctionResult(t.Context(), store, a), "refunded")
+
+	a.Status = collection.AuctionUnsold
+	assert.Contains(t, auctionResult(t.Context(), store, a), "<@1> keeps it")
+}
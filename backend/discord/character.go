@@ -0,0 +1,107 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/Karitham/corde"
+
+	"github.com/karitham/waifubot/collection"
+)
+
+const (
+	// characterDescLimit keeps descriptions well under Discord's 4096 characters.
+	characterDescLimit = 1500
+	// appearancesLimit is how many media /character lists by name.
+	appearancesLimit = 10
+)
+
+// CharacterHandler handles the /character command and its autocomplete.
+type CharacterHandler struct {
+	store collection.Store
+}
+
+// Register wires the character sub-routes on the mux.
+func (h *CharacterHandler) Register(m *corde.Mux) {
+	m.SlashCommand("", wrap(wrapCtx(h.Character), trace[corde.SlashCommandInteractionData]))
+	m.Autocomplete("id", h.Autocomplete)
+}
+
+// Character shows what the catalog knows about a character and how many players hold it.
+func (h *CharacterHandler) Character(ctx context.Context, w corde.ResponseWriter, cmd CommandContext) {
+	charID, err := cmd.OptInt("id")
+	if err != nil {
+		w.Respond(rspErr("select a character to look up"))
+		return
+	}
+
+	info, err := collection.CharacterDetails(ctx, h.store, int64(charID), cmd.GuildID())
+	if err != nil {
+		if errors.Is(err, collection.ErrNotFound) {
+			w.Respond(newErrf("Character %d not found", charID))
+			return
+		}
+		slog.Error("error getting character details", "error", err, "char_id", charID)
+		w.Respond(rspErr("Failed to get character details"))
+		return
+	}
+
+	w.Respond(corde.NewResp().Embeds(characterEmbed(info)))
+}
+
+// Autocomplete provides character suggestions for the character command.
+func (h *CharacterHandler) Autocomplete(ctx context.Context, w corde.ResponseWriter, i *corde.Interaction[corde.AutocompleteInteractionData]) {
+	autocomplete(ctx, w, i, "id", h.store.SearchGlobalCharacters, formatCharacterChoice)
+}
+
+func characterEmbed(info collection.CharacterInfo) corde.Embed {
+	color := collection.GradientColor(info.Favorites)
+
+	desc := sanitizeDesc(strings.TrimSpace(info.Description))
+	if desc == "" {
+		desc = "No description available."
+	}
+
+	firstClaimed := "Never"
+	if !info.Stats.FirstClaimed.IsZero() {
+		firstClaimed = fmt.Sprintf("<t:%d:D>", info.Stats.FirstClaimed.Unix())
+	}
+
+	return corde.NewEmbed().
+		Title(info.Name).
+		URL(fmt.Sprintf("https://anilist.co/character/%d", info.ID)).
+		Thumbnail(corde.Image{URL: info.Image}).
+		Description(truncateString(desc, characterDescLimit)).
+		Color(color).
+		FieldInline("Rarity", fmt.Sprintf("%s (#%06X)", info.Rarity(), color)).
+		FieldInline("Favorites", fmt.Sprintf("%d", info.Favorites)).
+		FieldInline("Value", fmt.Sprintf("%d", collection.CharacterValue(info.Favorites))).
+		FieldInline("Wishlisted by", fmt.Sprintf("%d", info.Stats.Wishlisted)).
+		FieldInline("Owners", fmt.Sprintf("%d (%d in this server)", info.Stats.Owners, info.Stats.GuildOwners)).
+		FieldInline("First claimed", firstClaimed).
+		Field("Appearances", appearancesText(info)).
+		Embed()
+}
+
+// appearancesText lists the titles of the media a character appears in.
+func appearancesText(info collection.CharacterInfo) string {
+	if len(info.Media) == 0 {
+		if info.MediaTitle != "" {
+			return info.MediaTitle
+		}
+		return "Unknown"
+	}
+
+	var sb strings.Builder
+	for i, m := range info.Media {
+		if i == appearancesLimit {
+			fmt.Fprintf(&sb, "and %d more", len(info.Media)-i)
+			break
+		}
+		fmt.Fprintf(&sb, "%s (%s)\n", m.Title, strings.ToLower(m.Type))
+	}
+	return truncateString(strings.TrimSpace(sb.String()), 1024)
+}
@@ -0,0 +1,103 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/karitham/waifubot/catalog"
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/collection/collectiontest"
+	"github.com/karitham/waifubot/discord/cordetest"
+)
+
+func TestCharacterHandler_Character(t *testing.T) {
+	rem := func(_ context.Context, id int64) (catalog.Character, error) {
+		return catalog.Character{ID: id, Name: "Rem", Favorites: 6000, MediaTitle: "Re:Zero"}, nil
+	}
+
+	tests := []struct {
+		name        string
+		cmd         CommandContext
+		store       *collectiontest.MockStore
+		wantContent []string
+	}{
+		{
+			name:        "missing character option",
+			cmd:         &MockCommandContext{ErrVal: errors.New("option not found")},
+			store:       &collectiontest.MockStore{},
+			wantContent: []string{"select a character"},
+		},
+		{
+			name: "unknown character",
+			cmd:  &MockCommandContext{OptIntVals: map[string]int{"id": 42}},
+			store: &collectiontest.MockStore{
+				GetCharacterByIDFunc: func(context.Context, int64) (catalog.Character, error) {
+					return catalog.Character{}, collection.ErrNotFound
+				},
+			},
+			wantContent: []string{"Character 42 not found"},
+		},
+		{
+			name:        "never claimed",
+			cmd:         &MockCommandContext{OptIntVals: map[string]int{"id": 42}},
+			store:       &collectiontest.MockStore{GetCharacterByIDFunc: rem},
+			wantContent: []string{"Rem", "No description available", "Legendary", "Never", "Re:Zero"},
+		},
+		{
+			name: "details",
+			cmd:  &MockCommandContext{OptIntVals: map[string]int{"id": 42}, GuildIDVal: 7},
+			store: &collectiontest.MockStore{
+				GetCharacterByIDFunc: rem,
+				CharacterDescriptionFunc: func(context.Context, int64) (string, error) {
+					return "Maid of the Roswaal mansion. ~!Spoiler!~", nil
+				},
+				MediaByCharacterFunc: func(context.Context, int64) ([]catalog.Media, error) {
+					return []catalog.Media{{Title: "Re:Zero", Type: "ANIME"}, {Title: "Re:Zero", Type: "MANGA"}}, nil
+				},
+				CharacterStatsFunc: func(_ context.Context, _ int64, guildID uint64) (catalog.CharacterStats, error) {
+					assert.Equal(t, uint64(7), guildID)
+					return catalog.CharacterStats{Owners: 12, GuildOwners: 3, Wishlisted: 5, FirstClaimed: time.Unix(1700000000, 0)}, nil
+				},
+			},
+			wantContent: []string{"||Spoiler||", "12 (3 in this server)", "<t:1700000000:D>", "Re:Zero (manga)"},
+		},
+		{
+			name: "store error",
+			cmd:  &MockCommandContext{OptIntVals: map[string]int{"id": 42}},
+			store: &collectiontest.MockStore{
+				GetCharacterByIDFunc: rem,
+				CharacterStatsFunc: func(context.Context, int64, uint64) (catalog.CharacterStats, error) {
+					return catalog.CharacterStats{}, errors.New("db down")
+				},
+			},
+			wantContent: []string{"Failed to get character details"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &cordetest.MockResponseWriter{}
+			h := &CharacterHandler{store: tt.store}
+
+			h.Character(t.Context(), w, tt.cmd)
+
+			assert.True(t, w.RespondCalled)
+			for _, want := range tt.wantContent {
+				w.AssertContains(t, want)
+			}
+		})
+	}
+}
+
+func TestAppearancesText(t *testing.T) {
+	media := make([]catalog.Media, appearancesLimit+3)
+	for i := range media {
+		media[i] = catalog.Media{Title: "Show", Type: "ANIME"}
+	}
+	assert.Contains(t, appearancesText(collection.CharacterInfo{Media: media}), "and 3 more")
+	assert.Equal(t, "Unknown", appearancesText(collection.CharacterInfo{}))
+}
@@ -8,11 +8,14 @@ import (
 	"github.com/Karitham/corde"
 
 	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/storage/dropstore"
 )
 
 // ClaimHandler handles the /claim command.
 type ClaimHandler struct {
-	store collection.Store
+	store          collection.Store
+	drops          dropstore.Store
+	hintThresholds []int // failed attempts unlocking each hint, ascending
 }
 
 // claimOptions holds the parsed options for the claim command.
@@ -45,8 +48,10 @@ func (h *ClaimHandler) Claim(ctx context.Context, w corde.ResponseWriter, cmd Co
 		switch {
 		case errors.Is(err, collection.ErrNoDropInChannel):
 			w.Respond(rspErr("No character to claim in this channel. Wait for the next drop!"))
+		case errors.Is(err, collection.ErrDropExpired):
+			w.Respond(rspErr("Too late, this drop has expired. Wait for the next one!"))
 		case errors.Is(err, collection.ErrWrongCharacterName):
-			w.Respond(rspErr("Wrong name! Check the hint and try again."))
+			h.miss(ctx, w, corde.Snowflake(cmd.ChannelID()))
 		case errors.Is(err, collection.ErrAlreadyOwned):
 			w.Respond(rspErr("You already have this character in your collection!"))
 		default:
@@ -61,5 +66,41 @@ func (h *ClaimHandler) Claim(ctx context.Context, w corde.ResponseWriter, cmd Co
 
 	rarity := collection.RarityFromFavorites(char.Favorites)
 
-	w.Respond(claimEmbed(char, rarity.String()))
+	w.Respond(corde.NewResp().
+		Embeds(claimEmbed(char, rarity.String())).
+		Embeds(unlockedAchievements(ctx, h.store, cmd.UserID(), collection.EventClaim, char)...))
+}
+
+// miss counts a wrong guess on the channel's drop. When the guess unlocks a
+// new hint, the hint is posted publicly instead of the usual reply.
+func (h *ClaimHandler) miss(ctx context.Context, w corde.ResponseWriter, channelID corde.Snowflake) {
+	wrong := rspErr("Wrong name! Check the hint and try again.")
+	if h.drops == nil || len(h.hintThresholds) == 0 {
+		w.Respond(wrong)
+		return
+	}
+
+	m, err := h.drops.RecordMiss(ctx, channelID)
+	if err != nil {
+		slog.Debug("failed to record drop miss", "error", err, "channel_id", channelID)
+		w.Respond(wrong)
+		return
+	}
+
+	level := dropstore.HintLevel(m.Attempts, h.hintThresholds)
+	if level <= m.HintsShown {
+		w.Respond(wrong)
+		return
+	}
+
+	advanced, err := h.drops.AdvanceHint(ctx, channelID, level)
+	if err != nil || !advanced {
+		if err != nil {
+			slog.Error("failed to advance drop hint", "error", err, "channel_id", channelID)
+		}
+		w.Respond(wrong)
+		return
+	}
+
+	w.Respond(corde.NewResp().Embeds(hintEmbed(m.Drop, m.Attempts, level, len(h.hintThresholds))))
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/karitham/waifubot/collection"
 	"github.com/karitham/waifubot/collection/collectiontest"
 	"github.com/karitham/waifubot/discord/cordetest"
+	"github.com/karitham/waifubot/storage/dropstore"
 )
 
 func TestClaimHandler_Claim(t *testing.T) {
@@ -50,6 +51,22 @@ func TestClaimHandler_Claim(t *testing.T) {
 			wantContent:       "No character to claim in this channel",
 			wantRespondCalled: true,
 		},
+		{
+			name: "expired drop",
+			cmd: &MockCommandContext{
+				UserIDVal:     1,
+				ChannelIDVal:  2,
+				GuildIDVal:    3,
+				OptStringVals: map[string]string{"name": "Sakura"},
+			},
+			store: &collectiontest.MockStore{
+				GetDropForUpdateFunc: func(ctx context.Context, channelID uint64) (collection.Drop, error) {
+					return collection.Drop{Character: collection.Character{ID: 42, Name: "Sakura"}, ExpiresAt: time.Now().Add(-time.Second)}, nil
+				},
+			},
+			wantContent:       "this drop has expired",
+			wantRespondCalled: true,
+		},
 		{
 			name: "wrong character name",
 			cmd: &MockCommandContext{
@@ -60,7 +77,7 @@ func TestClaimHandler_Claim(t *testing.T) {
 			},
 			store: &collectiontest.MockStore{
 				GetDropForUpdateFunc: func(ctx context.Context, channelID uint64) (collection.Drop, error) {
-					return collection.Drop{ID: 42, Name: "Naruto"}, nil
+					return collection.Drop{Character: collection.Character{ID: 42, Name: "Naruto"}}, nil
 				},
 			},
 			wantContent:       "Wrong name! Check the hint",
@@ -76,7 +93,7 @@ func TestClaimHandler_Claim(t *testing.T) {
 			},
 			store: &collectiontest.MockStore{
 				GetDropForUpdateFunc: func(ctx context.Context, channelID uint64) (collection.Drop, error) {
-					return collection.Drop{ID: 42, Name: "Sakura"}, nil
+					return collection.Drop{Character: collection.Character{ID: 42, Name: "Sakura"}}, nil
 				},
 				AddToCollectionFunc: func(ctx context.Context, userID collection.UserID, char collection.Character, source string, acquiredAt time.Time) error {
 					return collection.ErrAlreadyOwned
@@ -111,7 +128,7 @@ func TestClaimHandler_Claim(t *testing.T) {
 			},
 			store: &collectiontest.MockStore{
 				GetDropForUpdateFunc: func(ctx context.Context, channelID uint64) (collection.Drop, error) {
-					return collection.Drop{ID: 42, Name: "Sakura", Image: "https://example.com/sakura.png", Favorites: 1000}, nil
+					return collection.Drop{Character: collection.Character{ID: 42, Name: "Sakura", Image: "https://example.com/sakura.png", Favorites: 1000}}, nil
 				},
 				AddToCollectionFunc: func(ctx context.Context, userID collection.UserID, char collection.Character, source string, acquiredAt time.Time) error {
 					return nil
@@ -148,3 +165,33 @@ func TestClaimHandler_Claim(t *testing.T) {
 		})
 	}
 }
+
+func TestClaimHandler_Hints(t *testing.T) {
+	store := &collectiontest.MockStore{
+		GetDropForUpdateFunc: func(ctx context.Context, channelID uint64) (collection.Drop, error) {
+			return collection.Drop{Character: collection.Character{ID: 42, Name: "Sakura Haruno", MediaTitle: "Naruto"}}, nil
+		},
+	}
+	drops := &fakeDropStore{drop: dropstore.Drop{ID: 42, Name: "Sakura Haruno", MediaTitle: "Naruto"}}
+	h := &ClaimHandler{store: store, drops: drops, hintThresholds: []int{2, 3}}
+	cmd := &MockCommandContext{UserIDVal: 1, ChannelIDVal: 2, OptStringVals: map[string]string{"name": "Hinata"}}
+
+	w := &cordetest.MockResponseWriter{}
+	h.Claim(t.Context(), w, cmd)
+	w.AssertContains(t, "Wrong name! Check the hint")
+
+	w = &cordetest.MockResponseWriter{}
+	h.Claim(t.Context(), w, cmd)
+	w.AssertContains(t, "Hint 1/2")
+	w.AssertContains(t, "*Naruto*")
+
+	w = &cordetest.MockResponseWriter{}
+	h.Claim(t.Context(), w, cmd)
+	w.AssertContains(t, "Hint 2/2")
+	w.AssertContains(t, "`S _ _ _ _ _   H _ _ _ _ _`")
+
+	w = &cordetest.MockResponseWriter{}
+	h.Claim(t.Context(), w, cmd)
+	w.AssertContains(t, "Wrong name! Check the hint")
+	assert.Equal(t, 4, drops.misses)
+}
@@ -18,6 +18,10 @@ type CommandContext interface {
 	OptInt(key string) (int, error)
 	OptInt64(key string) (int64, error)
 	OptUser(key string) (corde.User, error)
+	OptSnowflake(key string) (corde.Snowflake, error)
+	// HasOpt reports whether the user filled in an option.
+	// The Opt getters return a zero value for missing options.
+	HasOpt(key string) bool
 }
 
 type slashCommandCtx struct {
@@ -48,6 +52,15 @@ func (c *slashCommandCtx) OptInt64(key string) (int64, error) { return c.i.Data.
 
 func (c *slashCommandCtx) OptUser(key string) (corde.User, error) { return c.i.Data.OptionsUser(key) }
 
+func (c *slashCommandCtx) OptSnowflake(key string) (corde.Snowflake, error) {
+	return c.i.Data.Options.Snowflake(key)
+}
+
+func (c *slashCommandCtx) HasOpt(key string) bool {
+	_, ok := c.i.Data.Options[key]
+	return ok
+}
+
 // wrapCtx adapts a CommandContext-accepting handler into the middleware chain's signature.
 func wrapCtx(
 	handler func(ctx context.Context, w corde.ResponseWriter, cmd CommandContext),
@@ -70,6 +83,7 @@ type MockCommandContext struct {
 	OptIntVals           map[string]int
 	OptInt64Vals         map[string]int64
 	OptUserVals          map[string]corde.User
+	OptSnowflakeVals     map[string]corde.Snowflake
 	ErrVal               error
 }
 
@@ -114,4 +128,21 @@ func (m *MockCommandContext) OptUser(key string) (corde.User, error) {
 	return v, nil
 }
 
+func (m *MockCommandContext) OptSnowflake(key string) (corde.Snowflake, error) {
+	v, ok := m.OptSnowflakeVals[key]
+	if !ok {
+		return 0, m.ErrVal
+	}
+	return v, nil
+}
+
+func (m *MockCommandContext) HasOpt(key string) bool {
+	_, s := m.OptStringVals[key]
+	_, i := m.OptIntVals[key]
+	_, i64 := m.OptInt64Vals[key]
+	_, u := m.OptUserVals[key]
+	_, sf := m.OptSnowflakeVals[key]
+	return s || i || i64 || u || sf
+}
+
 var _ CommandContext = (*MockCommandContext)(nil)
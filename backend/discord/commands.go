@@ -1,5 +1,14 @@
 package discord
 
+// trackerSourceOption lets a /search subcommand ask another tracker than the configured one.
+var trackerSourceOption = OptionDef{
+	Name: "source", Description: "Tracker to search, defaults to the bot's", Type: OptionString,
+	Choices: []ChoiceDef{
+		{Name: "AniList", Value: "anilist"},
+		{Name: "MyAnimeList", Value: "mal"},
+	},
+}
+
 var commandDefinitions = []CommandDef{
 	{
 		Name: "list", Description: "View a user's character collection",
@@ -7,6 +16,18 @@ var commandDefinitions = []CommandDef{
 			{Name: "user", Description: "User to list characters for (optional)", Type: OptionUser},
 		},
 	},
+	{
+		Name: "collection", Description: "Track your collection",
+		Options: []OptionDef{
+			{
+				Name: "series", Description: "See how much of a series you own", Type: OptionSubcommand,
+				Options: []OptionDef{
+					{Name: "series", Description: "ID of the anime or manga series", Type: OptionInt, Required: true, Autocomplete: true},
+					{Name: "user", Description: "User to check (optional, defaults to you)", Type: OptionUser},
+				},
+			},
+		},
+	},
 	{
 		Name: "verify", Description: "Check if a user owns a specific character",
 		Options: []OptionDef{
@@ -18,6 +39,7 @@ var commandDefinitions = []CommandDef{
 		Name: "token", Description: "Manage your tokens",
 		Options: []OptionDef{
 			{Name: "balance", Description: "View your token balance", Type: OptionSubcommand},
+			{Name: "history", Description: "View your recent token changes", Type: OptionSubcommand},
 			{
 				Name: "give", Description: "Give tokens to another user", Type: OptionSubcommand,
 				Options: []OptionDef{
@@ -40,31 +62,36 @@ var commandDefinitions = []CommandDef{
 		},
 	},
 	{Name: "roll", Description: "Roll for a random character"},
+	{Name: "daily", Description: "Claim your daily tokens and keep your streak going"},
 	{
-		Name: "search", Description: "Search AniList for anime, manga, characters, or users",
+		Name: "search", Description: "Search AniList or MyAnimeList for anime, manga, characters, or users",
 		Options: []OptionDef{
 			{
 				Name: "anime", Description: "Search for an anime by name", Type: OptionSubcommand,
 				Options: []OptionDef{
 					{Name: "name", Description: "name you wish to search", Type: OptionString, Required: true},
+					trackerSourceOption,
 				},
 			},
 			{
 				Name: "manga", Description: "Search for a manga by name", Type: OptionSubcommand,
 				Options: []OptionDef{
 					{Name: "name", Description: "name you wish to search", Type: OptionString, Required: true},
+					trackerSourceOption,
 				},
 			},
 			{
 				Name: "char", Description: "Search for a character by name", Type: OptionSubcommand,
 				Options: []OptionDef{
 					{Name: "name", Description: "name you wish to search", Type: OptionString, Required: true},
+					trackerSourceOption,
 				},
 			},
 			{
 				Name: "user", Description: "Search for a user by name", Type: OptionSubcommand,
 				Options: []OptionDef{
 					{Name: "name", Description: "name you wish to search", Type: OptionString, Required: true},
+					trackerSourceOption,
 				},
 			},
 		},
@@ -110,6 +137,99 @@ var commandDefinitions = []CommandDef{
 			{Name: "id", Description: "ID of the character to give", Type: OptionInt, Required: true, Autocomplete: true},
 		},
 	},
+	{
+		Name: "trade", Description: "Trade characters and tokens with another user",
+		Options: []OptionDef{
+			{
+				Name: "offer", Description: "Offer a trade to another user", Type: OptionSubcommand,
+				Options: []OptionDef{
+					{Name: "user", Description: "User to trade with", Type: OptionUser, Required: true},
+					{Name: "give", Description: "Comma-separated IDs of characters you give", Type: OptionString},
+					{Name: "want", Description: "Comma-separated IDs of characters you want", Type: OptionString},
+					{Name: "give_tokens", Description: "Number of tokens you give", Type: OptionInt},
+					{Name: "want_tokens", Description: "Number of tokens you want", Type: OptionInt},
+				},
+			},
+			{
+				Name: "accept", Description: "Accept a trade offer you received", Type: OptionSubcommand,
+				Options: []OptionDef{
+					{Name: "id", Description: "ID of the trade offer", Type: OptionInt, Required: true},
+				},
+			},
+			{
+				Name: "decline", Description: "Decline a trade offer you received", Type: OptionSubcommand,
+				Options: []OptionDef{
+					{Name: "id", Description: "ID of the trade offer", Type: OptionInt, Required: true},
+				},
+			},
+			{
+				Name: "cancel", Description: "Cancel a trade offer you sent", Type: OptionSubcommand,
+				Options: []OptionDef{
+					{Name: "id", Description: "ID of the trade offer", Type: OptionInt, Required: true},
+				},
+			},
+			{Name: "list", Description: "List your pending trade offers", Type: OptionSubcommand},
+		},
+	},
+	{
+		Name: "auction", Description: "Auction characters for tokens",
+		Options: []OptionDef{
+			{
+				Name: "create", Description: "Put one of your characters up for auction", Type: OptionSubcommand,
+				Options: []OptionDef{
+					{Name: "id", Description: "ID of the character to auction", Type: OptionInt, Required: true, Autocomplete: true},
+					{Name: "duration", Description: "How long the auction runs, e.g. 30m or 12h", Type: OptionString, Required: true},
+					{Name: "start", Description: "Minimum first bid in tokens", Type: OptionInt},
+				},
+			},
+			{
+				Name: "bid", Description: "Bid tokens on an open auction", Type: OptionSubcommand,
+				Options: []OptionDef{
+					{Name: "id", Description: "ID of the auction", Type: OptionInt, Required: true},
+					{Name: "amount", Description: "Number of tokens to bid", Type: OptionInt, Required: true},
+				},
+			},
+			{Name: "list", Description: "List open auctions", Type: OptionSubcommand},
+		},
+	},
+	{
+		Name: "config", Description: "Configure the bot for this server (requires Manage Server)",
+		Options: []OptionDef{
+			{Name: "show", Description: "Show this server's settings", Type: OptionSubcommand},
+			{
+				Name: "set", Description: "Change this server's settings", Type: OptionSubcommand,
+				Options: []OptionDef{
+					{Name: "roll_cooldown", Description: "Time between free rolls, e.g. 30m or 2h", Type: OptionString},
+					{Name: "interaction_needed", Description: "Messages needed before a character drops", Type: OptionInt},
+					{Name: "series_roll_cost", Description: "Token cost of a series roll", Type: OptionInt},
+				},
+			},
+			{Name: "reset", Description: "Restore the default settings", Type: OptionSubcommand},
+			{
+				Name: "drops", Description: "Choose where messages count and where drops appear", Type: OptionSubcommandGroup,
+				Options: []OptionDef{
+					{
+						Name: "allow", Description: "Count messages in this channel towards drops", Type: OptionSubcommand,
+						Options: []OptionDef{
+							{Name: "channel", Description: "Channel to allow", Type: OptionChannel, Required: true},
+						},
+					},
+					{
+						Name: "deny", Description: "Remove a channel from the drop allow-list", Type: OptionSubcommand,
+						Options: []OptionDef{
+							{Name: "channel", Description: "Channel to remove", Type: OptionChannel, Required: true},
+						},
+					},
+					{
+						Name: "channel", Description: "Send all drops to one channel, or omit to drop in each channel", Type: OptionSubcommand,
+						Options: []OptionDef{
+							{Name: "channel", Description: "Channel that receives drops", Type: OptionChannel},
+						},
+					},
+				},
+			},
+		},
+	},
 	{
 		Name: "claim", Description: "Claim a character by name",
 		Options: []OptionDef{
@@ -123,6 +243,40 @@ var commandDefinitions = []CommandDef{
 			{Name: "id", Description: "ID of the character", Type: OptionInt, Required: true, Autocomplete: true},
 		},
 	},
+	{
+		Name: "history", Description: "Show who owned a character and how it changed hands",
+		Options: []OptionDef{
+			{Name: "id", Description: "ID of the character", Type: OptionInt, Required: true, Autocomplete: true},
+		},
+	},
+	{
+		Name: "character", Description: "Show a character's details and who collects it",
+		Options: []OptionDef{
+			{Name: "id", Description: "ID of the character", Type: OptionInt, Required: true, Autocomplete: true},
+		},
+	},
+	{
+		Name: "leaderboard", Description: "Show the top collectors",
+		Options: []OptionDef{
+			{
+				Name: "category", Description: "What to rank collectors by", Type: OptionString, Required: true,
+				Choices: []ChoiceDef{
+					{Name: "Collection size", Value: "collection"},
+					{Name: "Rarity score", Value: "rarity"},
+					{Name: "Legendary characters", Value: "legendary"},
+					{Name: "Tokens", Value: "tokens"},
+					{Name: "Series completed", Value: "series"},
+				},
+			},
+			{
+				Name: "scope", Description: "Rank everyone or only this server, defaults to everyone", Type: OptionString,
+				Choices: []ChoiceDef{
+					{Name: "Global", Value: "global"},
+					{Name: "This server", Value: "server"},
+				},
+			},
+		},
+	},
 	{
 		Name: "wishlist", Description: "Manage your character wishlist",
 		Options: []OptionDef{
@@ -0,0 +1,68 @@
+package discord
+
+import (
+	"strings"
+
+	"github.com/Karitham/corde"
+)
+
+// The mux routes message components (buttons, select menus) by longest prefix on
+// their custom ID, the same way it routes slash commands by name.
+// A custom ID is therefore laid out as `<command>/<action>/<state...>`:
+// the leading segments pick the handler and the trailing ones carry whatever
+// state it needs, since component interactions carry nothing else.
+//
+// Discord caps custom IDs at 100 characters, so state must stay small.
+
+// componentIDSep separates custom ID segments.
+const componentIDSep = "/"
+
+// emptySegment stands in for empty state, as the mux cleans empty path segments away.
+const emptySegment = "-"
+
+// componentID builds a custom ID from a route and its state segments.
+func componentID(route string, state ...string) string {
+	parts := make([]string, 0, len(state)+1)
+	parts = append(parts, route)
+	for _, s := range state {
+		if s == "" {
+			s = emptySegment
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, componentIDSep)
+}
+
+// componentState returns the state segments following route in a custom ID.
+// It reports false if the custom ID doesn't belong to route.
+func componentState(customID, route string) ([]string, bool) {
+	rest, ok := strings.CutPrefix(customID, route+componentIDSep)
+	if !ok {
+		return nil, false
+	}
+	state := strings.Split(rest, componentIDSep)
+	for i, s := range state {
+		if s == emptySegment {
+			state[i] = ""
+		}
+	}
+	return state, true
+}
+
+// componentRoute strips the state off a component route, keeping `<command>/<action>`.
+// Used for metric labels, where per-user state would explode cardinality.
+func componentRoute(route string) string {
+	parts := strings.SplitN(route, componentIDSep, 3)
+	return strings.Join(parts[:min(len(parts), 2)], componentIDSep)
+}
+
+// button creates a clickable button component.
+func button(customID, label string, style corde.Style, disabled bool) corde.Component {
+	return corde.Component{
+		Type:     corde.COMPONENT_BUTTON,
+		CustomID: customID,
+		Style:    style,
+		Label:    label,
+		Disabled: disabled,
+	}
+}
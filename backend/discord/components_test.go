@@ -0,0 +1,24 @@
+package discord
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComponentID_RoundTrip(t *testing.T) {
+	id := componentID("list/next", "42", "", "d")
+	assert.Equal(t, "list/next/42/-/d", id)
+
+	state, ok := componentState(id, "list/next")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"42", "", "d"}, state)
+
+	_, ok = componentState(id, "list/prev")
+	assert.False(t, ok)
+}
+
+func TestComponentRoute(t *testing.T) {
+	assert.Equal(t, "list/next", componentRoute("list/next/42/0/d"))
+	assert.Equal(t, "list", componentRoute("list"))
+}
@@ -0,0 +1,237 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/Karitham/corde"
+
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/settings"
+)
+
+// ConfigHandler handles the /config command. Every subcommand requires Manage Guild.
+type ConfigHandler struct {
+	settings *settings.Service
+}
+
+// Register wires the config sub-routes on the mux.
+func (h *ConfigHandler) Register(m *corde.Mux) {
+	admin := requirePermission[corde.SlashCommandInteractionData](permManageGuild, "Manage Server")
+	m.SlashCommand("show", wrap(wrapCtx(h.Show), trace[corde.SlashCommandInteractionData], admin))
+	m.SlashCommand("set", wrap(wrapCtx(h.Set), trace[corde.SlashCommandInteractionData], admin))
+	m.SlashCommand("reset", wrap(wrapCtx(h.Reset), trace[corde.SlashCommandInteractionData], admin))
+	m.Route("drops", func(m *corde.Mux) {
+		m.SlashCommand("allow", wrap(wrapCtx(h.AllowDropChannel), trace[corde.SlashCommandInteractionData], admin))
+		m.SlashCommand("deny", wrap(wrapCtx(h.DenyDropChannel), trace[corde.SlashCommandInteractionData], admin))
+		m.SlashCommand("channel", wrap(wrapCtx(h.SetDropChannel), trace[corde.SlashCommandInteractionData], admin))
+	})
+}
+
+// parseConfigOverrides parses the options of /config set. Missing options are left unchanged.
+func parseConfigOverrides(cmd CommandContext) (settings.Overrides, error) {
+	var o settings.Overrides
+
+	if cmd.HasOpt("roll_cooldown") {
+		raw, _ := cmd.OptString("roll_cooldown")
+		d, err := time.ParseDuration(strings.TrimSpace(raw))
+		if err != nil {
+			return settings.Overrides{}, fmt.Errorf("%q is not a duration, try something like 30m or 2h", raw)
+		}
+		d = d.Round(time.Second)
+		o.RollCooldown = &d
+	}
+	if cmd.HasOpt("interaction_needed") {
+		n, err := cmd.OptInt("interaction_needed")
+		if err != nil {
+			return settings.Overrides{}, fmt.Errorf("invalid interactions needed: %w", err)
+		}
+		v := int64(n)
+		o.InteractionNeeded = &v
+	}
+	if cmd.HasOpt("series_roll_cost") {
+		n, err := cmd.OptInt("series_roll_cost")
+		if err != nil {
+			return settings.Overrides{}, fmt.Errorf("invalid series roll cost: %w", err)
+		}
+		v := int32(n)
+		o.SeriesRollCost = &v
+	}
+
+	if o == (settings.Overrides{}) {
+		return settings.Overrides{}, errors.New("specify at least one setting to change")
+	}
+	return o, nil
+}
+
+// Show displays the settings that apply to the guild.
+func (h *ConfigHandler) Show(ctx context.Context, w corde.ResponseWriter, cmd CommandContext) {
+	h.respondSettings(ctx, w, cmd, "Server settings")
+}
+
+// Set changes one or more settings for the guild.
+func (h *ConfigHandler) Set(ctx context.Context, w corde.ResponseWriter, cmd CommandContext) {
+	logger := slog.With("user_id", cmd.UserID(), "guild_id", cmd.GuildID())
+
+	patch, err := parseConfigOverrides(cmd)
+	if err != nil {
+		w.Respond(rspErr(err.Error()))
+		return
+	}
+
+	if _, err := h.settings.Update(ctx, cmd.GuildID(), patch); err != nil {
+		if errors.Is(err, settings.ErrInvalidSetting) {
+			w.Respond(rspErr(err.Error()))
+			return
+		}
+		logger.Error("error updating guild settings", "error", err)
+		w.Respond(rspErr("Failed to update server settings"))
+		return
+	}
+
+	h.respondSettings(ctx, w, cmd, "Settings updated")
+}
+
+// Reset restores the default settings for the guild.
+func (h *ConfigHandler) Reset(ctx context.Context, w corde.ResponseWriter, cmd CommandContext) {
+	logger := slog.With("user_id", cmd.UserID(), "guild_id", cmd.GuildID())
+
+	if err := h.settings.Reset(ctx, cmd.GuildID()); err != nil {
+		logger.Error("error resetting guild settings", "error", err)
+		w.Respond(rspErr("Failed to reset server settings"))
+		return
+	}
+
+	h.respondSettings(ctx, w, cmd, "Settings reset")
+}
+
+// AllowDropChannel adds a channel to the drop allow-list.
+// Once the list has a channel, only listed channels count towards drops.
+func (h *ConfigHandler) AllowDropChannel(ctx context.Context, w corde.ResponseWriter, cmd CommandContext) {
+	logger := slog.With("user_id", cmd.UserID(), "guild_id", cmd.GuildID())
+
+	channelID, err := cmd.OptSnowflake("channel")
+	if err != nil || channelID == 0 {
+		w.Respond(rspErr("select a channel"))
+		return
+	}
+
+	if err := h.settings.AllowDropChannel(ctx, cmd.GuildID(), uint64(channelID)); err != nil {
+		logger.Error("error allowing drop channel", "error", err, "channel_id", channelID)
+		w.Respond(rspErr("Failed to update the drop channels"))
+		return
+	}
+
+	h.respondSettings(ctx, w, cmd, fmt.Sprintf("Messages in <#%d> now count towards drops", channelID))
+}
+
+// DenyDropChannel removes a channel from the drop allow-list.
+func (h *ConfigHandler) DenyDropChannel(ctx context.Context, w corde.ResponseWriter, cmd CommandContext) {
+	logger := slog.With("user_id", cmd.UserID(), "guild_id", cmd.GuildID())
+
+	channelID, err := cmd.OptSnowflake("channel")
+	if err != nil || channelID == 0 {
+		w.Respond(rspErr("select a channel"))
+		return
+	}
+
+	removed, err := h.settings.DenyDropChannel(ctx, cmd.GuildID(), uint64(channelID))
+	if err != nil {
+		logger.Error("error denying drop channel", "error", err, "channel_id", channelID)
+		w.Respond(rspErr("Failed to update the drop channels"))
+		return
+	}
+	if !removed {
+		w.Respond(rspErr(fmt.Sprintf("<#%d> is not on the drop allow-list", channelID)))
+		return
+	}
+
+	h.respondSettings(ctx, w, cmd, fmt.Sprintf("Removed <#%d> from the drop allow-list", channelID))
+}
+
+// SetDropChannel sends every drop in the guild to one channel. Without a channel,
+// each channel gets its own drops again.
+func (h *ConfigHandler) SetDropChannel(ctx context.Context, w corde.ResponseWriter, cmd CommandContext) {
+	logger := slog.With("user_id", cmd.UserID(), "guild_id", cmd.GuildID())
+
+	var channelID uint64
+	if cmd.HasOpt("channel") {
+		id, err := cmd.OptSnowflake("channel")
+		if err != nil {
+			w.Respond(rspErr("select a channel"))
+			return
+		}
+		channelID = uint64(id)
+	}
+
+	if _, err := h.settings.Update(ctx, cmd.GuildID(), settings.Overrides{DropChannelID: &channelID}); err != nil {
+		logger.Error("error setting drop channel", "error", err, "channel_id", channelID)
+		w.Respond(rspErr("Failed to update the drop channel"))
+		return
+	}
+
+	title := "Each channel now gets its own drops"
+	if channelID != 0 {
+		title = "Drops now go to one channel"
+	}
+	h.respondSettings(ctx, w, cmd, title)
+}
+
+// respondSettings responds with the guild's current settings.
+func (h *ConfigHandler) respondSettings(ctx context.Context, w corde.ResponseWriter, cmd CommandContext, title string) {
+	o, err := h.settings.Overrides(ctx, cmd.GuildID())
+	if err != nil {
+		slog.Error("error loading guild settings", "error", err, "guild_id", cmd.GuildID())
+		w.Respond(rspErr("Failed to load server settings"))
+		return
+	}
+	channels, err := h.settings.DropChannels(ctx, cmd.GuildID())
+	if err != nil {
+		slog.Error("error loading drop channels", "error", err, "guild_id", cmd.GuildID())
+		w.Respond(rspErr("Failed to load server settings"))
+		return
+	}
+
+	w.Respond(configEmbed(title, o, o.Apply(h.settings.Defaults()), channels))
+}
+
+// configEmbed lists the effective settings, marking the ones left at their default.
+func configEmbed(title string, o settings.Overrides, c collection.Config, dropChannels []uint64) *corde.RespB {
+	mark := func(overridden bool) string {
+		if overridden {
+			return ""
+		}
+		return " (default)"
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "**Roll cooldown:** %s%s\n", c.RollCooldown, mark(o.RollCooldown != nil))
+	fmt.Fprintf(&sb, "**Messages per drop:** %d%s\n", c.InteractionNeeded, mark(o.InteractionNeeded != nil))
+	fmt.Fprintf(&sb, "**Series roll cost:** %d tokens%s\n", c.SeriesRollCost, mark(o.SeriesRollCost != nil))
+
+	if o.DropChannelID != nil && *o.DropChannelID != 0 {
+		fmt.Fprintf(&sb, "**Drop channel:** <#%d>, messages count server-wide\n", *o.DropChannelID)
+	} else {
+		sb.WriteString("**Drop channel:** the channel that reached the threshold (default)\n")
+	}
+
+	if len(dropChannels) == 0 {
+		sb.WriteString("**Counting channels:** all (default)\n")
+	} else {
+		mentions := make([]string, len(dropChannels))
+		for i, id := range dropChannels {
+			mentions[i] = fmt.Sprintf("<#%d>", id)
+		}
+		fmt.Fprintf(&sb, "**Counting channels:** %s\n", strings.Join(mentions, ", "))
+	}
+
+	return corde.NewResp().Embeds(corde.NewEmbed().
+		Title(title).
+		Description(sb.String()).
+		Color(AnilistColor),
+	).Ephemeral()
+}
@@ -0,0 +1,188 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Karitham/corde"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/discord/cordetest"
+	"github.com/karitham/waifubot/settings"
+)
+
+// memSettings is an in-memory settings.Store.
+type memSettings struct {
+	rows     map[uint64]settings.Overrides
+	channels map[uint64][]uint64
+}
+
+func newMemSettings() *memSettings {
+	return &memSettings{rows: map[uint64]settings.Overrides{}, channels: map[uint64][]uint64{}}
+}
+
+func (m *memSettings) GetGuildSettings(_ context.Context, guildID uint64) (settings.Overrides, error) {
+	return m.rows[guildID], nil
+}
+
+func (m *memSettings) UpsertGuildSettings(_ context.Context, guildID uint64, o settings.Overrides) error {
+	m.rows[guildID] = o
+	return nil
+}
+
+func (m *memSettings) DeleteGuildSettings(_ context.Context, guildID uint64) error {
+	delete(m.rows, guildID)
+	return nil
+}
+
+func (m *memSettings) ListDropChannels(_ context.Context, guildID uint64) ([]uint64, error) {
+	return m.channels[guildID], nil
+}
+
+func (m *memSettings) AddDropChannel(_ context.Context, guildID, channelID uint64) error {
+	if !slices.Contains(m.channels[guildID], channelID) {
+		m.channels[guildID] = append(m.channels[guildID], channelID)
+	}
+	return nil
+}
+
+func (m *memSettings) RemoveDropChannel(_ context.Context, guildID, channelID uint64) (bool, error) {
+	i := slices.Index(m.channels[guildID], channelID)
+	if i < 0 {
+		return false, nil
+	}
+	m.channels[guildID] = slices.Delete(m.channels[guildID], i, i+1)
+	return true, nil
+}
+
+var configDefaults = collection.Config{RollCooldown: 2 * time.Hour, InteractionNeeded: 25, SeriesRollCost: 20}
+
+func TestParseConfigOverrides(t *testing.T) {
+	missing := errors.New("option not found")
+
+	o, err := parseConfigOverrides(&MockCommandContext{
+		OptStringVals: map[string]string{"roll_cooldown": "90m"},
+		OptIntVals:    map[string]int{"series_roll_cost": 5},
+		ErrVal:        missing,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, o.RollCooldown)
+	assert.Equal(t, 90*time.Minute, *o.RollCooldown)
+	require.NotNil(t, o.SeriesRollCost)
+	assert.Equal(t, int32(5), *o.SeriesRollCost)
+	assert.Nil(t, o.InteractionNeeded)
+
+	_, err = parseConfigOverrides(&MockCommandContext{ErrVal: missing})
+	assert.ErrorContains(t, err, "at least one setting")
+
+	_, err = parseConfigOverrides(&MockCommandContext{OptStringVals: map[string]string{"roll_cooldown": "soon"}, ErrVal: missing})
+	assert.ErrorContains(t, err, "not a duration")
+}
+
+func TestConfigHandler(t *testing.T) {
+	store := newMemSettings()
+	h := &ConfigHandler{settings: settings.NewService(store, configDefaults, time.Hour)}
+	missing := errors.New("option not found")
+
+	w := &cordetest.MockResponseWriter{}
+	h.Show(t.Context(), w, &MockCommandContext{GuildIDVal: 7})
+	w.AssertContains(t, "**Messages per drop:** 25 (default)")
+
+	w = &cordetest.MockResponseWriter{}
+	h.Set(t.Context(), w, &MockCommandContext{GuildIDVal: 7, OptIntVals: map[string]int{"interaction_needed": 0}, ErrVal: missing})
+	w.AssertContains(t, "at least 1")
+
+	w = &cordetest.MockResponseWriter{}
+	h.Set(t.Context(), w, &MockCommandContext{GuildIDVal: 7, OptIntVals: map[string]int{"interaction_needed": 5}, ErrVal: missing})
+	w.AssertContains(t, "**Messages per drop:** 5\n")
+	w.AssertContains(t, "**Series roll cost:** 20 tokens (default)")
+
+	cfg, err := h.settings.GuildConfig(t.Context(), 7)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), cfg.InteractionNeeded)
+
+	w = &cordetest.MockResponseWriter{}
+	h.Reset(t.Context(), w, &MockCommandContext{GuildIDVal: 7})
+	w.AssertContains(t, "**Messages per drop:** 25 (default)")
+	assert.Empty(t, store.rows)
+}
+
+func TestRequirePermission(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions string
+		wantCalled  bool
+	}{
+		{name: "manage guild", permissions: "32", wantCalled: true},
+		{name: "administrator", permissions: "8", wantCalled: true},
+		{name: "other permissions", permissions: "3072"},
+		{name: "no member", permissions: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			h := wrap(
+				func(context.Context, corde.ResponseWriter, *corde.Interaction[corde.SlashCommandInteractionData]) {
+					called = true
+				},
+				requirePermission[corde.SlashCommandInteractionData](permManageGuild, "Manage Server"),
+			)
+
+			w := &cordetest.MockResponseWriter{}
+			h(t.Context(), w, &corde.Interaction[corde.SlashCommandInteractionData]{
+				Member: corde.Member{Permissions: tt.permissions},
+			})
+
+			assert.Equal(t, tt.wantCalled, called)
+			if !tt.wantCalled {
+				w.AssertContains(t, "Manage Server permission")
+			}
+		})
+	}
+}
+
+func TestConfigHandler_Drops(t *testing.T) {
+	store := newMemSettings()
+	h := &ConfigHandler{settings: settings.NewService(store, configDefaults, time.Hour)}
+	missing := errors.New("option not found")
+
+	w := &cordetest.MockResponseWriter{}
+	h.AllowDropChannel(t.Context(), w, &MockCommandContext{GuildIDVal: 7, OptSnowflakeVals: map[string]corde.Snowflake{"channel": 11}, ErrVal: missing})
+	w.AssertContains(t, "**Counting channels:** <#11>")
+
+	route, ok, err := h.settings.DropRoute(t.Context(), 7, 12)
+	require.NoError(t, err)
+	assert.False(t, ok, "channels off the allow-list should not count")
+
+	w = &cordetest.MockResponseWriter{}
+	h.SetDropChannel(t.Context(), w, &MockCommandContext{GuildIDVal: 7, OptSnowflakeVals: map[string]corde.Snowflake{"channel": 99}, ErrVal: missing})
+	w.AssertContains(t, "**Drop channel:** <#99>")
+
+	route, ok, err = h.settings.DropRoute(t.Context(), 7, 11)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, settings.DropRoute{CounterID: 7, ChannelID: 99}, route)
+
+	w = &cordetest.MockResponseWriter{}
+	h.DenyDropChannel(t.Context(), w, &MockCommandContext{GuildIDVal: 7, OptSnowflakeVals: map[string]corde.Snowflake{"channel": 12}, ErrVal: missing})
+	w.AssertContains(t, "not on the drop allow-list")
+
+	w = &cordetest.MockResponseWriter{}
+	h.DenyDropChannel(t.Context(), w, &MockCommandContext{GuildIDVal: 7, OptSnowflakeVals: map[string]corde.Snowflake{"channel": 11}, ErrVal: missing})
+	w.AssertContains(t, "**Counting channels:** all (default)")
+
+	w = &cordetest.MockResponseWriter{}
+	h.SetDropChannel(t.Context(), w, &MockCommandContext{GuildIDVal: 7, ErrVal: missing})
+	w.AssertContains(t, "**Drop channel:** the channel that reached the threshold (default)")
+
+	route, ok, err = h.settings.DropRoute(t.Context(), 7, 12)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, settings.DropRoute{CounterID: 12, ChannelID: 12}, route)
+}
@@ -2,8 +2,10 @@ package cordetest
 
 import (
 	"context"
+	"time"
 
 	"github.com/karitham/waifubot/catalog"
+	"github.com/karitham/waifubot/collection"
 )
 
 // MockCatalogStore implements catalog.Store for testing.
@@ -15,6 +17,17 @@ type MockCatalogStore struct {
 	GetCharacterHoldersInGuildFunc func(ctx context.Context, guildID uint64, charID int64) ([]uint64, error)
 	GetActiveIDsFunc               func(ctx context.Context) ([]int64, error)
 	MarkCharactersInactiveFunc     func(ctx context.Context, ids []int64) error
+	UpsertMediaFunc                func(ctx context.Context, media []catalog.Media) error
+	SetCharacterMediaFunc          func(ctx context.Context, charID int64, mediaIDs []int64) error
+	SetMediaCharactersFunc         func(ctx context.Context, media catalog.Media, chars []catalog.Character, syncedAt time.Time) error
+	CharacterDescriptionFunc       func(ctx context.Context, charID int64) (string, error)
+	CharacterStatsFunc             func(ctx context.Context, charID int64, guildID uint64) (catalog.CharacterStats, error)
+	CharactersStatsFunc            func(ctx context.Context, charIDs []int64) (map[int64]catalog.CharacterStats, error)
+	GetMediaFunc                   func(ctx context.Context, mediaID int64) (catalog.Media, error)
+	MediaByMalIDFunc               func(ctx context.Context, malID int64, mediaType string) (catalog.Media, error)
+	SearchMediaFunc                func(ctx context.Context, term string) ([]catalog.Media, error)
+	CharactersByMediaFunc          func(ctx context.Context, mediaID int64) ([]catalog.Character, error)
+	MediaByCharacterFunc           func(ctx context.Context, charID int64) ([]catalog.Media, error)
 }
 
 var _ catalog.Store = (*MockCatalogStore)(nil)
@@ -67,3 +80,80 @@ func (m *MockCatalogStore) GetActiveIDs(ctx context.Context) ([]int64, error) {
 	}
 	return nil, nil
 }
+
+func (m *MockCatalogStore) UpsertMedia(ctx context.Context, media []catalog.Media) error {
+	if m.UpsertMediaFunc != nil {
+		return m.UpsertMediaFunc(ctx, media)
+	}
+	return nil
+}
+
+func (m *MockCatalogStore) SetCharacterMedia(ctx context.Context, charID int64, mediaIDs []int64) error {
+	if m.SetCharacterMediaFunc != nil {
+		return m.SetCharacterMediaFunc(ctx, charID, mediaIDs)
+	}
+	return nil
+}
+
+func (m *MockCatalogStore) SetMediaCharacters(ctx context.Context, media catalog.Media, chars []catalog.Character, syncedAt time.Time) error {
+	if m.SetMediaCharactersFunc != nil {
+		return m.SetMediaCharactersFunc(ctx, media, chars, syncedAt)
+	}
+	return nil
+}
+
+func (m *MockCatalogStore) CharacterDescription(ctx context.Context, charID int64) (string, error) {
+	if m.CharacterDescriptionFunc != nil {
+		return m.CharacterDescriptionFunc(ctx, charID)
+	}
+	return "", nil
+}
+
+func (m *MockCatalogStore) CharacterStats(ctx context.Context, charID int64, guildID uint64) (catalog.CharacterStats, error) {
+	if m.CharacterStatsFunc != nil {
+		return m.CharacterStatsFunc(ctx, charID, guildID)
+	}
+	return catalog.CharacterStats{}, nil
+}
+
+func (m *MockCatalogStore) CharactersStats(ctx context.Context, charIDs []int64) (map[int64]catalog.CharacterStats, error) {
+	if m.CharactersStatsFunc != nil {
+		return m.CharactersStatsFunc(ctx, charIDs)
+	}
+	return nil, nil
+}
+
+func (m *MockCatalogStore) GetMedia(ctx context.Context, mediaID int64) (catalog.Media, error) {
+	if m.GetMediaFunc != nil {
+		return m.GetMediaFunc(ctx, mediaID)
+	}
+	return catalog.Media{}, collection.ErrNotFound
+}
+
+func (m *MockCatalogStore) MediaByMalID(ctx context.Context, malID int64, mediaType string) (catalog.Media, error) {
+	if m.MediaByMalIDFunc != nil {
+		return m.MediaByMalIDFunc(ctx, malID, mediaType)
+	}
+	return catalog.Media{}, collection.ErrNotFound
+}
+
+func (m *MockCatalogStore) SearchMedia(ctx context.Context, term string) ([]catalog.Media, error) {
+	if m.SearchMediaFunc != nil {
+		return m.SearchMediaFunc(ctx, term)
+	}
+	return nil, nil
+}
+
+func (m *MockCatalogStore) CharactersByMedia(ctx context.Context, mediaID int64) ([]catalog.Character, error) {
+	if m.CharactersByMediaFunc != nil {
+		return m.CharactersByMediaFunc(ctx, mediaID)
+	}
+	return nil, nil
+}
+
+func (m *MockCatalogStore) MediaByCharacter(ctx context.Context, charID int64) ([]catalog.Media, error) {
+	if m.MediaByCharacterFunc != nil {
+		return m.MediaByCharacterFunc(ctx, charID)
+	}
+	return nil, nil
+}
@@ -0,0 +1,56 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/Karitham/corde"
+
+	"github.com/karitham/waifubot/collection"
+)
+
+// DailyHandler handles the /daily command.
+type DailyHandler struct {
+	store  collection.Store
+	config collection.DailyConfig
+}
+
+// Daily pays the user's daily reward.
+func (h *DailyHandler) Daily(ctx context.Context, w corde.ResponseWriter, cmd CommandContext) {
+	reward, err := collection.ClaimDaily(ctx, h.store, cmd.UserID(), h.config)
+
+	var cd collection.ErrDailyCooldown
+	switch {
+	case errors.As(err, &cd):
+		w.Respond(rspErr(cd.Error()))
+		return
+	case err != nil:
+		slog.Error("error claiming daily reward", "error", err, "user_id", cmd.UserID())
+		w.Respond(rspErr("An error occurred, please try again later"))
+		return
+	}
+
+	w.Respond(corde.NewResp().Embeds(dailyEmbed(reward)))
+}
+
+func dailyEmbed(r collection.DailyReward) corde.Embed {
+	var sb strings.Builder
+	if r.StreakLost {
+		sb.WriteString("Your previous streak ran out, starting over.\n")
+	}
+	fmt.Fprintf(&sb, "You got **%d** tokens", r.Tokens)
+	if r.Bonus > 0 {
+		fmt.Fprintf(&sb, " and a **%d** token bonus for a %d day streak", r.Bonus, r.Streak)
+	}
+	fmt.Fprintf(&sb, ". You now have %d tokens.\n\n", r.Balance)
+	fmt.Fprintf(&sb, "Come back <t:%d:R>, before <t:%d:f> to keep your streak.", r.Next.Unix(), r.ExpiresAt.Unix())
+
+	return corde.NewEmbed().
+		Title(fmt.Sprintf("Daily reward, day %d", r.Streak)).
+		Description(sb.String()).
+		Color(AnilistColor).
+		Embed()
+}
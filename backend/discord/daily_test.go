@@ -0,0 +1,63 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/collection/collectiontest"
+	"github.com/karitham/waifubot/discord/cordetest"
+)
+
+func TestDailyHandler_Daily(t *testing.T) {
+	tests := []struct {
+		name        string
+		store       *collectiontest.MockStore
+		wantContent string
+	}{
+		{
+			name: "success",
+			store: &collectiontest.MockStore{
+				GetUserFunc: func(ctx context.Context, userID collection.UserID) (collection.User, error) {
+					return collection.User{UserID: userID, DailyStreak: 6, LastDaily: time.Now().Add(-25 * time.Hour)}, nil
+				},
+			},
+			wantContent: "a **5** token bonus for a 7 day streak",
+		},
+		{
+			name: "cooldown",
+			store: &collectiontest.MockStore{
+				GetUserFunc: func(ctx context.Context, userID collection.UserID) (collection.User, error) {
+					return collection.User{UserID: userID, DailyStreak: 1, LastDaily: time.Now()}, nil
+				},
+			},
+			wantContent: "You can claim your daily reward again in",
+		},
+		{
+			name: "store error",
+			store: &collectiontest.MockStore{
+				GetUserFunc: func(ctx context.Context, userID collection.UserID) (collection.User, error) {
+					return collection.User{}, errors.New("database on fire")
+				},
+			},
+			wantContent: "An error occurred",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &cordetest.MockResponseWriter{}
+			cmd := &MockCommandContext{UserIDVal: 1}
+			h := &DailyHandler{store: tt.store, config: collection.DefaultDailyConfig()}
+
+			h.Daily(t.Context(), w, cmd)
+
+			assert.True(t, w.RespondCalled)
+			w.AssertContains(t, tt.wantContent)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/Karitham/corde"
 
@@ -34,6 +35,8 @@ func (r *Router) drop(ctx context.Context, channelID corde.Snowflake) {
 		ImageURL:   char.ImageURL,
 		MediaTitle: char.MediaTitle,
 		Favorites:  char.Favorites,
+		Aliases:    catChar.Aliases,
+		ExpiresAt:  time.Now().Add(r.DropLifetime),
 	})
 	if err != nil {
 		logger.Error("failed to set channel character", "error", err, "character_id", char.ID, "character_name", char.Name)
@@ -49,9 +52,60 @@ func (r *Router) drop(ctx context.Context, channelID corde.Snowflake) {
 	}
 	defer img.Close()
 
-	_, err = r.mux.CreateMessage(channelID, dropMessage(char, img))
+	msg, err := r.mux.CreateMessage(channelID, dropMessage(char, img))
 	if err != nil {
 		logger.Error("failed to create drop message", "error", err, "character_id", char.ID, "character_name", char.Name)
 		return
 	}
+
+	if err := r.DropStore.SetMessage(ctx, channelID, char.ID, msg.ID); err != nil {
+		logger.Error("failed to record drop message", "error", err, "character_id", char.ID, "message_id", msg.ID)
+	}
+}
+
+// reapBatchSize bounds how many expired drops are revealed per tick.
+const reapBatchSize = 100
+
+// RunDropReaper removes expired drops every interval and edits their message
+// to reveal the character nobody claimed. It blocks until ctx is done.
+func (r *Router) RunDropReaper(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+
+		r.reapDrops(ctx, time.Now())
+	}
+}
+
+// reapDrops deletes the drops expired at now and reveals them.
+func (r *Router) reapDrops(ctx context.Context, now time.Time) {
+	drops, err := r.DropStore.Expired(ctx, now, reapBatchSize)
+	if err != nil {
+		slog.Error("error listing expired drops", "error", err)
+		return
+	}
+
+	for _, d := range drops {
+		logger := slog.With("channel_id", uint64(d.ChannelID), "character_id", d.ID)
+
+		deleted, err := r.DropStore.DeleteExpired(ctx, d.ChannelID, now)
+		if err != nil {
+			logger.Error("failed to delete expired drop", "error", err)
+			continue
+		}
+		// Claimed or replaced since we listed it; nothing to reveal.
+		if !deleted || d.MessageID == 0 {
+			continue
+		}
+
+		if err := r.client.EditMessageEmbeds(ctx, d.ChannelID, d.MessageID, revealEmbed(d.Drop)); err != nil {
+			logger.Error("failed to reveal expired drop", "error", err, "message_id", uint64(d.MessageID))
+		}
+	}
 }
@@ -0,0 +1,72 @@
+package discord
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Karitham/corde"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/karitham/waifubot/storage/dropstore"
+)
+
+type fakeDropStore struct {
+	dropstore.Store
+	expired []dropstore.ExpiredDrop
+	claimed map[corde.Snowflake]bool
+	deleted []corde.Snowflake
+
+	drop       dropstore.Drop
+	misses     int
+	hintsShown int
+}
+
+func (f *fakeDropStore) RecordMiss(_ context.Context, _ corde.Snowflake) (dropstore.Miss, error) {
+	f.misses++
+	return dropstore.Miss{Drop: f.drop, Attempts: f.misses, HintsShown: f.hintsShown}, nil
+}
+
+func (f *fakeDropStore) AdvanceHint(_ context.Context, _ corde.Snowflake, level int) (bool, error) {
+	if f.hintsShown >= level {
+		return false, nil
+	}
+	f.hintsShown = level
+	return true, nil
+}
+
+func (f *fakeDropStore) Expired(_ context.Context, now time.Time, _ int32) ([]dropstore.ExpiredDrop, error) {
+	var out []dropstore.ExpiredDrop
+	for _, d := range f.expired {
+		if !d.ExpiresAt.After(now) {
+			out = append(out, d)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeDropStore) DeleteExpired(_ context.Context, id corde.Snowflake, _ time.Time) (bool, error) {
+	if f.claimed[id] {
+		return false, nil
+	}
+	f.deleted = append(f.deleted, id)
+	return true, nil
+}
+
+func TestReapDrops(t *testing.T) {
+	now := time.Now()
+	store := &fakeDropStore{
+		expired: []dropstore.ExpiredDrop{
+			{ChannelID: 1, Drop: dropstore.Drop{ID: 10, ExpiresAt: now.Add(-time.Minute)}},
+			{ChannelID: 2, Drop: dropstore.Drop{ID: 20, ExpiresAt: now.Add(-time.Second)}},
+			{ChannelID: 3, Drop: dropstore.Drop{ID: 30, ExpiresAt: now.Add(time.Minute)}},
+		},
+		claimed: map[corde.Snowflake]bool{2: true},
+	}
+
+	// None of the drops recorded a message, so nothing is edited.
+	r := &Router{DropStore: store}
+	r.reapDrops(t.Context(), now)
+
+	assert.Equal(t, []corde.Snowflake{1}, store.deleted)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/Karitham/corde"
 
 	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/storage/dropstore"
 )
 
 func rollEmbed(char collection.MediaCharacter, usersWanting string) corde.Embed {
@@ -71,6 +72,20 @@ func dropMessage(char collection.MediaCharacter, image io.Reader) corde.Message
 	return msg
 }
 
+// revealEmbed replaces a drop message once nobody claimed the character in time.
+// The image still points at the attachment uploaded with the original drop.
+func revealEmbed(d dropstore.Drop) corde.Embed {
+	char := collection.MediaCharacter{Name: d.Name, MediaTitle: d.MediaTitle, Favorites: d.Favorites}
+	return corde.Embed{
+		Title:       "Drop expired",
+		Description: fmt.Sprintf("Nobody claimed this one in time. It was **%s** from *%s*.\n\n⭐ Rarity: %s", d.Name, d.MediaTitle, char.Rarity()),
+		URL:         fmt.Sprintf("https://anilist.co/character/%d", d.ID),
+		Image:       corde.Image{URL: "attachment://image.png"},
+		Footer:      corde.Footer{IconURL: AnilistIconURL, Text: "View on Anilist"},
+		Color:       collection.GradientColor(d.Favorites),
+	}
+}
+
 func claimEmbed(char collection.Character, rarity string) corde.Embed {
 	return corde.NewEmbed().
 		Title(char.Name).
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/storage/dropstore"
 )
 
 func TestRollEmbed(t *testing.T) {
@@ -289,3 +290,12 @@ func (e *errorHTTPClient) Do(req *http.Request) (*http.Response, error) {
 		Body:       io.NopCloser(strings.NewReader("")),
 	}, nil
 }
+
+func TestRevealEmbed(t *testing.T) {
+	e := revealEmbed(dropstore.Drop{ID: 42, Name: "Sakura Haruno", MediaTitle: "Naruto", Favorites: 1000})
+
+	assert.Equal(t, "Drop expired", e.Title)
+	assert.Contains(t, e.Description, "**Sakura Haruno** from *Naruto*")
+	assert.Equal(t, "https://anilist.co/character/42", e.URL)
+	assert.Equal(t, "attachment://image.png", e.Image.URL)
+}
@@ -72,7 +72,9 @@ func (h *GiveHandler) Give(ctx context.Context, w corde.ResponseWriter, cmd Comm
 		return
 	}
 
-	w.Respond(corde.NewResp().Contentf("Gave %s (%d) to %s", char.Name, opts.charID, opts.recipient.Username))
+	w.Respond(corde.NewResp().
+		Contentf("Gave %s (%d) to %s", char.Name, opts.charID, opts.recipient.Username).
+		Embeds(unlockedAchievements(ctx, h.store, opts.recipientID, collection.EventGive, char.Character)...))
 }
 
 // Autocomplete provides character suggestions for the give command.
@@ -0,0 +1,93 @@
+package discord
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"unicode"
+
+	"github.com/Karitham/corde"
+
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/storage/dropstore"
+)
+
+// hintEmbed renders the hint unlocked at level, out of levels hints in total.
+// The first hint gives the media, the second the initials, and every later one
+// fills more letters into the masked name.
+func hintEmbed(d dropstore.Drop, attempts, level, levels int) corde.Embed {
+	var hint string
+	switch {
+	case level == 1 && d.MediaTitle != "":
+		hint = fmt.Sprintf("They're from *%s*.", d.MediaTitle)
+	case level <= 2:
+		hint = fmt.Sprintf("Their initials are `%s`.", maskName(d.Name, d.ID, 0))
+	default:
+		hint = fmt.Sprintf("Their name is `%s`.", maskName(d.Name, d.ID, revealFraction(level, levels)))
+	}
+
+	return corde.Embed{
+		Title:       fmt.Sprintf("Wrong name! Hint %d/%d", level, levels),
+		Description: fmt.Sprintf("Nobody got it after %d tries. %s", attempts, hint),
+		Color:       collection.GradientColor(d.Favorites),
+	}
+}
+
+// revealFraction is the share of non-initial letters shown by a masked-name hint.
+// The last hint shows at most two thirds of them.
+func revealFraction(level, levels int) float64 {
+	masked := levels - 2
+	if masked <= 0 {
+		return 0
+	}
+	return float64(level-2) / float64(masked) * 2 / 3
+}
+
+// maskName hides the letters of name except each word's initial and a reveal
+// fraction of the others. Letters are revealed in an order seeded by the
+// character ID, so later hints only ever add letters to earlier ones.
+// At least one letter always stays hidden when there is one to hide.
+func maskName(name string, id int64, reveal float64) string {
+	words := strings.Fields(name)
+
+	var hidden []int // rune offsets into the joined name
+	runes := []rune(strings.Join(words, " "))
+	start := true
+	for i, r := range runes {
+		if r == ' ' {
+			start = true
+			continue
+		}
+		if !start && unicode.IsLetter(r) {
+			hidden = append(hidden, i)
+		}
+		start = false
+	}
+
+	masked := make(map[int]bool, len(hidden))
+	for _, i := range hidden {
+		masked[i] = true
+	}
+
+	n := min(int(float64(len(hidden))*reveal), len(hidden)-1)
+	rng := rand.New(rand.NewPCG(uint64(id), 0))
+	for _, p := range rng.Perm(len(hidden))[:max(n, 0)] {
+		delete(masked, hidden[p])
+	}
+
+	var sb strings.Builder
+	for i, r := range runes {
+		if i > 0 {
+			sb.WriteRune(' ')
+		}
+		switch {
+		case r == ' ':
+			sb.WriteRune(' ')
+		case masked[i]:
+			sb.WriteRune('_')
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
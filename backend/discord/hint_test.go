@@ -0,0 +1,61 @@
+package discord
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/karitham/waifubot/storage/dropstore"
+)
+
+func TestMaskName(t *testing.T) {
+	assert.Equal(t, "L _ _ _   A _ _ _ _ _ _ _", maskName("Levi Ackerman", 1, 0))
+	assert.Equal(t, "M _ _ _ _ _   D .   L _ _ _ _", maskName("Monkey  D. Luffy", 1, 0))
+	assert.Equal(t, "L", maskName("L", 1, 1), "nothing to hide")
+
+	full := maskName("Levi Ackerman", 1, 1)
+	assert.Equal(t, 1, strings.Count(full, "_"), "one letter always stays hidden")
+
+	// Later hints only reveal more letters.
+	prev := maskName("Lelouch Lamperouge", 7, 0)
+	for _, reveal := range []float64{0.2, 0.4, 0.6} {
+		next := maskName("Lelouch Lamperouge", 7, reveal)
+		assert.Less(t, strings.Count(next, "_"), strings.Count(prev, "_"))
+		for i, r := range []rune(next) {
+			if p := []rune(prev)[i]; p != '_' {
+				assert.Equal(t, p, r)
+			}
+		}
+		prev = next
+	}
+}
+
+func TestHintEmbed(t *testing.T) {
+	d := dropstore.Drop{ID: 7, Name: "Lelouch Lamperouge", MediaTitle: "Code Geass"}
+	levels := len(dropstore.DefaultHintThresholds)
+
+	e := hintEmbed(d, 3, 1, levels)
+	assert.Equal(t, "Wrong name! Hint 1/4", e.Title)
+	assert.Contains(t, e.Description, "after 3 tries")
+	assert.Contains(t, e.Description, "*Code Geass*")
+
+	e = hintEmbed(d, 6, 2, levels)
+	assert.Contains(t, e.Description, "`L _ _ _ _ _ _   L _ _ _ _ _ _ _ _ _`")
+
+	e = hintEmbed(d, 12, 4, levels)
+	assert.Contains(t, e.Description, "Their name is")
+	assert.NotContains(t, e.Description, "Lelouch Lamperouge")
+
+	e = hintEmbed(dropstore.Drop{Name: "Rem"}, 3, 1, levels)
+	assert.Contains(t, e.Description, "initials", "falls back to initials without a media title")
+}
+
+func TestHintLevel(t *testing.T) {
+	thresholds := []int{3, 6, 9}
+	assert.Equal(t, 0, dropstore.HintLevel(2, thresholds))
+	assert.Equal(t, 1, dropstore.HintLevel(3, thresholds))
+	assert.Equal(t, 2, dropstore.HintLevel(8, thresholds))
+	assert.Equal(t, 3, dropstore.HintLevel(100, thresholds))
+	assert.Equal(t, 0, dropstore.HintLevel(100, nil))
+}
@@ -0,0 +1,110 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/Karitham/corde"
+
+	"github.com/karitham/waifubot/collection"
+)
+
+// historyLimit is how many ownership events /history shows.
+const historyLimit = 15
+
+// HistoryHandler handles the /history command and its autocomplete.
+type HistoryHandler struct {
+	store collection.Store
+}
+
+// Register wires the history sub-routes on the mux.
+func (h *HistoryHandler) Register(m *corde.Mux) {
+	m.SlashCommand("", wrap(wrapCtx(h.History), trace[corde.SlashCommandInteractionData]))
+	m.Autocomplete("id", h.Autocomplete)
+}
+
+// History shows who owned a character and how it changed hands.
+func (h *HistoryHandler) History(ctx context.Context, w corde.ResponseWriter, cmd CommandContext) {
+	charID, err := cmd.OptInt("id")
+	if err != nil {
+		w.Respond(rspErr("select a character to look up"))
+		return
+	}
+
+	char, events, err := collection.CharacterHistory(ctx, h.store, int64(charID), historyLimit)
+	if err != nil {
+		if errors.Is(err, collection.ErrNotFound) {
+			w.Respond(newErrf("Character %d not found", charID))
+			return
+		}
+		slog.Error("error getting character history", "error", err, "char_id", charID)
+		w.Respond(rspErr("Failed to get character history"))
+		return
+	}
+
+	w.Respond(corde.NewResp().Embeds(historyEmbed(char, events)).Ephemeral())
+}
+
+// Autocomplete provides character suggestions for the history command.
+func (h *HistoryHandler) Autocomplete(ctx context.Context, w corde.ResponseWriter, i *corde.Interaction[corde.AutocompleteInteractionData]) {
+	autocomplete(ctx, w, i, "id", h.store.SearchGlobalCharacters, formatCharacterChoice)
+}
+
+func historyEmbed(char collection.Character, events []collection.OwnershipEvent) corde.Embed {
+	var sb strings.Builder
+	if len(events) == 0 {
+		sb.WriteString("No recorded ownership changes yet.")
+	}
+	for _, e := range events {
+		fmt.Fprintf(&sb, "<t:%d:d> %s\n", e.CreatedAt.Unix(), describeEvent(e))
+	}
+
+	return corde.NewEmbed().
+		Title(fmt.Sprintf("History of %s", char.Name)).
+		URL(fmt.Sprintf("https://anilist.co/character/%d", char.ID)).
+		Thumbnail(corde.Image{URL: char.Image}).
+		Description(sb.String()).
+		Color(collection.GradientColor(char.Favorites)).
+		Embed()
+}
+
+// describeEvent renders one ledger entry as a line of text.
+func describeEvent(e collection.OwnershipEvent) string {
+	switch e.Kind {
+	case collection.EventRoll:
+		return fmt.Sprintf("rolled by <@%d>", e.ToUserID)
+	case collection.EventClaim:
+		return fmt.Sprintf("claimed by <@%d>", e.ToUserID)
+	case collection.EventSeriesRoll:
+		return fmt.Sprintf("series rolled by <@%d> for %s", e.ToUserID, tokensText(e.Tokens))
+	case collection.EventGive:
+		return fmt.Sprintf("given by <@%d> to <@%d>", e.FromUserID, e.ToUserID)
+	case collection.EventSell:
+		return fmt.Sprintf("sold by <@%d> for %s", e.FromUserID, tokensText(e.Tokens))
+	case collection.EventTrade:
+		return fmt.Sprintf("traded by <@%d> to <@%d> (trade #%d)", e.FromUserID, e.ToUserID, e.ReferenceID)
+	case collection.EventAuction:
+		return fmt.Sprintf("auctioned by <@%d> to <@%d> for %s (auction #%d)", e.FromUserID, e.ToUserID, tokensText(e.Tokens), e.ReferenceID)
+	case collection.EventAdmin:
+		return fmt.Sprintf("moved by an admin from %s to %s", mentionOrNone(e.FromUserID), mentionOrNone(e.ToUserID))
+	default:
+		return string(e.Kind)
+	}
+}
+
+func mentionOrNone(userID collection.UserID) string {
+	if userID == 0 {
+		return "nobody"
+	}
+	return fmt.Sprintf("<@%d>", userID)
+}
+
+func tokensText(n int32) string {
+	if n == 1 {
+		return "1 token"
+	}
+	return fmt.Sprintf("%d tokens", n)
+}
@@ -0,0 +1,102 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/karitham/waifubot/catalog"
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/collection/collectiontest"
+	"github.com/karitham/waifubot/discord/cordetest"
+)
+
+func TestHistoryHandler_History(t *testing.T) {
+	rem := func(_ context.Context, id int64) (catalog.Character, error) {
+		return catalog.Character{ID: id, Name: "Rem"}, nil
+	}
+
+	tests := []struct {
+		name        string
+		cmd         CommandContext
+		store       *collectiontest.MockStore
+		wantContent []string
+	}{
+		{
+			name:        "missing character option",
+			cmd:         &MockCommandContext{ErrVal: errors.New("option not found")},
+			store:       &collectiontest.MockStore{},
+			wantContent: []string{"select a character"},
+		},
+		{
+			name: "unknown character",
+			cmd:  &MockCommandContext{OptIntVals: map[string]int{"id": 42}},
+			store: &collectiontest.MockStore{
+				GetCharacterByIDFunc: func(context.Context, int64) (catalog.Character, error) {
+					return catalog.Character{}, collection.ErrNotFound
+				},
+			},
+			wantContent: []string{"Character 42 not found"},
+		},
+		{
+			name:        "no events",
+			cmd:         &MockCommandContext{OptIntVals: map[string]int{"id": 42}},
+			store:       &collectiontest.MockStore{GetCharacterByIDFunc: rem},
+			wantContent: []string{"History of Rem", "No recorded ownership changes"},
+		},
+		{
+			name: "events",
+			cmd:  &MockCommandContext{OptIntVals: map[string]int{"id": 42}},
+			store: &collectiontest.MockStore{
+				GetCharacterByIDFunc: rem,
+				ListOwnershipEventsFunc: func(context.Context, int64, int32) ([]collection.OwnershipEvent, error) {
+					now := time.Now()
+					return []collection.OwnershipEvent{
+						{Kind: collection.EventAuction, FromUserID: 2, ToUserID: 3, Tokens: 40, ReferenceID: 7, CreatedAt: now},
+						{Kind: collection.EventGive, FromUserID: 1, ToUserID: 2, CreatedAt: now},
+						{Kind: collection.EventClaim, ToUserID: 1, CreatedAt: now},
+					}, nil
+				},
+			},
+			wantContent: []string{
+				"auctioned by <@2> to <@3> for 40 tokens (auction #7)",
+				"given by <@1> to <@2>",
+				"claimed by <@1>",
+			},
+		},
+		{
+			name: "store error",
+			cmd:  &MockCommandContext{OptIntVals: map[string]int{"id": 42}},
+			store: &collectiontest.MockStore{
+				GetCharacterByIDFunc: rem,
+				ListOwnershipEventsFunc: func(context.Context, int64, int32) ([]collection.OwnershipEvent, error) {
+					return nil, errors.New("db down")
+				},
+			},
+			wantContent: []string{"Failed to get character history"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &cordetest.MockResponseWriter{}
+			h := &HistoryHandler{store: tt.store}
+
+			h.History(t.Context(), w, tt.cmd)
+
+			assert.True(t, w.RespondCalled)
+			for _, want := range tt.wantContent {
+				w.AssertContains(t, want)
+			}
+		})
+	}
+}
+
+func TestDescribeEvent(t *testing.T) {
+	assert.Equal(t, "sold by <@1> for 1 token", describeEvent(collection.OwnershipEvent{Kind: collection.EventSell, FromUserID: 1, Tokens: 1}))
+	assert.Equal(t, "traded by <@1> to <@2> (trade #9)", describeEvent(collection.OwnershipEvent{Kind: collection.EventTrade, FromUserID: 1, ToUserID: 2, ReferenceID: 9}))
+	assert.Equal(t, "moved by an admin from <@1> to <@2>", describeEvent(collection.OwnershipEvent{Kind: collection.EventAdmin, FromUserID: 1, ToUserID: 2}))
+}
@@ -0,0 +1,99 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/Karitham/corde"
+
+	"github.com/karitham/waifubot/guild"
+	"github.com/karitham/waifubot/leaderboard"
+)
+
+// LeaderboardColor is the embed color of /leaderboard.
+const LeaderboardColor = 0xf1c40f
+
+// LeaderboardHandler handles the /leaderboard command.
+type LeaderboardHandler struct {
+	boards       *leaderboard.Service
+	guildIndexer *guild.Indexer
+	guildTxFn    func(context.Context) (guild.TxQuerier, error)
+}
+
+// Register wires the leaderboard sub-routes on the mux.
+func (h *LeaderboardHandler) Register(m *corde.Mux) {
+	m.SlashCommand("", wrap(
+		wrapCtx(h.Leaderboard),
+		indexMiddleware[corde.SlashCommandInteractionData](h.guildIndexer, h.guildTxFn),
+		trace[corde.SlashCommandInteractionData],
+	))
+}
+
+// Leaderboard shows the top players of a category, with the caller's own rank.
+func (h *LeaderboardHandler) Leaderboard(ctx context.Context, w corde.ResponseWriter, cmd CommandContext) {
+	name, err := cmd.OptString("category")
+	if err != nil {
+		w.Respond(rspErr("select a leaderboard"))
+		return
+	}
+	category, err := leaderboard.ParseCategory(name)
+	if err != nil {
+		w.Respond(newErrf("Unknown leaderboard %q", name))
+		return
+	}
+
+	var guildID uint64
+	if scope, _ := cmd.OptString("scope"); scope == "server" {
+		guildID = cmd.GuildID()
+	}
+
+	board, err := h.boards.Top(ctx, category, guildID, leaderboard.DefaultLimit)
+	if err != nil {
+		if errors.Is(err, leaderboard.ErrUnknownCategory) {
+			w.Respond(newErrf("Unknown leaderboard %q", name))
+			return
+		}
+		slog.Error("error getting leaderboard", "error", err, "category", category, "guild_id", guildID)
+		w.Respond(rspErr("Failed to get the leaderboard"))
+		return
+	}
+
+	own, err := h.boards.Rank(ctx, category, guildID, cmd.UserID())
+	if err != nil {
+		slog.Warn("error getting leaderboard rank", "error", err, "category", category, "user_id", cmd.UserID())
+	}
+
+	w.Respond(corde.NewResp().Embeds(leaderboardEmbed(board, own)))
+}
+
+func leaderboardEmbed(board leaderboard.Board, own leaderboard.Entry) corde.Embed {
+	scope := "Global"
+	if board.GuildID != 0 {
+		scope = "Server"
+	}
+
+	var sb strings.Builder
+	if len(board.Entries) == 0 {
+		sb.WriteString("Nobody is on this leaderboard yet.")
+	}
+	for _, e := range board.Entries {
+		fmt.Fprintf(&sb, "**#%d** <@%d> · %d\n", e.Rank, e.UserID, e.Value)
+	}
+	if own.Rank > 0 {
+		fmt.Fprintf(&sb, "\nYou are **#%d** with %d.", own.Rank, own.Value)
+	} else {
+		sb.WriteString("\nYou are not on this leaderboard yet.")
+	}
+	if !board.RefreshedAt.IsZero() {
+		fmt.Fprintf(&sb, "\nUpdated <t:%d:R>.", board.RefreshedAt.Unix())
+	}
+
+	return corde.NewEmbed().
+		Title(fmt.Sprintf("%s leaderboard: %s", scope, board.Category.Title())).
+		Description(sb.String()).
+		Color(LeaderboardColor).
+		Embed()
+}
@@ -0,0 +1,99 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/discord/cordetest"
+	"github.com/karitham/waifubot/leaderboard"
+)
+
+// boardStore serves fixed leaderboard entries and records the guild asked for.
+type boardStore struct {
+	entries []leaderboard.Entry
+	rank    leaderboard.Entry
+	err     error
+	guildID uint64
+}
+
+func (s *boardStore) Top(_ context.Context, _ leaderboard.Category, guildID uint64, _ int32) ([]leaderboard.Entry, error) {
+	s.guildID = guildID
+	return s.entries, s.err
+}
+
+func (s *boardStore) Rank(context.Context, leaderboard.Category, uint64, uint64) (leaderboard.Entry, error) {
+	if s.rank.Rank == 0 {
+		return leaderboard.Entry{}, collection.ErrNotFound
+	}
+	return s.rank, nil
+}
+
+func (s *boardStore) RefreshedAt(context.Context) (time.Time, error) {
+	return time.Unix(1700000000, 0), nil
+}
+
+func (s *boardStore) Refresh(context.Context) error { return nil }
+
+func TestLeaderboardHandler_Leaderboard(t *testing.T) {
+	top := []leaderboard.Entry{{Rank: 1, UserID: 10, Value: 120}, {Rank: 2, UserID: 11, Value: 90}}
+
+	tests := []struct {
+		name        string
+		cmd         *MockCommandContext
+		store       *boardStore
+		wantGuild   uint64
+		wantContent []string
+	}{
+		{
+			name:        "missing category",
+			cmd:         &MockCommandContext{ErrVal: errors.New("option not found")},
+			store:       &boardStore{},
+			wantContent: []string{"select a leaderboard"},
+		},
+		{
+			name:        "unknown category",
+			cmd:         &MockCommandContext{OptStringVals: map[string]string{"category": "height"}},
+			store:       &boardStore{},
+			wantContent: []string{`Unknown leaderboard "height"`},
+		},
+		{
+			name:        "global",
+			cmd:         &MockCommandContext{UserIDVal: 11, GuildIDVal: 5, OptStringVals: map[string]string{"category": "rarity"}},
+			store:       &boardStore{entries: top, rank: leaderboard.Entry{Rank: 2, Value: 90}},
+			wantContent: []string{"Global leaderboard: Rarity score", "**#1** <@10> · 120", "You are **#2** with 90", "<t:1700000000:R>"},
+		},
+		{
+			name:        "server",
+			cmd:         &MockCommandContext{UserIDVal: 12, GuildIDVal: 5, OptStringVals: map[string]string{"category": "series", "scope": "server"}},
+			store:       &boardStore{},
+			wantGuild:   5,
+			wantContent: []string{"Server leaderboard: Series completed", "Nobody is on this leaderboard yet", "You are not on this leaderboard yet"},
+		},
+		{
+			name:        "store error",
+			cmd:         &MockCommandContext{OptStringVals: map[string]string{"category": "tokens"}},
+			store:       &boardStore{err: errors.New("db down")},
+			wantContent: []string{"Failed to get the leaderboard"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &cordetest.MockResponseWriter{}
+			h := &LeaderboardHandler{boards: leaderboard.NewService(tt.store)}
+
+			h.Leaderboard(t.Context(), w, tt.cmd)
+
+			assert.True(t, w.RespondCalled)
+			assert.Equal(t, tt.wantGuild, tt.store.guildID)
+			for _, want := range tt.wantContent {
+				w.AssertContains(t, want)
+			}
+		})
+	}
+}
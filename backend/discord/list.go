@@ -1,15 +1,43 @@
 package discord
 
 import (
+	"cmp"
 	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"unicode/utf8"
 
 	"github.com/Karitham/corde"
 
 	"github.com/karitham/waifubot/collection"
 )
 
-// ListHandler handles the /list command.
+const (
+	// listPageSize is how many characters a /list page shows (embed fields, 3 per row).
+	listPageSize = 18
+	// listMediaFilterMax bounds the media filter so it fits in a custom ID.
+	listMediaFilterMax = 40
+)
+
+// list component actions, routed under /list.
+// Prev and next are buttons, the others select menus.
+const (
+	listActionPrev   = "prev"
+	listActionNext   = "next"
+	listActionSort   = "sort"
+	listActionRarity = "rarity"
+	listActionMedia  = "media"
+
+	// listMediaAll is the value of the media menu option showing every media.
+	listMediaAll = emptySegment
+)
+
+// ListHandler handles the /list command and its pagination components.
 type ListHandler struct {
 	store collection.Store
 }
@@ -32,7 +60,19 @@ func parseListOptions(cmd CommandContext) listOptions {
 	return listOptions{}
 }
 
-// List displays a user's character collection.
+// RegisterComponents wires the list component sub-routes on the mux.
+// The slash command itself is mounted by the router alongside its middleware.
+func (h *ListHandler) RegisterComponents(m *corde.Mux) {
+	for _, action := range []string{listActionPrev, listActionNext} {
+		m.ButtonComponent(action, wrap(h.Button, trace[corde.ButtonInteractionData]))
+	}
+	// corde hands select menu interactions over as modal data, see selectMenu.
+	for _, action := range []string{listActionSort, listActionRarity, listActionMedia} {
+		m.Mount(corde.SelectMenuInteraction, action, wrap(h.Select, trace[corde.ModalInteractionData]))
+	}
+}
+
+// List displays the first page of a user's character collection.
 func (h *ListHandler) List(ctx context.Context, w corde.ResponseWriter, cmd CommandContext) {
 	opts := parseListOptions(cmd)
 
@@ -56,18 +96,302 @@ func (h *ListHandler) List(ctx context.Context, w corde.ResponseWriter, cmd Comm
 		return
 	}
 
-	embed := corde.NewEmbed().
+	header := corde.NewEmbed().
 		Titlef("%s's List", username).
 		Thumbnail(corde.Image{URL: avatar}).
-		URL(fmt.Sprintf("https://waifugui.karitham.dev/#/list/%d", userID))
+		URL(fmt.Sprintf("https://waifugui.karitham.dev/#/list/%d", userID)).
+		Embed()
+
+	st := listState{userID: userID, sort: collection.SortByDate, rarity: -1}
+	w.Respond(listPage(header, chars, st).Ephemeral())
+}
 
-	if len(chars) > 18 {
-		chars = chars[:18]
+// Button handles the pagination buttons of a /list message.
+func (h *ListHandler) Button(ctx context.Context, w corde.ResponseWriter, i *corde.Interaction[corde.ButtonInteractionData]) {
+	action, st, err := parseListComponentID(i.Data.CustomID)
+	if err != nil {
+		slog.Debug("invalid list component", "custom_id", i.Data.CustomID, "error", err)
+		w.Respond(rspErr("This list is outdated, run /list again"))
+		return
 	}
 
+	switch action {
+	case listActionPrev:
+		st.page--
+	case listActionNext:
+		st.page++
+	}
+
+	h.update(ctx, w, i.Message, st)
+}
+
+// Select applies the sort or filter picked in one of the select menus of a /list message.
+func (h *ListHandler) Select(ctx context.Context, w corde.ResponseWriter, i *corde.Interaction[corde.ModalInteractionData]) {
+	action, st, err := parseListComponentID(i.Data.CustomID)
+	if err == nil {
+		st, err = st.pick(action, selectValues(ctx))
+	}
+	if err != nil {
+		slog.Debug("invalid list selection", "custom_id", i.Data.CustomID, "error", err)
+		w.Respond(rspErr("This list is outdated, run /list again"))
+		return
+	}
+
+	h.update(ctx, w, i.Message, st)
+}
+
+// update re-renders a /list message in place, keeping its header embed.
+func (h *ListHandler) update(ctx context.Context, w corde.ResponseWriter, msg *corde.Message, st listState) {
+	chars, err := collection.Characters(ctx, h.store, st.userID)
+	if err != nil {
+		slog.Error("error listing collection", "error", err, "user_id", st.userID)
+		w.Respond(rspErr("An error occurred dialing the database, please try again later"))
+		return
+	}
+
+	var header corde.Embed
+	if msg != nil && len(msg.Embeds) > 0 {
+		header = corde.Embed{Title: msg.Embeds[0].Title, URL: msg.Embeds[0].URL, Thumbnail: msg.Embeds[0].Thumbnail}
+	}
+
+	w.Update(listPage(header, chars, st))
+}
+
+// listPage filters, sorts and pages chars according to st and renders the page with its controls.
+func listPage(header corde.Embed, chars []collection.OwnedCharacter, st listState) *corde.RespB {
+	media := listMediaOptions(chars, st.media)
+	chars = collection.FilterCharacters(chars, st.filter())
+	collection.SortCharacters(chars, st.sort)
+
+	pages := max(1, (len(chars)+listPageSize-1)/listPageSize)
+	st.page = min(max(st.page, 0), pages-1)
+
+	embed := header
+	embed.Fields = []corde.Field{}
+	embed.Footer = corde.Footer{Text: fmt.Sprintf("Page %d/%d · %d characters%s", st.page+1, pages, len(chars), st.describeFilter())}
+	if len(chars) == 0 {
+		embed.Description = "No characters match these filters"
+	}
+
+	start := st.page * listPageSize
+	for _, c := range chars[start:min(start+listPageSize, len(chars))] {
+		embed.Fields = append(embed.Fields, corde.Field{
+			Name:   c.Name,
+			Value:  fmt.Sprintf("%d — %s", c.ID, c.Date.Format("02/01")),
+			Inline: true,
+		})
+	}
+
+	state := st.encode()
+	return corde.NewResp().Embeds(embed).
+		ActionRow(
+			button(componentID("list/"+listActionPrev, state...), "◀ Prev", corde.BUTTON_SECONDARY, st.page == 0),
+			button(componentID("list/"+listActionNext, state...), "Next ▶", corde.BUTTON_SECONDARY, st.page >= pages-1),
+		).
+		ActionRow(selectMenu{
+			CustomID:    componentID("list/"+listActionSort, state...),
+			Placeholder: "Sort by",
+			Options:     listSortOptions(st.sort),
+		}.Component()).
+		ActionRow(selectMenu{
+			CustomID:    componentID("list/"+listActionRarity, state...),
+			Placeholder: "Filter by rarity",
+			Options:     listRarityOptions(st.rarity),
+		}.Component()).
+		ActionRow(selectMenu{
+			CustomID:    componentID("list/"+listActionMedia, state...),
+			Placeholder: "Filter by media",
+			Options:     media,
+		}.Component())
+}
+
+// listState is the view state of a /list message, carried in its component custom IDs.
+type listState struct {
+	userID uint64
+	page   int
+	sort   collection.CollectionSort
+	rarity int // a collection.RarityTier, or -1 for any
+	media  string
+}
+
+func (s listState) filter() collection.CollectionFilter {
+	f := collection.CollectionFilter{Media: s.media}
+	if s.rarity >= 0 {
+		r := collection.RarityTier(s.rarity)
+		f.Rarity = &r
+	}
+	return f
+}
+
+func (s listState) describeFilter() string {
+	if s.media == "" {
+		return ""
+	}
+	return fmt.Sprintf(" · media: %q", s.media)
+}
+
+var listSortCodes = map[collection.CollectionSort]string{
+	collection.SortByDate:   "d",
+	collection.SortByRarity: "r",
+	collection.SortByMedia:  "m",
+}
+
+// encode returns the state as custom ID segments: user, page, sort, rarity, media.
+// The media filter is base64 encoded so user input can't inject separators.
+func (s listState) encode() []string {
+	return []string{
+		strconv.FormatUint(s.userID, 10),
+		strconv.Itoa(s.page),
+		listSortCodes[s.sort],
+		strconv.Itoa(s.rarity),
+		base64.RawURLEncoding.EncodeToString([]byte(s.media)),
+	}
+}
+
+// pick returns the state with the value picked in the select menu of action, back on the first page.
+func (s listState) pick(action string, values []string) (listState, error) {
+	if len(values) != 1 {
+		return s, fmt.Errorf("expected one value, got %d", len(values))
+	}
+
+	var err error
+	switch action {
+	case listActionSort:
+		s.sort, err = parseListSort(values[0])
+	case listActionRarity:
+		s.rarity, err = parseListRarity(values[0])
+	case listActionMedia:
+		s.media = ""
+		if values[0] != listMediaAll {
+			s.media = truncateBytes(values[0], listMediaFilterMax)
+		}
+	default:
+		err = fmt.Errorf("%q is not a select menu", action)
+	}
+	s.page = 0
+	return s, err
+}
+
+func parseListComponentID(customID string) (string, listState, error) {
+	for _, action := range []string{listActionPrev, listActionNext, listActionSort, listActionRarity, listActionMedia} {
+		state, ok := componentState(customID, "list/"+action)
+		if !ok {
+			continue
+		}
+		st, err := decodeListState(state)
+		return action, st, err
+	}
+	return "", listState{}, errors.New("unknown list action")
+}
+
+func decodeListState(parts []string) (listState, error) {
+	if len(parts) != 5 {
+		return listState{}, fmt.Errorf("expected 5 state segments, got %d", len(parts))
+	}
+
+	userID, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return listState{}, fmt.Errorf("invalid user: %w", err)
+	}
+	page, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return listState{}, fmt.Errorf("invalid page: %w", err)
+	}
+	sort, err := parseListSort(parts[2])
+	if err != nil {
+		return listState{}, err
+	}
+	rarity, err := parseListRarity(parts[3])
+	if err != nil {
+		return listState{}, err
+	}
+	media, err := base64.RawURLEncoding.DecodeString(parts[4])
+	if err != nil {
+		return listState{}, fmt.Errorf("invalid media filter: %w", err)
+	}
+
+	return listState{userID: userID, page: page, sort: sort, rarity: rarity, media: string(media)}, nil
+}
+
+func parseListSort(code string) (collection.CollectionSort, error) {
+	for s, c := range listSortCodes {
+		if c == code {
+			return s, nil
+		}
+	}
+	return "", fmt.Errorf("invalid sort %q", code)
+}
+
+func parseListRarity(v string) (int, error) {
+	rarity, err := strconv.Atoi(v)
+	if err != nil || rarity < -1 || rarity > int(collection.RarityLegendary) {
+		return 0, fmt.Errorf("invalid rarity %q", v)
+	}
+	return rarity, nil
+}
+
+func listSortLabel(s collection.CollectionSort) string {
+	switch s {
+	case collection.SortByRarity:
+		return "Rarity"
+	case collection.SortByMedia:
+		return "Media"
+	default:
+		return "Date"
+	}
+}
+
+func listSortOptions(current collection.CollectionSort) []selectOption {
+	options := make([]selectOption, 0, len(listSortCodes))
+	for _, s := range []collection.CollectionSort{collection.SortByDate, collection.SortByRarity, collection.SortByMedia} {
+		options = append(options, selectOption{Label: "Sort: " + listSortLabel(s), Value: listSortCodes[s], Default: s == current})
+	}
+	return options
+}
+
+func listRarityLabel(r int) string {
+	if r < 0 {
+		return "All"
+	}
+	return collection.RarityTier(r).String()
+}
+
+func listRarityOptions(current int) []selectOption {
+	options := make([]selectOption, 0, int(collection.RarityLegendary)+2)
+	for r := -1; r <= int(collection.RarityLegendary); r++ {
+		options = append(options, selectOption{Label: "Rarity: " + listRarityLabel(r), Value: strconv.Itoa(r), Default: r == current})
+	}
+	return options
+}
+
+// listMediaOptions offers the media the user has the most characters of, and every media.
+// Values are titles cut to fit the filter, which matches titles containing it.
+func listMediaOptions(chars []collection.OwnedCharacter, current string) []selectOption {
+	counts := map[string]int{}
 	for _, c := range chars {
-		embed.FieldInline(c.Name, fmt.Sprintf("%d — %s", c.ID, c.Date.Format("02/01")))
+		if title := truncateBytes(c.MediaTitle, listMediaFilterMax); title != "" && title != listMediaAll {
+			counts[title]++
+		}
+	}
+	titles := slices.Collect(maps.Keys(counts))
+	slices.SortFunc(titles, func(a, b string) int {
+		return cmp.Or(cmp.Compare(counts[b], counts[a]), cmp.Compare(a, b))
+	})
+
+	options := []selectOption{{Label: "Media: All", Value: listMediaAll, Default: current == ""}}
+	for _, title := range titles[:min(len(titles), selectMenuMaxOptions-1)] {
+		options = append(options, selectOption{Label: title, Value: title, Default: title == current})
 	}
+	return options
+}
 
-	w.Respond(corde.NewResp().Embeds(embed).Ephemeral())
+// truncateBytes cuts s to at most n bytes without splitting a UTF-8 sequence.
+func truncateBytes(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
 }
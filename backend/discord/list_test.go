@@ -3,10 +3,14 @@ package discord
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
 	"testing"
 	"time"
 
+	"github.com/Karitham/corde"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/karitham/waifubot/collection"
 	"github.com/karitham/waifubot/collection/collectiontest"
@@ -94,3 +98,196 @@ func TestListHandler_List(t *testing.T) {
 		})
 	}
 }
+
+func manyCharacters(n int) []collection.OwnedCharacter {
+	chars := make([]collection.OwnedCharacter, n)
+	for i := range chars {
+		chars[i] = collection.OwnedCharacter{
+			Character: collection.Character{ID: int64(i + 1), Name: fmt.Sprintf("Char%d", i+1), MediaTitle: "Media", Favorites: i * 100},
+			Date:      time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(i) * time.Hour),
+		}
+	}
+	return chars
+}
+
+func TestListHandler_List_Paginates(t *testing.T) {
+	store := &collectiontest.MockStore{
+		GetCollectionFunc: func(ctx context.Context, userID collection.UserID) ([]collection.OwnedCharacter, error) {
+			return manyCharacters(40), nil
+		},
+	}
+	w := &cordetest.MockResponseWriter{}
+	h := &ListHandler{store: store}
+
+	h.List(t.Context(), w, &MockCommandContext{UserIDVal: 1, UsernameVal: "testuser"})
+
+	data := w.LastRespond.InteractionRespData()
+	require.Len(t, data.Embeds, 1)
+	assert.Len(t, data.Embeds[0].Fields, listPageSize)
+	assert.Equal(t, "Char40", data.Embeds[0].Fields[0].Name, "newest first by default")
+	assert.Contains(t, data.Embeds[0].Footer.Text, "Page 1/3")
+	require.Len(t, data.Components, 4)
+
+	prev, next := data.Components[0].Components[0], data.Components[0].Components[1]
+	assert.True(t, prev.Disabled)
+	assert.False(t, next.Disabled)
+	for _, row := range data.Components {
+		for _, c := range row.Components {
+			assert.LessOrEqual(t, len(c.CustomID), 100, "custom IDs are capped by discord")
+		}
+	}
+}
+
+func TestListHandler_Button(t *testing.T) {
+	store := &collectiontest.MockStore{
+		GetCollectionFunc: func(ctx context.Context, userID collection.UserID) ([]collection.OwnedCharacter, error) {
+			assert.Equal(t, collection.UserID(7), userID)
+			return manyCharacters(40), nil
+		},
+	}
+	h := &ListHandler{store: store}
+	msg := &corde.Message{Embeds: []corde.Embed{{Title: "someone's List"}}}
+	st := listState{userID: 7, sort: collection.SortByDate, rarity: -1}
+
+	press := func(action string, st listState) *cordetest.MockResponseWriter {
+		w := &cordetest.MockResponseWriter{}
+		h.Button(t.Context(), w, &corde.Interaction[corde.ButtonInteractionData]{
+			Data:    corde.ButtonInteractionData{CustomID: componentID("list/"+action, st.encode()...)},
+			Message: msg,
+		})
+		return w
+	}
+
+	t.Run("next page", func(t *testing.T) {
+		w := press(listActionNext, st)
+		require.True(t, w.UpdateCalled)
+		data := w.LastUpdate.InteractionRespData()
+		assert.Equal(t, "someone's List", data.Embeds[0].Title)
+		assert.Contains(t, data.Embeds[0].Footer.Text, "Page 2/3")
+		assert.Equal(t, "Char22", data.Embeds[0].Fields[0].Name)
+	})
+
+	t.Run("next past the end stays on the last page", func(t *testing.T) {
+		last := st
+		last.page = 2
+		w := press(listActionNext, last)
+		data := w.LastUpdate.InteractionRespData()
+		assert.Contains(t, data.Embeds[0].Footer.Text, "Page 3/3")
+		assert.Len(t, data.Embeds[0].Fields, 4)
+	})
+
+	t.Run("outdated custom id", func(t *testing.T) {
+		w := &cordetest.MockResponseWriter{}
+		h.Button(t.Context(), w, &corde.Interaction[corde.ButtonInteractionData]{
+			Data: corde.ButtonInteractionData{CustomID: "list/next/garbage"},
+		})
+		assert.True(t, w.RespondCalled)
+		w.AssertContains(t, "outdated")
+	})
+}
+
+func TestListHandler_Select(t *testing.T) {
+	store := &collectiontest.MockStore{
+		GetCollectionFunc: func(ctx context.Context, userID collection.UserID) ([]collection.OwnedCharacter, error) {
+			chars := manyCharacters(40)
+			chars[1].MediaTitle = "Re:Zero"
+			return chars, nil
+		},
+	}
+	h := &ListHandler{store: store}
+	msg := &corde.Message{Embeds: []corde.Embed{{Title: "someone's List"}}}
+	st := listState{userID: 7, page: 1, sort: collection.SortByDate, rarity: -1}
+
+	pick := func(action string, st listState, values ...string) *cordetest.MockResponseWriter {
+		w := &cordetest.MockResponseWriter{}
+		h.Select(withSelectValues(t.Context(), values), w, &corde.Interaction[corde.ModalInteractionData]{
+			Data:    corde.ModalInteractionData{CustomID: componentID("list/"+action, st.encode()...)},
+			Message: msg,
+		})
+		return w
+	}
+	defaults := func(c corde.Component) []string {
+		var labels []string
+		for _, o := range c.Options {
+			if o.Focused {
+				labels = append(labels, o.Name)
+			}
+		}
+		return labels
+	}
+
+	t.Run("sort resets the page", func(t *testing.T) {
+		w := pick(listActionSort, st, "r")
+		require.True(t, w.UpdateCalled)
+		data := w.LastUpdate.InteractionRespData()
+		assert.Equal(t, "someone's List", data.Embeds[0].Title)
+		assert.Contains(t, data.Embeds[0].Footer.Text, "Page 1/3")
+		assert.Equal(t, "Char40", data.Embeds[0].Fields[0].Name)
+		assert.Equal(t, []string{"Sort: Rarity"}, defaults(data.Components[1].Components[0]))
+	})
+
+	t.Run("rarity filter", func(t *testing.T) {
+		w := pick(listActionRarity, st, strconv.Itoa(int(collection.RarityLegendary)))
+		data := w.LastUpdate.InteractionRespData()
+		assert.Contains(t, data.Embeds[0].Footer.Text, "Page 1/1 · 0 characters")
+		assert.Equal(t, []string{"Rarity: Legendary"}, defaults(data.Components[2].Components[0]))
+	})
+
+	t.Run("media filter", func(t *testing.T) {
+		w := pick(listActionMedia, st, "Re:Zero")
+		data := w.LastUpdate.InteractionRespData()
+		assert.Contains(t, data.Embeds[0].Footer.Text, `1 characters · media: "Re:Zero"`)
+
+		menu := data.Components[3].Components[0]
+		require.Len(t, menu.Options, 3)
+		assert.Equal(t, "Media", menu.Options[1].Name, "media with the most characters first")
+		assert.Equal(t, []string{"Re:Zero"}, defaults(menu))
+
+		// The filter round-trips through the custom IDs of the new controls.
+		_, next, err := parseListComponentID(data.Components[0].Components[1].CustomID)
+		require.NoError(t, err)
+		assert.Equal(t, "Re:Zero", next.media)
+	})
+
+	t.Run("every media", func(t *testing.T) {
+		filtered := st
+		filtered.media = "Re:Zero"
+		w := pick(listActionMedia, filtered, listMediaAll)
+		data := w.LastUpdate.InteractionRespData()
+		assert.Contains(t, data.Embeds[0].Footer.Text, "40 characters")
+		assert.Equal(t, []string{"Media: All"}, defaults(data.Components[3].Components[0]))
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		w := pick(listActionSort, st, "x")
+		assert.True(t, w.RespondCalled)
+		w.AssertContains(t, "outdated")
+	})
+
+	t.Run("no value", func(t *testing.T) {
+		w := pick(listActionRarity, st)
+		assert.True(t, w.RespondCalled)
+		w.AssertContains(t, "outdated")
+	})
+}
+
+func TestListMediaOptions(t *testing.T) {
+	chars := make([]collection.OwnedCharacter, 30)
+	for i := range chars {
+		chars[i].MediaTitle = fmt.Sprintf("Media %02d", i)
+	}
+	chars = append(chars, collection.OwnedCharacter{Character: collection.Character{MediaTitle: "Media 29"}})
+
+	options := listMediaOptions(chars, "")
+	require.Len(t, options, selectMenuMaxOptions)
+	assert.Equal(t, selectOption{Label: "Media: All", Value: listMediaAll, Default: true}, options[0])
+	assert.Equal(t, "Media 29", options[1].Value)
+	assert.Equal(t, "Media 00", options[2].Value)
+}
+
+func TestListState_EncodeDecode(t *testing.T) {
+	st := listState{userID: 18446744073709551615, page: 12, sort: collection.SortByMedia, rarity: int(collection.RarityRare), media: "Ünïcode / title"}
+	got, err := decodeListState(st.encode())
+	require.NoError(t, err)
+	assert.Equal(t, st, got)
+}
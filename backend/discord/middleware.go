@@ -3,30 +3,44 @@ package discord
 import (
 	"context"
 	"log/slog"
+	"strconv"
 
 	"github.com/Karitham/corde"
+
+	"github.com/karitham/waifubot/settings"
 	"github.com/karitham/waifubot/storage/interactionstore"
 )
 
-// interaction middleware — increments interaction count and checks for drop trigger.
-func interact[T corde.InteractionDataConstraint](inter interactionstore.Store, interact func(ctx context.Context, count int64, i *corde.Interaction[T])) func(func(ctx context.Context, w corde.ResponseWriter, i *corde.Interaction[T])) func(ctx context.Context, w corde.ResponseWriter, i *corde.Interaction[T]) {
+// interaction middleware — counts the interaction towards its drop route and checks for drop trigger.
+// Interactions in channels that route returns false for are not counted at all.
+func interact[T corde.InteractionDataConstraint](
+	inter interactionstore.Store,
+	route func(ctx context.Context, guildID, channelID corde.Snowflake) (settings.DropRoute, bool),
+	interact func(ctx context.Context, count int64, route settings.DropRoute, i *corde.Interaction[T]),
+) func(func(ctx context.Context, w corde.ResponseWriter, i *corde.Interaction[T])) func(ctx context.Context, w corde.ResponseWriter, i *corde.Interaction[T]) {
 	return func(next func(ctx context.Context, w corde.ResponseWriter, i *corde.Interaction[T])) func(ctx context.Context, w corde.ResponseWriter, i *corde.Interaction[T]) {
 		return func(ctx context.Context, w corde.ResponseWriter, i *corde.Interaction[T]) {
 			go func() {
 				ctx := context.Background()
 
-				err := inter.Increment(ctx, i.ChannelID)
+				r, ok := route(ctx, i.GuildID, i.ChannelID)
+				if !ok {
+					return
+				}
+				counter := corde.Snowflake(r.CounterID)
+
+				err := inter.Increment(ctx, counter)
 				if err != nil {
 					slog.Debug("failed to increment interaction count", "error", err)
 				}
 
-				count, err := inter.Get(ctx, i.ChannelID)
+				count, err := inter.Get(ctx, counter)
 				if err != nil {
 					slog.Error("failed to get interaction count", "error", err)
 					return
 				}
 
-				interact(ctx, count, i)
+				interact(ctx, count, r, i)
 			}()
 
 			next(ctx, w, i)
@@ -43,3 +57,24 @@ func wrap[T corde.InteractionDataConstraint](
 	}
 	return next
 }
+
+// Discord permission bits, see https://discord.com/developers/docs/topics/permissions.
+const (
+	permAdministrator uint64 = 1 << 3
+	permManageGuild   uint64 = 1 << 5
+)
+
+// requirePermission rejects interactions from members who lack perm in the channel.
+// Administrators always pass.
+func requirePermission[T corde.InteractionDataConstraint](perm uint64, name string) func(func(ctx context.Context, w corde.ResponseWriter, i *corde.Interaction[T])) func(ctx context.Context, w corde.ResponseWriter, i *corde.Interaction[T]) {
+	return func(next func(ctx context.Context, w corde.ResponseWriter, i *corde.Interaction[T])) func(ctx context.Context, w corde.ResponseWriter, i *corde.Interaction[T]) {
+		return func(ctx context.Context, w corde.ResponseWriter, i *corde.Interaction[T]) {
+			p, _ := strconv.ParseUint(i.Member.Permissions, 10, 64)
+			if p&permAdministrator == 0 && p&perm != perm {
+				w.Respond(Privf("You need the %s permission to do that", name))
+				return
+			}
+			next(ctx, w, i)
+		}
+	}
+}
@@ -2,10 +2,9 @@ package discord
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
-	"net/url"
-	"strings"
 	"time"
 
 	"github.com/Karitham/corde"
@@ -72,17 +71,19 @@ func (h *ProfileHandler) View(ctx context.Context, w corde.ResponseWriter, cmd C
 		Title(opts.targetUsername).
 		URL(fmt.Sprintf("https://waifugui.karitham.dev/#/list/%d", opts.targetUserID)).
 		Descriptionf(
-			"%s\n%s last rolled %s ago and has %d tokens.\nThey have %d characters.\nFavorite: %s\n%s",
+			"%s\n%s last rolled %s ago and has %d tokens.\nThey have %d characters, worth %d.\nFavorite: %s\n%s",
 			data.Quote,
 			opts.targetUsername,
 			time.Since(data.Date.UTC()).Truncate(time.Second),
 			data.Tokens,
 			data.CharacterCount,
+			data.Value,
 			data.Favorite.Name,
 			anilistURLDesc,
 		).
 		Field("Collection", fmt.Sprintf("[View Collection](https://waifugui.karitham.dev/#/list/%d)", opts.targetUserID)).
-		Field("Wishlist", fmt.Sprintf("[View Wishlist](https://waifugui.karitham.dev/#/wishlist/%d)", opts.targetUserID))
+		Field("Wishlist", fmt.Sprintf("[View Wishlist](https://waifugui.karitham.dev/#/wishlist/%d)", opts.targetUserID)).
+		Field("Badges", badgesText(data.Achievements))
 	if data.Favorite.Image != "" {
 		resp.Thumbnail(corde.Image{URL: data.Favorite.Image})
 	}
@@ -95,7 +96,11 @@ func (h *ProfileHandler) EditFavorite(ctx context.Context, w corde.ResponseWrite
 	logger := slog.With("user_id", cmd.UserID(), "guild_id", cmd.GuildID())
 
 	optID, _ := cmd.OptInt64("id")
-	if err := h.store.UpdateFavorite(ctx, cmd.UserID(), optID); err != nil {
+	if err := collection.SetFavorite(ctx, h.store, cmd.UserID(), optID); err != nil {
+		if errors.Is(err, collection.ErrNotFound) {
+			w.Respond(corde.NewResp().Contentf("Character %d not found", optID).Ephemeral())
+			return
+		}
 		logger.Error("error setting favorite character", "error", err, "character_id", optID)
 		w.Respond(corde.NewResp().Content("An error occurred setting this character").Ephemeral())
 		return
@@ -107,12 +112,7 @@ func (h *ProfileHandler) EditFavorite(ctx context.Context, w corde.ResponseWrite
 // EditQuote sets the user's profile quote.
 func (h *ProfileHandler) EditQuote(ctx context.Context, w corde.ResponseWriter, cmd CommandContext) {
 	quote, _ := cmd.OptString("value")
-	if len(quote) > 1024 {
-		w.Respond(corde.NewResp().Content("quote is too long").Ephemeral())
-		return
-	}
-
-	if err := h.store.UpdateQuote(ctx, cmd.UserID(), quote); err != nil {
+	if err := collection.SetQuote(ctx, h.store, cmd.UserID(), quote); err != nil {
 		w.Respond(corde.NewResp().Content(err.Error()).Ephemeral())
 		return
 	}
@@ -123,23 +123,7 @@ func (h *ProfileHandler) EditQuote(ctx context.Context, w corde.ResponseWriter,
 // EditAnilistURL sets the user's Anilist profile URL.
 func (h *ProfileHandler) EditAnilistURL(ctx context.Context, w corde.ResponseWriter, cmd CommandContext) {
 	anilistURL, _ := cmd.OptString("url")
-	parsedURL, err := url.Parse(anilistURL)
-	if err != nil {
-		w.Respond(corde.NewResp().Content("invalid URL").Ephemeral())
-		return
-	}
-
-	if parsedURL.Host != "anilist.co" {
-		w.Respond(corde.NewResp().Content("invalid Anilist URL").Ephemeral())
-		return
-	}
-
-	if !strings.HasPrefix(parsedURL.Path, "/user/") {
-		w.Respond(corde.NewResp().Content("invalid Anilist URL").Ephemeral())
-		return
-	}
-
-	if err := h.store.UpdateAnilistURL(ctx, cmd.UserID(), anilistURL); err != nil {
+	if err := collection.SetAnilistURL(ctx, h.store, cmd.UserID(), anilistURL); err != nil {
 		w.Respond(corde.NewResp().Content(err.Error()).Ephemeral())
 		return
 	}
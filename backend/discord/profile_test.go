@@ -46,8 +46,12 @@ func TestProfileHandler_View(t *testing.T) {
 				CountCollectionFunc: func(ctx context.Context, userID collection.UserID) (int64, error) {
 					return 5, nil
 				},
+				ListAchievementsFunc: func(ctx context.Context, userID collection.UserID) ([]collection.AchievementUnlock, error) {
+					return []collection.AchievementUnlock{{ID: "owned_10"}, {ID: "retired"}}, nil
+				},
 			},
-			wantTitle: "testuser",
+			wantContent: "🏅 Collector",
+			wantTitle:   "testuser",
 		},
 		{
 			name: "other user profile",
@@ -71,8 +75,12 @@ func TestProfileHandler_View(t *testing.T) {
 				CountCollectionFunc: func(ctx context.Context, userID collection.UserID) (int64, error) {
 					return 3, nil
 				},
+				CollectionValueFunc: func(ctx context.Context, userID collection.UserID) (int64, error) {
+					return 57, nil
+				},
 			},
-			wantTitle: "otheruser",
+			wantContent: "They have 3 characters, worth 57.",
+			wantTitle:   "otheruser",
 		},
 		{
 			name: "store error",
@@ -13,6 +13,7 @@ import (
 
 // RollHandler handles the /roll command.
 type RollHandler struct {
+	store       collection.Store
 	rollService *collection.RollService
 	wishlist    wishlist.Store
 }
@@ -21,7 +22,7 @@ type RollHandler struct {
 func (h *RollHandler) Roll(ctx context.Context, w corde.ResponseWriter, cmd CommandContext) {
 	logger := slog.With("user_id", cmd.UserID(), "guild_id", cmd.GuildID())
 
-	char, err := h.rollService.Roll(ctx, cmd.UserID())
+	char, err := h.rollService.Roll(ctx, cmd.GuildID(), cmd.UserID())
 
 	var cd collection.ErrRollCooldown
 	switch {
@@ -39,5 +40,7 @@ func (h *RollHandler) Roll(ctx context.Context, w corde.ResponseWriter, cmd Comm
 		logger.Error("error getting users wanting character", "error", err)
 	}
 
-	w.Respond(rollEmbed(char, formatUsersWantingCharacter(wantingUsers, cmd.UserID())))
+	w.Respond(corde.NewResp().
+		Embeds(rollEmbed(char, formatUsersWantingCharacter(wantingUsers, cmd.UserID()))).
+		Embeds(unlockedAchievements(ctx, h.store, cmd.UserID(), collection.EventRoll, collection.Character{ID: char.ID, Favorites: char.Favorites})...))
 }
@@ -140,8 +140,9 @@ func TestRollHandler_Roll(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			w := &cordetest.MockResponseWriter{}
-			svc := collection.NewRollService(tt.store, collection.RollConfig{RollCooldown: tt.config.RollCooldown})
+			svc := collection.NewRollService(tt.store, tt.config)
 			h := &RollHandler{
+				store:       tt.store,
 				rollService: svc,
 				wishlist:    tt.wishlist,
 			}
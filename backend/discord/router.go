@@ -3,6 +3,8 @@ package discord
 import (
 	"context"
 	"log/slog"
+	"net/http"
+	"slices"
 	"time"
 
 	"github.com/Karitham/corde"
@@ -10,16 +12,21 @@ import (
 
 	"github.com/karitham/waifubot/collection"
 	"github.com/karitham/waifubot/guild"
+	"github.com/karitham/waifubot/leaderboard"
+	"github.com/karitham/waifubot/settings"
 	"github.com/karitham/waifubot/storage/dropstore"
 	"github.com/karitham/waifubot/storage/interactionstore"
+	"github.com/karitham/waifubot/tracker"
 	"github.com/karitham/waifubot/wishlist"
 
 	"github.com/karitham/waifubot/catalog"
 )
 
 const (
-	AnilistColor   = 0x02a9ff
-	AnilistIconURL = "https://anilist.co/img/icons/favicon-32x32.png"
+	AnilistColor       = 0x02a9ff
+	AnilistIconURL     = "https://anilist.co/img/icons/favicon-32x32.png"
+	MyAnimeListColor   = 0x2e51a2
+	MyAnimeListIconURL = "https://cdn.myanimelist.net/images/favicon.ico"
 )
 
 var (
@@ -40,7 +47,7 @@ var (
 	)
 )
 
-// TrackingService is the interface for the anilist service.
+// TrackingService is the interface for a tracker backend, see tracker.Service.
 type TrackingService interface {
 	Anime(ctx context.Context, name string) ([]collection.Media, error)
 	Manga(ctx context.Context, name string) ([]collection.Media, error)
@@ -59,24 +66,30 @@ type CommandStore interface {
 
 // Router holds the infrastructure needed to wire command handlers.
 type Router struct {
-	mux               *corde.Mux
-	Store             collection.Store
-	Catalog           catalog.Store
-	CommandStore      CommandStore
-	WishlistStore     wishlist.Store
-	AnimeService      TrackingService
-	DropStore         dropstore.Store
-	InterStore        interactionstore.Store
-	GuildIndexer      *guild.Indexer
-	GuildOps          guild.GuildQuerier
-	guildTxFn         func(context.Context) (guild.TxQuerier, error)
-	AppID             corde.Snowflake
-	GuildID           *corde.Snowflake
-	BotToken          string
-	PublicKey         string
-	RollCooldown      time.Duration
-	InteractionNeeded int64
-	SeriesRollCost    int32
+	mux            *corde.Mux
+	Store          collection.Store
+	Catalog        catalog.Store
+	CommandStore   CommandStore
+	WishlistStore  wishlist.Store
+	AnimeService   TrackingService
+	Tracker        tracker.Provider                     // provider of AnimeService; defaults to tracker.AniList
+	Trackers       map[tracker.Provider]TrackingService // backends /search can pick with its source option
+	MediaSource    collection.MediaCharacterSource      // lists every character of a series, by catalog ID; defaults to AnimeService
+	DropStore      dropstore.Store
+	InterStore     interactionstore.Store
+	GuildIndexer   *guild.Indexer
+	GuildOps       guild.GuildQuerier
+	guildTxFn      func(context.Context) (guild.TxQuerier, error)
+	Settings       *settings.Service
+	Leaderboards   *leaderboard.Service
+	DropLifetime   time.Duration          // how long drops stay claimable; defaults to collection.DropLifetime
+	HintThresholds []int                  // failed claims unlocking each drop hint; defaults to dropstore.DefaultHintThresholds
+	Daily          collection.DailyConfig // daily reward schedule; empty fields take collection's defaults
+	client         *Client
+	AppID          corde.Snowflake
+	GuildID        *corde.Snowflake
+	BotToken       string
+	PublicKey      string
 }
 
 // New constructs a Router with all dependencies and runs command migration.
@@ -88,50 +101,88 @@ func New(r *Router) *Router {
 		return r.Store.WithTx(ctx)
 	}
 
+	if r.DropLifetime <= 0 {
+		r.DropLifetime = collection.DropLifetime
+	}
+	if r.Tracker == "" {
+		r.Tracker = tracker.AniList
+	}
+	if r.MediaSource == nil {
+		r.MediaSource = r.AnimeService
+	}
+	if r.HintThresholds == nil {
+		r.HintThresholds = dropstore.DefaultHintThresholds
+	}
+	r.HintThresholds = slices.Sorted(slices.Values(r.HintThresholds))
+	if len(r.Daily.Schedule) == 0 {
+		r.Daily.Schedule = collection.DefaultDailySchedule
+	}
+	if r.Daily.Grace < 0 {
+		r.Daily.Grace = 0
+	}
+	if r.Daily.Milestones == nil {
+		r.Daily.Milestones = collection.DefaultStreakMilestones
+	}
+	r.client = NewClient(r.BotToken)
+
 	r.MustMigrateCommands()
 
 	return r
 }
 
 // Register sets up the mux, middleware, and all command routes.
-// Returns the configured *corde.Mux.
-func (r *Router) Register() *corde.Mux {
+// Returns the configured mux, wrapped so it can send and receive select menus.
+func (r *Router) Register() http.Handler {
 	r.mux = corde.NewMux(r.PublicKey, r.AppID, r.BotToken)
 	r.mux.OnNotFound = r.RemoveUnknownCommands
 
 	t := trace[corde.SlashCommandInteractionData]
-	i := interact(r.InterStore, r.onInteraction)
+	i := interact(r.InterStore, r.dropRoute, r.onInteraction)
 	idx := indexMiddleware[corde.SlashCommandInteractionData](r.GuildIndexer, r.guildTxFn)
 
 	// Construct handlers
 	infoHandler := &InfoHandler{}
-	claimHandler := &ClaimHandler{store: r.Store}
+	claimHandler := &ClaimHandler{store: r.Store, drops: r.DropStore, hintThresholds: r.HintThresholds}
 	listHandler := &ListHandler{store: r.Store}
+	dailyHandler := &DailyHandler{store: r.Store, config: r.Daily}
 	giveHandler := &GiveHandler{store: r.Store}
+	tradeHandler := &TradeHandler{store: r.Store}
+	auctionHandler := &AuctionHandler{store: r.Store}
+	configHandler := &ConfigHandler{settings: r.Settings}
 	verifyHandler := &VerifyHandler{store: r.Store, guildIndexer: r.GuildIndexer, guildTxFn: r.guildTxFn}
 	profileHandler := &ProfileHandler{store: r.Store}
 	searchHandler := &SearchHandler{
 		animeService:  r.AnimeService,
+		provider:      r.Tracker,
+		trackers:      r.Trackers,
 		interStore:    r.InterStore,
+		dropRoute:     r.dropRoute,
 		onInteraction: r.onInteraction,
 		guildIndexer:  r.GuildIndexer,
 		guildTxFn:     r.guildTxFn,
 	}
+	historyHandler := &HistoryHandler{store: r.Store}
+	characterHandler := &CharacterHandler{store: r.Store}
+	leaderboardHandler := &LeaderboardHandler{boards: r.Leaderboards, guildIndexer: r.GuildIndexer, guildTxFn: r.guildTxFn}
+	collectionHandler := &CollectionHandler{store: r.Store, animeService: r.AnimeService, mediaSource: r.MediaSource}
 	holdersHandler := &HoldersHandler{guildOps: r.GuildOps, catalog: r.Catalog, guildIndexer: r.GuildIndexer, guildTxFn: r.guildTxFn}
 	rollHandler := &RollHandler{
-		rollService: collection.NewRollService(r.Store, collection.RollConfig{RollCooldown: r.RollCooldown}),
+		store:       r.Store,
+		rollService: collection.NewRollService(r.Store, r.Settings),
 		wishlist:    r.WishlistStore,
 	}
 	tokenHandler := &TokenHandler{
 		store:        r.Store,
 		animeService: r.AnimeService,
-		rollService:  collection.NewRollService(r.Store, collection.RollConfig{RollCooldown: r.RollCooldown}),
-		config:       collection.Config{RollCooldown: r.RollCooldown, SeriesRollCost: r.SeriesRollCost},
+		mediaSource:  r.MediaSource,
+		rollService:  collection.NewRollService(r.Store, r.Settings),
+		config:       r.Settings,
 	}
 	wishlistHandler := &WishlistHandler{
 		wishlist:     r.WishlistStore,
 		store:        r.Store,
 		animeService: r.AnimeService,
+		mediaSource:  r.MediaSource,
 		catalog:      r.Catalog,
 		guildIndexer: r.GuildIndexer,
 		guildTxFn:    r.guildTxFn,
@@ -141,20 +192,44 @@ func (r *Router) Register() *corde.Mux {
 	r.mux.SlashCommand("info", wrap(wrapCtx(infoHandler.Info), t))
 	r.mux.SlashCommand("claim", wrap(wrapCtx(claimHandler.Claim), t))
 	r.mux.SlashCommand("list", wrap(wrapCtx(listHandler.List), t, i, idx))
+	r.mux.Route("list", listHandler.RegisterComponents)
+	r.mux.Route("collection", collectionHandler.Register)
 	r.mux.Route("give", giveHandler.Register)
+	r.mux.Route("trade", tradeHandler.Register)
+	r.mux.Route("auction", auctionHandler.Register)
+	r.mux.Route("config", configHandler.Register)
 	r.mux.Route("verify", verifyHandler.Register)
 	r.mux.Route("profile", profileHandler.Register)
 	r.mux.Route("search", searchHandler.Register)
 	r.mux.Route("holders", holdersHandler.Register)
+	r.mux.Route("history", historyHandler.Register)
+	r.mux.Route("character", characterHandler.Register)
+	r.mux.Route("leaderboard", leaderboardHandler.Register)
 	r.mux.SlashCommand("roll", wrap(wrapCtx(rollHandler.Roll), t, i, idx))
+	r.mux.SlashCommand("daily", wrap(wrapCtx(dailyHandler.Daily), t))
 	r.mux.Route("token", tokenHandler.Register)
 	r.mux.Route("wishlist", wishlistHandler.Register)
 
-	return r.mux
+	return selectMenus(r.mux)
+}
+
+// dropRoute resolves where an interaction counts towards drops.
+// Interactions are not counted when the guild's settings can't be read.
+func (r *Router) dropRoute(ctx context.Context, guildID, channelID corde.Snowflake) (settings.DropRoute, bool) {
+	route, ok, err := r.Settings.DropRoute(ctx, uint64(guildID), uint64(channelID))
+	if err != nil {
+		slog.Error("failed to resolve drop route", "error", err, "guild_id", guildID, "channel_id", channelID)
+		return settings.DropRoute{}, false
+	}
+	return route, ok
 }
 
-func (r *Router) onInteraction(ctx context.Context, count int64, i *corde.Interaction[corde.SlashCommandInteractionData]) {
-	if count < r.InteractionNeeded {
+func (r *Router) onInteraction(ctx context.Context, count int64, route settings.DropRoute, i *corde.Interaction[corde.SlashCommandInteractionData]) {
+	config, err := r.Settings.GuildConfig(ctx, uint64(i.GuildID))
+	if err != nil {
+		slog.Error("failed to load guild config, using defaults", "error", err, "guild_id", i.GuildID)
+	}
+	if count < config.InteractionNeeded {
 		return
 	}
 
@@ -162,8 +237,8 @@ func (r *Router) onInteraction(ctx context.Context, count int64, i *corde.Intera
 		return
 	}
 
-	_ = r.InterStore.Reset(ctx, i.ChannelID)
-	r.drop(ctx, i.ChannelID)
+	_ = r.InterStore.Reset(ctx, corde.Snowflake(route.CounterID))
+	r.drop(ctx, corde.Snowflake(route.ChannelID))
 }
 
 func (r *Router) RemoveUnknownCommands(ctx context.Context, w corde.ResponseWriter, i *corde.Interaction[corde.JsonRaw]) {
@@ -9,14 +9,19 @@ import (
 
 	"github.com/karitham/waifubot/collection"
 	"github.com/karitham/waifubot/guild"
+	"github.com/karitham/waifubot/settings"
 	"github.com/karitham/waifubot/storage/interactionstore"
+	"github.com/karitham/waifubot/tracker"
 )
 
 // SearchHandler handles the /search command and its subcommands.
 type SearchHandler struct {
 	animeService  TrackingService
+	provider      tracker.Provider // provider of animeService
+	trackers      map[tracker.Provider]TrackingService
 	interStore    interactionstore.Store
-	onInteraction func(context.Context, int64, *corde.Interaction[corde.SlashCommandInteractionData])
+	dropRoute     func(context.Context, corde.Snowflake, corde.Snowflake) (settings.DropRoute, bool)
+	onInteraction func(context.Context, int64, settings.DropRoute, *corde.Interaction[corde.SlashCommandInteractionData])
 	guildIndexer  *guild.Indexer
 	guildTxFn     func(context.Context) (guild.TxQuerier, error)
 }
@@ -24,7 +29,7 @@ type SearchHandler struct {
 // Register wires the search sub-routes on the mux.
 func (h *SearchHandler) Register(m *corde.Mux) {
 	t := trace[corde.SlashCommandInteractionData]
-	i := interact(h.interStore, h.onInteraction)
+	i := interact(h.interStore, h.dropRoute, h.onInteraction)
 	idx := indexMiddleware[corde.SlashCommandInteractionData](h.guildIndexer, h.guildTxFn)
 
 	m.SlashCommand("char", wrap(wrapCtx(h.SearchChar), t, i, idx))
@@ -37,7 +42,8 @@ func (h *SearchHandler) Register(m *corde.Mux) {
 func (h *SearchHandler) SearchAnime(ctx context.Context, w corde.ResponseWriter, cmd CommandContext) {
 	search, _ := cmd.OptString("name")
 
-	media, err := h.animeService.Anime(ctx, search)
+	svc, provider := h.tracker(cmd)
+	media, err := svc.Anime(ctx, search)
 	if err != nil {
 		slog.ErrorContext(ctx, "error with anime service", "error", err)
 		w.Respond(rspErr("Error searching for this anime, either it doesn't exist or something went wrong"))
@@ -49,14 +55,15 @@ func (h *SearchHandler) SearchAnime(ctx context.Context, w corde.ResponseWriter,
 		return
 	}
 
-	w.Respond(mediaEmbed(media[0]))
+	w.Respond(mediaEmbed(media[0], provider))
 }
 
 // SearchManga searches for manga by name.
 func (h *SearchHandler) SearchManga(ctx context.Context, w corde.ResponseWriter, cmd CommandContext) {
 	search, _ := cmd.OptString("name")
 
-	media, err := h.animeService.Manga(ctx, search)
+	svc, provider := h.tracker(cmd)
+	media, err := svc.Manga(ctx, search)
 	if err != nil {
 		slog.ErrorContext(ctx, "error with anime service", "error", err)
 		w.Respond(rspErr("Error searching for this manga, either it doesn't exist or something went wrong"))
@@ -68,14 +75,15 @@ func (h *SearchHandler) SearchManga(ctx context.Context, w corde.ResponseWriter,
 		return
 	}
 
-	w.Respond(mediaEmbed(media[0]))
+	w.Respond(mediaEmbed(media[0], provider))
 }
 
-// SearchUser searches for an AniList user by name.
+// SearchUser searches for a tracker user by name.
 func (h *SearchHandler) SearchUser(ctx context.Context, w corde.ResponseWriter, cmd CommandContext) {
 	search, _ := cmd.OptString("name")
 
-	users, err := h.animeService.User(ctx, search)
+	svc, provider := h.tracker(cmd)
+	users, err := svc.User(ctx, search)
 	if err != nil {
 		slog.ErrorContext(ctx, "error with user service", "error", err)
 		w.Respond(rspErr("Error searching for this user, either it doesn't exist or something went wrong"))
@@ -87,14 +95,15 @@ func (h *SearchHandler) SearchUser(ctx context.Context, w corde.ResponseWriter,
 		return
 	}
 
-	w.Respond(userEmbed(users[0]))
+	w.Respond(userEmbed(users[0], provider))
 }
 
 // SearchChar searches for a character by name.
 func (h *SearchHandler) SearchChar(ctx context.Context, w corde.ResponseWriter, cmd CommandContext) {
 	search, _ := cmd.OptString("name")
 
-	characters, err := h.animeService.Character(ctx, search)
+	svc, provider := h.tracker(cmd)
+	characters, err := svc.Character(ctx, search)
 	if err != nil {
 		slog.ErrorContext(ctx, "error with char service", "error", err)
 		w.Respond(rspErr("Error searching for this character, either it doesn't exist or something went wrong"))
@@ -106,10 +115,19 @@ func (h *SearchHandler) SearchChar(ctx context.Context, w corde.ResponseWriter,
 		return
 	}
 
-	w.Respond(charEmbed(characters[0]))
+	w.Respond(charEmbed(characters[0], provider))
 }
 
-func mediaEmbed(m collection.Media) *corde.EmbedB {
+// tracker returns the backend picked with the source option, or the default one.
+func (h *SearchHandler) tracker(cmd CommandContext) (TrackingService, tracker.Provider) {
+	source, _ := cmd.OptString("source")
+	if svc, ok := h.trackers[tracker.Provider(source)]; ok {
+		return svc, tracker.Provider(source)
+	}
+	return h.animeService, h.provider
+}
+
+func mediaEmbed(m collection.Media, p tracker.Provider) *corde.EmbedB {
 	return applyEmbedOpt(corde.NewEmbed().
 		Title(m.Title).
 		URL(m.URL).
@@ -117,40 +135,50 @@ func mediaEmbed(m collection.Media) *corde.EmbedB {
 		ImageURL(m.BannerImageURL).
 		Thumbnail(corde.Image{URL: m.CoverImageURL}).
 		Description(m.Description),
-		anilistFooter,
+		trackerFooter(p),
 		sanitizeDescOpt,
 	)
 }
 
-func userEmbed(u collection.TrackerUser) *corde.EmbedB {
+func userEmbed(u collection.TrackerUser, p tracker.Provider) *corde.EmbedB {
 	return applyEmbedOpt(corde.NewEmbed().
 		Title(u.Name).
 		URL(u.URL).
-		Color(AnilistColor).
+		Color(trackerColor(p)).
 		ImageURL(u.ImageURL).
 		Description(u.About),
-		anilistFooter,
+		trackerFooter(p),
 		sanitizeDescOpt,
 	)
 }
 
-func charEmbed(c collection.MediaCharacter) *corde.EmbedB {
+func charEmbed(c collection.MediaCharacter, p tracker.Provider) *corde.EmbedB {
 	return applyEmbedOpt(corde.NewEmbed().
 		Title(c.Name).
-		Color(AnilistColor).
+		Color(trackerColor(p)).
 		URL(c.URL).
 		Thumbnail(corde.Image{URL: c.ImageURL}).
 		Description(c.Description),
-		anilistFooter,
+		trackerFooter(p),
 		sanitizeDescOpt,
 	)
 }
 
-func anilistFooter(b *corde.EmbedB) *corde.EmbedB {
-	return b.Footer(corde.Footer{
-		Text:    "View on Anilist",
-		IconURL: AnilistIconURL,
-	})
+func trackerFooter(p tracker.Provider) func(*corde.EmbedB) *corde.EmbedB {
+	footer := corde.Footer{Text: "View on Anilist", IconURL: AnilistIconURL}
+	if p == tracker.MyAnimeList {
+		footer = corde.Footer{Text: "View on MyAnimeList", IconURL: MyAnimeListIconURL}
+	}
+	return func(b *corde.EmbedB) *corde.EmbedB {
+		return b.Footer(footer)
+	}
+}
+
+func trackerColor(p tracker.Provider) uint32 {
+	if p == tracker.MyAnimeList {
+		return MyAnimeListColor
+	}
+	return AnilistColor
 }
 
 func applyEmbedOpt(b *corde.EmbedB, opts ...func(*corde.EmbedB) *corde.EmbedB) *corde.EmbedB {
@@ -8,6 +8,7 @@ import (
 	"github.com/karitham/waifubot/collection"
 	"github.com/karitham/waifubot/collection/collectiontest"
 	"github.com/karitham/waifubot/discord/cordetest"
+	"github.com/karitham/waifubot/tracker"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -298,3 +299,46 @@ func TestSearchHandler_SearchChar(t *testing.T) {
 		})
 	}
 }
+
+func TestSearchHandler_Source(t *testing.T) {
+	anilist := &collectiontest.MockAnimeService{CharacterFunc: func(context.Context, string) ([]collection.MediaCharacter, error) {
+		return []collection.MediaCharacter{{Name: "From AniList"}}, nil
+	}}
+	mal := &collectiontest.MockAnimeService{CharacterFunc: func(context.Context, string) ([]collection.MediaCharacter, error) {
+		return []collection.MediaCharacter{{Name: "From MyAnimeList"}}, nil
+	}}
+	h := &SearchHandler{
+		animeService: anilist,
+		provider:     tracker.AniList,
+		trackers:     map[tracker.Provider]TrackingService{tracker.AniList: anilist, tracker.MyAnimeList: mal},
+	}
+
+	tests := []struct {
+		name       string
+		source     string
+		wantTitle  string
+		wantFooter string
+	}{
+		{name: "default", wantTitle: "From AniList", wantFooter: "View on Anilist"},
+		{name: "mal", source: "mal", wantTitle: "From MyAnimeList", wantFooter: "View on MyAnimeList"},
+		{name: "unknown source", source: "kitsu", wantTitle: "From AniList", wantFooter: "View on Anilist"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := map[string]string{"name": "saber"}
+			if tt.source != "" {
+				opts["source"] = tt.source
+			}
+			w := &cordetest.MockResponseWriter{}
+
+			h.SearchChar(t.Context(), w, &MockCommandContext{OptStringVals: opts})
+
+			data := w.LastRespond.InteractionRespData()
+			if assert.Len(t, data.Embeds, 1) {
+				assert.Equal(t, tt.wantTitle, data.Embeds[0].Title)
+				assert.Equal(t, tt.wantFooter, data.Embeds[0].Footer.Text)
+			}
+		})
+	}
+}
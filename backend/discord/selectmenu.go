@@ -0,0 +1,176 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/Karitham/corde"
+)
+
+// corde v0.10.0 routes select menu interactions, but stops short of the rest:
+// corde.Component can only carry command options, which have a name where
+// select options have a label, and the values a user picked are dropped before
+// the handler sees the interaction.
+// selectMenu encodes its options as command options, and selectMenus, wrapped
+// around the mux, rewrites them into select options on the way out and hands
+// the picked values to the handler through the context on the way in.
+
+// selectOption is one choice of a select menu.
+type selectOption struct {
+	Label   string
+	Value   string
+	Default bool
+}
+
+// selectMenu is a string select menu picking one of its options.
+type selectMenu struct {
+	CustomID    string
+	Placeholder string
+	Options     []selectOption
+}
+
+// selectMenuMaxOptions is how many options Discord allows in a select menu.
+const selectMenuMaxOptions = 25
+
+// Component encodes the menu as a corde component, its options as command options
+// with the label as name and the default flag as focused.
+func (s selectMenu) Component() corde.Component {
+	options := make([]corde.Option, 0, len(s.Options))
+	for _, o := range s.Options {
+		value, _ := json.Marshal(o.Value)
+		options = append(options, corde.Option{Name: o.Label, Value: value, Focused: o.Default})
+	}
+	return corde.Component{
+		Type:        corde.COMPONENT_SELECT_MENU,
+		CustomID:    s.CustomID,
+		Placeholder: s.Placeholder,
+		Options:     options,
+	}
+}
+
+type selectValuesKey struct{}
+
+// selectValues returns the values picked in the select menu of the interaction handled.
+func selectValues(ctx context.Context) []string {
+	values, _ := ctx.Value(selectValuesKey{}).([]string)
+	return values
+}
+
+func withSelectValues(ctx context.Context, values []string) context.Context {
+	return context.WithValue(ctx, selectValuesKey{}, values)
+}
+
+// selectMenus completes corde's select menu support, see selectMenu.
+// The values are read before corde verifies the request, but handlers only
+// run once it has verified the very same body.
+func selectMenus(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "unable to read body", http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		var in struct {
+			Type corde.InteractionType `json:"type"`
+			Data struct {
+				ComponentType corde.ComponentType `json:"component_type"`
+				Values        []string            `json:"values"`
+			} `json:"data"`
+		}
+		if json.Unmarshal(body, &in) == nil &&
+			in.Type == corde.INTERACTION_TYPE_MESSAGE_COMPONENT &&
+			in.Data.ComponentType == corde.COMPONENT_SELECT_MENU {
+			r = r.WithContext(withSelectValues(r.Context(), in.Data.Values))
+		}
+
+		rw := &selectMenuWriter{ResponseWriter: w}
+		next.ServeHTTP(rw, r)
+
+		out := rw.body.Bytes()
+		if strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+			out = rewriteSelectOptions(out)
+		}
+		if rw.status != 0 {
+			w.WriteHeader(rw.status)
+		}
+		_, _ = w.Write(out)
+	})
+}
+
+// selectMenuWriter holds the response back so its select options can be rewritten.
+type selectMenuWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *selectMenuWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *selectMenuWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+// rewriteSelectOptions turns the options selectMenu encoded back into select options.
+// Responses without select menus are returned untouched.
+func rewriteSelectOptions(body []byte) []byte {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil || !rewriteSelectMenus(v) {
+		return body
+	}
+
+	out, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return out
+}
+
+// rewriteSelectMenus rewrites the options of every select menu found in v, and reports whether it found one.
+func rewriteSelectMenus(v any) bool {
+	found := false
+	switch v := v.(type) {
+	case []any:
+		for _, e := range v {
+			found = rewriteSelectMenus(e) || found
+		}
+	case map[string]any:
+		if v["type"] == json.Number("3") && v["custom_id"] != nil {
+			if options, ok := v["options"].([]any); ok {
+				for i, o := range options {
+					options[i] = selectOptionJSON(o)
+				}
+				found = true
+			}
+		}
+		found = rewriteSelectMenus(v["components"]) || found
+		found = rewriteSelectMenus(v["data"]) || found
+	}
+	return found
+}
+
+func selectOptionJSON(o any) any {
+	option, ok := o.(map[string]any)
+	if !ok {
+		return o
+	}
+	out := map[string]any{"label": option["name"], "value": option["value"]}
+	if description, ok := option["description"]; ok {
+		out["description"] = description
+	}
+	if option["focused"] == true {
+		out["default"] = true
+	}
+	return out
+}
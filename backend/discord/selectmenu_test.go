@@ -0,0 +1,88 @@
+package discord
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Karitham/corde"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectMenus(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	var got []string
+	mux := corde.NewMux(hex.EncodeToString(pub), 1, "")
+	mux.Mount(corde.SelectMenuInteraction, "list/sort", func(ctx context.Context, w corde.ResponseWriter, i *corde.Interaction[corde.ModalInteractionData]) {
+		got = selectValues(ctx)
+		w.Update(corde.NewResp().Content("sorted").ActionRow(selectMenu{
+			CustomID:    "list/sort/1",
+			Placeholder: "Sort by",
+			Options: []selectOption{
+				{Label: "Sort: Date", Value: "d"},
+				{Label: "Sort: Rarity", Value: "r", Default: true},
+			},
+		}.Component()))
+	})
+	h := selectMenus(mux)
+
+	body := `{"type":3,"id":"1","token":"t","data":{"custom_id":"list/sort/1","component_type":3,"values":["r"]}}`
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set("X-Signature-Timestamp", "1700000000")
+	req.Header.Set("X-Signature-Ed25519", hex.EncodeToString(ed25519.Sign(priv, []byte("1700000000"+body))))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []string{"r"}, got)
+
+	var resp struct {
+		Data struct {
+			Content    string `json:"content"`
+			Components []struct {
+				Components []struct {
+					Type        int              `json:"type"`
+					Placeholder string           `json:"placeholder"`
+					Options     []map[string]any `json:"options"`
+				} `json:"components"`
+			} `json:"components"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "sorted", resp.Data.Content)
+	require.Len(t, resp.Data.Components, 1)
+	require.Len(t, resp.Data.Components[0].Components, 1)
+	menu := resp.Data.Components[0].Components[0]
+	assert.Equal(t, int(corde.COMPONENT_SELECT_MENU), menu.Type)
+	assert.Equal(t, "Sort by", menu.Placeholder)
+	assert.Equal(t, []map[string]any{
+		{"label": "Sort: Date", "value": "d"},
+		{"label": "Sort: Rarity", "value": "r", "default": true},
+	}, menu.Options)
+}
+
+func TestSelectMenus_Unverified(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	called := false
+	mux := corde.NewMux(hex.EncodeToString(pub), 1, "")
+	mux.Mount(corde.SelectMenuInteraction, "list/sort", func(context.Context, corde.ResponseWriter, *corde.Interaction[corde.ModalInteractionData]) {
+		called = true
+	})
+
+	body := `{"type":3,"data":{"custom_id":"list/sort/1","component_type":3,"values":["r"]}}`
+	rec := httptest.NewRecorder()
+	selectMenus(mux).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.False(t, called)
+}
@@ -0,0 +1,107 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/Karitham/corde"
+
+	"github.com/karitham/waifubot/collection"
+)
+
+// seriesMissingShown is how many missing characters /collection series lists.
+const seriesMissingShown = 15
+
+// CollectionHandler handles the /collection command.
+type CollectionHandler struct {
+	store        collection.Store
+	animeService TrackingService
+	mediaSource  collection.MediaCharacterSource
+}
+
+// Register wires the collection sub-routes on the mux.
+func (h *CollectionHandler) Register(m *corde.Mux) {
+	m.Route("series", func(m *corde.Mux) {
+		m.SlashCommand("", trace(wrapCtx(h.Series)))
+		m.Autocomplete("series", h.SeriesAutocomplete)
+	})
+}
+
+// seriesOptions holds the parsed options for the collection series command.
+type seriesOptions struct {
+	mediaID        int64
+	targetUserID   uint64
+	targetUsername string
+}
+
+func parseSeriesOptions(cmd CommandContext) (seriesOptions, error) {
+	mediaID, err := cmd.OptInt64("series")
+	if err != nil {
+		return seriesOptions{}, fmt.Errorf("select a series to check: %w", err)
+	}
+	opts := seriesOptions{
+		mediaID:        mediaID,
+		targetUserID:   cmd.UserID(),
+		targetUsername: cmd.Username(),
+	}
+	if user, ok := cmd.FirstResolvedUser(); ok {
+		opts.targetUserID = uint64(user.ID)
+		opts.targetUsername = user.Username
+	}
+	return opts, nil
+}
+
+// Series shows how much of a series a user owns and which characters are missing.
+func (h *CollectionHandler) Series(ctx context.Context, w corde.ResponseWriter, cmd CommandContext) {
+	logger := slog.With("user_id", cmd.UserID(), "guild_id", cmd.GuildID())
+
+	opts, err := parseSeriesOptions(cmd)
+	if err != nil {
+		w.Respond(rspErr(err.Error()))
+		return
+	}
+
+	progress, err := collection.SeriesCompletion(ctx, h.store, h.mediaSource, opts.targetUserID, opts.mediaID)
+	if err != nil {
+		if errors.Is(err, collection.ErrMediaNotFound) {
+			w.Respond(rspErr("No characters found for this series"))
+			return
+		}
+		logger.Error("error getting series completion", "error", err, "media_id", opts.mediaID)
+		w.Respond(rspErr("An error occurred, please try again later"))
+		return
+	}
+
+	w.Respond(seriesEmbed(opts.targetUsername, progress))
+}
+
+func seriesEmbed(username string, p collection.SeriesProgress) corde.Embed {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s owns **%d** of %d characters.\n", username, p.Owned, p.Total)
+	if len(p.Missing) == 0 {
+		sb.WriteString("\nThis series is complete! 🎉")
+	} else {
+		sb.WriteString("\n**Missing**\n")
+		for _, c := range p.Missing[:min(len(p.Missing), seriesMissingShown)] {
+			fmt.Fprintf(&sb, "`%d` %s (❤️ %d)\n", c.ID, c.Name, c.Favorites)
+		}
+		if more := len(p.Missing) - seriesMissingShown; more > 0 {
+			fmt.Fprintf(&sb, "…and %d more\n", more)
+		}
+	}
+
+	return corde.NewEmbed().
+		// Truncate so a series is only shown as 100% once it is complete.
+		Title(fmt.Sprintf("%s: %d%% complete", p.Title, int(p.Percent()))).
+		Description(sb.String()).
+		Color(AnilistColor).
+		Embed()
+}
+
+// SeriesAutocomplete provides media suggestions for the collection series command.
+func (h *CollectionHandler) SeriesAutocomplete(ctx context.Context, w corde.ResponseWriter, i *corde.Interaction[corde.AutocompleteInteractionData]) {
+	autocomplete(ctx, w, i, "series", h.animeService.SearchMedia, formatMediaChoice)
+}
@@ -0,0 +1,86 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/karitham/waifubot/catalog"
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/collection/collectiontest"
+	"github.com/karitham/waifubot/discord/cordetest"
+)
+
+func TestCollectionHandler_Series(t *testing.T) {
+	media := func(context.Context, int64) (catalog.Media, error) {
+		return catalog.Media{ID: 21, Title: "One Piece", CharactersSyncedAt: time.Now()}, nil
+	}
+	chars := func(context.Context, int64) ([]catalog.Character, error) {
+		return []catalog.Character{
+			{ID: 62, Name: "Roronoa Zoro", Favorites: 90},
+			{ID: 40, Name: "Monkey D. Luffy", Favorites: 50},
+			{ID: 723, Name: "Nami", Favorites: 30},
+		}, nil
+	}
+
+	tests := []struct {
+		name        string
+		store       *collectiontest.MockStore
+		wantContent []string
+	}{
+		{
+			name: "in progress",
+			store: &collectiontest.MockStore{
+				GetMediaFunc:          media,
+				CharactersByMediaFunc: chars,
+				GetCollectionIDsFunc: func(context.Context, collection.UserID) ([]int64, error) {
+					return []int64{40, 62}, nil
+				},
+			},
+			wantContent: []string{"One Piece: 66% complete", "testuser owns **2** of 3 characters", "`723` Nami"},
+		},
+		{
+			name: "complete",
+			store: &collectiontest.MockStore{
+				GetMediaFunc:          media,
+				CharactersByMediaFunc: chars,
+				GetCollectionIDsFunc: func(context.Context, collection.UserID) ([]int64, error) {
+					return []int64{40, 62, 723}, nil
+				},
+			},
+			wantContent: []string{"One Piece: 100% complete", "This series is complete!"},
+		},
+		{
+			name:        "unknown series",
+			store:       &collectiontest.MockStore{},
+			wantContent: []string{"No characters found for this series"},
+		},
+		{
+			name: "store error",
+			store: &collectiontest.MockStore{
+				GetMediaFunc: func(context.Context, int64) (catalog.Media, error) {
+					return catalog.Media{}, errors.New("database on fire")
+				},
+			},
+			wantContent: []string{"An error occurred"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &cordetest.MockResponseWriter{}
+			cmd := &MockCommandContext{UserIDVal: 1, UsernameVal: "testuser", OptInt64Vals: map[string]int64{"series": 21}}
+			h := &CollectionHandler{store: tt.store, mediaSource: &collectiontest.MockAnimeService{}}
+
+			h.Series(t.Context(), w, cmd)
+
+			assert.True(t, w.RespondCalled)
+			for _, want := range tt.wantContent {
+				w.AssertContains(t, want)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 
 	"github.com/Karitham/corde"
 
@@ -16,13 +17,15 @@ import (
 type TokenHandler struct {
 	store        collection.Store
 	animeService TrackingService
+	mediaSource  collection.MediaCharacterSource
 	rollService  *collection.RollService
-	config       collection.Config
+	config       collection.ConfigSource
 }
 
 // Register wires the token sub-routes on the mux.
 func (h *TokenHandler) Register(m *corde.Mux) {
 	m.SlashCommand("balance", trace(wrapCtx(h.Balance)))
+	m.SlashCommand("history", trace(wrapCtx(h.History)))
 	m.SlashCommand("give", wrap(wrapCtx(h.Give), trace[corde.SlashCommandInteractionData]))
 	m.Route("sell", func(m *corde.Mux) {
 		m.SlashCommand("", wrap(
@@ -50,6 +53,67 @@ func (h *TokenHandler) Balance(ctx context.Context, w corde.ResponseWriter, cmd
 	w.Respond(corde.NewResp().Contentf("You have %d tokens", user.Tokens).Ephemeral())
 }
 
+// tokenHistoryLimit is how many token changes /token history shows.
+const tokenHistoryLimit = 15
+
+// History shows where the user's recent tokens came from and went to.
+func (h *TokenHandler) History(ctx context.Context, w corde.ResponseWriter, cmd CommandContext) {
+	changes, err := collection.TokenHistory(ctx, h.store, cmd.UserID(), tokenHistoryLimit)
+	if err != nil {
+		slog.Error("error getting token history", "error", err, "user_id", cmd.UserID())
+		w.Respond(rspErr("Failed to get your token history"))
+		return
+	}
+	if len(changes) == 0 {
+		w.Respond(Privf("You have no token history yet"))
+		return
+	}
+
+	var sb strings.Builder
+	for _, c := range changes {
+		fmt.Fprintf(&sb, "<t:%d:d> **%+d** %s, balance %d\n", c.CreatedAt.Unix(), c.Amount, describeTokenChange(c), c.Balance)
+	}
+
+	w.Respond(corde.NewResp().Embeds(corde.NewEmbed().
+		Title("Token history").
+		Description(sb.String()).
+		Color(AnilistColor),
+	).Ephemeral())
+}
+
+// describeTokenChange says why a balance changed, from the user's point of view.
+func describeTokenChange(c collection.TokenChange) string {
+	switch c.Reason {
+	case collection.TokenOpeningBalance:
+		return "opening balance"
+	case collection.TokenTransfer:
+		if c.Amount < 0 {
+			return fmt.Sprintf("sent to <@%d>", c.CounterpartyID)
+		}
+		return fmt.Sprintf("received from <@%d>", c.CounterpartyID)
+	case collection.TokenTrade:
+		return fmt.Sprintf("trade #%d with <@%d>", c.ReferenceID, c.CounterpartyID)
+	case collection.TokenSell:
+		return "sold a character"
+	case collection.TokenSeriesRoll:
+		return "series roll"
+	case collection.TokenAuctionBid:
+		return fmt.Sprintf("bid on auction #%d", c.ReferenceID)
+	case collection.TokenAuctionRefund:
+		return fmt.Sprintf("refund from auction #%d", c.ReferenceID)
+	case collection.TokenAuctionSale:
+		return fmt.Sprintf("sold at auction #%d", c.ReferenceID)
+	case collection.TokenAdmin:
+		return "adjusted by an admin"
+	case collection.TokenDaily:
+		return "daily reward"
+	case collection.TokenStreakBonus:
+		return fmt.Sprintf("%d day streak bonus", c.ReferenceID)
+	default:
+		return string(c.Reason)
+	}
+}
+
 // tokenGiveOptions holds the parsed options for the token give command.
 type tokenGiveOptions struct {
 	recipientID uint64
@@ -171,10 +235,17 @@ func (h *TokenHandler) Roll(ctx context.Context, w corde.ResponseWriter, cmd Com
 		return
 	}
 
-	char, err := h.rollService.SeriesRoll(ctx, cmd.UserID(), opts.mediaID, h.config.SeriesRollCost, h.animeService)
+	config, err := h.config.GuildConfig(ctx, cmd.GuildID())
+	if err != nil {
+		logger.Error("error loading guild config", "error", err)
+		w.Respond(rspErr("An error occurred, please try again later"))
+		return
+	}
+
+	char, err := h.rollService.SeriesRoll(ctx, cmd.UserID(), opts.mediaID, config.SeriesRollCost, h.mediaSource)
 	if err != nil {
 		if errors.Is(err, collection.ErrInsufficientTokens) {
-			w.Respond(rspErr(fmt.Sprintf("You need %d tokens to roll for a series", h.config.SeriesRollCost)))
+			w.Respond(rspErr(fmt.Sprintf("You need %d tokens to roll for a series", config.SeriesRollCost)))
 			return
 		}
 		if errors.Is(err, collection.ErrNoUnownedCharacters) {
@@ -190,7 +261,9 @@ func (h *TokenHandler) Roll(ctx context.Context, w corde.ResponseWriter, cmd Com
 		return
 	}
 
-	w.Respond(seriesRollEmbed(char, h.config.SeriesRollCost))
+	w.Respond(corde.NewResp().
+		Embeds(seriesRollEmbed(char, config.SeriesRollCost)).
+		Embeds(unlockedAchievements(ctx, h.store, cmd.UserID(), collection.EventSeriesRoll, collection.Character{ID: char.ID, Favorites: char.Favorites})...))
 }
 
 // SeriesAutocomplete provides media suggestions for the token roll command.
@@ -60,6 +60,57 @@ func TestTokenHandler_Balance(t *testing.T) {
 	}
 }
 
+func TestTokenHandler_History(t *testing.T) {
+	tests := []struct {
+		name        string
+		store       *collectiontest.MockStore
+		wantContent []string
+	}{
+		{
+			name: "changes",
+			store: &collectiontest.MockStore{
+				ListTokenChangesFunc: func(ctx context.Context, userID collection.UserID, limit int32) ([]collection.TokenChange, error) {
+					return []collection.TokenChange{
+						{Amount: -3, Balance: 4, Reason: collection.TokenTransfer, CounterpartyID: 2},
+						{Amount: 1, Balance: 7, Reason: collection.TokenSell},
+						{Amount: 6, Balance: 6, Reason: collection.TokenAuctionSale, CounterpartyID: 3, ReferenceID: 9},
+					}, nil
+				},
+			},
+			wantContent: []string{"**-3** sent to <@2>, balance 4", "**+1** sold a character", "sold at auction #9"},
+		},
+		{
+			name:        "no changes",
+			store:       &collectiontest.MockStore{},
+			wantContent: []string{"no token history"},
+		},
+		{
+			name: "store error",
+			store: &collectiontest.MockStore{
+				ListTokenChangesFunc: func(ctx context.Context, userID collection.UserID, limit int32) ([]collection.TokenChange, error) {
+					return nil, errors.New("database on fire")
+				},
+			},
+			wantContent: []string{"Failed to get your token history"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &cordetest.MockResponseWriter{}
+			cmd := &MockCommandContext{UserIDVal: 1}
+			h := &TokenHandler{store: tt.store}
+
+			h.History(t.Context(), w, cmd)
+
+			assert.True(t, w.RespondCalled)
+			for _, want := range tt.wantContent {
+				w.AssertContains(t, want)
+			}
+		})
+	}
+}
+
 func TestTokenHandler_Give(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -381,7 +432,7 @@ func TestTokenHandler_Roll(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			w := &cordetest.MockResponseWriter{}
-			h := &TokenHandler{store: tt.store, animeService: tt.animeService, rollService: collection.NewRollService(tt.store, collection.RollConfig{RollCooldown: tokenConfig.RollCooldown}), config: tokenConfig}
+			h := &TokenHandler{store: tt.store, mediaSource: tt.animeService, rollService: collection.NewRollService(tt.store, tokenConfig), config: tokenConfig}
 
 			h.Roll(t.Context(), w, tt.cmd)
 
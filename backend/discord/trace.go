@@ -11,8 +11,12 @@ import (
 func trace[T corde.InteractionDataConstraint](next func(ctx context.Context, w corde.ResponseWriter, i *corde.Interaction[T])) func(ctx context.Context, w corde.ResponseWriter, i *corde.Interaction[T]) {
 	return func(ctx context.Context, w corde.ResponseWriter, i *corde.Interaction[T]) {
 		start := time.Now()
+		route := i.Route
+		if i.Type == corde.INTERACTION_TYPE_MESSAGE_COMPONENT || i.Type == corde.INTERACTION_TYPE_MODAL {
+			route = componentRoute(route)
+		}
 		l := slog.With(
-			"route", i.Route,
+			"route", route,
 			"guild", i.GuildID,
 			"channel", i.ChannelID,
 			"user", i.Member.User.ID,
@@ -22,8 +26,8 @@ func trace[T corde.InteractionDataConstraint](next func(ctx context.Context, w c
 		next(ctx, w, i)
 
 		duration := time.Since(start)
-		commandCounter.WithLabelValues(i.Route).Inc()
-		commandDuration.WithLabelValues(i.Route).Observe(duration.Seconds())
+		commandCounter.WithLabelValues(route).Inc()
+		commandDuration.WithLabelValues(route).Observe(duration.Seconds())
 
 		l.Info("request completed", "took", duration.String())
 	}
@@ -0,0 +1,273 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/Karitham/corde"
+
+	"github.com/karitham/waifubot/collection"
+)
+
+// TradeHandler handles the /trade command and its subcommands.
+type TradeHandler struct {
+	store collection.Store
+}
+
+// Register wires the trade sub-routes on the mux.
+func (h *TradeHandler) Register(m *corde.Mux) {
+	m.SlashCommand("offer", wrap(wrapCtx(h.Offer), trace[corde.SlashCommandInteractionData]))
+	m.SlashCommand("accept", wrap(wrapCtx(h.Accept), trace[corde.SlashCommandInteractionData]))
+	m.SlashCommand("decline", wrap(wrapCtx(h.Decline), trace[corde.SlashCommandInteractionData]))
+	m.SlashCommand("cancel", wrap(wrapCtx(h.Cancel), trace[corde.SlashCommandInteractionData]))
+	m.SlashCommand("list", wrap(wrapCtx(h.List), trace[corde.SlashCommandInteractionData]))
+}
+
+// tradeOfferOptions holds the parsed options for the trade offer command.
+type tradeOfferOptions struct {
+	recipient       corde.User
+	offered         []int64
+	requested       []int64
+	offeredTokens   int32
+	requestedTokens int32
+}
+
+// parseTradeOfferOptions parses the trade offer options.
+// Everything except the user is optional; missing options count as nothing offered.
+func parseTradeOfferOptions(cmd CommandContext) (tradeOfferOptions, error) {
+	user, err := cmd.OptUser("user")
+	if err != nil {
+		return tradeOfferOptions{}, fmt.Errorf("select a user to trade with: %w", err)
+	}
+
+	give, _ := cmd.OptString("give")
+	offered, err := parseCharacterIDs(give)
+	if err != nil {
+		return tradeOfferOptions{}, fmt.Errorf("invalid character IDs to give: %w", err)
+	}
+	want, _ := cmd.OptString("want")
+	requested, err := parseCharacterIDs(want)
+	if err != nil {
+		return tradeOfferOptions{}, fmt.Errorf("invalid character IDs to receive: %w", err)
+	}
+
+	giveTokens, _ := cmd.OptInt("give_tokens")
+	wantTokens, _ := cmd.OptInt("want_tokens")
+	if giveTokens > math.MaxInt32 || wantTokens > math.MaxInt32 {
+		return tradeOfferOptions{}, fmt.Errorf("token amounts cannot exceed %d", math.MaxInt32)
+	}
+
+	return tradeOfferOptions{
+		recipient:       user,
+		offered:         offered,
+		requested:       requested,
+		offeredTokens:   int32(giveTokens),
+		requestedTokens: int32(wantTokens),
+	}, nil
+}
+
+// parseCharacterIDs splits a comma or space separated list of character IDs.
+func parseCharacterIDs(s string) ([]int64, error) {
+	fields := strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' })
+	ids := make([]int64, 0, len(fields))
+	for _, f := range fields {
+		id, err := strconv.ParseInt(f, 10, 64)
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("%q is not a character ID", f)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// Offer proposes a trade to another user.
+func (h *TradeHandler) Offer(ctx context.Context, w corde.ResponseWriter, cmd CommandContext) {
+	logger := slog.With("user_id", cmd.UserID(), "guild_id", cmd.GuildID())
+
+	opts, err := parseTradeOfferOptions(cmd)
+	if err != nil {
+		w.Respond(rspErr(err.Error()))
+		return
+	}
+
+	offer, err := collection.OfferTrade(ctx, h.store, collection.TradeOffer{
+		FromUserID:          cmd.UserID(),
+		ToUserID:            uint64(opts.recipient.ID),
+		OfferedCharacters:   opts.offered,
+		RequestedCharacters: opts.requested,
+		OfferedTokens:       opts.offeredTokens,
+		RequestedTokens:     opts.requestedTokens,
+	})
+	if err != nil {
+		if msg, ok := tradeErrMessage(err); ok {
+			w.Respond(rspErr(msg))
+			return
+		}
+		logger.Error("error creating trade offer", "error", err, "to_user_id", opts.recipient.ID)
+		w.Respond(rspErr("Failed to create trade offer"))
+		return
+	}
+
+	w.Respond(corde.NewResp().Contentf(
+		"<@%d>, <@%d> wants to trade with you (offer #%d).\n%s\nUse `/trade accept id:%d` or `/trade decline id:%d`. Expires <t:%d:R>.",
+		offer.ToUserID, offer.FromUserID, offer.ID,
+		h.describeOffer(ctx, offer),
+		offer.ID, offer.ID, offer.ExpiresAt.Unix(),
+	))
+}
+
+// Accept executes a trade offer the user received.
+func (h *TradeHandler) Accept(ctx context.Context, w corde.ResponseWriter, cmd CommandContext) {
+	logger := slog.With("user_id", cmd.UserID(), "guild_id", cmd.GuildID())
+
+	id, err := cmd.OptInt64("id")
+	if err != nil {
+		w.Respond(rspErr("specify the trade offer ID"))
+		return
+	}
+
+	offer, err := collection.AcceptTrade(ctx, h.store, cmd.UserID(), id)
+	if err != nil {
+		if msg, ok := tradeErrMessage(err); ok {
+			w.Respond(rspErr(msg))
+			return
+		}
+		logger.Error("error accepting trade offer", "error", err, "trade_id", id)
+		w.Respond(rspErr("Failed to accept trade offer"))
+		return
+	}
+
+	w.Respond(corde.NewResp().Contentf(
+		"Trade #%d between <@%d> and <@%d> is done.\n%s",
+		offer.ID, offer.FromUserID, offer.ToUserID, h.describeOffer(ctx, offer),
+	))
+}
+
+// Decline rejects a trade offer the user received.
+func (h *TradeHandler) Decline(ctx context.Context, w corde.ResponseWriter, cmd CommandContext) {
+	h.close(ctx, w, cmd, collection.DeclineTrade, "declined")
+}
+
+// Cancel withdraws a trade offer the user sent.
+func (h *TradeHandler) Cancel(ctx context.Context, w corde.ResponseWriter, cmd CommandContext) {
+	h.close(ctx, w, cmd, collection.CancelTrade, "cancelled")
+}
+
+func (h *TradeHandler) close(
+	ctx context.Context,
+	w corde.ResponseWriter,
+	cmd CommandContext,
+	fn func(context.Context, collection.Store, collection.UserID, int64) (collection.TradeOffer, error),
+	verb string,
+) {
+	logger := slog.With("user_id", cmd.UserID(), "guild_id", cmd.GuildID())
+
+	id, err := cmd.OptInt64("id")
+	if err != nil {
+		w.Respond(rspErr("specify the trade offer ID"))
+		return
+	}
+
+	offer, err := fn(ctx, h.store, cmd.UserID(), id)
+	if err != nil {
+		if msg, ok := tradeErrMessage(err); ok {
+			w.Respond(rspErr(msg))
+			return
+		}
+		logger.Error("error closing trade offer", "error", err, "trade_id", id, "status", verb)
+		w.Respond(rspErr("Failed to update trade offer"))
+		return
+	}
+
+	w.Respond(corde.NewResp().Contentf("Trade #%d between <@%d> and <@%d> was %s.", offer.ID, offer.FromUserID, offer.ToUserID, verb))
+}
+
+// List shows the user's pending trade offers.
+func (h *TradeHandler) List(ctx context.Context, w corde.ResponseWriter, cmd CommandContext) {
+	logger := slog.With("user_id", cmd.UserID(), "guild_id", cmd.GuildID())
+
+	offers, err := collection.ListTrades(ctx, h.store, cmd.UserID())
+	if err != nil {
+		logger.Error("error listing trade offers", "error", err)
+		w.Respond(rspErr("Failed to list trade offers"))
+		return
+	}
+	if len(offers) == 0 {
+		w.Respond(Privf("You have no pending trade offers"))
+		return
+	}
+
+	var sb strings.Builder
+	for _, o := range offers {
+		dir := fmt.Sprintf("to <@%d>", o.ToUserID)
+		if o.ToUserID == cmd.UserID() {
+			dir = fmt.Sprintf("from <@%d>", o.FromUserID)
+		}
+		fmt.Fprintf(&sb, "**#%d** %s, expires <t:%d:R>\n%s\n", o.ID, dir, o.ExpiresAt.Unix(), h.describeOffer(ctx, o))
+	}
+
+	w.Respond(corde.NewResp().Embeds(corde.NewEmbed().
+		Title("Pending trades").
+		Description(sb.String()).
+		Color(AnilistColor),
+	).Ephemeral())
+}
+
+// describeOffer renders both sides of an offer, resolving character names where possible.
+func (h *TradeHandler) describeOffer(ctx context.Context, o collection.TradeOffer) string {
+	return fmt.Sprintf("<@%d> gives: %s\n<@%d> gives: %s",
+		o.FromUserID, h.describeSide(ctx, o.OfferedCharacters, o.OfferedTokens),
+		o.ToUserID, h.describeSide(ctx, o.RequestedCharacters, o.RequestedTokens),
+	)
+}
+
+func (h *TradeHandler) describeSide(ctx context.Context, charIDs []int64, tokens int32) string {
+	parts := make([]string, 0, len(charIDs)+1)
+	for _, id := range charIDs {
+		char, err := h.store.GetCharacterByID(ctx, id)
+		if err != nil || char.Name == "" {
+			parts = append(parts, strconv.FormatInt(id, 10))
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s (%d)", char.Name, id))
+	}
+	if tokens > 0 {
+		parts = append(parts, fmt.Sprintf("%d tokens", tokens))
+	}
+	if len(parts) == 0 {
+		return "nothing"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// tradeErrMessage maps expected trade errors to a user-facing message.
+func tradeErrMessage(err error) (string, bool) {
+	switch {
+	case errors.Is(err, collection.ErrTradeNotFound):
+		return "That trade offer doesn't exist or isn't yours", true
+	case errors.Is(err, collection.ErrTradeNotPending):
+		return "That trade offer is no longer pending", true
+	case errors.Is(err, collection.ErrTradeExpired):
+		return "That trade offer has expired", true
+	case errors.Is(err, collection.ErrEmptyTrade):
+		return "A trade needs at least one character or token on either side", true
+	case errors.Is(err, collection.ErrTradeTooLarge):
+		return fmt.Sprintf("Each side can trade at most %d characters", collection.MaxTradeCharacters), true
+	case errors.Is(err, collection.ErrSameUserTransfer):
+		return "You cannot trade with yourself", true
+	case errors.Is(err, collection.ErrInvalidAmount):
+		return "Token amounts cannot be negative", true
+	case errors.Is(err, collection.ErrInsufficientTokens):
+		return "Not enough tokens to complete this trade", true
+	case errors.Is(err, collection.ErrUserDoesNotOwnCharacter):
+		return fmt.Sprintf("A character in this trade isn't owned by its trader anymore (%s)", err), true
+	case errors.Is(err, collection.ErrAlreadyOwned):
+		return fmt.Sprintf("A character in this trade is already owned by its recipient (%s)", err), true
+	}
+	return "", false
+}
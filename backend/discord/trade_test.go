@@ -0,0 +1,196 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Karitham/corde"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karitham/waifubot/catalog"
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/collection/collectiontest"
+	"github.com/karitham/waifubot/discord/cordetest"
+)
+
+func TestParseCharacterIDs(t *testing.T) {
+	ids, err := parseCharacterIDs("1, 2 3,,4")
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2, 3, 4}, ids)
+
+	ids, err = parseCharacterIDs("")
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+
+	_, err = parseCharacterIDs("1,abc")
+	assert.Error(t, err)
+
+	_, err = parseCharacterIDs("-3")
+	assert.Error(t, err)
+}
+
+func TestTradeHandler_Offer(t *testing.T) {
+	tests := []struct {
+		name        string
+		cmd         CommandContext
+		store       *collectiontest.MockStore
+		wantContent string
+	}{
+		{
+			name: "missing user",
+			cmd: &MockCommandContext{
+				UserIDVal: 1,
+				ErrVal:    errors.New("option not found"),
+			},
+			store:       &collectiontest.MockStore{},
+			wantContent: "select a user",
+		},
+		{
+			name: "invalid ids",
+			cmd: &MockCommandContext{
+				UserIDVal:     1,
+				OptUserVals:   map[string]corde.User{"user": {ID: 2}},
+				OptStringVals: map[string]string{"give": "sakura"},
+			},
+			store:       &collectiontest.MockStore{},
+			wantContent: "invalid character IDs",
+		},
+		{
+			name: "too many tokens",
+			cmd: &MockCommandContext{
+				UserIDVal:   1,
+				OptUserVals: map[string]corde.User{"user": {ID: 2}},
+				OptIntVals:  map[string]int{"want_tokens": math.MaxInt32 + 1},
+			},
+			store:       &collectiontest.MockStore{},
+			wantContent: "cannot exceed",
+		},
+		{
+			name: "empty trade",
+			cmd: &MockCommandContext{
+				UserIDVal:   1,
+				OptUserVals: map[string]corde.User{"user": {ID: 2}},
+			},
+			store:       &collectiontest.MockStore{},
+			wantContent: "at least one character or token",
+		},
+		{
+			name: "success",
+			cmd: &MockCommandContext{
+				UserIDVal:     1,
+				OptUserVals:   map[string]corde.User{"user": {ID: 2}},
+				OptStringVals: map[string]string{"give": "42"},
+				OptIntVals:    map[string]int{"want_tokens": 5},
+			},
+			store: &collectiontest.MockStore{
+				GetOwnedCharacterFunc: func(ctx context.Context, userID collection.UserID, charID int64) (collection.OwnedCharacter, error) {
+					if userID == 1 {
+						return collection.OwnedCharacter{}, nil
+					}
+					return collection.OwnedCharacter{}, collection.ErrNotFound
+				},
+				CreateTradeOfferFunc: func(ctx context.Context, offer collection.TradeOffer) (collection.TradeOffer, error) {
+					offer.ID = 9
+					return offer, nil
+				},
+				GetCharacterByIDFunc: func(ctx context.Context, charID int64) (catalog.Character, error) {
+					return catalog.Character{ID: charID, Name: "Sakura"}, nil
+				},
+			},
+			wantContent: "Sakura (42)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &cordetest.MockResponseWriter{}
+			h := &TradeHandler{store: tt.store}
+
+			h.Offer(t.Context(), w, tt.cmd)
+
+			assert.True(t, w.RespondCalled)
+			w.AssertContains(t, tt.wantContent)
+		})
+	}
+}
+
+func TestTradeHandler_Accept(t *testing.T) {
+	tests := []struct {
+		name        string
+		store       *collectiontest.MockStore
+		wantContent string
+	}{
+		{
+			name: "not found",
+			store: &collectiontest.MockStore{
+				GetTradeOfferForUpdateFunc: func(ctx context.Context, id int64) (collection.TradeOffer, error) {
+					return collection.TradeOffer{}, collection.ErrNotFound
+				},
+			},
+			wantContent: "doesn't exist",
+		},
+		{
+			name: "expired",
+			store: &collectiontest.MockStore{
+				GetTradeOfferForUpdateFunc: func(ctx context.Context, id int64) (collection.TradeOffer, error) {
+					return collection.TradeOffer{ID: id, FromUserID: 2, ToUserID: 1, Status: collection.TradePending, ExpiresAt: time.Now().Add(-time.Hour)}, nil
+				},
+			},
+			wantContent: "expired",
+		},
+		{
+			name: "store error",
+			store: &collectiontest.MockStore{
+				GetTradeOfferForUpdateFunc: func(ctx context.Context, id int64) (collection.TradeOffer, error) {
+					return collection.TradeOffer{}, errors.New("database on fire")
+				},
+			},
+			wantContent: "Failed to accept",
+		},
+		{
+			name: "success",
+			store: &collectiontest.MockStore{
+				GetTradeOfferForUpdateFunc: func(ctx context.Context, id int64) (collection.TradeOffer, error) {
+					return collection.TradeOffer{ID: id, FromUserID: 2, ToUserID: 1, OfferedTokens: 3, Status: collection.TradePending, ExpiresAt: time.Now().Add(time.Hour)}, nil
+				},
+			},
+			wantContent: "is done",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &cordetest.MockResponseWriter{}
+			cmd := &MockCommandContext{UserIDVal: 1, OptInt64Vals: map[string]int64{"id": 9}}
+			h := &TradeHandler{store: tt.store}
+
+			h.Accept(t.Context(), w, cmd)
+
+			assert.True(t, w.RespondCalled)
+			w.AssertContains(t, tt.wantContent)
+		})
+	}
+}
+
+func TestTradeHandler_List(t *testing.T) {
+	store := &collectiontest.MockStore{
+		ListTradeOffersFunc: func(ctx context.Context, userID collection.UserID, now time.Time) ([]collection.TradeOffer, error) {
+			return []collection.TradeOffer{
+				{ID: 3, FromUserID: 1, ToUserID: 2, OfferedTokens: 4, ExpiresAt: now.Add(time.Hour)},
+				{ID: 4, FromUserID: 5, ToUserID: 1, RequestedTokens: 1, ExpiresAt: now.Add(time.Hour)},
+			}, nil
+		},
+	}
+	w := &cordetest.MockResponseWriter{}
+	h := &TradeHandler{store: store}
+
+	h.List(t.Context(), w, &MockCommandContext{UserIDVal: 1})
+
+	assert.True(t, w.RespondCalled)
+	w.AssertContains(t, "#3** to <@2>")
+	w.AssertContains(t, "#4** from <@5>")
+}
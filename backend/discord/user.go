@@ -1,11 +1,14 @@
 package discord
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
+
+	"github.com/Karitham/corde"
 )
 
 // DiscordUser represents a Discord user object
@@ -63,6 +66,41 @@ func (c *Client) GetUser(ctx context.Context, userID string) (*DiscordUser, erro
 	return &user, nil
 }
 
+// EditMessageEmbeds replaces the embeds of a message, keeping its attachments.
+func (c *Client) EditMessageEmbeds(ctx context.Context, channelID, messageID corde.Snowflake, embeds ...corde.Embed) error {
+	url := fmt.Sprintf("https://discord.com/api/v10/channels/%d/messages/%d", channelID, messageID)
+
+	body, err := json.Marshal(struct {
+		Embeds []corde.Embed `json:"embeds"`
+	}{Embeds: embeds})
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bot "+c.token)
+	req.Header.Set("User-Agent", "WaifuBot (https://github.com/karitham/waifubot)")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close() // Ignore error as we're just cleaning up
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("discord API returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
 func DiscordAvatarURL(userID uint64, avatar string) string {
 	return fmt.Sprintf("https://cdn.discordapp.com/avatars/%d/%s.png", userID, avatar)
 }
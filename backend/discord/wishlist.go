@@ -2,6 +2,7 @@ package discord
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -58,6 +59,7 @@ type WishlistHandler struct {
 	wishlist     wishlist.Store
 	store        collection.Store
 	animeService TrackingService
+	mediaSource  collection.MediaCharacterSource
 	catalog      catalog.Store
 	guildIndexer *guild.Indexer
 	guildTxFn    func(context.Context) (guild.TxQuerier, error)
@@ -107,20 +109,21 @@ func (h *WishlistHandler) CharacterAdd(ctx context.Context, w corde.ResponseWrit
 		opts.charID, _ = cmd.OptInt64("character")
 	}
 
-	// Check if user already owns this character
-	has, char, err := collectionCheckOwnership(ctx, h.store, cmd.UserID(), opts.charID)
-	if err != nil {
-		logger.Error("error checking ownership", "error", err, "character_id", opts.charID)
-		w.Respond(rspErr("Unable to verify character ownership. Please try again."))
-		return
-	}
-	if has {
-		w.Respond(rspErr("You already own this character."))
-		return
-	}
-
-	err = h.wishlist.AddCharactersToWishlist(ctx, cmd.UserID(), []int64{opts.charID})
+	char, err := wishlist.AddCharacterToWishlist(ctx, h.wishlist, h.store, cmd.UserID(), opts.charID)
 	if err != nil {
+		if errors.Is(err, wishlist.ErrAlreadyOwned) {
+			w.Respond(rspErr("You already own this character."))
+			return
+		}
+		if errors.Is(err, wishlist.ErrOwnershipCheck) {
+			logger.Error("error checking ownership", "error", err, "character_id", opts.charID)
+			w.Respond(rspErr("Unable to verify character ownership. Please try again."))
+			return
+		}
+		if errors.Is(err, collection.ErrNotFound) {
+			w.Respond(newErrf("Character %d not found", opts.charID))
+			return
+		}
 		logger.Error("error adding character to wishlist", "error", err, "character_id", opts.charID)
 		w.Respond(rspErr("Unable to add character to wishlist. Please try again."))
 		return
@@ -207,7 +210,7 @@ func (h *WishlistHandler) MediaAdd(ctx context.Context, w corde.ResponseWriter,
 		opts.mediaID, _ = cmd.OptInt64("media")
 	}
 
-	count, err := wishlist.AddMediaToWishlist(ctx, h.wishlist, h.animeService, h.store, cmd.UserID(), opts.mediaID)
+	count, err := wishlist.AddMediaToWishlist(ctx, h.wishlist, h.mediaSource, h.store, cmd.UserID(), opts.mediaID)
 	if err != nil {
 		logger.Error("error adding media to wishlist", "error", err, "media_id", opts.mediaID)
 		w.Respond(rspErr("Unable to add characters from this media to your wishlist. Please try again."))
@@ -350,9 +350,9 @@ func TestWishlistHandler_MediaAdd(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			w := &cordetest.MockResponseWriter{}
 			h := &WishlistHandler{
-				wishlist:     tt.wlStore,
-				store:        tt.collStore,
-				animeService: tt.animeService,
+				wishlist:    tt.wlStore,
+				store:       tt.collStore,
+				mediaSource: tt.animeService,
 			}
 
 			h.MediaAdd(t.Context(), w, tt.cmd)
@@ -0,0 +1,250 @@
+// Package jikan searches MyAnimeList through the Jikan API.
+// IDs in its answers are MyAnimeList IDs, see tracker.Map to use them with the catalog.
+package jikan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/karitham/waifubot/collection"
+)
+
+// DefaultBaseURL is the public Jikan v4 API.
+const DefaultBaseURL = "https://api.jikan.moe/v4"
+
+// searchLimit is how many results a search asks for.
+const searchLimit = 5
+
+// Client is a MyAnimeList tracker backed by Jikan.
+type Client struct {
+	baseURL string
+	c       *http.Client
+}
+
+// New returns a Client for the public Jikan API.
+func New() *Client {
+	return NewWithURL(DefaultBaseURL, &http.Client{Timeout: 5 * time.Second})
+}
+
+// NewWithURL returns a Client for the Jikan API at baseURL.
+func NewWithURL(baseURL string, c *http.Client) *Client {
+	return &Client{baseURL: strings.TrimSuffix(baseURL, "/"), c: c}
+}
+
+// Anime returns the anime matching name, best match first.
+func (c *Client) Anime(ctx context.Context, name string) ([]collection.Media, error) {
+	return c.searchMedia(ctx, "anime", name, searchLimit)
+}
+
+// Manga returns the manga matching name, best match first.
+func (c *Client) Manga(ctx context.Context, name string) ([]collection.Media, error) {
+	return c.searchMedia(ctx, "manga", name, searchLimit)
+}
+
+// SearchMedia returns the anime then the manga matching search.
+func (c *Client) SearchMedia(ctx context.Context, search string) ([]collection.Media, error) {
+	anime, err := c.searchMedia(ctx, "anime", search, 2*searchLimit)
+	if err != nil {
+		return nil, err
+	}
+	manga, err := c.searchMedia(ctx, "manga", search, 2*searchLimit)
+	if err != nil {
+		return nil, err
+	}
+	return append(anime, manga...), nil
+}
+
+func (c *Client) searchMedia(ctx context.Context, kind, search string, limit int) ([]collection.Media, error) {
+	var resp struct {
+		Data []media `json:"data"`
+	}
+	q := url.Values{"q": {search}, "limit": {strconv.Itoa(limit)}}
+	if err := c.get(ctx, "/"+kind, q, &resp); err != nil {
+		return nil, err
+	}
+
+	res := make([]collection.Media, len(resp.Data))
+	for i, m := range resp.Data {
+		res[i] = m.toMedia(strings.ToUpper(kind))
+	}
+	return res, nil
+}
+
+// User returns the MyAnimeList users matching name.
+func (c *Client) User(ctx context.Context, name string) ([]collection.TrackerUser, error) {
+	var resp struct {
+		Data []struct {
+			Username string `json:"username"`
+			URL      string `json:"url"`
+			Images   images `json:"images"`
+		} `json:"data"`
+	}
+	q := url.Values{"q": {name}, "limit": {strconv.Itoa(searchLimit)}}
+	if err := c.get(ctx, "/users", q, &resp); err != nil {
+		return nil, err
+	}
+
+	users := make([]collection.TrackerUser, len(resp.Data))
+	for i, u := range resp.Data {
+		users[i] = collection.TrackerUser{
+			Name:     u.Username,
+			URL:      u.URL,
+			ImageURL: u.Images.JPG.ImageURL,
+		}
+	}
+	return users, nil
+}
+
+// Character returns the characters matching name, most favorited first.
+func (c *Client) Character(ctx context.Context, name string) ([]collection.MediaCharacter, error) {
+	var resp struct {
+		Data []character `json:"data"`
+	}
+	q := url.Values{
+		"q":        {name},
+		"limit":    {strconv.Itoa(searchLimit)},
+		"order_by": {"favorites"},
+		"sort":     {"desc"},
+	}
+	if err := c.get(ctx, "/characters", q, &resp); err != nil {
+		return nil, err
+	}
+
+	chars := make([]collection.MediaCharacter, len(resp.Data))
+	for i, ch := range resp.Data {
+		chars[i] = ch.toCharacter()
+	}
+	return chars, nil
+}
+
+// GetMediaCharacters returns the characters of an anime.
+// MyAnimeList anime and manga IDs overlap, use GetMangaCharacters for manga.
+func (c *Client) GetMediaCharacters(ctx context.Context, animeID int64) ([]collection.MediaCharacter, error) {
+	return c.mediaCharacters(ctx, "anime", animeID)
+}
+
+// GetMangaCharacters returns the characters of a manga.
+func (c *Client) GetMangaCharacters(ctx context.Context, mangaID int64) ([]collection.MediaCharacter, error) {
+	return c.mediaCharacters(ctx, "manga", mangaID)
+}
+
+func (c *Client) mediaCharacters(ctx context.Context, kind string, id int64) ([]collection.MediaCharacter, error) {
+	var resp struct {
+		Data []struct {
+			Character character `json:"character"`
+			Favorites int       `json:"favorites"`
+		} `json:"data"`
+	}
+	if err := c.get(ctx, fmt.Sprintf("/%s/%d/characters", kind, id), nil, &resp); err != nil {
+		return nil, err
+	}
+
+	chars := make([]collection.MediaCharacter, len(resp.Data))
+	for i, d := range resp.Data {
+		chars[i] = d.Character.toCharacter()
+		chars[i].Favorites = d.Favorites
+	}
+	return chars, nil
+}
+
+// get decodes the answer to a GET of path into v.
+func (c *Client) get(ctx context.Context, path string, q url.Values, v any) error {
+	u := c.baseURL + path
+	if len(q) > 0 {
+		u += "?" + q.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("jikan %s: unexpected status %s", path, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("error decoding jikan %s: %w", path, err)
+	}
+	return nil
+}
+
+type images struct {
+	JPG struct {
+		ImageURL      string `json:"image_url"`
+		LargeImageURL string `json:"large_image_url"`
+	} `json:"jpg"`
+}
+
+// large returns the largest image Jikan has.
+func (i images) large() string {
+	if i.JPG.LargeImageURL != "" {
+		return i.JPG.LargeImageURL
+	}
+	return i.JPG.ImageURL
+}
+
+type media struct {
+	MalID    int64  `json:"mal_id"`
+	URL      string `json:"url"`
+	Images   images `json:"images"`
+	Title    string `json:"title"`
+	Synopsis string `json:"synopsis"`
+	// Members is used as popularity: MyAnimeList's own popularity is a rank.
+	Members int `json:"members"`
+}
+
+func (m media) toMedia(mediaType string) collection.Media {
+	return collection.Media{
+		ID:            m.MalID,
+		MalID:         m.MalID,
+		Title:         m.Title,
+		URL:           m.URL,
+		CoverImageURL: m.Images.large(),
+		Description:   m.Synopsis,
+		Type:          mediaType,
+		Popularity:    m.Members,
+	}
+}
+
+type character struct {
+	MalID     int64    `json:"mal_id"`
+	URL       string   `json:"url"`
+	Images    images   `json:"images"`
+	Name      string   `json:"name"`
+	Nicknames []string `json:"nicknames"`
+	Favorites int      `json:"favorites"`
+	About     string   `json:"about"`
+}
+
+// toCharacter converts c, naming it first name first.
+func (c character) toCharacter() collection.MediaCharacter {
+	return collection.MediaCharacter{
+		ID:          c.MalID,
+		Name:        displayName(c.Name),
+		ImageURL:    c.Images.large(),
+		URL:         c.URL,
+		Description: c.About,
+		Favorites:   c.Favorites,
+		Aliases:     c.Nicknames,
+	}
+}
+
+// displayName turns MyAnimeList's "Family, Given" names into "Given Family".
+func displayName(name string) string {
+	family, given, ok := strings.Cut(name, ", ")
+	if !ok {
+		return name
+	}
+	return given + " " + family
+}
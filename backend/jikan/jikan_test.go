@@ -0,0 +1,111 @@
+package jikan
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karitham/waifubot/collection"
+)
+
+// fixtures serves the recorded Jikan answers in testdata by request path.
+func fixtures(t *testing.T) *Client {
+	t.Helper()
+	files := map[string]string{
+		"/anime":                  "testdata/anime_search.json",
+		"/manga":                  "testdata/manga_search.json",
+		"/users":                  "testdata/users_search.json",
+		"/characters":             "testdata/characters_search.json",
+		"/anime/10087/characters": "testdata/anime_characters.json",
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		http.ServeFile(w, r, file)
+	}))
+	t.Cleanup(srv.Close)
+	return NewWithURL(srv.URL+"/", srv.Client())
+}
+
+var fateZero = collection.Media{
+	ID:            10087,
+	MalID:         10087,
+	Title:         "Fate/Zero",
+	URL:           "https://myanimelist.net/anime/10087/Fate_Zero",
+	CoverImageURL: "https://cdn.myanimelist.net/images/anime/1171/109222l.jpg",
+	Description:   "With the promise of granting any wish, the omnipotent Holy Grail triggered three wars in the past.",
+	Type:          "ANIME",
+	Popularity:    1234567,
+}
+
+func TestClient_Media(t *testing.T) {
+	c := fixtures(t)
+
+	anime, err := c.Anime(t.Context(), "fate zero")
+	require.NoError(t, err)
+	assert.Equal(t, []collection.Media{fateZero}, anime)
+
+	manga, err := c.Manga(t.Context(), "fate zero")
+	require.NoError(t, err)
+	require.Len(t, manga, 1)
+	assert.Equal(t, "MANGA", manga[0].Type)
+	assert.Equal(t, int64(33887), manga[0].ID)
+
+	both, err := c.SearchMedia(t.Context(), "fate zero")
+	require.NoError(t, err)
+	assert.Equal(t, []collection.Media{fateZero, manga[0]}, both)
+}
+
+func TestClient_User(t *testing.T) {
+	users, err := fixtures(t).User(t.Context(), "karitham")
+	require.NoError(t, err)
+	assert.Equal(t, []collection.TrackerUser{{
+		Name:     "karitham",
+		URL:      "https://myanimelist.net/profile/karitham",
+		ImageURL: "https://cdn.myanimelist.net/images/userimages/1234.jpg",
+	}}, users)
+}
+
+func TestClient_Character(t *testing.T) {
+	chars, err := fixtures(t).Character(t.Context(), "saber")
+	require.NoError(t, err)
+	assert.Equal(t, []collection.MediaCharacter{{
+		ID:          497,
+		Name:        "Saber",
+		ImageURL:    "https://cdn.myanimelist.net/images/characters/10/340917.jpg",
+		URL:         "https://myanimelist.net/character/497/Saber",
+		Description: "Saber is the Servant of the Sword.",
+		Favorites:   52345,
+		Aliases:     []string{"Artoria Pendragon", "King of Knights"},
+	}}, chars)
+}
+
+func TestClient_MediaCharacters(t *testing.T) {
+	c := fixtures(t)
+
+	chars, err := c.GetMediaCharacters(t.Context(), 10087)
+	require.NoError(t, err)
+	require.Len(t, chars, 2)
+	assert.Equal(t, "Kiritsugu Emiya", chars[1].Name, "names are given name first")
+	assert.Equal(t, 13579, chars[1].Favorites)
+
+	_, err = c.GetMangaCharacters(t.Context(), 10087)
+	require.Error(t, err, "manga IDs are not anime IDs")
+}
+
+func TestDisplayName(t *testing.T) {
+	tests := map[string]string{
+		"Emiya, Kiritsugu": "Kiritsugu Emiya",
+		"Saber":            "Saber",
+		"":                 "",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, displayName(in), in)
+	}
+}
@@ -0,0 +1,177 @@
+// Package leaderboard ranks players by their collections and balances.
+//
+// Boards are read from aggregates the database refreshes periodically, so they
+// lag behind rolls and trades by up to the refresh interval.
+package leaderboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/karitham/waifubot/collection"
+)
+
+// Category is what a leaderboard ranks players by.
+type Category string
+
+const (
+	// Collection counts owned characters.
+	Collection Category = "collection"
+	// Rarity sums 1, 2, 5 and 10 points for Common, Uncommon, Rare and
+	// Legendary characters.
+	Rarity Category = "rarity"
+	// Legendary counts owned Legendary characters.
+	Legendary Category = "legendary"
+	// Tokens is the token balance.
+	Tokens Category = "tokens"
+	// Series counts the completed series, as collection.SeriesMinCharacters defines them.
+	Series Category = "series"
+)
+
+// Categories lists every Category in display order.
+var Categories = []Category{Collection, Rarity, Legendary, Tokens, Series}
+
+const (
+	// DefaultLimit is how many players a board shows when no limit is given.
+	DefaultLimit = 10
+	// MaxLimit is the most players a board shows.
+	MaxLimit = 100
+	// DefaultRefreshInterval is how often the aggregates are recomputed.
+	DefaultRefreshInterval = 10 * time.Minute
+)
+
+// ErrUnknownCategory is returned for a category not in Categories.
+var ErrUnknownCategory = errors.New("unknown leaderboard category")
+
+// ParseCategory returns the Category named s.
+func ParseCategory(s string) (Category, error) {
+	for _, c := range Categories {
+		if string(c) == s {
+			return c, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownCategory, s)
+}
+
+// Title returns the display name of the category.
+func (c Category) Title() string {
+	switch c {
+	case Collection:
+		return "Collection size"
+	case Rarity:
+		return "Rarity score"
+	case Legendary:
+		return "Legendary characters"
+	case Tokens:
+		return "Tokens"
+	case Series:
+		return "Series completed"
+	default:
+		return string(c)
+	}
+}
+
+// Entry is a player's place on a board. Rank is 0 for players not on it.
+// Ties share a rank.
+type Entry struct {
+	Rank     int
+	UserID   uint64
+	Username string
+	Avatar   string
+	Value    int64
+}
+
+// Board is the top of a leaderboard.
+type Board struct {
+	Category Category
+	// GuildID is the guild whose indexed members are ranked, 0 for everyone.
+	GuildID     uint64
+	Entries     []Entry
+	RefreshedAt time.Time
+}
+
+// Store reads and refreshes the ranked aggregates. A guildID of 0 ranks every player.
+type Store interface {
+	Top(ctx context.Context, c Category, guildID uint64, limit int32) ([]Entry, error)
+	// Rank returns collection.ErrNotFound for unknown users.
+	Rank(ctx context.Context, c Category, guildID, userID uint64) (Entry, error)
+	RefreshedAt(ctx context.Context) (time.Time, error)
+	Refresh(ctx context.Context) error
+}
+
+// Service serves leaderboards and keeps their aggregates fresh.
+type Service struct {
+	store Store
+}
+
+// NewService returns a Service reading from store.
+func NewService(store Store) *Service {
+	return &Service{store: store}
+}
+
+// Top returns the best limit players of category c, clamped to MaxLimit.
+func (s *Service) Top(ctx context.Context, c Category, guildID uint64, limit int) (Board, error) {
+	if _, err := ParseCategory(string(c)); err != nil {
+		return Board{}, err
+	}
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	limit = min(limit, MaxLimit)
+
+	entries, err := s.store.Top(ctx, c, guildID, int32(limit))
+	if err != nil {
+		return Board{}, err
+	}
+
+	refreshed, err := s.store.RefreshedAt(ctx)
+	if err != nil {
+		return Board{}, err
+	}
+
+	return Board{Category: c, GuildID: guildID, Entries: entries, RefreshedAt: refreshed}, nil
+}
+
+// Rank returns where userID stands in category c. Players with nothing to
+// show in the category, or who never played, have a Rank of 0.
+func (s *Service) Rank(ctx context.Context, c Category, guildID, userID uint64) (Entry, error) {
+	if _, err := ParseCategory(string(c)); err != nil {
+		return Entry{}, err
+	}
+
+	e, err := s.store.Rank(ctx, c, guildID, userID)
+	if errors.Is(err, collection.ErrNotFound) {
+		return Entry{UserID: userID}, nil
+	}
+	if err != nil {
+		return Entry{}, err
+	}
+	if e.Value <= 0 {
+		e.Rank = 0
+	}
+	return e, nil
+}
+
+// Run refreshes the aggregates every interval until ctx is done.
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+
+		start := time.Now()
+		if err := s.store.Refresh(ctx); err != nil {
+			slog.Error("error refreshing leaderboards", "error", err)
+			continue
+		}
+		slog.Debug("refreshed leaderboards", "took", time.Since(start))
+	}
+}
@@ -0,0 +1,115 @@
+package leaderboard
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karitham/waifubot/collection"
+)
+
+type fakeStore struct {
+	limit     int32
+	entries   []Entry
+	rank      Entry
+	rankErr   error
+	refreshed atomic.Int32
+}
+
+func (s *fakeStore) Top(_ context.Context, _ Category, _ uint64, limit int32) ([]Entry, error) {
+	s.limit = limit
+	return s.entries, nil
+}
+
+func (s *fakeStore) Rank(context.Context, Category, uint64, uint64) (Entry, error) {
+	return s.rank, s.rankErr
+}
+
+func (s *fakeStore) RefreshedAt(context.Context) (time.Time, error) {
+	return time.Unix(1700000000, 0), nil
+}
+
+func (s *fakeStore) Refresh(context.Context) error {
+	s.refreshed.Add(1)
+	return nil
+}
+
+func TestService_Top(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     int
+		wantLimit int32
+	}{
+		{name: "default", wantLimit: DefaultLimit},
+		{name: "explicit", limit: 3, wantLimit: 3},
+		{name: "clamped", limit: 1000, wantLimit: MaxLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeStore{entries: []Entry{{Rank: 1, UserID: 1, Value: 5}}}
+			board, err := NewService(store).Top(t.Context(), Tokens, 42, tt.limit)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantLimit, store.limit)
+			assert.Equal(t, Tokens, board.Category)
+			assert.Equal(t, uint64(42), board.GuildID)
+			assert.Len(t, board.Entries, 1)
+			assert.Equal(t, int64(1700000000), board.RefreshedAt.Unix())
+		})
+	}
+
+	_, err := NewService(&fakeStore{}).Top(t.Context(), "height", 0, 0)
+	require.ErrorIs(t, err, ErrUnknownCategory)
+}
+
+func TestService_Rank(t *testing.T) {
+	svc := NewService(&fakeStore{rank: Entry{Rank: 4, UserID: 1, Value: 12}})
+	e, err := svc.Rank(t.Context(), Collection, 0, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 4, e.Rank)
+
+	svc = NewService(&fakeStore{rank: Entry{Rank: 9, UserID: 1}})
+	e, err = svc.Rank(t.Context(), Collection, 0, 1)
+	require.NoError(t, err)
+	assert.Zero(t, e.Rank, "players with nothing to show are not ranked")
+
+	svc = NewService(&fakeStore{rankErr: collection.ErrNotFound})
+	e, err = svc.Rank(t.Context(), Collection, 0, 7)
+	require.NoError(t, err)
+	assert.Equal(t, Entry{UserID: 7}, e)
+
+	svc = NewService(&fakeStore{rankErr: errors.New("db down")})
+	_, err = svc.Rank(t.Context(), Collection, 0, 7)
+	require.Error(t, err)
+}
+
+func TestService_Run(t *testing.T) {
+	store := &fakeStore{}
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan struct{})
+	go func() {
+		NewService(store).Run(ctx, time.Millisecond)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool {
+		return store.refreshed.Load() > 0
+	}, time.Second, time.Millisecond)
+	cancel()
+	<-done
+}
+
+func TestParseCategory(t *testing.T) {
+	for _, c := range Categories {
+		got, err := ParseCategory(string(c))
+		require.NoError(t, err)
+		assert.Equal(t, c, got)
+	}
+	_, err := ParseCategory("")
+	require.ErrorIs(t, err, ErrUnknownCategory)
+}
@@ -0,0 +1,76 @@
+package leaderboard
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/storage/leaderboardstore"
+)
+
+type store struct {
+	q leaderboardstore.Querier
+}
+
+func NewStore(q leaderboardstore.Querier) Store {
+	return &store{q: q}
+}
+
+func (s *store) Top(ctx context.Context, c Category, guildID uint64, limit int32) ([]Entry, error) {
+	rows, err := s.q.Top(ctx, leaderboardstore.TopParams{
+		Category: string(c),
+		GuildID:  guildParam(guildID),
+		Lim:      limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, len(rows))
+	for i, r := range rows {
+		entries[i] = Entry{
+			Rank:     int(r.Rank),
+			UserID:   uint64(r.UserID),
+			Username: r.DiscordUsername,
+			Avatar:   r.DiscordAvatar,
+			Value:    r.Value,
+		}
+	}
+	return entries, nil
+}
+
+func (s *store) Rank(ctx context.Context, c Category, guildID, userID uint64) (Entry, error) {
+	row, err := s.q.Rank(ctx, leaderboardstore.RankParams{
+		Category: string(c),
+		GuildID:  guildParam(guildID),
+		UserID:   int64(userID),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Entry{}, collection.ErrNotFound
+		}
+		return Entry{}, err
+	}
+	return Entry{Rank: int(row.Rank), UserID: userID, Value: row.Value}, nil
+}
+
+func (s *store) RefreshedAt(ctx context.Context) (time.Time, error) {
+	ts, err := s.q.RefreshedAt(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return ts.Time, nil
+}
+
+func (s *store) Refresh(ctx context.Context) error {
+	return s.q.Refresh(ctx)
+}
+
+// guildParam turns the global board's guild ID of 0 into NULL.
+func guildParam(guildID uint64) pgtype.Int8 {
+	return pgtype.Int8{Int64: int64(guildID), Valid: guildID != 0}
+}
@@ -0,0 +1,146 @@
+// Package namematch decides whether a /claim guess names a dropped character.
+//
+// Names are compared after folding case, diacritics and punctuation. A guess
+// matches when it equals the character's name or one of its aliases, either
+// as written or with the words in any order, allowing a few typos on longer
+// names.
+package namematch
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// letterFolds spells out letters that have no Unicode decomposition.
+var letterFolds = strings.NewReplacer(
+	"ß", "ss",
+	"æ", "ae",
+	"œ", "oe",
+	"ø", "o",
+	"đ", "d",
+	"ł", "l",
+	"ı", "i",
+	"þ", "th",
+)
+
+// Normalize folds a name for comparison. It lowercases, strips diacritics,
+// drops apostrophes, turns other punctuation into spaces and collapses
+// whitespace, so "Kōsei  Arima" and "kosei arima" normalize the same.
+func Normalize(name string) string {
+	t := transform.Chain(norm.NFKD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	folded, _, err := transform.String(t, strings.ToLower(name))
+	if err != nil {
+		folded = strings.ToLower(name)
+	}
+	folded = letterFolds.Replace(folded)
+
+	var sb strings.Builder
+	for _, r := range folded {
+		switch {
+		case r == '\'' || r == '’' || r == '`':
+			// "D'Arby" is usually typed "darby"
+		case unicode.IsLetter(r) || unicode.IsNumber(r):
+			sb.WriteRune(r)
+		default:
+			sb.WriteRune(' ')
+		}
+	}
+
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
+
+// Tolerance is the number of typos accepted for a normalized name of n runes.
+// Short names must be exact so "Rem" doesn't claim "Ram".
+func Tolerance(n int) int {
+	switch {
+	case n <= 4:
+		return 0
+	case n <= 8:
+		return 1
+	case n <= 15:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// Match reports whether guess names the character called name, or any of its aliases.
+func Match(guess, name string, aliases ...string) bool {
+	g := Normalize(guess)
+	if g == "" {
+		return false
+	}
+	gSorted := sortedWords(g)
+
+	for _, candidate := range append([]string{name}, aliases...) {
+		c := Normalize(candidate)
+		if c == "" {
+			continue
+		}
+		if g == c || gSorted == sortedWords(c) {
+			return true
+		}
+
+		tol := Tolerance(len([]rune(c)))
+		if tol == 0 {
+			continue
+		}
+		if distance(g, c, tol) <= tol || distance(gSorted, sortedWords(c), tol) <= tol {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sortedWords puts the words of a normalized name in a canonical order,
+// so "levi ackerman" and "ackerman levi" compare equal.
+func sortedWords(s string) string {
+	words := strings.Fields(s)
+	slices.Sort(words)
+	return strings.Join(words, " ")
+}
+
+// distance is the optimal string alignment distance between a and b, counting
+// insertions, deletions, substitutions and adjacent transpositions as one edit.
+// It returns limit+1 as soon as the distance is known to exceed limit.
+func distance(a, b string, limit int) int {
+	ra, rb := []rune(a), []rune(b)
+	if d := len(ra) - len(rb); d > limit || -d > limit {
+		return limit + 1
+	}
+
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+			rowMin = min(rowMin, cur[j])
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+
+	return prev[len(rb)]
+}
@@ -0,0 +1,100 @@
+package namematch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "Kōsei Arima", want: "kosei arima"},
+		{in: "  Levi   Ackerman ", want: "levi ackerman"},
+		{in: "Monkey D. Luffy", want: "monkey d luffy"},
+		{in: "D'Arby", want: "darby"},
+		{in: "Zoë Hange", want: "zoe hange"},
+		{in: "Émilia", want: "emilia"},
+		{in: "Rem (Re:Zero)", want: "rem re zero"},
+		{in: "Ｌｉｇｈｔ Ｙａｇａｍｉ", want: "light yagami"},
+		{in: "Ærwin Strauß", want: "aerwin strauss"},
+		{in: "Hatsune Miku-chan", want: "hatsune miku chan"},
+		{in: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Normalize(tt.in), "Normalize(%q)", tt.in)
+	}
+}
+
+func TestTolerance(t *testing.T) {
+	assert.Equal(t, 0, Tolerance(3))
+	assert.Equal(t, 0, Tolerance(4))
+	assert.Equal(t, 1, Tolerance(5))
+	assert.Equal(t, 1, Tolerance(8))
+	assert.Equal(t, 2, Tolerance(13))
+	assert.Equal(t, 3, Tolerance(20))
+}
+
+func TestDistance(t *testing.T) {
+	assert.Equal(t, 0, distance("levi", "levi", 2))
+	assert.Equal(t, 1, distance("levi", "lavi", 2))
+	assert.Equal(t, 1, distance("levi", "elvi", 2), "adjacent transposition is one edit")
+	assert.Equal(t, 1, distance("mikasa", "mikas", 2))
+	assert.Equal(t, 3, distance("sasuke", "itachi", 2), "stops once the limit is exceeded")
+	assert.Equal(t, 3, distance("a", "abcd", 2))
+}
+
+// The corpus uses character names and aliases as they appear on AniList.
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		guess   string
+		char    string
+		aliases []string
+		want    bool
+	}{
+		{name: "exact", guess: "Levi Ackerman", char: "Levi Ackerman", want: true},
+		{name: "case and spacing", guess: "  levi   ACKERMAN", char: "Levi Ackerman", want: true},
+		{name: "family name first", guess: "Ackerman Levi", char: "Levi Ackerman", want: true},
+		{name: "three words reordered", guess: "Luffy Monkey D.", char: "Monkey D. Luffy", want: true},
+		{name: "diacritics dropped", guess: "Kosei Arima", char: "Kōsei Arima", want: true},
+		{name: "long vowel spelled out", guess: "Kousei Arima", char: "Kōsei Arima", want: true},
+		{name: "diacritics added", guess: "Émilia", char: "Emilia", want: true},
+		{name: "punctuation dropped", guess: "Monkey D Luffy", char: "Monkey D. Luffy", want: true},
+		{name: "apostrophe dropped", guess: "Daniel Darby", char: "Daniel J. D'Arby", aliases: []string{"Daniel D'Arby"}, want: true},
+		{name: "one typo", guess: "Mikasa Akerman", char: "Mikasa Ackerman", want: true},
+		{name: "two typos on a long name", guess: "Lelouch Lamperuoge", char: "Lelouch Lamperouge", want: true},
+		{name: "transposed letters", guess: "Satoru Gjoo", char: "Satoru Gojou", want: true},
+		{name: "typo and reordered", guess: "Gojo Satoro", char: "Satoru Gojou", want: true},
+		{name: "alias", guess: "Eren Jaeger", char: "Eren Yeager", aliases: []string{"Eren Jaeger"}, want: true},
+		{name: "spelling variant within tolerance", guess: "Eren Jaeger", char: "Eren Yeager", want: true},
+		{name: "alias far from the name", guess: "Ryuzaki", char: "L Lawliet", aliases: []string{"L", "Ryuzaki"}, want: true},
+		{name: "short alias", guess: "Zero", char: "Lelouch Lamperouge", aliases: []string{"Lelouch vi Britannia", "Zero"}, want: true},
+		{name: "alias reordered", guess: "Britannia Lelouch vi", char: "Lelouch Lamperouge", aliases: []string{"Lelouch vi Britannia"}, want: true},
+		{name: "alias with typo", guess: "Lelouch vi Brittania", char: "Lelouch Lamperouge", aliases: []string{"Lelouch vi Britannia"}, want: true},
+		{name: "fullwidth input", guess: "Ｌｉｇｈｔ Ｙａｇａｍｉ", char: "Light Yagami", want: true},
+		{name: "native name", guess: "竈門炭治郎", char: "Tanjirou Kamado", aliases: []string{"竈門炭治郎"}, want: true},
+
+		{name: "empty guess", guess: "", char: "Levi Ackerman"},
+		{name: "punctuation only", guess: "...", char: "Levi Ackerman"},
+		{name: "given name only", guess: "Naruto", char: "Naruto Uzumaki"},
+		{name: "family name only", guess: "Ackerman", char: "Levi Ackerman"},
+		{name: "sibling", guess: "Alphonse Elric", char: "Edward Elric"},
+		{name: "same family", guess: "Itachi Uchiha", char: "Sasuke Uchiha"},
+		{name: "same family, close given name", guess: "Mikasa Ackerman", char: "Levi Ackerman"},
+		{name: "short name typo", guess: "Ram", char: "Rem"},
+		{name: "short alias typo", guess: "Zer0", char: "Lelouch Lamperouge", aliases: []string{"Zero"}},
+		{name: "too many typos", guess: "Mekasa Akermen", char: "Mikasa Ackerman"},
+		{name: "other character, same given name", guess: "Eren Kruger", char: "Eren Yeager"},
+		{name: "different character", guess: "Light Yagami", char: "L Lawliet", aliases: []string{"L", "Ryuzaki"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Match(tt.guess, tt.char, tt.aliases...))
+		})
+	}
+}
@@ -1,6 +1,14 @@
 // Code generated by ogen, DO NOT EDIT.
 package api
 
+type AddToWishlistRes interface {
+	addToWishlistRes()
+}
+
+type ExportUserRes interface {
+	exportUserRes()
+}
+
 type FindUserRes interface {
 	findUserRes()
 }
@@ -9,14 +17,38 @@ type FindUserV1Res interface {
 	findUserV1Res()
 }
 
+type GetCatalogCharacterRes interface {
+	getCatalogCharacterRes()
+}
+
+type GetCharacterHistoryRes interface {
+	getCharacterHistoryRes()
+}
+
 type GetCollectionV1Res interface {
 	getCollectionV1Res()
 }
 
+type GetLeaderboardRes interface {
+	getLeaderboardRes()
+}
+
+type GetMediaCharactersRes interface {
+	getMediaCharactersRes()
+}
+
 type GetProfileV1Res interface {
 	getProfileV1Res()
 }
 
+type GetSeriesCompletionRes interface {
+	getSeriesCompletionRes()
+}
+
+type GetSessionRes interface {
+	getSessionRes()
+}
+
 type GetUserRes interface {
 	getUserRes()
 }
@@ -25,6 +57,34 @@ type GetUserV1Res interface {
 	getUserV1Res()
 }
 
+type GetValueHistoryRes interface {
+	getValueHistoryRes()
+}
+
 type GetWishlistRes interface {
 	getWishlistRes()
 }
+
+type LoginCallbackRes interface {
+	loginCallbackRes()
+}
+
+type LoginRes interface {
+	loginRes()
+}
+
+type LogoutRes interface {
+	logoutRes()
+}
+
+type RemoveFromWishlistRes interface {
+	removeFromWishlistRes()
+}
+
+type SetFavoriteRes interface {
+	setFavoriteRes()
+}
+
+type UpdateProfileRes interface {
+	updateProfileRes()
+}
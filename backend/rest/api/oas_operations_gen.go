@@ -6,11 +6,28 @@ package api
 type OperationName = string
 
 const (
-	FindUserOperation        OperationName = "FindUser"
-	FindUserV1Operation      OperationName = "FindUserV1"
-	GetCollectionV1Operation OperationName = "GetCollectionV1"
-	GetProfileV1Operation    OperationName = "GetProfileV1"
-	GetUserOperation         OperationName = "GetUser"
-	GetUserV1Operation       OperationName = "GetUserV1"
-	GetWishlistOperation     OperationName = "GetWishlist"
+	AddToWishlistOperation       OperationName = "AddToWishlist"
+	ExportUserOperation          OperationName = "ExportUser"
+	FindUserOperation            OperationName = "FindUser"
+	FindUserV1Operation          OperationName = "FindUserV1"
+	GetCatalogCharacterOperation OperationName = "GetCatalogCharacter"
+	GetCharacterHistoryOperation OperationName = "GetCharacterHistory"
+	GetCollectionV1Operation     OperationName = "GetCollectionV1"
+	GetLeaderboardOperation      OperationName = "GetLeaderboard"
+	GetMediaCharactersOperation  OperationName = "GetMediaCharacters"
+	GetProfileV1Operation        OperationName = "GetProfileV1"
+	GetSeriesCompletionOperation OperationName = "GetSeriesCompletion"
+	GetSessionOperation          OperationName = "GetSession"
+	GetUserOperation             OperationName = "GetUser"
+	GetUserV1Operation           OperationName = "GetUserV1"
+	GetValueHistoryOperation     OperationName = "GetValueHistory"
+	GetWishlistOperation         OperationName = "GetWishlist"
+	LoginOperation               OperationName = "Login"
+	LoginCallbackOperation       OperationName = "LoginCallback"
+	LogoutOperation              OperationName = "Logout"
+	RemoveFromWishlistOperation  OperationName = "RemoveFromWishlist"
+	SearchCharactersOperation    OperationName = "SearchCharacters"
+	SearchMediaOperation         OperationName = "SearchMedia"
+	SetFavoriteOperation         OperationName = "SetFavorite"
+	UpdateProfileOperation       OperationName = "UpdateProfile"
 )
@@ -3,11 +3,507 @@
 package api
 
 import (
+	"io"
 	"time"
 
 	"github.com/go-faster/errors"
 )
 
+// An unlocked achievement badge.
+// Ref: #/components/schemas/Achievement
+type Achievement struct {
+	// Stable achievement identifier.
+	ID string `json:"id"`
+	// Badge name.
+	Name string `json:"name"`
+	// What it takes to unlock the achievement.
+	Description string `json:"description"`
+	// When the user unlocked it.
+	UnlockedAt time.Time `json:"unlocked_at"`
+}
+
+// GetID returns the value of ID.
+func (s *Achievement) GetID() string {
+	return s.ID
+}
+
+// GetName returns the value of Name.
+func (s *Achievement) GetName() string {
+	return s.Name
+}
+
+// GetDescription returns the value of Description.
+func (s *Achievement) GetDescription() string {
+	return s.Description
+}
+
+// GetUnlockedAt returns the value of UnlockedAt.
+func (s *Achievement) GetUnlockedAt() time.Time {
+	return s.UnlockedAt
+}
+
+// SetID sets the value of ID.
+func (s *Achievement) SetID(val string) {
+	s.ID = val
+}
+
+// SetName sets the value of Name.
+func (s *Achievement) SetName(val string) {
+	s.Name = val
+}
+
+// SetDescription sets the value of Description.
+func (s *Achievement) SetDescription(val string) {
+	s.Description = val
+}
+
+// SetUnlockedAt sets the value of UnlockedAt.
+func (s *Achievement) SetUnlockedAt(val time.Time) {
+	s.UnlockedAt = val
+}
+
+type AddToWishlistBadRequest Error
+
+func (*AddToWishlistBadRequest) addToWishlistRes() {}
+
+type AddToWishlistConflict Error
+
+func (*AddToWishlistConflict) addToWishlistRes() {}
+
+// AddToWishlistNoContent is response for AddToWishlist operation.
+type AddToWishlistNoContent struct{}
+
+func (*AddToWishlistNoContent) addToWishlistRes() {}
+
+type AddToWishlistNotFound Error
+
+func (*AddToWishlistNotFound) addToWishlistRes() {}
+
+type AddToWishlistUnauthorized Error
+
+func (*AddToWishlistUnauthorized) addToWishlistRes() {}
+
+// A backup of user accounts.
+// Ref: #/components/schemas/Backup
+type Backup struct {
+	// Version of the backup format.
+	Version int `json:"version"`
+	// When the backup was made.
+	ExportedAt OptDateTime  `json:"exported_at"`
+	Users      []BackupUser `json:"users"`
+}
+
+// GetVersion returns the value of Version.
+func (s *Backup) GetVersion() int {
+	return s.Version
+}
+
+// GetExportedAt returns the value of ExportedAt.
+func (s *Backup) GetExportedAt() OptDateTime {
+	return s.ExportedAt
+}
+
+// GetUsers returns the value of Users.
+func (s *Backup) GetUsers() []BackupUser {
+	return s.Users
+}
+
+// SetVersion sets the value of Version.
+func (s *Backup) SetVersion(val int) {
+	s.Version = val
+}
+
+// SetExportedAt sets the value of ExportedAt.
+func (s *Backup) SetExportedAt(val OptDateTime) {
+	s.ExportedAt = val
+}
+
+// SetUsers sets the value of Users.
+func (s *Backup) SetUsers(val []BackupUser) {
+	s.Users = val
+}
+
+// A character of a backed up collection or wishlist. Only the ID is imported, the rest comes from
+// the catalog.
+// Ref: #/components/schemas/BackupCharacter
+type BackupCharacter struct {
+	// Character ID.
+	ID int64 `json:"id"`
+	// Character name.
+	Name OptString `json:"name"`
+	// Character image URL.
+	Image OptString `json:"image"`
+	// Media the character is from.
+	MediaTitle OptString `json:"media_title"`
+	// Number of favorites on the character.
+	Favorites OptInt `json:"favorites"`
+	// How a collected character was acquired.
+	Source OptString `json:"source"`
+	// When a collected character was acquired.
+	AcquiredAt OptDateTime `json:"acquired_at"`
+}
+
+// GetID returns the value of ID.
+func (s *BackupCharacter) GetID() int64 {
+	return s.ID
+}
+
+// GetName returns the value of Name.
+func (s *BackupCharacter) GetName() OptString {
+	return s.Name
+}
+
+// GetImage returns the value of Image.
+func (s *BackupCharacter) GetImage() OptString {
+	return s.Image
+}
+
+// GetMediaTitle returns the value of MediaTitle.
+func (s *BackupCharacter) GetMediaTitle() OptString {
+	return s.MediaTitle
+}
+
+// GetFavorites returns the value of Favorites.
+func (s *BackupCharacter) GetFavorites() OptInt {
+	return s.Favorites
+}
+
+// GetSource returns the value of Source.
+func (s *BackupCharacter) GetSource() OptString {
+	return s.Source
+}
+
+// GetAcquiredAt returns the value of AcquiredAt.
+func (s *BackupCharacter) GetAcquiredAt() OptDateTime {
+	return s.AcquiredAt
+}
+
+// SetID sets the value of ID.
+func (s *BackupCharacter) SetID(val int64) {
+	s.ID = val
+}
+
+// SetName sets the value of Name.
+func (s *BackupCharacter) SetName(val OptString) {
+	s.Name = val
+}
+
+// SetImage sets the value of Image.
+func (s *BackupCharacter) SetImage(val OptString) {
+	s.Image = val
+}
+
+// SetMediaTitle sets the value of MediaTitle.
+func (s *BackupCharacter) SetMediaTitle(val OptString) {
+	s.MediaTitle = val
+}
+
+// SetFavorites sets the value of Favorites.
+func (s *BackupCharacter) SetFavorites(val OptInt) {
+	s.Favorites = val
+}
+
+// SetSource sets the value of Source.
+func (s *BackupCharacter) SetSource(val OptString) {
+	s.Source = val
+}
+
+// SetAcquiredAt sets the value of AcquiredAt.
+func (s *BackupCharacter) SetAcquiredAt(val OptDateTime) {
+	s.AcquiredAt = val
+}
+
+// BackupHeaders wraps Backup with response headers.
+type BackupHeaders struct {
+	ContentDisposition OptString
+	Response           Backup
+}
+
+// GetContentDisposition returns the value of ContentDisposition.
+func (s *BackupHeaders) GetContentDisposition() OptString {
+	return s.ContentDisposition
+}
+
+// GetResponse returns the value of Response.
+func (s *BackupHeaders) GetResponse() Backup {
+	return s.Response
+}
+
+// SetContentDisposition sets the value of ContentDisposition.
+func (s *BackupHeaders) SetContentDisposition(val OptString) {
+	s.ContentDisposition = val
+}
+
+// SetResponse sets the value of Response.
+func (s *BackupHeaders) SetResponse(val Backup) {
+	s.Response = val
+}
+
+func (*BackupHeaders) exportUserRes() {}
+
+// The account of one user.
+// Ref: #/components/schemas/BackupUser
+type BackupUser struct {
+	// Discord user ID.
+	UserID string `json:"user_id"`
+	// Token balance.
+	Tokens int32 `json:"tokens"`
+	// Profile quote.
+	Quote OptString `json:"quote"`
+	// ID of the character shown on the profile.
+	Favorite OptInt64 `json:"favorite"`
+	// Linked Anilist user page.
+	AnilistURL OptString         `json:"anilist_url"`
+	Collection []BackupCharacter `json:"collection"`
+	Wishlist   []BackupCharacter `json:"wishlist"`
+}
+
+// GetUserID returns the value of UserID.
+func (s *BackupUser) GetUserID() string {
+	return s.UserID
+}
+
+// GetTokens returns the value of Tokens.
+func (s *BackupUser) GetTokens() int32 {
+	return s.Tokens
+}
+
+// GetQuote returns the value of Quote.
+func (s *BackupUser) GetQuote() OptString {
+	return s.Quote
+}
+
+// GetFavorite returns the value of Favorite.
+func (s *BackupUser) GetFavorite() OptInt64 {
+	return s.Favorite
+}
+
+// GetAnilistURL returns the value of AnilistURL.
+func (s *BackupUser) GetAnilistURL() OptString {
+	return s.AnilistURL
+}
+
+// GetCollection returns the value of Collection.
+func (s *BackupUser) GetCollection() []BackupCharacter {
+	return s.Collection
+}
+
+// GetWishlist returns the value of Wishlist.
+func (s *BackupUser) GetWishlist() []BackupCharacter {
+	return s.Wishlist
+}
+
+// SetUserID sets the value of UserID.
+func (s *BackupUser) SetUserID(val string) {
+	s.UserID = val
+}
+
+// SetTokens sets the value of Tokens.
+func (s *BackupUser) SetTokens(val int32) {
+	s.Tokens = val
+}
+
+// SetQuote sets the value of Quote.
+func (s *BackupUser) SetQuote(val OptString) {
+	s.Quote = val
+}
+
+// SetFavorite sets the value of Favorite.
+func (s *BackupUser) SetFavorite(val OptInt64) {
+	s.Favorite = val
+}
+
+// SetAnilistURL sets the value of AnilistURL.
+func (s *BackupUser) SetAnilistURL(val OptString) {
+	s.AnilistURL = val
+}
+
+// SetCollection sets the value of Collection.
+func (s *BackupUser) SetCollection(val []BackupCharacter) {
+	s.Collection = val
+}
+
+// SetWishlist sets the value of Wishlist.
+func (s *BackupUser) SetWishlist(val []BackupCharacter) {
+	s.Wishlist = val
+}
+
+type BearerAuth struct {
+	Token string
+	Roles []string
+}
+
+// GetToken returns the value of Token.
+func (s *BearerAuth) GetToken() string {
+	return s.Token
+}
+
+// GetRoles returns the value of Roles.
+func (s *BearerAuth) GetRoles() []string {
+	return s.Roles
+}
+
+// SetToken sets the value of Token.
+func (s *BearerAuth) SetToken(val string) {
+	s.Token = val
+}
+
+// SetRoles sets the value of Roles.
+func (s *BearerAuth) SetRoles(val []string) {
+	s.Roles = val
+}
+
+// A character of the catalog and how players hold it.
+// Ref: #/components/schemas/CatalogCharacter
+type CatalogCharacter struct {
+	// Character ID.
+	ID int64 `json:"id"`
+	// Character name.
+	Name string `json:"name"`
+	// Character image URL.
+	Image string `json:"image"`
+	// Title of the character's most popular media.
+	MediaTitle string `json:"media_title"`
+	// Number of favorites on the character.
+	Favorites int        `json:"favorites"`
+	Rarity    RarityTier `json:"rarity"`
+	// Whether the character can still be rolled and dropped.
+	Active bool `json:"active"`
+	// Number of players owning the character.
+	Owners int `json:"owners"`
+	// Number of players wishing for the character.
+	Wishlisted int `json:"wishlisted"`
+	// AniList's markdown description. Only set when getting a single character.
+	Description OptString `json:"description"`
+	// Media the character appears in, most popular first. Only set when getting a single character.
+	Media []Media `json:"media"`
+	// When the character first entered a collection. Only set when getting a single character that was
+	// ever claimed.
+	FirstClaimed OptDateTime `json:"first_claimed"`
+}
+
+// GetID returns the value of ID.
+func (s *CatalogCharacter) GetID() int64 {
+	return s.ID
+}
+
+// GetName returns the value of Name.
+func (s *CatalogCharacter) GetName() string {
+	return s.Name
+}
+
+// GetImage returns the value of Image.
+func (s *CatalogCharacter) GetImage() string {
+	return s.Image
+}
+
+// GetMediaTitle returns the value of MediaTitle.
+func (s *CatalogCharacter) GetMediaTitle() string {
+	return s.MediaTitle
+}
+
+// GetFavorites returns the value of Favorites.
+func (s *CatalogCharacter) GetFavorites() int {
+	return s.Favorites
+}
+
+// GetRarity returns the value of Rarity.
+func (s *CatalogCharacter) GetRarity() RarityTier {
+	return s.Rarity
+}
+
+// GetActive returns the value of Active.
+func (s *CatalogCharacter) GetActive() bool {
+	return s.Active
+}
+
+// GetOwners returns the value of Owners.
+func (s *CatalogCharacter) GetOwners() int {
+	return s.Owners
+}
+
+// GetWishlisted returns the value of Wishlisted.
+func (s *CatalogCharacter) GetWishlisted() int {
+	return s.Wishlisted
+}
+
+// GetDescription returns the value of Description.
+func (s *CatalogCharacter) GetDescription() OptString {
+	return s.Description
+}
+
+// GetMedia returns the value of Media.
+func (s *CatalogCharacter) GetMedia() []Media {
+	return s.Media
+}
+
+// GetFirstClaimed returns the value of FirstClaimed.
+func (s *CatalogCharacter) GetFirstClaimed() OptDateTime {
+	return s.FirstClaimed
+}
+
+// SetID sets the value of ID.
+func (s *CatalogCharacter) SetID(val int64) {
+	s.ID = val
+}
+
+// SetName sets the value of Name.
+func (s *CatalogCharacter) SetName(val string) {
+	s.Name = val
+}
+
+// SetImage sets the value of Image.
+func (s *CatalogCharacter) SetImage(val string) {
+	s.Image = val
+}
+
+// SetMediaTitle sets the value of MediaTitle.
+func (s *CatalogCharacter) SetMediaTitle(val string) {
+	s.MediaTitle = val
+}
+
+// SetFavorites sets the value of Favorites.
+func (s *CatalogCharacter) SetFavorites(val int) {
+	s.Favorites = val
+}
+
+// SetRarity sets the value of Rarity.
+func (s *CatalogCharacter) SetRarity(val RarityTier) {
+	s.Rarity = val
+}
+
+// SetActive sets the value of Active.
+func (s *CatalogCharacter) SetActive(val bool) {
+	s.Active = val
+}
+
+// SetOwners sets the value of Owners.
+func (s *CatalogCharacter) SetOwners(val int) {
+	s.Owners = val
+}
+
+// SetWishlisted sets the value of Wishlisted.
+func (s *CatalogCharacter) SetWishlisted(val int) {
+	s.Wishlisted = val
+}
+
+// SetDescription sets the value of Description.
+func (s *CatalogCharacter) SetDescription(val OptString) {
+	s.Description = val
+}
+
+// SetMedia sets the value of Media.
+func (s *CatalogCharacter) SetMedia(val []Media) {
+	s.Media = val
+}
+
+// SetFirstClaimed sets the value of FirstClaimed.
+func (s *CatalogCharacter) SetFirstClaimed(val OptDateTime) {
+	s.FirstClaimed = val
+}
+
+func (*CatalogCharacter) getCatalogCharacterRes() {}
+
 // Character information.
 // Ref: #/components/schemas/Character
 type Character struct {
@@ -85,16 +581,45 @@ func (s *Character) SetFavorites(val int) {
 	s.Favorites = val
 }
 
-// Character source type.
-type CharacterType string
+// A character and its ownership events, newest first.
+// Ref: #/components/schemas/CharacterHistory
+type CharacterHistory struct {
+	Character Character        `json:"character"`
+	Events    []OwnershipEvent `json:"events"`
+}
 
-const (
-	CharacterTypeROLL       CharacterType = "ROLL"
-	CharacterTypeCLAIM      CharacterType = "CLAIM"
-	CharacterTypeGIVE       CharacterType = "GIVE"
-	CharacterTypeOLD        CharacterType = "OLD"
-	CharacterTypeSERIESROLL CharacterType = "SERIES_ROLL"
-	CharacterTypeTRADE      CharacterType = "TRADE"
+// GetCharacter returns the value of Character.
+func (s *CharacterHistory) GetCharacter() Character {
+	return s.Character
+}
+
+// GetEvents returns the value of Events.
+func (s *CharacterHistory) GetEvents() []OwnershipEvent {
+	return s.Events
+}
+
+// SetCharacter sets the value of Character.
+func (s *CharacterHistory) SetCharacter(val Character) {
+	s.Character = val
+}
+
+// SetEvents sets the value of Events.
+func (s *CharacterHistory) SetEvents(val []OwnershipEvent) {
+	s.Events = val
+}
+
+func (*CharacterHistory) getCharacterHistoryRes() {}
+
+// Character source type.
+type CharacterType string
+
+const (
+	CharacterTypeROLL       CharacterType = "ROLL"
+	CharacterTypeCLAIM      CharacterType = "CLAIM"
+	CharacterTypeGIVE       CharacterType = "GIVE"
+	CharacterTypeOLD        CharacterType = "OLD"
+	CharacterTypeSERIESROLL CharacterType = "SERIES_ROLL"
+	CharacterTypeTRADE      CharacterType = "TRADE"
 )
 
 // AllValues returns all CharacterType values.
@@ -160,8 +685,10 @@ func (s *CharacterType) UnmarshalText(data []byte) error {
 type CollectionResponse struct {
 	// List of characters in user's collection.
 	Characters []Character `json:"characters"`
-	// Total number of characters in collection.
+	// Total number of characters in collection matching the filters, across every page.
 	Total int `json:"total"`
+	// Cursor of the next page, absent on the last one.
+	NextCursor OptString `json:"next_cursor"`
 }
 
 // GetCharacters returns the value of Characters.
@@ -174,6 +701,11 @@ func (s *CollectionResponse) GetTotal() int {
 	return s.Total
 }
 
+// GetNextCursor returns the value of NextCursor.
+func (s *CollectionResponse) GetNextCursor() OptString {
+	return s.NextCursor
+}
+
 // SetCharacters sets the value of Characters.
 func (s *CollectionResponse) SetCharacters(val []Character) {
 	s.Characters = val
@@ -184,137 +716,1147 @@ func (s *CollectionResponse) SetTotal(val int) {
 	s.Total = val
 }
 
-func (*CollectionResponse) getCollectionV1Res() {}
-
-// Standard error response.
-// Ref: #/components/schemas/Error
-type Error struct {
-	// Error message.
-	Message string `json:"message"`
-	// Error code.
-	ErrorCode string `json:"error_code"`
-	// HTTP status code.
-	StatusCode int `json:"status_code"`
+// SetNextCursor sets the value of NextCursor.
+func (s *CollectionResponse) SetNextCursor(val OptString) {
+	s.NextCursor = val
+}
+
+func (*CollectionResponse) getCollectionV1Res() {}
+
+// Standard error response.
+// Ref: #/components/schemas/Error
+type Error struct {
+	// Error message.
+	Message string `json:"message"`
+	// Error code.
+	ErrorCode string `json:"error_code"`
+	// HTTP status code.
+	StatusCode int `json:"status_code"`
+}
+
+// GetMessage returns the value of Message.
+func (s *Error) GetMessage() string {
+	return s.Message
+}
+
+// GetErrorCode returns the value of ErrorCode.
+func (s *Error) GetErrorCode() string {
+	return s.ErrorCode
+}
+
+// GetStatusCode returns the value of StatusCode.
+func (s *Error) GetStatusCode() int {
+	return s.StatusCode
+}
+
+// SetMessage sets the value of Message.
+func (s *Error) SetMessage(val string) {
+	s.Message = val
+}
+
+// SetErrorCode sets the value of ErrorCode.
+func (s *Error) SetErrorCode(val string) {
+	s.ErrorCode = val
+}
+
+// SetStatusCode sets the value of StatusCode.
+func (s *Error) SetStatusCode(val int) {
+	s.StatusCode = val
+}
+
+func (*Error) getLeaderboardRes()  {}
+func (*Error) getSessionRes()      {}
+func (*Error) getValueHistoryRes() {}
+func (*Error) loginRes()           {}
+func (*Error) logoutRes()          {}
+
+type ExportUserBadRequest Error
+
+func (*ExportUserBadRequest) exportUserRes() {}
+
+type ExportUserFormat string
+
+const (
+	ExportUserFormatJSON ExportUserFormat = "json"
+	ExportUserFormatCsv  ExportUserFormat = "csv"
+)
+
+// AllValues returns all ExportUserFormat values.
+func (ExportUserFormat) AllValues() []ExportUserFormat {
+	return []ExportUserFormat{
+		ExportUserFormatJSON,
+		ExportUserFormatCsv,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s ExportUserFormat) MarshalText() ([]byte, error) {
+	switch s {
+	case ExportUserFormatJSON:
+		return []byte(s), nil
+	case ExportUserFormatCsv:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *ExportUserFormat) UnmarshalText(data []byte) error {
+	switch ExportUserFormat(data) {
+	case ExportUserFormatJSON:
+		*s = ExportUserFormatJSON
+		return nil
+	case ExportUserFormatCsv:
+		*s = ExportUserFormatCsv
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+type ExportUserNotFound Error
+
+func (*ExportUserNotFound) exportUserRes() {}
+
+type ExportUserOKTextCsv struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s ExportUserOKTextCsv) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+// ExportUserOKTextCsvHeaders wraps ExportUserOKTextCsv with response headers.
+type ExportUserOKTextCsvHeaders struct {
+	ContentDisposition OptString
+	Response           ExportUserOKTextCsv
+}
+
+// GetContentDisposition returns the value of ContentDisposition.
+func (s *ExportUserOKTextCsvHeaders) GetContentDisposition() OptString {
+	return s.ContentDisposition
+}
+
+// GetResponse returns the value of Response.
+func (s *ExportUserOKTextCsvHeaders) GetResponse() ExportUserOKTextCsv {
+	return s.Response
+}
+
+// SetContentDisposition sets the value of ContentDisposition.
+func (s *ExportUserOKTextCsvHeaders) SetContentDisposition(val OptString) {
+	s.ContentDisposition = val
+}
+
+// SetResponse sets the value of Response.
+func (s *ExportUserOKTextCsvHeaders) SetResponse(val ExportUserOKTextCsv) {
+	s.Response = val
+}
+
+func (*ExportUserOKTextCsvHeaders) exportUserRes() {}
+
+// The character to show on a profile.
+// Ref: #/components/schemas/FavoriteUpdate
+type FavoriteUpdate struct {
+	// Character ID.
+	CharacterID int64 `json:"character_id"`
+}
+
+// GetCharacterID returns the value of CharacterID.
+func (s *FavoriteUpdate) GetCharacterID() int64 {
+	return s.CharacterID
+}
+
+// SetCharacterID sets the value of CharacterID.
+func (s *FavoriteUpdate) SetCharacterID(val int64) {
+	s.CharacterID = val
+}
+
+type FindUserBadRequest Error
+
+func (*FindUserBadRequest) findUserRes() {}
+
+type FindUserNotFound Error
+
+func (*FindUserNotFound) findUserRes() {}
+
+type FindUserV1BadRequest Error
+
+func (*FindUserV1BadRequest) findUserV1Res() {}
+
+type FindUserV1NotFound Error
+
+func (*FindUserV1NotFound) findUserV1Res() {}
+
+type GetCatalogCharacterBadRequest Error
+
+func (*GetCatalogCharacterBadRequest) getCatalogCharacterRes() {}
+
+type GetCatalogCharacterNotFound Error
+
+func (*GetCatalogCharacterNotFound) getCatalogCharacterRes() {}
+
+type GetCharacterHistoryBadRequest Error
+
+func (*GetCharacterHistoryBadRequest) getCharacterHistoryRes() {}
+
+type GetCharacterHistoryNotFound Error
+
+func (*GetCharacterHistoryNotFound) getCharacterHistoryRes() {}
+
+type GetCollectionV1BadRequest Error
+
+func (*GetCollectionV1BadRequest) getCollectionV1Res() {}
+
+type GetCollectionV1NotFound Error
+
+func (*GetCollectionV1NotFound) getCollectionV1Res() {}
+
+type GetCollectionV1Order string
+
+const (
+	GetCollectionV1OrderAsc  GetCollectionV1Order = "asc"
+	GetCollectionV1OrderDesc GetCollectionV1Order = "desc"
+)
+
+// AllValues returns all GetCollectionV1Order values.
+func (GetCollectionV1Order) AllValues() []GetCollectionV1Order {
+	return []GetCollectionV1Order{
+		GetCollectionV1OrderAsc,
+		GetCollectionV1OrderDesc,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s GetCollectionV1Order) MarshalText() ([]byte, error) {
+	switch s {
+	case GetCollectionV1OrderAsc:
+		return []byte(s), nil
+	case GetCollectionV1OrderDesc:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *GetCollectionV1Order) UnmarshalText(data []byte) error {
+	switch GetCollectionV1Order(data) {
+	case GetCollectionV1OrderAsc:
+		*s = GetCollectionV1OrderAsc
+		return nil
+	case GetCollectionV1OrderDesc:
+		*s = GetCollectionV1OrderDesc
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+type GetCollectionV1Sort string
+
+const (
+	GetCollectionV1SortDate   GetCollectionV1Sort = "date"
+	GetCollectionV1SortRarity GetCollectionV1Sort = "rarity"
+	GetCollectionV1SortMedia  GetCollectionV1Sort = "media"
+	GetCollectionV1SortName   GetCollectionV1Sort = "name"
+	GetCollectionV1SortID     GetCollectionV1Sort = "id"
+)
+
+// AllValues returns all GetCollectionV1Sort values.
+func (GetCollectionV1Sort) AllValues() []GetCollectionV1Sort {
+	return []GetCollectionV1Sort{
+		GetCollectionV1SortDate,
+		GetCollectionV1SortRarity,
+		GetCollectionV1SortMedia,
+		GetCollectionV1SortName,
+		GetCollectionV1SortID,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s GetCollectionV1Sort) MarshalText() ([]byte, error) {
+	switch s {
+	case GetCollectionV1SortDate:
+		return []byte(s), nil
+	case GetCollectionV1SortRarity:
+		return []byte(s), nil
+	case GetCollectionV1SortMedia:
+		return []byte(s), nil
+	case GetCollectionV1SortName:
+		return []byte(s), nil
+	case GetCollectionV1SortID:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *GetCollectionV1Sort) UnmarshalText(data []byte) error {
+	switch GetCollectionV1Sort(data) {
+	case GetCollectionV1SortDate:
+		*s = GetCollectionV1SortDate
+		return nil
+	case GetCollectionV1SortRarity:
+		*s = GetCollectionV1SortRarity
+		return nil
+	case GetCollectionV1SortMedia:
+		*s = GetCollectionV1SortMedia
+		return nil
+	case GetCollectionV1SortName:
+		*s = GetCollectionV1SortName
+		return nil
+	case GetCollectionV1SortID:
+		*s = GetCollectionV1SortID
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+type GetCollectionV1Source string
+
+const (
+	GetCollectionV1SourceROLL       GetCollectionV1Source = "ROLL"
+	GetCollectionV1SourceCLAIM      GetCollectionV1Source = "CLAIM"
+	GetCollectionV1SourceGIVE       GetCollectionV1Source = "GIVE"
+	GetCollectionV1SourceOLD        GetCollectionV1Source = "OLD"
+	GetCollectionV1SourceSERIESROLL GetCollectionV1Source = "SERIES_ROLL"
+	GetCollectionV1SourceTRADE      GetCollectionV1Source = "TRADE"
+)
+
+// AllValues returns all GetCollectionV1Source values.
+func (GetCollectionV1Source) AllValues() []GetCollectionV1Source {
+	return []GetCollectionV1Source{
+		GetCollectionV1SourceROLL,
+		GetCollectionV1SourceCLAIM,
+		GetCollectionV1SourceGIVE,
+		GetCollectionV1SourceOLD,
+		GetCollectionV1SourceSERIESROLL,
+		GetCollectionV1SourceTRADE,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s GetCollectionV1Source) MarshalText() ([]byte, error) {
+	switch s {
+	case GetCollectionV1SourceROLL:
+		return []byte(s), nil
+	case GetCollectionV1SourceCLAIM:
+		return []byte(s), nil
+	case GetCollectionV1SourceGIVE:
+		return []byte(s), nil
+	case GetCollectionV1SourceOLD:
+		return []byte(s), nil
+	case GetCollectionV1SourceSERIESROLL:
+		return []byte(s), nil
+	case GetCollectionV1SourceTRADE:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *GetCollectionV1Source) UnmarshalText(data []byte) error {
+	switch GetCollectionV1Source(data) {
+	case GetCollectionV1SourceROLL:
+		*s = GetCollectionV1SourceROLL
+		return nil
+	case GetCollectionV1SourceCLAIM:
+		*s = GetCollectionV1SourceCLAIM
+		return nil
+	case GetCollectionV1SourceGIVE:
+		*s = GetCollectionV1SourceGIVE
+		return nil
+	case GetCollectionV1SourceOLD:
+		*s = GetCollectionV1SourceOLD
+		return nil
+	case GetCollectionV1SourceSERIESROLL:
+		*s = GetCollectionV1SourceSERIESROLL
+		return nil
+	case GetCollectionV1SourceTRADE:
+		*s = GetCollectionV1SourceTRADE
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+type GetMediaCharactersBadRequest Error
+
+func (*GetMediaCharactersBadRequest) getMediaCharactersRes() {}
+
+type GetMediaCharactersNotFound Error
+
+func (*GetMediaCharactersNotFound) getMediaCharactersRes() {}
+
+type GetProfileV1BadRequest Error
+
+func (*GetProfileV1BadRequest) getProfileV1Res() {}
+
+type GetProfileV1NotFound Error
+
+func (*GetProfileV1NotFound) getProfileV1Res() {}
+
+type GetSeriesCompletionBadRequest Error
+
+func (*GetSeriesCompletionBadRequest) getSeriesCompletionRes() {}
+
+type GetSeriesCompletionNotFound Error
+
+func (*GetSeriesCompletionNotFound) getSeriesCompletionRes() {}
+
+type GetUserBadRequest Error
+
+func (*GetUserBadRequest) getUserRes() {}
+
+type GetUserNotFound Error
+
+func (*GetUserNotFound) getUserRes() {}
+
+type GetUserV1BadRequest Error
+
+func (*GetUserV1BadRequest) getUserV1Res() {}
+
+type GetUserV1NotFound Error
+
+func (*GetUserV1NotFound) getUserV1Res() {}
+
+type GetValueHistoryOKApplicationJSON []ValuePoint
+
+func (*GetValueHistoryOKApplicationJSON) getValueHistoryRes() {}
+
+type GetWishlistBadRequest Error
+
+func (*GetWishlistBadRequest) getWishlistRes() {}
+
+type GetWishlistNotFound Error
+
+func (*GetWishlistNotFound) getWishlistRes() {}
+
+// The top collectors of a category.
+// Ref: #/components/schemas/Leaderboard
+type Leaderboard struct {
+	Category LeaderboardCategory `json:"category"`
+	// Guild whose members are ranked, absent for the global leaderboard.
+	GuildID OptString          `json:"guild_id"`
+	Entries []LeaderboardEntry `json:"entries"`
+	// When the rankings were last computed.
+	RefreshedAt time.Time `json:"refreshed_at"`
+}
+
+// GetCategory returns the value of Category.
+func (s *Leaderboard) GetCategory() LeaderboardCategory {
+	return s.Category
+}
+
+// GetGuildID returns the value of GuildID.
+func (s *Leaderboard) GetGuildID() OptString {
+	return s.GuildID
+}
+
+// GetEntries returns the value of Entries.
+func (s *Leaderboard) GetEntries() []LeaderboardEntry {
+	return s.Entries
+}
+
+// GetRefreshedAt returns the value of RefreshedAt.
+func (s *Leaderboard) GetRefreshedAt() time.Time {
+	return s.RefreshedAt
+}
+
+// SetCategory sets the value of Category.
+func (s *Leaderboard) SetCategory(val LeaderboardCategory) {
+	s.Category = val
+}
+
+// SetGuildID sets the value of GuildID.
+func (s *Leaderboard) SetGuildID(val OptString) {
+	s.GuildID = val
+}
+
+// SetEntries sets the value of Entries.
+func (s *Leaderboard) SetEntries(val []LeaderboardEntry) {
+	s.Entries = val
+}
+
+// SetRefreshedAt sets the value of RefreshedAt.
+func (s *Leaderboard) SetRefreshedAt(val time.Time) {
+	s.RefreshedAt = val
+}
+
+func (*Leaderboard) getLeaderboardRes() {}
+
+// What a leaderboard ranks collectors by.
+// Ref: #/components/schemas/LeaderboardCategory
+type LeaderboardCategory string
+
+const (
+	LeaderboardCategoryCollection LeaderboardCategory = "collection"
+	LeaderboardCategoryRarity     LeaderboardCategory = "rarity"
+	LeaderboardCategoryLegendary  LeaderboardCategory = "legendary"
+	LeaderboardCategoryTokens     LeaderboardCategory = "tokens"
+	LeaderboardCategorySeries     LeaderboardCategory = "series"
+)
+
+// AllValues returns all LeaderboardCategory values.
+func (LeaderboardCategory) AllValues() []LeaderboardCategory {
+	return []LeaderboardCategory{
+		LeaderboardCategoryCollection,
+		LeaderboardCategoryRarity,
+		LeaderboardCategoryLegendary,
+		LeaderboardCategoryTokens,
+		LeaderboardCategorySeries,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s LeaderboardCategory) MarshalText() ([]byte, error) {
+	switch s {
+	case LeaderboardCategoryCollection:
+		return []byte(s), nil
+	case LeaderboardCategoryRarity:
+		return []byte(s), nil
+	case LeaderboardCategoryLegendary:
+		return []byte(s), nil
+	case LeaderboardCategoryTokens:
+		return []byte(s), nil
+	case LeaderboardCategorySeries:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *LeaderboardCategory) UnmarshalText(data []byte) error {
+	switch LeaderboardCategory(data) {
+	case LeaderboardCategoryCollection:
+		*s = LeaderboardCategoryCollection
+		return nil
+	case LeaderboardCategoryRarity:
+		*s = LeaderboardCategoryRarity
+		return nil
+	case LeaderboardCategoryLegendary:
+		*s = LeaderboardCategoryLegendary
+		return nil
+	case LeaderboardCategoryTokens:
+		*s = LeaderboardCategoryTokens
+		return nil
+	case LeaderboardCategorySeries:
+		*s = LeaderboardCategorySeries
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// A collector's place on a leaderboard.
+// Ref: #/components/schemas/LeaderboardEntry
+type LeaderboardEntry struct {
+	// Position on the leaderboard, tied collectors share it.
+	Rank int `json:"rank"`
+	// User ID.
+	UserID string `json:"user_id"`
+	// Discord username.
+	DiscordUsername string `json:"discord_username"`
+	// Discord avatar URL.
+	DiscordAvatar OptString `json:"discord_avatar"`
+	// The collector's score in the category.
+	Value int64 `json:"value"`
+}
+
+// GetRank returns the value of Rank.
+func (s *LeaderboardEntry) GetRank() int {
+	return s.Rank
+}
+
+// GetUserID returns the value of UserID.
+func (s *LeaderboardEntry) GetUserID() string {
+	return s.UserID
+}
+
+// GetDiscordUsername returns the value of DiscordUsername.
+func (s *LeaderboardEntry) GetDiscordUsername() string {
+	return s.DiscordUsername
+}
+
+// GetDiscordAvatar returns the value of DiscordAvatar.
+func (s *LeaderboardEntry) GetDiscordAvatar() OptString {
+	return s.DiscordAvatar
+}
+
+// GetValue returns the value of Value.
+func (s *LeaderboardEntry) GetValue() int64 {
+	return s.Value
+}
+
+// SetRank sets the value of Rank.
+func (s *LeaderboardEntry) SetRank(val int) {
+	s.Rank = val
+}
+
+// SetUserID sets the value of UserID.
+func (s *LeaderboardEntry) SetUserID(val string) {
+	s.UserID = val
+}
+
+// SetDiscordUsername sets the value of DiscordUsername.
+func (s *LeaderboardEntry) SetDiscordUsername(val string) {
+	s.DiscordUsername = val
+}
+
+// SetDiscordAvatar sets the value of DiscordAvatar.
+func (s *LeaderboardEntry) SetDiscordAvatar(val OptString) {
+	s.DiscordAvatar = val
+}
+
+// SetValue sets the value of Value.
+func (s *LeaderboardEntry) SetValue(val int64) {
+	s.Value = val
+}
+
+type LoginCallbackBadRequest Error
+
+func (*LoginCallbackBadRequest) loginCallbackRes() {}
+
+type LoginCallbackNotFound Error
+
+func (*LoginCallbackNotFound) loginCallbackRes() {}
+
+// LogoutNoContent is response for Logout operation.
+type LogoutNoContent struct {
+	SetCookie string
+}
+
+// GetSetCookie returns the value of SetCookie.
+func (s *LogoutNoContent) GetSetCookie() string {
+	return s.SetCookie
+}
+
+// SetSetCookie sets the value of SetCookie.
+func (s *LogoutNoContent) SetSetCookie(val string) {
+	s.SetCookie = val
+}
+
+func (*LogoutNoContent) logoutRes() {}
+
+// An anime or manga from the catalog.
+// Ref: #/components/schemas/Media
+type Media struct {
+	// AniList anime or manga ID.
+	ID int64 `json:"id"`
+	// Media title.
+	Title string `json:"title"`
+	// ANIME or MANGA.
+	Type string `json:"type"`
+	// Cover image URL.
+	CoverImage string `json:"cover_image"`
+	// Number of AniList users with the media in their list.
+	Popularity int `json:"popularity"`
+}
+
+// GetID returns the value of ID.
+func (s *Media) GetID() int64 {
+	return s.ID
+}
+
+// GetTitle returns the value of Title.
+func (s *Media) GetTitle() string {
+	return s.Title
+}
+
+// GetType returns the value of Type.
+func (s *Media) GetType() string {
+	return s.Type
+}
+
+// GetCoverImage returns the value of CoverImage.
+func (s *Media) GetCoverImage() string {
+	return s.CoverImage
+}
+
+// GetPopularity returns the value of Popularity.
+func (s *Media) GetPopularity() int {
+	return s.Popularity
+}
+
+// SetID sets the value of ID.
+func (s *Media) SetID(val int64) {
+	s.ID = val
+}
+
+// SetTitle sets the value of Title.
+func (s *Media) SetTitle(val string) {
+	s.Title = val
+}
+
+// SetType sets the value of Type.
+func (s *Media) SetType(val string) {
+	s.Type = val
+}
+
+// SetCoverImage sets the value of CoverImage.
+func (s *Media) SetCoverImage(val string) {
+	s.CoverImage = val
+}
+
+// SetPopularity sets the value of Popularity.
+func (s *Media) SetPopularity(val int) {
+	s.Popularity = val
+}
+
+// The characters of an anime or manga.
+// Ref: #/components/schemas/MediaCharacters
+type MediaCharacters struct {
+	// AniList anime or manga ID.
+	MediaID int64 `json:"media_id"`
+	// Media title.
+	Title string `json:"title"`
+	// Characters of the media, most favorited first.
+	Characters []Character `json:"characters"`
+}
+
+// GetMediaID returns the value of MediaID.
+func (s *MediaCharacters) GetMediaID() int64 {
+	return s.MediaID
+}
+
+// GetTitle returns the value of Title.
+func (s *MediaCharacters) GetTitle() string {
+	return s.Title
+}
+
+// GetCharacters returns the value of Characters.
+func (s *MediaCharacters) GetCharacters() []Character {
+	return s.Characters
+}
+
+// SetMediaID sets the value of MediaID.
+func (s *MediaCharacters) SetMediaID(val int64) {
+	s.MediaID = val
+}
+
+// SetTitle sets the value of Title.
+func (s *MediaCharacters) SetTitle(val string) {
+	s.Title = val
+}
+
+// SetCharacters sets the value of Characters.
+func (s *MediaCharacters) SetCharacters(val []Character) {
+	s.Characters = val
+}
+
+func (*MediaCharacters) getMediaCharactersRes() {}
+
+// NewOptCharacter returns new OptCharacter with value set to v.
+func NewOptCharacter(v Character) OptCharacter {
+	return OptCharacter{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptCharacter is optional Character.
+type OptCharacter struct {
+	Value Character
+	Set   bool
+}
+
+// IsSet returns true if OptCharacter was set.
+func (o OptCharacter) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptCharacter) Reset() {
+	var v Character
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptCharacter) SetTo(v Character) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptCharacter) Get() (v Character, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptCharacter) Or(d Character) Character {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptDateTime returns new OptDateTime with value set to v.
+func NewOptDateTime(v time.Time) OptDateTime {
+	return OptDateTime{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptDateTime is optional time.Time.
+type OptDateTime struct {
+	Value time.Time
+	Set   bool
+}
+
+// IsSet returns true if OptDateTime was set.
+func (o OptDateTime) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptDateTime) Reset() {
+	var v time.Time
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptDateTime) SetTo(v time.Time) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptDateTime) Get() (v time.Time, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptDateTime) Or(d time.Time) time.Time {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptExportUserFormat returns new OptExportUserFormat with value set to v.
+func NewOptExportUserFormat(v ExportUserFormat) OptExportUserFormat {
+	return OptExportUserFormat{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptExportUserFormat is optional ExportUserFormat.
+type OptExportUserFormat struct {
+	Value ExportUserFormat
+	Set   bool
+}
+
+// IsSet returns true if OptExportUserFormat was set.
+func (o OptExportUserFormat) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptExportUserFormat) Reset() {
+	var v ExportUserFormat
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptExportUserFormat) SetTo(v ExportUserFormat) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptExportUserFormat) Get() (v ExportUserFormat, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptExportUserFormat) Or(d ExportUserFormat) ExportUserFormat {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptGetCollectionV1Order returns new OptGetCollectionV1Order with value set to v.
+func NewOptGetCollectionV1Order(v GetCollectionV1Order) OptGetCollectionV1Order {
+	return OptGetCollectionV1Order{
+		Value: v,
+		Set:   true,
+	}
 }
 
-// GetMessage returns the value of Message.
-func (s *Error) GetMessage() string {
-	return s.Message
+// OptGetCollectionV1Order is optional GetCollectionV1Order.
+type OptGetCollectionV1Order struct {
+	Value GetCollectionV1Order
+	Set   bool
 }
 
-// GetErrorCode returns the value of ErrorCode.
-func (s *Error) GetErrorCode() string {
-	return s.ErrorCode
-}
+// IsSet returns true if OptGetCollectionV1Order was set.
+func (o OptGetCollectionV1Order) IsSet() bool { return o.Set }
 
-// GetStatusCode returns the value of StatusCode.
-func (s *Error) GetStatusCode() int {
-	return s.StatusCode
+// Reset unsets value.
+func (o *OptGetCollectionV1Order) Reset() {
+	var v GetCollectionV1Order
+	o.Value = v
+	o.Set = false
 }
 
-// SetMessage sets the value of Message.
-func (s *Error) SetMessage(val string) {
-	s.Message = val
+// SetTo sets value to v.
+func (o *OptGetCollectionV1Order) SetTo(v GetCollectionV1Order) {
+	o.Set = true
+	o.Value = v
 }
 
-// SetErrorCode sets the value of ErrorCode.
-func (s *Error) SetErrorCode(val string) {
-	s.ErrorCode = val
+// Get returns value and boolean that denotes whether value was set.
+func (o OptGetCollectionV1Order) Get() (v GetCollectionV1Order, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
 }
 
-// SetStatusCode sets the value of StatusCode.
-func (s *Error) SetStatusCode(val int) {
-	s.StatusCode = val
+// Or returns value if set, or given parameter if does not.
+func (o OptGetCollectionV1Order) Or(d GetCollectionV1Order) GetCollectionV1Order {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
 }
 
-type FindUserBadRequest Error
+// NewOptGetCollectionV1Sort returns new OptGetCollectionV1Sort with value set to v.
+func NewOptGetCollectionV1Sort(v GetCollectionV1Sort) OptGetCollectionV1Sort {
+	return OptGetCollectionV1Sort{
+		Value: v,
+		Set:   true,
+	}
+}
 
-func (*FindUserBadRequest) findUserRes() {}
+// OptGetCollectionV1Sort is optional GetCollectionV1Sort.
+type OptGetCollectionV1Sort struct {
+	Value GetCollectionV1Sort
+	Set   bool
+}
 
-type FindUserNotFound Error
+// IsSet returns true if OptGetCollectionV1Sort was set.
+func (o OptGetCollectionV1Sort) IsSet() bool { return o.Set }
 
-func (*FindUserNotFound) findUserRes() {}
+// Reset unsets value.
+func (o *OptGetCollectionV1Sort) Reset() {
+	var v GetCollectionV1Sort
+	o.Value = v
+	o.Set = false
+}
 
-type FindUserV1BadRequest Error
+// SetTo sets value to v.
+func (o *OptGetCollectionV1Sort) SetTo(v GetCollectionV1Sort) {
+	o.Set = true
+	o.Value = v
+}
 
-func (*FindUserV1BadRequest) findUserV1Res() {}
+// Get returns value and boolean that denotes whether value was set.
+func (o OptGetCollectionV1Sort) Get() (v GetCollectionV1Sort, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
 
-type FindUserV1NotFound Error
+// Or returns value if set, or given parameter if does not.
+func (o OptGetCollectionV1Sort) Or(d GetCollectionV1Sort) GetCollectionV1Sort {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
 
-func (*FindUserV1NotFound) findUserV1Res() {}
+// NewOptGetCollectionV1Source returns new OptGetCollectionV1Source with value set to v.
+func NewOptGetCollectionV1Source(v GetCollectionV1Source) OptGetCollectionV1Source {
+	return OptGetCollectionV1Source{
+		Value: v,
+		Set:   true,
+	}
+}
 
-type GetCollectionV1BadRequest Error
+// OptGetCollectionV1Source is optional GetCollectionV1Source.
+type OptGetCollectionV1Source struct {
+	Value GetCollectionV1Source
+	Set   bool
+}
 
-func (*GetCollectionV1BadRequest) getCollectionV1Res() {}
+// IsSet returns true if OptGetCollectionV1Source was set.
+func (o OptGetCollectionV1Source) IsSet() bool { return o.Set }
 
-type GetCollectionV1NotFound Error
+// Reset unsets value.
+func (o *OptGetCollectionV1Source) Reset() {
+	var v GetCollectionV1Source
+	o.Value = v
+	o.Set = false
+}
 
-func (*GetCollectionV1NotFound) getCollectionV1Res() {}
+// SetTo sets value to v.
+func (o *OptGetCollectionV1Source) SetTo(v GetCollectionV1Source) {
+	o.Set = true
+	o.Value = v
+}
 
-type GetProfileV1BadRequest Error
+// Get returns value and boolean that denotes whether value was set.
+func (o OptGetCollectionV1Source) Get() (v GetCollectionV1Source, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
 
-func (*GetProfileV1BadRequest) getProfileV1Res() {}
+// Or returns value if set, or given parameter if does not.
+func (o OptGetCollectionV1Source) Or(d GetCollectionV1Source) GetCollectionV1Source {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
 
-type GetProfileV1NotFound Error
+// NewOptInt returns new OptInt with value set to v.
+func NewOptInt(v int) OptInt {
+	return OptInt{
+		Value: v,
+		Set:   true,
+	}
+}
 
-func (*GetProfileV1NotFound) getProfileV1Res() {}
+// OptInt is optional int.
+type OptInt struct {
+	Value int
+	Set   bool
+}
 
-type GetUserBadRequest Error
+// IsSet returns true if OptInt was set.
+func (o OptInt) IsSet() bool { return o.Set }
 
-func (*GetUserBadRequest) getUserRes() {}
+// Reset unsets value.
+func (o *OptInt) Reset() {
+	var v int
+	o.Value = v
+	o.Set = false
+}
 
-type GetUserNotFound Error
+// SetTo sets value to v.
+func (o *OptInt) SetTo(v int) {
+	o.Set = true
+	o.Value = v
+}
 
-func (*GetUserNotFound) getUserRes() {}
+// Get returns value and boolean that denotes whether value was set.
+func (o OptInt) Get() (v int, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
 
-type GetUserV1BadRequest Error
+// Or returns value if set, or given parameter if does not.
+func (o OptInt) Or(d int) int {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
 
-func (*GetUserV1BadRequest) getUserV1Res() {}
+// NewOptInt32 returns new OptInt32 with value set to v.
+func NewOptInt32(v int32) OptInt32 {
+	return OptInt32{
+		Value: v,
+		Set:   true,
+	}
+}
 
-type GetUserV1NotFound Error
+// OptInt32 is optional int32.
+type OptInt32 struct {
+	Value int32
+	Set   bool
+}
 
-func (*GetUserV1NotFound) getUserV1Res() {}
+// IsSet returns true if OptInt32 was set.
+func (o OptInt32) IsSet() bool { return o.Set }
 
-type GetWishlistBadRequest Error
+// Reset unsets value.
+func (o *OptInt32) Reset() {
+	var v int32
+	o.Value = v
+	o.Set = false
+}
 
-func (*GetWishlistBadRequest) getWishlistRes() {}
+// SetTo sets value to v.
+func (o *OptInt32) SetTo(v int32) {
+	o.Set = true
+	o.Value = v
+}
 
-type GetWishlistNotFound Error
+// Get returns value and boolean that denotes whether value was set.
+func (o OptInt32) Get() (v int32, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
 
-func (*GetWishlistNotFound) getWishlistRes() {}
+// Or returns value if set, or given parameter if does not.
+func (o OptInt32) Or(d int32) int32 {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
 
-// NewOptCharacter returns new OptCharacter with value set to v.
-func NewOptCharacter(v Character) OptCharacter {
-	return OptCharacter{
+// NewOptInt64 returns new OptInt64 with value set to v.
+func NewOptInt64(v int64) OptInt64 {
+	return OptInt64{
 		Value: v,
 		Set:   true,
 	}
 }
 
-// OptCharacter is optional Character.
-type OptCharacter struct {
-	Value Character
+// OptInt64 is optional int64.
+type OptInt64 struct {
+	Value int64
 	Set   bool
 }
 
-// IsSet returns true if OptCharacter was set.
-func (o OptCharacter) IsSet() bool { return o.Set }
+// IsSet returns true if OptInt64 was set.
+func (o OptInt64) IsSet() bool { return o.Set }
 
 // Reset unsets value.
-func (o *OptCharacter) Reset() {
-	var v Character
+func (o *OptInt64) Reset() {
+	var v int64
 	o.Value = v
 	o.Set = false
 }
 
 // SetTo sets value to v.
-func (o *OptCharacter) SetTo(v Character) {
+func (o *OptInt64) SetTo(v int64) {
 	o.Set = true
 	o.Value = v
 }
 
 // Get returns value and boolean that denotes whether value was set.
-func (o OptCharacter) Get() (v Character, ok bool) {
+func (o OptInt64) Get() (v int64, ok bool) {
 	if !o.Set {
 		return v, false
 	}
@@ -322,7 +1864,7 @@ func (o OptCharacter) Get() (v Character, ok bool) {
 }
 
 // Or returns value if set, or given parameter if does not.
-func (o OptCharacter) Or(d Character) Character {
+func (o OptInt64) Or(d int64) int64 {
 	if v, ok := o.Get(); ok {
 		return v
 	}
@@ -400,47 +1942,93 @@ func NewOptNilDateTime(v time.Time) OptNilDateTime {
 	}
 }
 
-// OptNilDateTime is optional nullable time.Time.
-type OptNilDateTime struct {
-	Value time.Time
+// OptNilDateTime is optional nullable time.Time.
+type OptNilDateTime struct {
+	Value time.Time
+	Set   bool
+	Null  bool
+}
+
+// IsSet returns true if OptNilDateTime was set.
+func (o OptNilDateTime) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptNilDateTime) Reset() {
+	var v time.Time
+	o.Value = v
+	o.Set = false
+	o.Null = false
+}
+
+// SetTo sets value to v.
+func (o *OptNilDateTime) SetTo(v time.Time) {
+	o.Set = true
+	o.Null = false
+	o.Value = v
+}
+
+// IsNull returns true if value is Null.
+func (o OptNilDateTime) IsNull() bool { return o.Null }
+
+// SetToNull sets value to null.
+func (o *OptNilDateTime) SetToNull() {
+	o.Set = true
+	o.Null = true
+	var v time.Time
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptNilDateTime) Get() (v time.Time, ok bool) {
+	if o.Null {
+		return v, false
+	}
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptNilDateTime) Or(d time.Time) time.Time {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptRarityTier returns new OptRarityTier with value set to v.
+func NewOptRarityTier(v RarityTier) OptRarityTier {
+	return OptRarityTier{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptRarityTier is optional RarityTier.
+type OptRarityTier struct {
+	Value RarityTier
 	Set   bool
-	Null  bool
 }
 
-// IsSet returns true if OptNilDateTime was set.
-func (o OptNilDateTime) IsSet() bool { return o.Set }
+// IsSet returns true if OptRarityTier was set.
+func (o OptRarityTier) IsSet() bool { return o.Set }
 
 // Reset unsets value.
-func (o *OptNilDateTime) Reset() {
-	var v time.Time
+func (o *OptRarityTier) Reset() {
+	var v RarityTier
 	o.Value = v
 	o.Set = false
-	o.Null = false
 }
 
 // SetTo sets value to v.
-func (o *OptNilDateTime) SetTo(v time.Time) {
-	o.Set = true
-	o.Null = false
-	o.Value = v
-}
-
-// IsNull returns true if value is Null.
-func (o OptNilDateTime) IsNull() bool { return o.Null }
-
-// SetToNull sets value to null.
-func (o *OptNilDateTime) SetToNull() {
+func (o *OptRarityTier) SetTo(v RarityTier) {
 	o.Set = true
-	o.Null = true
-	var v time.Time
 	o.Value = v
 }
 
 // Get returns value and boolean that denotes whether value was set.
-func (o OptNilDateTime) Get() (v time.Time, ok bool) {
-	if o.Null {
-		return v, false
-	}
+func (o OptRarityTier) Get() (v RarityTier, ok bool) {
 	if !o.Set {
 		return v, false
 	}
@@ -448,7 +2036,7 @@ func (o OptNilDateTime) Get() (v time.Time, ok bool) {
 }
 
 // Or returns value if set, or given parameter if does not.
-func (o OptNilDateTime) Or(d time.Time) time.Time {
+func (o OptRarityTier) Or(d RarityTier) RarityTier {
 	if v, ok := o.Get(); ok {
 		return v
 	}
@@ -501,6 +2089,179 @@ func (o OptString) Or(d string) string {
 	return d
 }
 
+// One entry of the append-only ownership ledger.
+// Ref: #/components/schemas/OwnershipEvent
+type OwnershipEvent struct {
+	// Event ID.
+	ID int64 `json:"id"`
+	// How the character changed hands.
+	Kind OwnershipEventKind `json:"kind"`
+	// Previous owner, absent when the character entered the game.
+	FromUserID OptString `json:"from_user_id"`
+	// New owner, absent when the character was sold.
+	ToUserID OptString `json:"to_user_id"`
+	// Tokens paid for the character.
+	Tokens int32 `json:"tokens"`
+	// Trade or auction the event belongs to.
+	ReferenceID OptInt64 `json:"reference_id"`
+	// When the event happened.
+	Date time.Time `json:"date"`
+}
+
+// GetID returns the value of ID.
+func (s *OwnershipEvent) GetID() int64 {
+	return s.ID
+}
+
+// GetKind returns the value of Kind.
+func (s *OwnershipEvent) GetKind() OwnershipEventKind {
+	return s.Kind
+}
+
+// GetFromUserID returns the value of FromUserID.
+func (s *OwnershipEvent) GetFromUserID() OptString {
+	return s.FromUserID
+}
+
+// GetToUserID returns the value of ToUserID.
+func (s *OwnershipEvent) GetToUserID() OptString {
+	return s.ToUserID
+}
+
+// GetTokens returns the value of Tokens.
+func (s *OwnershipEvent) GetTokens() int32 {
+	return s.Tokens
+}
+
+// GetReferenceID returns the value of ReferenceID.
+func (s *OwnershipEvent) GetReferenceID() OptInt64 {
+	return s.ReferenceID
+}
+
+// GetDate returns the value of Date.
+func (s *OwnershipEvent) GetDate() time.Time {
+	return s.Date
+}
+
+// SetID sets the value of ID.
+func (s *OwnershipEvent) SetID(val int64) {
+	s.ID = val
+}
+
+// SetKind sets the value of Kind.
+func (s *OwnershipEvent) SetKind(val OwnershipEventKind) {
+	s.Kind = val
+}
+
+// SetFromUserID sets the value of FromUserID.
+func (s *OwnershipEvent) SetFromUserID(val OptString) {
+	s.FromUserID = val
+}
+
+// SetToUserID sets the value of ToUserID.
+func (s *OwnershipEvent) SetToUserID(val OptString) {
+	s.ToUserID = val
+}
+
+// SetTokens sets the value of Tokens.
+func (s *OwnershipEvent) SetTokens(val int32) {
+	s.Tokens = val
+}
+
+// SetReferenceID sets the value of ReferenceID.
+func (s *OwnershipEvent) SetReferenceID(val OptInt64) {
+	s.ReferenceID = val
+}
+
+// SetDate sets the value of Date.
+func (s *OwnershipEvent) SetDate(val time.Time) {
+	s.Date = val
+}
+
+// How the character changed hands.
+type OwnershipEventKind string
+
+const (
+	OwnershipEventKindRoll       OwnershipEventKind = "roll"
+	OwnershipEventKindClaim      OwnershipEventKind = "claim"
+	OwnershipEventKindSeriesRoll OwnershipEventKind = "series_roll"
+	OwnershipEventKindGive       OwnershipEventKind = "give"
+	OwnershipEventKindSell       OwnershipEventKind = "sell"
+	OwnershipEventKindTrade      OwnershipEventKind = "trade"
+	OwnershipEventKindAuction    OwnershipEventKind = "auction"
+	OwnershipEventKindAdmin      OwnershipEventKind = "admin"
+)
+
+// AllValues returns all OwnershipEventKind values.
+func (OwnershipEventKind) AllValues() []OwnershipEventKind {
+	return []OwnershipEventKind{
+		OwnershipEventKindRoll,
+		OwnershipEventKindClaim,
+		OwnershipEventKindSeriesRoll,
+		OwnershipEventKindGive,
+		OwnershipEventKindSell,
+		OwnershipEventKindTrade,
+		OwnershipEventKindAuction,
+		OwnershipEventKindAdmin,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s OwnershipEventKind) MarshalText() ([]byte, error) {
+	switch s {
+	case OwnershipEventKindRoll:
+		return []byte(s), nil
+	case OwnershipEventKindClaim:
+		return []byte(s), nil
+	case OwnershipEventKindSeriesRoll:
+		return []byte(s), nil
+	case OwnershipEventKindGive:
+		return []byte(s), nil
+	case OwnershipEventKindSell:
+		return []byte(s), nil
+	case OwnershipEventKindTrade:
+		return []byte(s), nil
+	case OwnershipEventKindAuction:
+		return []byte(s), nil
+	case OwnershipEventKindAdmin:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *OwnershipEventKind) UnmarshalText(data []byte) error {
+	switch OwnershipEventKind(data) {
+	case OwnershipEventKindRoll:
+		*s = OwnershipEventKindRoll
+		return nil
+	case OwnershipEventKindClaim:
+		*s = OwnershipEventKindClaim
+		return nil
+	case OwnershipEventKindSeriesRoll:
+		*s = OwnershipEventKindSeriesRoll
+		return nil
+	case OwnershipEventKindGive:
+		*s = OwnershipEventKindGive
+		return nil
+	case OwnershipEventKindSell:
+		*s = OwnershipEventKindSell
+		return nil
+	case OwnershipEventKindTrade:
+		*s = OwnershipEventKindTrade
+		return nil
+	case OwnershipEventKindAuction:
+		*s = OwnershipEventKindAuction
+		return nil
+	case OwnershipEventKindAdmin:
+		*s = OwnershipEventKindAdmin
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
 // Complete user profile including user information, collection, and favorite character.
 // Ref: #/components/schemas/Profile
 type Profile struct {
@@ -605,6 +2366,294 @@ func (s *Profile) SetWaifus(val []Character) {
 func (*Profile) getUserRes()   {}
 func (*Profile) getUserV1Res() {}
 
+// Profile fields to change.
+// Ref: #/components/schemas/ProfileUpdate
+type ProfileUpdate struct {
+	// New personal quote, empty to clear it.
+	Quote OptString `json:"quote"`
+	// New Anilist user URL.
+	AnilistURL OptString `json:"anilist_url"`
+}
+
+// GetQuote returns the value of Quote.
+func (s *ProfileUpdate) GetQuote() OptString {
+	return s.Quote
+}
+
+// GetAnilistURL returns the value of AnilistURL.
+func (s *ProfileUpdate) GetAnilistURL() OptString {
+	return s.AnilistURL
+}
+
+// SetQuote sets the value of Quote.
+func (s *ProfileUpdate) SetQuote(val OptString) {
+	s.Quote = val
+}
+
+// SetAnilistURL sets the value of AnilistURL.
+func (s *ProfileUpdate) SetAnilistURL(val OptString) {
+	s.AnilistURL = val
+}
+
+// Rarity tier of a character, from its favorites.
+// Ref: #/components/schemas/RarityTier
+type RarityTier string
+
+const (
+	RarityTierCommon    RarityTier = "common"
+	RarityTierUncommon  RarityTier = "uncommon"
+	RarityTierRare      RarityTier = "rare"
+	RarityTierLegendary RarityTier = "legendary"
+)
+
+// AllValues returns all RarityTier values.
+func (RarityTier) AllValues() []RarityTier {
+	return []RarityTier{
+		RarityTierCommon,
+		RarityTierUncommon,
+		RarityTierRare,
+		RarityTierLegendary,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s RarityTier) MarshalText() ([]byte, error) {
+	switch s {
+	case RarityTierCommon:
+		return []byte(s), nil
+	case RarityTierUncommon:
+		return []byte(s), nil
+	case RarityTierRare:
+		return []byte(s), nil
+	case RarityTierLegendary:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *RarityTier) UnmarshalText(data []byte) error {
+	switch RarityTier(data) {
+	case RarityTierCommon:
+		*s = RarityTierCommon
+		return nil
+	case RarityTierUncommon:
+		*s = RarityTierUncommon
+		return nil
+	case RarityTierRare:
+		*s = RarityTierRare
+		return nil
+	case RarityTierLegendary:
+		*s = RarityTierLegendary
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/responses/redirect
+type Redirect struct {
+	Location  string
+	SetCookie []string
+}
+
+// GetLocation returns the value of Location.
+func (s *Redirect) GetLocation() string {
+	return s.Location
+}
+
+// GetSetCookie returns the value of SetCookie.
+func (s *Redirect) GetSetCookie() []string {
+	return s.SetCookie
+}
+
+// SetLocation sets the value of Location.
+func (s *Redirect) SetLocation(val string) {
+	s.Location = val
+}
+
+// SetSetCookie sets the value of SetCookie.
+func (s *Redirect) SetSetCookie(val []string) {
+	s.SetCookie = val
+}
+
+func (*Redirect) loginCallbackRes() {}
+func (*Redirect) loginRes()         {}
+
+type RemoveFromWishlistBadRequest Error
+
+func (*RemoveFromWishlistBadRequest) removeFromWishlistRes() {}
+
+// RemoveFromWishlistNoContent is response for RemoveFromWishlist operation.
+type RemoveFromWishlistNoContent struct{}
+
+func (*RemoveFromWishlistNoContent) removeFromWishlistRes() {}
+
+type RemoveFromWishlistUnauthorized Error
+
+func (*RemoveFromWishlistUnauthorized) removeFromWishlistRes() {}
+
+// How much of a series a user owns.
+// Ref: #/components/schemas/SeriesCompletion
+type SeriesCompletion struct {
+	// AniList anime or manga ID.
+	MediaID int64 `json:"media_id"`
+	// Series title.
+	Title string `json:"title"`
+	// Number of characters in the series.
+	Total int `json:"total"`
+	// Number of those characters the user owns.
+	Owned int `json:"owned"`
+	// Share of the series owned, from 0 to 100.
+	Percent float64 `json:"percent"`
+	// Characters the user doesn't own, most favorited first.
+	Missing []Character `json:"missing"`
+}
+
+// GetMediaID returns the value of MediaID.
+func (s *SeriesCompletion) GetMediaID() int64 {
+	return s.MediaID
+}
+
+// GetTitle returns the value of Title.
+func (s *SeriesCompletion) GetTitle() string {
+	return s.Title
+}
+
+// GetTotal returns the value of Total.
+func (s *SeriesCompletion) GetTotal() int {
+	return s.Total
+}
+
+// GetOwned returns the value of Owned.
+func (s *SeriesCompletion) GetOwned() int {
+	return s.Owned
+}
+
+// GetPercent returns the value of Percent.
+func (s *SeriesCompletion) GetPercent() float64 {
+	return s.Percent
+}
+
+// GetMissing returns the value of Missing.
+func (s *SeriesCompletion) GetMissing() []Character {
+	return s.Missing
+}
+
+// SetMediaID sets the value of MediaID.
+func (s *SeriesCompletion) SetMediaID(val int64) {
+	s.MediaID = val
+}
+
+// SetTitle sets the value of Title.
+func (s *SeriesCompletion) SetTitle(val string) {
+	s.Title = val
+}
+
+// SetTotal sets the value of Total.
+func (s *SeriesCompletion) SetTotal(val int) {
+	s.Total = val
+}
+
+// SetOwned sets the value of Owned.
+func (s *SeriesCompletion) SetOwned(val int) {
+	s.Owned = val
+}
+
+// SetPercent sets the value of Percent.
+func (s *SeriesCompletion) SetPercent(val float64) {
+	s.Percent = val
+}
+
+// SetMissing sets the value of Missing.
+func (s *SeriesCompletion) SetMissing(val []Character) {
+	s.Missing = val
+}
+
+func (*SeriesCompletion) getSeriesCompletionRes() {}
+
+// A logged in user.
+// Ref: #/components/schemas/Session
+type Session struct {
+	// Discord user ID.
+	UserID string `json:"user_id"`
+	// When the session ends.
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// GetUserID returns the value of UserID.
+func (s *Session) GetUserID() string {
+	return s.UserID
+}
+
+// GetExpiresAt returns the value of ExpiresAt.
+func (s *Session) GetExpiresAt() time.Time {
+	return s.ExpiresAt
+}
+
+// SetUserID sets the value of UserID.
+func (s *Session) SetUserID(val string) {
+	s.UserID = val
+}
+
+// SetExpiresAt sets the value of ExpiresAt.
+func (s *Session) SetExpiresAt(val time.Time) {
+	s.ExpiresAt = val
+}
+
+func (*Session) getSessionRes() {}
+
+type SessionCookie struct {
+	APIKey string
+	Roles  []string
+}
+
+// GetAPIKey returns the value of APIKey.
+func (s *SessionCookie) GetAPIKey() string {
+	return s.APIKey
+}
+
+// GetRoles returns the value of Roles.
+func (s *SessionCookie) GetRoles() []string {
+	return s.Roles
+}
+
+// SetAPIKey sets the value of APIKey.
+func (s *SessionCookie) SetAPIKey(val string) {
+	s.APIKey = val
+}
+
+// SetRoles sets the value of Roles.
+func (s *SessionCookie) SetRoles(val []string) {
+	s.Roles = val
+}
+
+type SetFavoriteBadRequest Error
+
+func (*SetFavoriteBadRequest) setFavoriteRes() {}
+
+// SetFavoriteNoContent is response for SetFavorite operation.
+type SetFavoriteNoContent struct{}
+
+func (*SetFavoriteNoContent) setFavoriteRes() {}
+
+type SetFavoriteNotFound Error
+
+func (*SetFavoriteNotFound) setFavoriteRes() {}
+
+type SetFavoriteUnauthorized Error
+
+func (*SetFavoriteUnauthorized) setFavoriteRes() {}
+
+type UpdateProfileBadRequest Error
+
+func (*UpdateProfileBadRequest) updateProfileRes() {}
+
+type UpdateProfileUnauthorized Error
+
+func (*UpdateProfileUnauthorized) updateProfileRes() {}
+
 // Response containing only the user ID.
 // Ref: #/components/schemas/UserIdResponse
 type UserIdResponse struct {
@@ -625,7 +2674,7 @@ func (s *UserIdResponse) SetID(val string) {
 func (*UserIdResponse) findUserRes()   {}
 func (*UserIdResponse) findUserV1Res() {}
 
-// User profile with favorite character.
+// User profile with favorite character and badges.
 // Ref: #/components/schemas/UserProfile
 type UserProfile struct {
 	// User ID.
@@ -634,6 +2683,8 @@ type UserProfile struct {
 	Quote OptString `json:"quote"`
 	// User's token balance.
 	Tokens int32 `json:"tokens"`
+	// Value of the user's collection, computed from the favorites of its characters.
+	CollectionValue int64 `json:"collection_value"`
 	// Anilist user URL.
 	AnilistURL OptString `json:"anilist_url"`
 	// Discord username.
@@ -642,6 +2693,8 @@ type UserProfile struct {
 	DiscordAvatar OptString `json:"discord_avatar"`
 	// User's favorite character (may be null if no favorite set).
 	Favorite OptCharacter `json:"favorite"`
+	// Achievements the user unlocked.
+	Achievements []Achievement `json:"achievements"`
 }
 
 // GetID returns the value of ID.
@@ -659,6 +2712,11 @@ func (s *UserProfile) GetTokens() int32 {
 	return s.Tokens
 }
 
+// GetCollectionValue returns the value of CollectionValue.
+func (s *UserProfile) GetCollectionValue() int64 {
+	return s.CollectionValue
+}
+
 // GetAnilistURL returns the value of AnilistURL.
 func (s *UserProfile) GetAnilistURL() OptString {
 	return s.AnilistURL
@@ -679,6 +2737,11 @@ func (s *UserProfile) GetFavorite() OptCharacter {
 	return s.Favorite
 }
 
+// GetAchievements returns the value of Achievements.
+func (s *UserProfile) GetAchievements() []Achievement {
+	return s.Achievements
+}
+
 // SetID sets the value of ID.
 func (s *UserProfile) SetID(val string) {
 	s.ID = val
@@ -694,6 +2757,11 @@ func (s *UserProfile) SetTokens(val int32) {
 	s.Tokens = val
 }
 
+// SetCollectionValue sets the value of CollectionValue.
+func (s *UserProfile) SetCollectionValue(val int64) {
+	s.CollectionValue = val
+}
+
 // SetAnilistURL sets the value of AnilistURL.
 func (s *UserProfile) SetAnilistURL(val OptString) {
 	s.AnilistURL = val
@@ -714,7 +2782,42 @@ func (s *UserProfile) SetFavorite(val OptCharacter) {
 	s.Favorite = val
 }
 
-func (*UserProfile) getProfileV1Res() {}
+// SetAchievements sets the value of Achievements.
+func (s *UserProfile) SetAchievements(val []Achievement) {
+	s.Achievements = val
+}
+
+func (*UserProfile) getProfileV1Res()  {}
+func (*UserProfile) updateProfileRes() {}
+
+// The value of a collection at the end of a day.
+// Ref: #/components/schemas/ValuePoint
+type ValuePoint struct {
+	// Day the value was recorded for (UTC).
+	Day time.Time `json:"day"`
+	// Value of the collection.
+	Value int64 `json:"value"`
+}
+
+// GetDay returns the value of Day.
+func (s *ValuePoint) GetDay() time.Time {
+	return s.Day
+}
+
+// GetValue returns the value of Value.
+func (s *ValuePoint) GetValue() int64 {
+	return s.Value
+}
+
+// SetDay sets the value of Day.
+func (s *ValuePoint) SetDay(val time.Time) {
+	s.Day = val
+}
+
+// SetValue sets the value of Value.
+func (s *ValuePoint) SetValue(val int64) {
+	s.Value = val
+}
 
 // User's wishlist response.
 // Ref: #/components/schemas/WishlistResponse
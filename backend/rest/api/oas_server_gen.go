@@ -8,6 +8,19 @@ import (
 
 // Handler handles operations described by OpenAPI v3 specification.
 type Handler interface {
+	// AddToWishlist implements addToWishlist operation.
+	//
+	// Add a character the logged in user doesn't own to their wishlist.
+	//
+	// PUT /api/v1/me/wishlist/{characterID}
+	AddToWishlist(ctx context.Context, params AddToWishlistParams) (AddToWishlistRes, error)
+	// ExportUser implements exportUser operation.
+	//
+	// Back up a user's collection, wishlist, tokens and profile. The backup can be imported back with
+	// `waifubot import`.
+	//
+	// GET /api/v1/export/{userID}
+	ExportUser(ctx context.Context, params ExportUserParams) (ExportUserRes, error)
 	// FindUser implements findUser operation.
 	//
 	// Find a user by their Anilist URL or Discord username. Query parameters are mutually exclusive.
@@ -24,18 +37,58 @@ type Handler interface {
 	//
 	// GET /api/v1/user/find
 	FindUserV1(ctx context.Context, params FindUserV1Params) (FindUserV1Res, error)
+	// GetCatalogCharacter implements getCatalogCharacter operation.
+	//
+	// Retrieve a character from the catalog with its description, the media it appears in and how many
+	// players own and wish for it.
+	//
+	// GET /api/v1/characters/{characterID}
+	GetCatalogCharacter(ctx context.Context, params GetCatalogCharacterParams) (GetCatalogCharacterRes, error)
+	// GetCharacterHistory implements getCharacterHistory operation.
+	//
+	// Retrieve the ownership events of a character, newest first.
+	//
+	// GET /api/v1/character/{characterID}/history
+	GetCharacterHistory(ctx context.Context, params GetCharacterHistoryParams) (GetCharacterHistoryRes, error)
 	// GetCollectionV1 implements getCollectionV1 operation.
 	//
-	// Retrieve a user's character collection.
+	// Retrieve a user's character collection, filtered and sorted. Without a limit the whole collection
+	// is returned in one page; with one, follow next_cursor to get the next page.
 	//
 	// GET /api/v1/collection/{userID}
 	GetCollectionV1(ctx context.Context, params GetCollectionV1Params) (GetCollectionV1Res, error)
+	// GetLeaderboard implements getLeaderboard operation.
+	//
+	// Rank collectors in a category, globally or among the indexed members of a guild. Rankings are
+	// refreshed periodically.
+	//
+	// GET /api/v1/leaderboard/{category}
+	GetLeaderboard(ctx context.Context, params GetLeaderboardParams) (GetLeaderboardRes, error)
+	// GetMediaCharacters implements getMediaCharacters operation.
+	//
+	// List the characters of an anime or manga, most favorited first.
+	//
+	// GET /api/v1/media/{mediaID}/characters
+	GetMediaCharacters(ctx context.Context, params GetMediaCharactersParams) (GetMediaCharactersRes, error)
 	// GetProfileV1 implements getProfileV1 operation.
 	//
 	// Retrieve a user's profile information and favorite character.
 	//
 	// GET /api/v1/profile/{userID}
 	GetProfileV1(ctx context.Context, params GetProfileV1Params) (GetProfileV1Res, error)
+	// GetSeriesCompletion implements getSeriesCompletion operation.
+	//
+	// Compare a user's collection against every character of an anime or manga, reporting the percent
+	// owned and the missing characters.
+	//
+	// GET /api/v1/collection/{userID}/series/{mediaID}
+	GetSeriesCompletion(ctx context.Context, params GetSeriesCompletionParams) (GetSeriesCompletionRes, error)
+	// GetSession implements getSession operation.
+	//
+	// Retrieve who is logged in and until when.
+	//
+	// GET /api/v1/auth/session
+	GetSession(ctx context.Context) (GetSessionRes, error)
 	// GetUser implements getUser operation.
 	//
 	// Retrieve a user's complete profile including user info, collection, and favorite character.
@@ -52,29 +105,89 @@ type Handler interface {
 	//
 	// GET /api/v1/user/{userID}
 	GetUserV1(ctx context.Context, params GetUserV1Params) (GetUserV1Res, error)
+	// GetValueHistory implements getValueHistory operation.
+	//
+	// Retrieve the daily value of a user's collection, oldest first. Days before the user's first
+	// recorded value are left out.
+	//
+	// GET /api/v1/profile/{userID}/value-history
+	GetValueHistory(ctx context.Context, params GetValueHistoryParams) (GetValueHistoryRes, error)
 	// GetWishlist implements getWishlist operation.
 	//
 	// Retrieve a user's wishlist of characters.
 	//
 	// GET /api/v1/wishlist/{userID}
 	GetWishlist(ctx context.Context, params GetWishlistParams) (GetWishlistRes, error)
+	// Login implements login operation.
+	//
+	// Redirect to Discord to authorize the application. The OAuth2 state is kept in a short-lived cookie
+	// and checked by the callback.
+	//
+	// GET /api/v1/auth/login
+	Login(ctx context.Context) (LoginRes, error)
+	// LoginCallback implements loginCallback operation.
+	//
+	// Trade the authorization code Discord sent back for a session, set the session cookie, clear the
+	// state cookie and redirect to the frontend. The session token is also accepted as a bearer token.
+	//
+	// GET /api/v1/auth/callback
+	LoginCallback(ctx context.Context, params LoginCallbackParams) (LoginCallbackRes, error)
+	// Logout implements logout operation.
+	//
+	// End the current session and clear the session cookie.
+	//
+	// POST /api/v1/auth/logout
+	Logout(ctx context.Context) (LogoutRes, error)
+	// RemoveFromWishlist implements removeFromWishlist operation.
+	//
+	// Remove a character from the logged in user's wishlist.
+	//
+	// DELETE /api/v1/me/wishlist/{characterID}
+	RemoveFromWishlist(ctx context.Context, params RemoveFromWishlistParams) (RemoveFromWishlistRes, error)
+	// SearchCharacters implements searchCharacters operation.
+	//
+	// Search the active characters of the catalog by name or ID prefix, with how many players own and
+	// wish for them.
+	//
+	// GET /api/v1/characters
+	SearchCharacters(ctx context.Context, params SearchCharactersParams) ([]CatalogCharacter, error)
+	// SearchMedia implements searchMedia operation.
+	//
+	// Search the anime and manga known to the catalog by title or ID, most popular first.
+	//
+	// GET /api/v1/media
+	SearchMedia(ctx context.Context, params SearchMediaParams) ([]Media, error)
+	// SetFavorite implements setFavorite operation.
+	//
+	// Set the character shown on the logged in user's profile. It doesn't have to be owned.
+	//
+	// PUT /api/v1/me/favorite
+	SetFavorite(ctx context.Context, req *FavoriteUpdate) (SetFavoriteRes, error)
+	// UpdateProfile implements updateProfile operation.
+	//
+	// Change the quote or Anilist URL of the logged in user. Fields left out are kept.
+	//
+	// PATCH /api/v1/me/profile
+	UpdateProfile(ctx context.Context, req *ProfileUpdate) (UpdateProfileRes, error)
 }
 
 // Server implements http server based on OpenAPI v3 specification and
 // calls Handler to handle requests.
 type Server struct {
-	h Handler
+	h   Handler
+	sec SecurityHandler
 	baseServer
 }
 
 // NewServer creates new Server.
-func NewServer(h Handler, opts ...ServerOption) (*Server, error) {
+func NewServer(h Handler, sec SecurityHandler, opts ...ServerOption) (*Server, error) {
 	s, err := newServerConfig(opts...).baseServer()
 	if err != nil {
 		return nil, err
 	}
 	return &Server{
 		h:          h,
+		sec:        sec,
 		baseServer: s,
 	}, nil
 }
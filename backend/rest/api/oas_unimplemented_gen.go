@@ -13,6 +13,25 @@ type UnimplementedHandler struct{}
 
 var _ Handler = UnimplementedHandler{}
 
+// AddToWishlist implements addToWishlist operation.
+//
+// Add a character the logged in user doesn't own to their wishlist.
+//
+// PUT /api/v1/me/wishlist/{characterID}
+func (UnimplementedHandler) AddToWishlist(ctx context.Context, params AddToWishlistParams) (r AddToWishlistRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ExportUser implements exportUser operation.
+//
+// Back up a user's collection, wishlist, tokens and profile. The backup can be imported back with
+// `waifubot import`.
+//
+// GET /api/v1/export/{userID}
+func (UnimplementedHandler) ExportUser(ctx context.Context, params ExportUserParams) (r ExportUserRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // FindUser implements findUser operation.
 //
 // Find a user by their Anilist URL or Discord username. Query parameters are mutually exclusive.
@@ -35,15 +54,54 @@ func (UnimplementedHandler) FindUserV1(ctx context.Context, params FindUserV1Par
 	return r, ht.ErrNotImplemented
 }
 
+// GetCatalogCharacter implements getCatalogCharacter operation.
+//
+// Retrieve a character from the catalog with its description, the media it appears in and how many
+// players own and wish for it.
+//
+// GET /api/v1/characters/{characterID}
+func (UnimplementedHandler) GetCatalogCharacter(ctx context.Context, params GetCatalogCharacterParams) (r GetCatalogCharacterRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetCharacterHistory implements getCharacterHistory operation.
+//
+// Retrieve the ownership events of a character, newest first.
+//
+// GET /api/v1/character/{characterID}/history
+func (UnimplementedHandler) GetCharacterHistory(ctx context.Context, params GetCharacterHistoryParams) (r GetCharacterHistoryRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // GetCollectionV1 implements getCollectionV1 operation.
 //
-// Retrieve a user's character collection.
+// Retrieve a user's character collection, filtered and sorted. Without a limit the whole collection
+// is returned in one page; with one, follow next_cursor to get the next page.
 //
 // GET /api/v1/collection/{userID}
 func (UnimplementedHandler) GetCollectionV1(ctx context.Context, params GetCollectionV1Params) (r GetCollectionV1Res, _ error) {
 	return r, ht.ErrNotImplemented
 }
 
+// GetLeaderboard implements getLeaderboard operation.
+//
+// Rank collectors in a category, globally or among the indexed members of a guild. Rankings are
+// refreshed periodically.
+//
+// GET /api/v1/leaderboard/{category}
+func (UnimplementedHandler) GetLeaderboard(ctx context.Context, params GetLeaderboardParams) (r GetLeaderboardRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetMediaCharacters implements getMediaCharacters operation.
+//
+// List the characters of an anime or manga, most favorited first.
+//
+// GET /api/v1/media/{mediaID}/characters
+func (UnimplementedHandler) GetMediaCharacters(ctx context.Context, params GetMediaCharactersParams) (r GetMediaCharactersRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // GetProfileV1 implements getProfileV1 operation.
 //
 // Retrieve a user's profile information and favorite character.
@@ -53,6 +111,25 @@ func (UnimplementedHandler) GetProfileV1(ctx context.Context, params GetProfileV
 	return r, ht.ErrNotImplemented
 }
 
+// GetSeriesCompletion implements getSeriesCompletion operation.
+//
+// Compare a user's collection against every character of an anime or manga, reporting the percent
+// owned and the missing characters.
+//
+// GET /api/v1/collection/{userID}/series/{mediaID}
+func (UnimplementedHandler) GetSeriesCompletion(ctx context.Context, params GetSeriesCompletionParams) (r GetSeriesCompletionRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetSession implements getSession operation.
+//
+// Retrieve who is logged in and until when.
+//
+// GET /api/v1/auth/session
+func (UnimplementedHandler) GetSession(ctx context.Context) (r GetSessionRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // GetUser implements getUser operation.
 //
 // Retrieve a user's complete profile including user info, collection, and favorite character.
@@ -75,6 +152,16 @@ func (UnimplementedHandler) GetUserV1(ctx context.Context, params GetUserV1Param
 	return r, ht.ErrNotImplemented
 }
 
+// GetValueHistory implements getValueHistory operation.
+//
+// Retrieve the daily value of a user's collection, oldest first. Days before the user's first
+// recorded value are left out.
+//
+// GET /api/v1/profile/{userID}/value-history
+func (UnimplementedHandler) GetValueHistory(ctx context.Context, params GetValueHistoryParams) (r GetValueHistoryRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // GetWishlist implements getWishlist operation.
 //
 // Retrieve a user's wishlist of characters.
@@ -83,3 +170,78 @@ func (UnimplementedHandler) GetUserV1(ctx context.Context, params GetUserV1Param
 func (UnimplementedHandler) GetWishlist(ctx context.Context, params GetWishlistParams) (r GetWishlistRes, _ error) {
 	return r, ht.ErrNotImplemented
 }
+
+// Login implements login operation.
+//
+// Redirect to Discord to authorize the application. The OAuth2 state is kept in a short-lived cookie
+// and checked by the callback.
+//
+// GET /api/v1/auth/login
+func (UnimplementedHandler) Login(ctx context.Context) (r LoginRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// LoginCallback implements loginCallback operation.
+//
+// Trade the authorization code Discord sent back for a session, set the session cookie, clear the
+// state cookie and redirect to the frontend. The session token is also accepted as a bearer token.
+//
+// GET /api/v1/auth/callback
+func (UnimplementedHandler) LoginCallback(ctx context.Context, params LoginCallbackParams) (r LoginCallbackRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// Logout implements logout operation.
+//
+// End the current session and clear the session cookie.
+//
+// POST /api/v1/auth/logout
+func (UnimplementedHandler) Logout(ctx context.Context) (r LogoutRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// RemoveFromWishlist implements removeFromWishlist operation.
+//
+// Remove a character from the logged in user's wishlist.
+//
+// DELETE /api/v1/me/wishlist/{characterID}
+func (UnimplementedHandler) RemoveFromWishlist(ctx context.Context, params RemoveFromWishlistParams) (r RemoveFromWishlistRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// SearchCharacters implements searchCharacters operation.
+//
+// Search the active characters of the catalog by name or ID prefix, with how many players own and
+// wish for them.
+//
+// GET /api/v1/characters
+func (UnimplementedHandler) SearchCharacters(ctx context.Context, params SearchCharactersParams) (r []CatalogCharacter, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// SearchMedia implements searchMedia operation.
+//
+// Search the anime and manga known to the catalog by title or ID, most popular first.
+//
+// GET /api/v1/media
+func (UnimplementedHandler) SearchMedia(ctx context.Context, params SearchMediaParams) (r []Media, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// SetFavorite implements setFavorite operation.
+//
+// Set the character shown on the logged in user's profile. It doesn't have to be owned.
+//
+// PUT /api/v1/me/favorite
+func (UnimplementedHandler) SetFavorite(ctx context.Context, req *FavoriteUpdate) (r SetFavoriteRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// UpdateProfile implements updateProfile operation.
+//
+// Change the quote or Anilist URL of the logged in user. Fields left out are kept.
+//
+// PATCH /api/v1/me/profile
+func (UnimplementedHandler) UpdateProfile(ctx context.Context, req *ProfileUpdate) (r UpdateProfileRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
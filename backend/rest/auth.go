@@ -0,0 +1,257 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-faster/jx"
+	"github.com/ogen-go/ogen/ogenerrors"
+
+	"github.com/karitham/waifubot/auth"
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/rest/api"
+	"github.com/karitham/waifubot/wishlist"
+)
+
+// stateTTL is how long a user has to authorize the application once login redirected them.
+const stateTTL = 10 * time.Minute
+
+type sessionKey struct{}
+
+// session returns the session the security handlers put in ctx.
+func session(ctx context.Context) auth.Session {
+	s, _ := ctx.Value(sessionKey{}).(auth.Session)
+	return s
+}
+
+func (s *Server) HandleSessionCookie(ctx context.Context, _ api.OperationName, t api.SessionCookie) (context.Context, error) {
+	return s.authenticate(ctx, t.APIKey)
+}
+
+func (s *Server) HandleBearerAuth(ctx context.Context, _ api.OperationName, t api.BearerAuth) (context.Context, error) {
+	return s.authenticate(ctx, t.Token)
+}
+
+// authenticate skips invalid sessions, so that a stale cookie doesn't hide a valid bearer token.
+func (s *Server) authenticate(ctx context.Context, token string) (context.Context, error) {
+	if s.logins == nil {
+		return nil, ogenerrors.ErrSkipServerSecurity
+	}
+
+	sess, err := s.logins.Authenticate(ctx, token)
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidSession) {
+			return nil, ogenerrors.ErrSkipServerSecurity
+		}
+		return nil, err
+	}
+	return context.WithValue(ctx, sessionKey{}, sess), nil
+}
+
+// ErrorHandler answers failed security checks with a 401 Error and
+// leaves every other error to ogen.
+func ErrorHandler(ctx context.Context, w http.ResponseWriter, r *http.Request, err error) {
+	var secErr *ogenerrors.SecurityError
+	if !errors.As(err, &secErr) {
+		ogenerrors.DefaultErrorHandler(ctx, w, r, err)
+		return
+	}
+
+	e := jx.GetEncoder()
+	defer jx.PutEncoder(e)
+	(&api.Error{
+		Message:    "log in to do this",
+		ErrorCode:  "unauthorized",
+		StatusCode: http.StatusUnauthorized,
+	}).Encode(e)
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusUnauthorized)
+	_, _ = w.Write(e.Bytes())
+}
+
+var errLoginDisabled = &api.Error{
+	Message:    "login is not enabled",
+	ErrorCode:  "login_disabled",
+	StatusCode: 404,
+}
+
+func (s *Server) Login(ctx context.Context) (api.LoginRes, error) {
+	if s.logins == nil {
+		return errLoginDisabled, nil
+	}
+
+	state := auth.NewState()
+	return &api.Redirect{
+		Location:  s.logins.AuthorizeURL(state),
+		SetCookie: []string{s.cookie(auth.StateCookie, state, "/api/v1/auth", stateTTL).String()},
+	}, nil
+}
+
+func (s *Server) LoginCallback(ctx context.Context, params api.LoginCallbackParams) (api.LoginCallbackRes, error) {
+	if s.logins == nil {
+		return (*api.LoginCallbackNotFound)(errLoginDisabled), nil
+	}
+
+	sess, err := s.logins.Login(ctx, params.Code, params.State, params.WaifubotOAuthState.Or(""))
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidState) || errors.Is(err, auth.ErrLoginFailed) {
+			return &api.LoginCallbackBadRequest{
+				Message:    "login failed, please try again",
+				ErrorCode:  "login_failed",
+				StatusCode: 400,
+			}, nil
+		}
+		return nil, err
+	}
+
+	// The state is spent, so its cookie goes away with the redirect.
+	return &api.Redirect{
+		Location: s.logins.Config().HomeURL,
+		SetCookie: []string{
+			s.cookie(auth.SessionCookie, sess.Token, "/", time.Until(sess.ExpiresAt)).String(),
+			s.cookie(auth.StateCookie, "", "/api/v1/auth", -1).String(),
+		},
+	}, nil
+}
+
+func (s *Server) Logout(ctx context.Context) (api.LogoutRes, error) {
+	if err := s.logins.Logout(ctx, session(ctx).Token); err != nil {
+		return nil, err
+	}
+	return &api.LogoutNoContent{
+		SetCookie: s.cookie(auth.SessionCookie, "", "/", -1).String(),
+	}, nil
+}
+
+func (s *Server) GetSession(ctx context.Context) (api.GetSessionRes, error) {
+	sess := session(ctx)
+	return &api.Session{
+		UserID:    strconv.FormatUint(sess.UserID, 10),
+		ExpiresAt: sess.ExpiresAt,
+	}, nil
+}
+
+func (s *Server) UpdateProfile(ctx context.Context, req *api.ProfileUpdate) (api.UpdateProfileRes, error) {
+	userID := session(ctx).UserID
+
+	if quote, ok := req.Quote.Get(); ok {
+		if err := collection.SetQuote(ctx, s.db, userID, quote); err != nil {
+			if errors.Is(err, collection.ErrQuoteTooLong) {
+				return &api.UpdateProfileBadRequest{
+					Message:    err.Error(),
+					ErrorCode:  "invalid_quote",
+					StatusCode: 400,
+				}, nil
+			}
+			return nil, err
+		}
+	}
+
+	if anilistURL, ok := req.AnilistURL.Get(); ok {
+		if err := collection.SetAnilistURL(ctx, s.db, userID, anilistURL); err != nil {
+			if errors.Is(err, collection.ErrInvalidAnilistURL) {
+				return &api.UpdateProfileBadRequest{
+					Message:    err.Error(),
+					ErrorCode:  "invalid_anilist_url",
+					StatusCode: 400,
+				}, nil
+			}
+			return nil, err
+		}
+	}
+
+	u, err := s.db.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.userProfile(ctx, u), nil
+}
+
+func (s *Server) SetFavorite(ctx context.Context, req *api.FavoriteUpdate) (api.SetFavoriteRes, error) {
+	if req.CharacterID <= 0 {
+		return &api.SetFavoriteBadRequest{
+			Message:    "invalid character id provided",
+			ErrorCode:  "invalid_id",
+			StatusCode: 400,
+		}, nil
+	}
+
+	if err := collection.SetFavorite(ctx, s.db, session(ctx).UserID, req.CharacterID); err != nil {
+		if errors.Is(err, collection.ErrNotFound) {
+			return &api.SetFavoriteNotFound{
+				Message:    "character not found",
+				ErrorCode:  "character_not_found",
+				StatusCode: 404,
+			}, nil
+		}
+		return nil, err
+	}
+	return &api.SetFavoriteNoContent{}, nil
+}
+
+func (s *Server) AddToWishlist(ctx context.Context, params api.AddToWishlistParams) (api.AddToWishlistRes, error) {
+	if params.CharacterID <= 0 {
+		return &api.AddToWishlistBadRequest{
+			Message:    "invalid character id provided",
+			ErrorCode:  "invalid_id",
+			StatusCode: 400,
+		}, nil
+	}
+
+	_, err := wishlist.AddCharacterToWishlist(ctx, s.wishlistStore, s.db, session(ctx).UserID, params.CharacterID)
+	switch {
+	case errors.Is(err, wishlist.ErrAlreadyOwned):
+		return &api.AddToWishlistConflict{
+			Message:    "you already own this character",
+			ErrorCode:  "already_owned",
+			StatusCode: 409,
+		}, nil
+	case errors.Is(err, collection.ErrNotFound):
+		return &api.AddToWishlistNotFound{
+			Message:    "character not found",
+			ErrorCode:  "character_not_found",
+			StatusCode: 404,
+		}, nil
+	case err != nil:
+		return nil, err
+	}
+	return &api.AddToWishlistNoContent{}, nil
+}
+
+func (s *Server) RemoveFromWishlist(ctx context.Context, params api.RemoveFromWishlistParams) (api.RemoveFromWishlistRes, error) {
+	if params.CharacterID <= 0 {
+		return &api.RemoveFromWishlistBadRequest{
+			Message:    "invalid character id provided",
+			ErrorCode:  "invalid_id",
+			StatusCode: 400,
+		}, nil
+	}
+
+	if err := s.wishlistStore.RemoveCharactersFromWishlist(ctx, session(ctx).UserID, []int64{params.CharacterID}); err != nil {
+		return nil, err
+	}
+	return &api.RemoveFromWishlistNoContent{}, nil
+}
+
+// cookie builds an HTTP-only cookie, secure when the login callback is served over HTTPS.
+// A negative maxAge deletes the cookie.
+func (s *Server) cookie(name, value, path string, maxAge time.Duration) *http.Cookie {
+	c := &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		MaxAge:   int(maxAge.Seconds()),
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(s.logins.Config().RedirectURL, "https://"),
+		SameSite: http.SameSiteLaxMode,
+	}
+	if maxAge < 0 {
+		c.MaxAge = -1
+	}
+	return c
+}
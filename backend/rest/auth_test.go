@@ -0,0 +1,178 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karitham/waifubot/auth"
+	"github.com/karitham/waifubot/auth/authtest"
+	"github.com/karitham/waifubot/catalog"
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/collection/collectiontest"
+	"github.com/karitham/waifubot/rest/api"
+	"github.com/karitham/waifubot/wishlist/wishlisttest"
+)
+
+type authFixture struct {
+	api      *httptest.Server
+	client   *http.Client
+	quote    string
+	favorite int64
+	wished   []int64
+}
+
+// newAuthFixture serves the REST API with logins against a fake Discord.
+// Users own character 1, and the catalog knows characters 1 and 2.
+func newAuthFixture(t *testing.T) *authFixture {
+	t.Helper()
+	f := &authFixture{}
+
+	provider := authtest.NewProvider("client", "secret")
+	t.Cleanup(provider.Close)
+	provider.LogInAs(authtest.User{ID: "42", Username: "saber"})
+
+	db := &collectiontest.MockStore{
+		UpdateQuoteFunc: func(_ context.Context, _ collection.UserID, quote string) error {
+			f.quote = quote
+			return nil
+		},
+		UpdateFavoriteFunc: func(_ context.Context, _ collection.UserID, charID int64) error {
+			f.favorite = charID
+			return nil
+		},
+		GetUserFunc: func(_ context.Context, userID collection.UserID) (collection.User, error) {
+			return collection.User{UserID: userID, Quote: f.quote, Favorite: f.favorite}, nil
+		},
+		GetCharacterByIDFunc: func(_ context.Context, charID int64) (catalog.Character, error) {
+			if charID > 2 {
+				return catalog.Character{}, collection.ErrNotFound
+			}
+			return catalog.Character{ID: charID}, nil
+		},
+		GetOwnedCharacterFunc: func(_ context.Context, _ collection.UserID, charID int64) (collection.OwnedCharacter, error) {
+			if charID != 1 {
+				return collection.OwnedCharacter{}, collection.ErrNotFound
+			}
+			return collection.OwnedCharacter{Character: collection.Character{ID: 1}}, nil
+		},
+	}
+	wishes := &wishlisttest.MockStore{
+		AddCharactersToWishlistFunc: func(_ context.Context, _ uint64, ids []int64) error {
+			f.wished = append(f.wished, ids...)
+			return nil
+		},
+	}
+
+	mux := http.NewServeMux()
+	f.api = httptest.NewServer(mux)
+	t.Cleanup(f.api.Close)
+
+	logins := auth.NewService(auth.Config{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		RedirectURL:  f.api.URL + "/api/v1/auth/callback",
+		HomeURL:      provider.URL + "/home",
+		Endpoints:    provider.Endpoints(),
+	}, &authtest.MemStore{}, db)
+
+	server := New(db, wishes, nil, nil, logins, nil)
+	handler, err := api.NewServer(server, server, api.WithErrorHandler(ErrorHandler))
+	require.NoError(t, err)
+	mux.Handle("/", handler)
+
+	jar, err := cookiejar.New(nil)
+	require.NoError(t, err)
+	f.client = &http.Client{Jar: jar, Timeout: 5 * time.Second}
+	return f
+}
+
+func (f *authFixture) do(t *testing.T, method, path, body string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequestWithContext(t.Context(), method, f.api.URL+path, strings.NewReader(body))
+	require.NoError(t, err)
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := f.client.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestAuth_LoginAndWrite(t *testing.T) {
+	f := newAuthFixture(t)
+
+	resp := f.do(t, http.MethodGet, "/api/v1/me/profile", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	resp = f.do(t, http.MethodPatch, "/api/v1/me/profile", `{"quote":"hi"}`)
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode, "writes need a session")
+
+	resp = f.do(t, http.MethodGet, "/api/v1/auth/login", "")
+	assert.Equal(t, "/home", resp.Request.URL.Path, "the callback lands on the home URL")
+
+	authURL, err := url.Parse(f.api.URL + "/api/v1/auth/callback")
+	require.NoError(t, err)
+	for _, c := range f.client.Jar.Cookies(authURL) {
+		assert.NotEqual(t, auth.StateCookie, c.Name, "the callback clears the state cookie")
+	}
+
+	resp = f.do(t, http.MethodGet, "/api/v1/auth/session", "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = f.do(t, http.MethodPatch, "/api/v1/me/profile", `{"quote":"hi"}`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "hi", f.quote)
+
+	resp = f.do(t, http.MethodPatch, "/api/v1/me/profile", `{"anilist_url":"https://example.com"}`)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = f.do(t, http.MethodPut, "/api/v1/me/favorite", `{"character_id":2}`)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, int64(2), f.favorite)
+	resp = f.do(t, http.MethodPut, "/api/v1/me/favorite", `{"character_id":3}`)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = f.do(t, http.MethodPut, "/api/v1/me/wishlist/2", "")
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp = f.do(t, http.MethodPut, "/api/v1/me/wishlist/1", "")
+	assert.Equal(t, http.StatusConflict, resp.StatusCode, "owned characters can't be wished for")
+	assert.Equal(t, []int64{2}, f.wished)
+
+	resp = f.do(t, http.MethodPost, "/api/v1/auth/logout", "")
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp = f.do(t, http.MethodGet, "/api/v1/auth/session", "")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestAuth_CallbackState(t *testing.T) {
+	f := newAuthFixture(t)
+
+	resp := f.do(t, http.MethodGet, "/api/v1/auth/callback?code=abc&state=forged", "")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "a callback needs the state set by login")
+}
+
+func TestAuth_Disabled(t *testing.T) {
+	server := New(&collectiontest.MockStore{}, &wishlisttest.MockStore{}, nil, nil, nil, nil)
+	handler, err := api.NewServer(server, server, api.WithErrorHandler(ErrorHandler))
+	require.NoError(t, err)
+
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/api/v1/auth/login", nil))
+	assert.Equal(t, http.StatusNotFound, rw.Code)
+
+	rw = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/me/wishlist/1", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	handler.ServeHTTP(rw, req)
+	assert.Equal(t, http.StatusUnauthorized, rw.Code)
+	assert.Contains(t, rw.Body.String(), `"error_code":"unauthorized"`)
+}
@@ -0,0 +1,38 @@
+package rest
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/go-chi/cors"
+)
+
+// CORSMiddleware lets the given frontend origins call every route with credentials.
+// Other origins may only read the public API: they get GET without credentials,
+// so no other website can use a user's session or reach the write API.
+func CORSMiddleware(origins []string) func(next http.Handler) http.Handler {
+	frontend := cors.Handler(cors.Options{
+		AllowedOrigins:   origins,
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "If-None-Match", "Last-Event-ID"},
+		MaxAge:           300,
+		AllowCredentials: true,
+	})
+	public := cors.Handler(cors.Options{
+		AllowedOrigins: []string{"*"},
+		AllowedMethods: []string{"GET", "OPTIONS"},
+		AllowedHeaders: []string{"Accept", "If-None-Match", "Last-Event-ID"},
+		MaxAge:         300,
+	})
+
+	return func(next http.Handler) http.Handler {
+		toFrontend, toPublic := frontend(next), public(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if slices.Contains(origins, r.Header.Get("Origin")) {
+				toFrontend.ServeHTTP(w, r)
+				return
+			}
+			toPublic.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORSMiddleware(t *testing.T) {
+	handler := CORSMiddleware([]string{"https://waifugui.karitham.dev"})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name            string
+		method          string
+		origin          string
+		requestMethod   string
+		wantOrigin      string
+		wantCredentials string
+	}{
+		{
+			name:            "frontend write preflight",
+			method:          http.MethodOptions,
+			origin:          "https://waifugui.karitham.dev",
+			requestMethod:   http.MethodPut,
+			wantOrigin:      "https://waifugui.karitham.dev",
+			wantCredentials: "true",
+		},
+		{
+			name:          "other origin write preflight",
+			method:        http.MethodOptions,
+			origin:        "https://evil.example",
+			requestMethod: http.MethodPut,
+		},
+		{
+			name:       "other origin read",
+			method:     http.MethodGet,
+			origin:     "https://evil.example",
+			wantOrigin: "*",
+		},
+		{
+			name:            "frontend read",
+			method:          http.MethodGet,
+			origin:          "https://waifugui.karitham.dev",
+			wantOrigin:      "https://waifugui.karitham.dev",
+			wantCredentials: "true",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/api/v1/profile", nil)
+			req.Header.Set("Origin", tt.origin)
+			if tt.requestMethod != "" {
+				req.Header.Set("Access-Control-Request-Method", tt.requestMethod)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantOrigin, rec.Header().Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, tt.wantCredentials, rec.Header().Get("Access-Control-Allow-Credentials"))
+		})
+	}
+}
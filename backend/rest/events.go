@@ -0,0 +1,141 @@
+package rest
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-faster/jx"
+
+	"github.com/karitham/waifubot/activity"
+	"github.com/karitham/waifubot/rest/api"
+)
+
+// EventsPath is where EventsHandler is served.
+const EventsPath = "/api/v1/events"
+
+// EventsHandler streams the activity of the collections as Server-Sent Events,
+// see the ActivityEvent schema. ogen can't stream responses, so it is served
+// next to the generated router, and must not be buffered or timed out.
+func EventsHandler(hub *activity.Hub) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+
+		var f activity.Filter
+		if v := q.Get("user_id"); v != "" {
+			id, err := parseUserID(v)
+			if err != nil {
+				writeError(w, &api.Error{Message: "invalid user id provided", ErrorCode: "invalid_id", StatusCode: http.StatusBadRequest})
+				return
+			}
+			f.UserID = id
+		}
+		if v := q.Get("guild_id"); v != "" {
+			id, err := strconv.ParseUint(v, 10, 64)
+			if err != nil || id == 0 {
+				writeError(w, &api.Error{Message: "invalid guild id provided", ErrorCode: "invalid_id", StatusCode: http.StatusBadRequest})
+				return
+			}
+			f.GuildID = id
+		}
+
+		// EventSource sends the header when it reconnects on its own, the query
+		// parameter is for clients picking a stream back up themselves.
+		last := r.Header.Get("Last-Event-ID")
+		if last == "" {
+			last = q.Get("last_event_id")
+		}
+		var afterID int64
+		if last != "" {
+			id, err := strconv.ParseInt(last, 10, 64)
+			if err != nil || id < 0 {
+				writeError(w, &api.Error{Message: "invalid last event id provided", ErrorCode: "invalid_cursor", StatusCode: http.StatusBadRequest})
+				return
+			}
+			afterID = id
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+
+		sw := &sseWriter{w: w, rc: http.NewResponseController(w)}
+		if err := sw.write([]byte("retry: 5000\n\n")); err != nil {
+			return
+		}
+
+		err := hub.Stream(r.Context(), f, afterID, sw)
+		switch {
+		case errors.Is(err, activity.ErrTooSlow):
+			// Closing the stream makes the client reconnect and catch up from its last event.
+			slog.Debug("activity stream fell behind", "user_id", f.UserID, "guild_id", f.GuildID)
+		case err != nil && r.Context().Err() == nil:
+			slog.Error("error streaming activity", "error", err)
+		}
+	})
+}
+
+func writeError(w http.ResponseWriter, apiErr *api.Error) {
+	e := jx.GetEncoder()
+	defer jx.PutEncoder(e)
+	apiErr.Encode(e)
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(apiErr.StatusCode)
+	_, _ = w.Write(e.Bytes())
+}
+
+// sseWriter writes events in the text/event-stream format, flushing each one.
+type sseWriter struct {
+	w  http.ResponseWriter
+	rc *http.ResponseController
+}
+
+func (s *sseWriter) Event(e activity.Event) error {
+	enc := jx.GetEncoder()
+	defer jx.PutEncoder(enc)
+
+	ev := mapOwnershipEvent(e.OwnershipEvent)
+	char := api.Character{
+		ID:        e.CharacterID,
+		Name:      e.CharacterName,
+		Image:     e.CharacterImage,
+		Favorites: e.CharacterFavorites,
+	}
+
+	enc.ObjStart()
+	enc.FieldStart("event")
+	ev.Encode(enc)
+	enc.FieldStart("character")
+	char.Encode(enc)
+	if e.FromUsername != "" {
+		enc.FieldStart("from_username")
+		enc.Str(e.FromUsername)
+	}
+	if e.ToUsername != "" {
+		enc.FieldStart("to_username")
+		enc.Str(e.ToUsername)
+	}
+	enc.ObjEnd()
+
+	msg := make([]byte, 0, len(enc.Bytes())+32)
+	msg = append(msg, "id: "...)
+	msg = strconv.AppendInt(msg, e.ID, 10)
+	msg = append(msg, "\ndata: "...)
+	msg = append(msg, enc.Bytes()...)
+	msg = append(msg, "\n\n"...)
+	return s.write(msg)
+}
+
+func (s *sseWriter) Ping() error {
+	return s.write([]byte(": ping\n\n"))
+}
+
+func (s *sseWriter) write(b []byte) error {
+	if _, err := s.w.Write(b); err != nil {
+		return err
+	}
+	return s.rc.Flush()
+}
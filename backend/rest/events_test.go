@@ -0,0 +1,82 @@
+package rest
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karitham/waifubot/activity"
+	"github.com/karitham/waifubot/collection"
+)
+
+type eventStore []activity.Event
+
+func (s eventStore) EventsAfter(_ context.Context, afterID int64, f activity.Filter, limit int32) ([]activity.Event, error) {
+	var out []activity.Event
+	for _, e := range s {
+		if e.ID > afterID && f.Match(e) && len(out) < int(limit) {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (s eventStore) LatestEventID(context.Context) (int64, error) {
+	return s[len(s)-1].ID, nil
+}
+
+func TestEventsHandler(t *testing.T) {
+	created := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	store := eventStore{
+		{OwnershipEvent: collection.OwnershipEvent{ID: 1, CharacterID: 7, Kind: collection.EventRoll, ToUserID: 10, CreatedAt: created}},
+		{OwnershipEvent: collection.OwnershipEvent{ID: 2, CharacterID: 8, Kind: collection.EventGive, FromUserID: 10, ToUserID: 20, CreatedAt: created},
+			CharacterName: "Rem", CharacterImage: "rem.jpg", CharacterFavorites: 1000, FromUsername: "kari", ToUsername: "rem"},
+	}
+	srv := httptest.NewServer(EventsHandler(activity.NewHub(store, nil)))
+	defer srv.Close()
+
+	t.Run("invalid filter", func(t *testing.T) {
+		resp, err := http.Get(srv.URL + "?guild_id=abc")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("resume", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"?user_id=20", nil)
+		require.NoError(t, err)
+		req.Header.Set("Last-Event-ID", "1")
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		body := bufio.NewReader(resp.Body)
+		var lines []string
+		for len(lines) < 5 {
+			line, err := body.ReadString('\n')
+			require.NoError(t, err)
+			lines = append(lines, strings.TrimSuffix(line, "\n"))
+		}
+		assert.Equal(t, []string{
+			"retry: 5000",
+			"",
+			"id: 2",
+			`data: {"event":{"id":2,"kind":"give","from_user_id":"10","to_user_id":"20","tokens":0,"date":"2024-01-15T10:30:00Z"},` +
+				`"character":{"name":"Rem","image":"rem.jpg","id":8,"favorites":1000},"from_username":"kari","to_username":"rem"}`,
+			"",
+		}, lines)
+	})
+}
@@ -3,18 +3,25 @@
 package rest
 
 import (
+	"bytes"
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/Karitham/corde"
 
+	"github.com/karitham/waifubot/auth"
+	"github.com/karitham/waifubot/backup"
+	"github.com/karitham/waifubot/catalog"
 	"github.com/karitham/waifubot/collection"
 	"github.com/karitham/waifubot/discord"
+	"github.com/karitham/waifubot/leaderboard"
 	"github.com/karitham/waifubot/services"
 	"github.com/karitham/waifubot/wishlist"
 
@@ -24,13 +31,21 @@ import (
 type Server struct {
 	db             collection.Store
 	wishlistStore  wishlist.Store
+	media          collection.MediaCharacterSource
+	boards         *leaderboard.Service
+	logins         *auth.Service
 	discordService *services.DiscordService
 }
 
-func New(db collection.Store, ws wishlist.Store, discordService *services.DiscordService) *Server {
+// New creates a Server. logins may be nil, which disables login and every
+// operation that needs a session.
+func New(db collection.Store, ws wishlist.Store, media collection.MediaCharacterSource, boards *leaderboard.Service, logins *auth.Service, discordService *services.DiscordService) *Server {
 	return &Server{
 		db:             db,
 		wishlistStore:  ws,
+		media:          media,
+		boards:         boards,
+		logins:         logins,
 		discordService: discordService,
 	}
 }
@@ -105,6 +120,12 @@ func (s *Server) GetProfileV1(ctx context.Context, params api.GetProfileV1Params
 		}, nil
 	}
 
+	return s.userProfile(ctx, u), nil
+}
+
+// userProfile maps a user to their REST profile. Achievements and value are
+// left empty when they can't be read.
+func (s *Server) userProfile(ctx context.Context, u collection.User) *api.UserProfile {
 	var fav api.OptCharacter
 	if u.Favorite != 0 {
 		favChar, err := s.db.GetCharacterByID(ctx, u.Favorite)
@@ -119,15 +140,58 @@ func (s *Server) GetProfileV1(ctx context.Context, params api.GetProfileV1Params
 		}
 	}
 
+	unlocked, err := collection.UserAchievements(ctx, s.db, u.UserID)
+	if err != nil {
+		slog.With("err", err).Warn("failed to get achievements")
+	}
+	achievements := make([]api.Achievement, len(unlocked))
+	for i, a := range unlocked {
+		achievements[i] = api.Achievement{
+			ID:          a.ID,
+			Name:        a.Name,
+			Description: a.Description,
+			UnlockedAt:  a.UnlockedAt,
+		}
+	}
+
+	value, err := s.db.CollectionValue(ctx, u.UserID)
+	if err != nil {
+		slog.With("err", err).Warn("failed to get collection value")
+	}
+
 	return &api.UserProfile{
 		ID:              fmt.Sprintf("%d", u.UserID),
 		Quote:           api.NewOptString(u.Quote),
 		Tokens:          u.Tokens,
+		CollectionValue: value,
 		AnilistURL:      api.NewOptString(u.AnilistURL),
 		DiscordUsername: u.DiscordUsername,
 		DiscordAvatar:   api.NewOptString(discord.DiscordAvatarURL(u.UserID, u.DiscordAvatar)),
 		Favorite:        fav,
-	}, nil
+		Achievements:    achievements,
+	}
+}
+
+func (s *Server) GetValueHistory(ctx context.Context, params api.GetValueHistoryParams) (api.GetValueHistoryRes, error) {
+	id, err := parseUserID(params.UserID)
+	if err != nil {
+		return &api.Error{
+			Message:    "invalid id provided",
+			ErrorCode:  "invalid_id",
+			StatusCode: 400,
+		}, nil
+	}
+
+	points, err := collection.ValueHistory(ctx, s.db, id, int(params.Days.Or(collection.DefaultValueHistoryDays)), time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	history := make(api.GetValueHistoryOKApplicationJSON, len(points))
+	for i, p := range points {
+		history[i] = api.ValuePoint{Day: p.Day, Value: p.Value}
+	}
+	return &history, nil
 }
 
 func (s *Server) GetCollectionV1(ctx context.Context, params api.GetCollectionV1Params) (api.GetCollectionV1Res, error) {
@@ -140,8 +204,15 @@ func (s *Server) GetCollectionV1(ctx context.Context, params api.GetCollectionV1
 		}, nil
 	}
 
-	chars, err := s.db.GetCollection(ctx, id)
+	page, err := collection.ListCollection(ctx, s.db, id, collectionQuery(params))
 	if err != nil {
+		if errors.Is(err, collection.ErrInvalidCursor) {
+			return &api.GetCollectionV1BadRequest{
+				Message:    "invalid cursor provided",
+				ErrorCode:  "invalid_cursor",
+				StatusCode: 400,
+			}, nil
+		}
 		return &api.GetCollectionV1NotFound{
 			Message:    "user not found",
 			ErrorCode:  "user_not_found",
@@ -149,15 +220,50 @@ func (s *Server) GetCollectionV1(ctx context.Context, params api.GetCollectionV1
 		}, nil
 	}
 
-	characters := make([]api.Character, len(chars))
-	for i, entry := range chars {
+	characters := make([]api.Character, len(page.Characters))
+	for i, entry := range page.Characters {
 		characters[i] = mapCharacter(entry.ID, entry.Name, entry.Image, entry.Favorites, entry.Source, entry.Date)
 	}
 
-	return &api.CollectionResponse{
+	resp := &api.CollectionResponse{
 		Characters: characters,
-		Total:      len(characters),
-	}, nil
+		Total:      int(page.Total),
+	}
+	if page.NextCursor != "" {
+		resp.NextCursor = api.NewOptString(page.NextCursor)
+	}
+	return resp, nil
+}
+
+var rarityTiers = map[api.RarityTier]collection.RarityTier{
+	api.RarityTierCommon:    collection.RarityCommon,
+	api.RarityTierUncommon:  collection.RarityUncommon,
+	api.RarityTierRare:      collection.RarityRare,
+	api.RarityTierLegendary: collection.RarityLegendary,
+}
+
+func collectionQuery(params api.GetCollectionV1Params) collection.CollectionQuery {
+	q := collection.CollectionQuery{
+		Filter: collection.CollectionFilter{
+			Media:        params.Media.Or(""),
+			Source:       string(params.Source.Or("")),
+			AcquiredFrom: params.From.Or(time.Time{}),
+			AcquiredTo:   params.To.Or(time.Time{}),
+			Search:       params.Search.Or(""),
+		},
+		Sort:   collection.CollectionSort(params.Sort.Or(api.GetCollectionV1SortDate)),
+		Cursor: params.Cursor.Or(""),
+		Limit:  int(params.Limit.Or(0)),
+	}
+	if r, ok := params.Rarity.Get(); ok {
+		tier := rarityTiers[r]
+		q.Filter.Rarity = &tier
+	}
+	if o, ok := params.Order.Get(); ok {
+		desc := o == api.GetCollectionV1OrderDesc
+		q.Descending = &desc
+	}
+	return q
 }
 
 func (s *Server) getUserProfile(ctx context.Context, id uint64) (api.GetUserRes, error) {
@@ -307,6 +413,368 @@ func (s *Server) GetWishlist(ctx context.Context, params api.GetWishlistParams)
 	}, nil
 }
 
+func (s *Server) ExportUser(ctx context.Context, params api.ExportUserParams) (api.ExportUserRes, error) {
+	id, err := parseUserID(params.UserID)
+	if err != nil {
+		return &api.ExportUserBadRequest{
+			Message:    "invalid id provided",
+			ErrorCode:  "invalid_id",
+			StatusCode: 400,
+		}, nil
+	}
+
+	b, err := backup.Export(ctx, s.db, s.wishlistStore, id)
+	if errors.Is(err, collection.ErrNotFound) {
+		return &api.ExportUserNotFound{
+			Message:    "user not found",
+			ErrorCode:  "user_not_found",
+			StatusCode: 404,
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	format := params.Format.Or(api.ExportUserFormatJSON)
+	disposition := api.NewOptString(fmt.Sprintf(`attachment; filename="waifubot-%d.%s"`, id, format))
+
+	if format == api.ExportUserFormatCsv {
+		var buf bytes.Buffer
+		if err := backup.Write(&buf, b, backup.FormatCSV); err != nil {
+			return nil, err
+		}
+		return &api.ExportUserOKTextCsvHeaders{
+			ContentDisposition: disposition,
+			Response:           api.ExportUserOKTextCsv{Data: &buf},
+		}, nil
+	}
+
+	return &api.BackupHeaders{
+		ContentDisposition: disposition,
+		Response:           mapBackup(b),
+	}, nil
+}
+
+// mapBackup leaves out the fields a backup doesn't have, like backup's own JSON encoding.
+func mapBackup(b backup.Backup) api.Backup {
+	chars := func(cs []backup.Character) []api.BackupCharacter {
+		out := make([]api.BackupCharacter, len(cs))
+		for i, c := range cs {
+			out[i] = api.BackupCharacter{ID: c.ID}
+			if c.Name != "" {
+				out[i].Name = api.NewOptString(c.Name)
+			}
+			if c.Image != "" {
+				out[i].Image = api.NewOptString(c.Image)
+			}
+			if c.MediaTitle != "" {
+				out[i].MediaTitle = api.NewOptString(c.MediaTitle)
+			}
+			if c.Favorites != 0 {
+				out[i].Favorites = api.NewOptInt(c.Favorites)
+			}
+			if c.Source != "" {
+				out[i].Source = api.NewOptString(c.Source)
+			}
+			if !c.AcquiredAt.IsZero() {
+				out[i].AcquiredAt = api.NewOptDateTime(c.AcquiredAt)
+			}
+		}
+		return out
+	}
+
+	users := make([]api.BackupUser, len(b.Users))
+	for i, u := range b.Users {
+		users[i] = api.BackupUser{
+			UserID:     strconv.FormatUint(u.UserID, 10),
+			Tokens:     u.Tokens,
+			Collection: chars(u.Collection),
+			Wishlist:   chars(u.Wishlist),
+		}
+		if u.Quote != "" {
+			users[i].Quote = api.NewOptString(u.Quote)
+		}
+		if u.Favorite != 0 {
+			users[i].Favorite = api.NewOptInt64(u.Favorite)
+		}
+		if u.AnilistURL != "" {
+			users[i].AnilistURL = api.NewOptString(u.AnilistURL)
+		}
+	}
+
+	return api.Backup{
+		Version:    b.Version,
+		ExportedAt: api.NewOptDateTime(b.ExportedAt),
+		Users:      users,
+	}
+}
+
+func (s *Server) GetCharacterHistory(ctx context.Context, params api.GetCharacterHistoryParams) (api.GetCharacterHistoryRes, error) {
+	if params.CharacterID <= 0 {
+		return &api.GetCharacterHistoryBadRequest{
+			Message:    "invalid character id provided",
+			ErrorCode:  "invalid_id",
+			StatusCode: 400,
+		}, nil
+	}
+
+	char, events, err := collection.CharacterHistory(ctx, s.db, params.CharacterID, params.Limit.Or(collection.DefaultHistoryLimit))
+	if err != nil {
+		if errors.Is(err, collection.ErrNotFound) {
+			return &api.GetCharacterHistoryNotFound{
+				Message:    "character not found",
+				ErrorCode:  "character_not_found",
+				StatusCode: 404,
+			}, nil
+		}
+		return nil, err
+	}
+
+	history := &api.CharacterHistory{
+		Character: api.Character{
+			ID:        char.ID,
+			Name:      char.Name,
+			Image:     char.Image,
+			Favorites: char.Favorites,
+		},
+		Events: make([]api.OwnershipEvent, len(events)),
+	}
+	for i, e := range events {
+		history.Events[i] = mapOwnershipEvent(e)
+	}
+	return history, nil
+}
+
+func (s *Server) GetSeriesCompletion(ctx context.Context, params api.GetSeriesCompletionParams) (api.GetSeriesCompletionRes, error) {
+	id, err := parseUserID(params.UserID)
+	if err != nil || params.MediaID <= 0 {
+		return &api.GetSeriesCompletionBadRequest{
+			Message:    "invalid id provided",
+			ErrorCode:  "invalid_id",
+			StatusCode: 400,
+		}, nil
+	}
+
+	progress, err := collection.SeriesCompletion(ctx, s.db, s.media, id, params.MediaID)
+	if err != nil {
+		if errors.Is(err, collection.ErrMediaNotFound) {
+			return &api.GetSeriesCompletionNotFound{
+				Message:    "no characters found for this series",
+				ErrorCode:  "series_not_found",
+				StatusCode: 404,
+			}, nil
+		}
+		return nil, err
+	}
+
+	missing := make([]api.Character, len(progress.Missing))
+	for i, c := range progress.Missing {
+		missing[i] = api.Character{
+			ID:        c.ID,
+			Name:      c.Name,
+			Image:     c.ImageURL,
+			Favorites: c.Favorites,
+		}
+	}
+
+	return &api.SeriesCompletion{
+		MediaID: progress.MediaID,
+		Title:   progress.Title,
+		Total:   progress.Total,
+		Owned:   progress.Owned,
+		Percent: progress.Percent(),
+		Missing: missing,
+	}, nil
+}
+
+func (s *Server) SearchCharacters(ctx context.Context, params api.SearchCharactersParams) ([]api.CatalogCharacter, error) {
+	infos, err := collection.SearchCharacterInfo(ctx, s.db, params.Search)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]api.CatalogCharacter, len(infos))
+	for i, info := range infos {
+		resp[i] = mapCatalogCharacter(info)
+	}
+	return resp, nil
+}
+
+func (s *Server) GetCatalogCharacter(ctx context.Context, params api.GetCatalogCharacterParams) (api.GetCatalogCharacterRes, error) {
+	if params.CharacterID <= 0 {
+		return &api.GetCatalogCharacterBadRequest{
+			Message:    "invalid character id provided",
+			ErrorCode:  "invalid_id",
+			StatusCode: 400,
+		}, nil
+	}
+
+	info, err := collection.CharacterDetails(ctx, s.db, params.CharacterID, 0)
+	if err != nil {
+		if errors.Is(err, collection.ErrNotFound) {
+			return &api.GetCatalogCharacterNotFound{
+				Message:    "character not found",
+				ErrorCode:  "character_not_found",
+				StatusCode: 404,
+			}, nil
+		}
+		return nil, err
+	}
+
+	char := mapCatalogCharacter(info)
+	char.Description = api.NewOptString(info.Description)
+	char.Media = make([]api.Media, len(info.Media))
+	for i, m := range info.Media {
+		char.Media[i] = mapMedia(m)
+	}
+	if !info.Stats.FirstClaimed.IsZero() {
+		char.FirstClaimed = api.NewOptDateTime(info.Stats.FirstClaimed)
+	}
+	return &char, nil
+}
+
+// mapCatalogCharacter maps what both character endpoints return.
+func mapCatalogCharacter(info collection.CharacterInfo) api.CatalogCharacter {
+	return api.CatalogCharacter{
+		ID:         info.ID,
+		Name:       info.Name,
+		Image:      info.Image,
+		MediaTitle: info.MediaTitle,
+		Favorites:  info.Favorites,
+		Rarity:     api.RarityTier(strings.ToLower(info.Rarity().String())),
+		Active:     info.IsActive,
+		Owners:     info.Stats.Owners,
+		Wishlisted: info.Stats.Wishlisted,
+	}
+}
+
+func mapMedia(m catalog.Media) api.Media {
+	return api.Media{
+		ID:         m.ID,
+		Title:      m.Title,
+		Type:       m.Type,
+		CoverImage: m.CoverImage,
+		Popularity: m.Popularity,
+	}
+}
+
+func (s *Server) SearchMedia(ctx context.Context, params api.SearchMediaParams) ([]api.Media, error) {
+	media, err := s.db.SearchMedia(ctx, params.Search)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]api.Media, len(media))
+	for i, m := range media {
+		resp[i] = mapMedia(m)
+	}
+	return resp, nil
+}
+
+func (s *Server) GetMediaCharacters(ctx context.Context, params api.GetMediaCharactersParams) (api.GetMediaCharactersRes, error) {
+	if params.MediaID <= 0 {
+		return &api.GetMediaCharactersBadRequest{
+			Message:    "invalid id provided",
+			ErrorCode:  "invalid_id",
+			StatusCode: 400,
+		}, nil
+	}
+
+	media, err := collection.MediaCharacters(ctx, s.db, s.media, params.MediaID)
+	if err != nil {
+		if errors.Is(err, collection.ErrMediaNotFound) {
+			return &api.GetMediaCharactersNotFound{
+				Message:    "no characters found for this series",
+				ErrorCode:  "series_not_found",
+				StatusCode: 404,
+			}, nil
+		}
+		return nil, err
+	}
+
+	chars := make([]api.Character, len(media.Characters))
+	for i, c := range media.Characters {
+		chars[i] = api.Character{
+			ID:        c.ID,
+			Name:      c.Name,
+			Image:     c.ImageURL,
+			Favorites: c.Favorites,
+		}
+	}
+	slices.SortStableFunc(chars, func(a, b api.Character) int {
+		return cmp.Compare(b.Favorites, a.Favorites)
+	})
+
+	return &api.MediaCharacters{
+		MediaID:    media.MediaID,
+		Title:      media.Title,
+		Characters: chars,
+	}, nil
+}
+
+func (s *Server) GetLeaderboard(ctx context.Context, params api.GetLeaderboardParams) (api.GetLeaderboardRes, error) {
+	var guildID uint64
+	if g, ok := params.GuildID.Get(); ok {
+		id, err := strconv.ParseUint(g, 10, 64)
+		if err != nil || id == 0 {
+			return &api.Error{
+				Message:    "invalid guild id provided",
+				ErrorCode:  "invalid_id",
+				StatusCode: 400,
+			}, nil
+		}
+		guildID = id
+	}
+
+	board, err := s.boards.Top(ctx, leaderboard.Category(params.Category), guildID, int(params.Limit.Or(leaderboard.DefaultLimit)))
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]api.LeaderboardEntry, len(board.Entries))
+	for i, e := range board.Entries {
+		entries[i] = api.LeaderboardEntry{
+			Rank:            e.Rank,
+			UserID:          strconv.FormatUint(e.UserID, 10),
+			DiscordUsername: e.Username,
+			Value:           e.Value,
+		}
+		if e.Avatar != "" {
+			entries[i].DiscordAvatar = api.NewOptString(discord.DiscordAvatarURL(e.UserID, e.Avatar))
+		}
+	}
+
+	resp := &api.Leaderboard{
+		Category:    params.Category,
+		Entries:     entries,
+		RefreshedAt: board.RefreshedAt,
+	}
+	if guildID != 0 {
+		resp.GuildID = api.NewOptString(strconv.FormatUint(guildID, 10))
+	}
+	return resp, nil
+}
+
+// mapOwnershipEvent leaves out the users and reference that an event doesn't have.
+func mapOwnershipEvent(e collection.OwnershipEvent) api.OwnershipEvent {
+	ev := api.OwnershipEvent{
+		ID:     e.ID,
+		Kind:   api.OwnershipEventKind(e.Kind),
+		Tokens: e.Tokens,
+		Date:   e.CreatedAt,
+	}
+	if e.FromUserID != 0 {
+		ev.FromUserID = api.NewOptString(strconv.FormatUint(e.FromUserID, 10))
+	}
+	if e.ToUserID != 0 {
+		ev.ToUserID = api.NewOptString(strconv.FormatUint(e.ToUserID, 10))
+	}
+	if e.ReferenceID != 0 {
+		ev.ReferenceID = api.NewOptInt64(e.ReferenceID)
+	}
+	return ev
+}
+
 // mapCharacter builds an api.Character from common fields.
 func mapCharacter(id int64, name, image string, favorites int, source string, date time.Time) api.Character {
 	return api.Character{
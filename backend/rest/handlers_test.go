@@ -0,0 +1,155 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-faster/jx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karitham/waifubot/backup"
+	"github.com/karitham/waifubot/catalog"
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/collection/collectiontest"
+	"github.com/karitham/waifubot/rest/api"
+	"github.com/karitham/waifubot/wishlist"
+	"github.com/karitham/waifubot/wishlist/wishlisttest"
+)
+
+func TestGetCollectionV1_Query(t *testing.T) {
+	var got collection.CollectionPageQuery
+	db := &collectiontest.MockStore{
+		ListCollectionPageFunc: func(_ context.Context, _ collection.UserID, q collection.CollectionPageQuery) ([]collection.OwnedCharacter, error) {
+			got = q
+			return []collection.OwnedCharacter{
+				{Character: collection.Character{ID: 1, Name: "Megumin"}},
+				{Character: collection.Character{ID: 2, Name: "Rem"}},
+			}, nil
+		},
+	}
+	handler, err := api.NewServer(New(db, nil, nil, nil, nil, nil), &Server{})
+	require.NoError(t, err)
+
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet,
+		"/api/v1/collection/42?rarity=rare&source=TRADE&from=2024-01-02T00:00:00Z&search=me&sort=name&order=desc&limit=1", nil))
+	require.Equal(t, http.StatusOK, rw.Code)
+	assert.Contains(t, rw.Body.String(), `"next_cursor"`)
+
+	rare := collection.RarityRare
+	assert.Equal(t, collection.CollectionPageQuery{
+		Filter: collection.CollectionFilter{
+			Rarity:       &rare,
+			Source:       "TRADE",
+			AcquiredFrom: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			Search:       "me",
+		},
+		Sort:       collection.SortByName,
+		Descending: true,
+		Limit:      2,
+	}, got)
+
+	rw = httptest.NewRecorder()
+	handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/api/v1/collection/42?cursor=bogus", nil))
+	assert.Equal(t, http.StatusBadRequest, rw.Code)
+	assert.Contains(t, rw.Body.String(), `"error_code":"invalid_cursor"`)
+}
+
+func TestCatalogCharacters(t *testing.T) {
+	db := &collectiontest.MockStore{
+		GetCharacterByIDFunc: func(_ context.Context, id int64) (catalog.Character, error) {
+			if id != 42 {
+				return catalog.Character{}, collection.ErrNotFound
+			}
+			return catalog.Character{ID: 42, Name: "Rem", Favorites: 1500, IsActive: true}, nil
+		},
+		CharacterStatsFunc: func(context.Context, int64, uint64) (catalog.CharacterStats, error) {
+			return catalog.CharacterStats{Owners: 3, Wishlisted: 7}, nil
+		},
+		SearchGlobalCharactersFunc: func(context.Context, string) ([]catalog.Character, error) {
+			return []catalog.Character{{ID: 42, Name: "Rem", Favorites: 6000}}, nil
+		},
+		CharactersStatsFunc: func(context.Context, []int64) (map[int64]catalog.CharacterStats, error) {
+			return map[int64]catalog.CharacterStats{42: {Owners: 3}}, nil
+		},
+	}
+	handler, err := api.NewServer(New(db, nil, nil, nil, nil, nil), &Server{})
+	require.NoError(t, err)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, path, nil))
+		return rw
+	}
+
+	rw := get("/api/v1/characters/42")
+	require.Equal(t, http.StatusOK, rw.Code)
+	var char api.CatalogCharacter
+	require.NoError(t, char.Decode(jx.DecodeBytes(rw.Body.Bytes())))
+	assert.Equal(t, api.RarityTierRare, char.Rarity)
+	assert.True(t, char.Active)
+	assert.Equal(t, 3, char.Owners)
+	assert.Equal(t, 7, char.Wishlisted)
+	assert.False(t, char.FirstClaimed.IsSet(), "never claimed")
+
+	assert.Equal(t, http.StatusNotFound, get("/api/v1/characters/43").Code)
+	assert.Equal(t, http.StatusBadRequest, get("/api/v1/characters/0").Code)
+
+	rw = get("/api/v1/characters?search=rem")
+	require.Equal(t, http.StatusOK, rw.Code)
+	assert.Contains(t, rw.Body.String(), `"rarity":"legendary"`)
+	assert.Contains(t, rw.Body.String(), `"owners":3`)
+	assert.NotContains(t, rw.Body.String(), `"media"`, "searches leave out the details")
+}
+
+func TestExportUser(t *testing.T) {
+	db := &collectiontest.MockStore{
+		GetUserFunc: func(_ context.Context, id collection.UserID) (collection.User, error) {
+			if id != 42 {
+				return collection.User{}, collection.ErrNotFound
+			}
+			return collection.User{UserID: 42, Tokens: 5, Quote: "hi"}, nil
+		},
+		GetCollectionFunc: func(context.Context, collection.UserID) ([]collection.OwnedCharacter, error) {
+			return []collection.OwnedCharacter{{
+				Character: collection.Character{ID: 1, Name: "Rem", MediaTitle: "Re:Zero", Favorites: 1000},
+				Source:    "ROLL",
+				Date:      time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC),
+			}}, nil
+		},
+	}
+	ws := &wishlisttest.MockStore{
+		GetUserCharacterWishlistFunc: func(context.Context, uint64) ([]wishlist.Character, error) {
+			return []wishlist.Character{{ID: 3, Name: "Emilia"}}, nil
+		},
+	}
+	handler, err := api.NewServer(New(db, ws, nil, nil, nil, nil), &Server{})
+	require.NoError(t, err)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, path, nil))
+		return rw
+	}
+
+	want, err := backup.Export(t.Context(), db, ws, 42)
+	require.NoError(t, err)
+
+	for _, f := range []backup.Format{backup.FormatJSON, backup.FormatCSV} {
+		rw := get("/api/v1/export/42?format=" + string(f))
+		require.Equal(t, http.StatusOK, rw.Code)
+		assert.Equal(t, `attachment; filename="waifubot-42.`+string(f)+`"`, rw.Header().Get("Content-Disposition"))
+
+		// The API's backups import like the CLI's.
+		got, err := backup.Read(rw.Body, f)
+		require.NoError(t, err)
+		assert.Equal(t, want.Users, got.Users)
+	}
+
+	assert.Equal(t, http.StatusNotFound, get("/api/v1/export/43").Code)
+	assert.Equal(t, http.StatusBadRequest, get("/api/v1/export/abc").Code)
+}
@@ -20,6 +20,7 @@ func TestOgenTelemetryIntegration(t *testing.T) {
 
 	srv, err := api.NewServer(
 		api.UnimplementedHandler{},
+		&Server{},
 		api.WithMeterProvider(telemetry.MeterProvider()),
 	)
 	require.NoError(t, err)
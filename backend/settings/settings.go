@@ -0,0 +1,254 @@
+package settings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/karitham/waifubot/collection"
+)
+
+// DefaultCacheTTL is how long a guild's settings are served from memory
+// before being read again, so changes made by another instance show up.
+const DefaultCacheTTL = time.Minute
+
+// MaxRollCooldown is the longest roll cooldown a guild can set.
+const MaxRollCooldown = 7 * 24 * time.Hour
+
+// ErrInvalidSetting is returned when an override is out of bounds.
+var ErrInvalidSetting = errors.New("invalid setting")
+
+// Overrides are the settings a guild changed. Nil fields fall back to the defaults.
+type Overrides struct {
+	RollCooldown      *time.Duration
+	InteractionNeeded *int64
+	SeriesRollCost    *int32
+	// DropChannelID is the channel every drop in the guild goes to.
+	// Nil or 0 means each channel gets its own drops.
+	DropChannelID *uint64
+}
+
+// Apply returns s with the overrides applied.
+func (o Overrides) Apply(s collection.Config) collection.Config {
+	if o.RollCooldown != nil {
+		s.RollCooldown = *o.RollCooldown
+	}
+	if o.InteractionNeeded != nil {
+		s.InteractionNeeded = *o.InteractionNeeded
+	}
+	if o.SeriesRollCost != nil {
+		s.SeriesRollCost = *o.SeriesRollCost
+	}
+	return s
+}
+
+// Merge returns o with every field set in patch replaced.
+func (o Overrides) Merge(patch Overrides) Overrides {
+	if patch.RollCooldown != nil {
+		o.RollCooldown = patch.RollCooldown
+	}
+	if patch.InteractionNeeded != nil {
+		o.InteractionNeeded = patch.InteractionNeeded
+	}
+	if patch.SeriesRollCost != nil {
+		o.SeriesRollCost = patch.SeriesRollCost
+	}
+	if patch.DropChannelID != nil {
+		o.DropChannelID = patch.DropChannelID
+	}
+	return o
+}
+
+// Validate checks the overrides are within bounds.
+func (o Overrides) Validate() error {
+	if o.RollCooldown != nil && (*o.RollCooldown < 0 || *o.RollCooldown > MaxRollCooldown) {
+		return fmt.Errorf("%w: roll cooldown must be between 0s and %s", ErrInvalidSetting, MaxRollCooldown)
+	}
+	if o.InteractionNeeded != nil && *o.InteractionNeeded <= 0 {
+		return fmt.Errorf("%w: interactions needed must be at least 1", ErrInvalidSetting)
+	}
+	if o.SeriesRollCost != nil && *o.SeriesRollCost < 0 {
+		return fmt.Errorf("%w: series roll cost cannot be negative", ErrInvalidSetting)
+	}
+	return nil
+}
+
+// DropRoute says where an interaction is counted and where the drop it triggers is sent.
+type DropRoute struct {
+	// CounterID is the channel, or the whole guild, the interaction counts towards.
+	CounterID uint64
+	// ChannelID is the channel the drop is sent to.
+	ChannelID uint64
+}
+
+// Store persists guild overrides and drop channel allow-lists.
+type Store interface {
+	// GetGuildSettings returns empty overrides for guilds that never changed anything.
+	GetGuildSettings(ctx context.Context, guildID uint64) (Overrides, error)
+	UpsertGuildSettings(ctx context.Context, guildID uint64, o Overrides) error
+	DeleteGuildSettings(ctx context.Context, guildID uint64) error
+
+	ListDropChannels(ctx context.Context, guildID uint64) ([]uint64, error)
+	AddDropChannel(ctx context.Context, guildID, channelID uint64) error
+	// RemoveDropChannel reports whether the channel was on the allow-list.
+	RemoveDropChannel(ctx context.Context, guildID, channelID uint64) (bool, error)
+}
+
+type cached struct {
+	overrides    Overrides
+	dropChannels []uint64
+	fetchedAt    time.Time
+}
+
+// Service resolves guild settings on top of the global defaults and caches
+// each guild's overrides for TTL. It implements collection.ConfigSource.
+type Service struct {
+	store    Store
+	defaults collection.Config
+	ttl      time.Duration
+
+	mu    sync.Mutex
+	cache map[uint64]cached
+}
+
+// NewService returns a Service that falls back to defaults for anything a guild didn't override.
+func NewService(store Store, defaults collection.Config, ttl time.Duration) *Service {
+	return &Service{
+		store:    store,
+		defaults: defaults,
+		ttl:      ttl,
+		cache:    make(map[uint64]cached),
+	}
+}
+
+// Defaults returns the global settings.
+func (s *Service) Defaults() collection.Config {
+	return s.defaults
+}
+
+// GuildConfig returns the settings that apply to guildID.
+// On error the defaults are returned alongside it, so callers can keep going.
+func (s *Service) GuildConfig(ctx context.Context, guildID uint64) (collection.Config, error) {
+	o, err := s.Overrides(ctx, guildID)
+	if err != nil {
+		return s.defaults, err
+	}
+	return o.Apply(s.defaults), nil
+}
+
+// Overrides returns the values guildID changed.
+func (s *Service) Overrides(ctx context.Context, guildID uint64) (Overrides, error) {
+	c, err := s.load(ctx, guildID)
+	return c.overrides, err
+}
+
+// DropChannels returns the guild's drop channel allow-list. Empty means every channel is allowed.
+func (s *Service) DropChannels(ctx context.Context, guildID uint64) ([]uint64, error) {
+	c, err := s.load(ctx, guildID)
+	return c.dropChannels, err
+}
+
+// DropRoute resolves how an interaction in channelID feeds drops.
+// It returns false when the channel is not on the guild's allow-list, in which
+// case the interaction must not be counted at all.
+func (s *Service) DropRoute(ctx context.Context, guildID, channelID uint64) (DropRoute, bool, error) {
+	c, err := s.load(ctx, guildID)
+	if err != nil {
+		return DropRoute{}, false, err
+	}
+
+	if len(c.dropChannels) > 0 && !slices.Contains(c.dropChannels, channelID) {
+		return DropRoute{}, false, nil
+	}
+	if c.overrides.DropChannelID != nil && *c.overrides.DropChannelID != 0 {
+		return DropRoute{CounterID: guildID, ChannelID: *c.overrides.DropChannelID}, true, nil
+	}
+	return DropRoute{CounterID: channelID, ChannelID: channelID}, true, nil
+}
+
+// AllowDropChannel adds channelID to the guild's drop channel allow-list.
+func (s *Service) AllowDropChannel(ctx context.Context, guildID, channelID uint64) error {
+	if err := s.store.AddDropChannel(ctx, guildID, channelID); err != nil {
+		return err
+	}
+	s.invalidate(guildID)
+	return nil
+}
+
+// DenyDropChannel removes channelID from the guild's drop channel allow-list.
+// It reports whether the channel was on it.
+func (s *Service) DenyDropChannel(ctx context.Context, guildID, channelID uint64) (bool, error) {
+	removed, err := s.store.RemoveDropChannel(ctx, guildID, channelID)
+	if err != nil {
+		return false, err
+	}
+	s.invalidate(guildID)
+	return removed, nil
+}
+
+// load returns the guild's cached settings, reading them from the store once the TTL passed.
+func (s *Service) load(ctx context.Context, guildID uint64) (cached, error) {
+	if guildID == 0 {
+		return cached{}, nil
+	}
+
+	s.mu.Lock()
+	c, ok := s.cache[guildID]
+	s.mu.Unlock()
+	if ok && time.Since(c.fetchedAt) < s.ttl {
+		return c, nil
+	}
+
+	o, err := s.store.GetGuildSettings(ctx, guildID)
+	if err != nil {
+		return cached{}, err
+	}
+	channels, err := s.store.ListDropChannels(ctx, guildID)
+	if err != nil {
+		return cached{}, err
+	}
+
+	c = cached{overrides: o, dropChannels: channels, fetchedAt: time.Now()}
+	s.mu.Lock()
+	s.cache[guildID] = c
+	s.mu.Unlock()
+	return c, nil
+}
+
+func (s *Service) invalidate(guildID uint64) {
+	s.mu.Lock()
+	delete(s.cache, guildID)
+	s.mu.Unlock()
+}
+
+// Update applies patch on top of the guild's current overrides and returns the result.
+func (s *Service) Update(ctx context.Context, guildID uint64, patch Overrides) (Overrides, error) {
+	if err := patch.Validate(); err != nil {
+		return Overrides{}, err
+	}
+
+	current, err := s.store.GetGuildSettings(ctx, guildID)
+	if err != nil {
+		return Overrides{}, err
+	}
+	o := current.Merge(patch)
+	if err := s.store.UpsertGuildSettings(ctx, guildID, o); err != nil {
+		return Overrides{}, err
+	}
+
+	s.invalidate(guildID)
+	return o, nil
+}
+
+// Reset drops every override for the guild. The drop channel allow-list is kept.
+func (s *Service) Reset(ctx context.Context, guildID uint64) error {
+	if err := s.store.DeleteGuildSettings(ctx, guildID); err != nil {
+		return err
+	}
+
+	s.invalidate(guildID)
+	return nil
+}
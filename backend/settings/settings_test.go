@@ -0,0 +1,194 @@
+package settings_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/settings"
+)
+
+type fakeStore struct {
+	rows     map[uint64]settings.Overrides
+	channels map[uint64][]uint64
+	gets     int
+	err      error
+	saved    settings.Overrides
+}
+
+func (f *fakeStore) GetGuildSettings(_ context.Context, guildID uint64) (settings.Overrides, error) {
+	f.gets++
+	return f.rows[guildID], f.err
+}
+
+func (f *fakeStore) UpsertGuildSettings(_ context.Context, guildID uint64, o settings.Overrides) error {
+	f.saved = o
+	f.rows[guildID] = o
+	return nil
+}
+
+func (f *fakeStore) DeleteGuildSettings(_ context.Context, guildID uint64) error {
+	delete(f.rows, guildID)
+	return nil
+}
+
+func (f *fakeStore) ListDropChannels(_ context.Context, guildID uint64) ([]uint64, error) {
+	return f.channels[guildID], nil
+}
+
+func (f *fakeStore) AddDropChannel(_ context.Context, guildID, channelID uint64) error {
+	if !slices.Contains(f.channels[guildID], channelID) {
+		f.channels[guildID] = append(f.channels[guildID], channelID)
+	}
+	return nil
+}
+
+func (f *fakeStore) RemoveDropChannel(_ context.Context, guildID, channelID uint64) (bool, error) {
+	i := slices.Index(f.channels[guildID], channelID)
+	if i < 0 {
+		return false, nil
+	}
+	f.channels[guildID] = slices.Delete(f.channels[guildID], i, i+1)
+	return true, nil
+}
+
+var defaults = collection.Config{RollCooldown: 2 * time.Hour, InteractionNeeded: 25, SeriesRollCost: 20}
+
+func ptr[T any](v T) *T { return &v }
+
+func TestService_GuildConfig(t *testing.T) {
+	store := &fakeStore{channels: map[uint64][]uint64{}, rows: map[uint64]settings.Overrides{
+		1: {InteractionNeeded: ptr(int64(5))},
+	}}
+	svc := settings.NewService(store, defaults, time.Hour)
+
+	got, err := svc.GuildConfig(t.Context(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, collection.Config{RollCooldown: 2 * time.Hour, InteractionNeeded: 5, SeriesRollCost: 20}, got)
+
+	_, _ = svc.GuildConfig(t.Context(), 1)
+	assert.Equal(t, 1, store.gets, "second read should hit the cache")
+
+	got, err = svc.GuildConfig(t.Context(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, defaults, got)
+
+	got, err = svc.GuildConfig(t.Context(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, defaults, got)
+	assert.Equal(t, 2, store.gets, "DMs never hit the store")
+}
+
+func TestService_GuildConfig_StoreError(t *testing.T) {
+	store := &fakeStore{channels: map[uint64][]uint64{}, rows: map[uint64]settings.Overrides{}, err: errors.New("database on fire")}
+	svc := settings.NewService(store, defaults, time.Hour)
+
+	got, err := svc.GuildConfig(t.Context(), 1)
+	require.Error(t, err)
+	assert.Equal(t, defaults, got)
+}
+
+func TestService_CacheExpires(t *testing.T) {
+	store := &fakeStore{channels: map[uint64][]uint64{}, rows: map[uint64]settings.Overrides{}}
+	svc := settings.NewService(store, defaults, 0)
+
+	_, _ = svc.GuildConfig(t.Context(), 1)
+	_, _ = svc.GuildConfig(t.Context(), 1)
+	assert.Equal(t, 2, store.gets)
+}
+
+func TestService_Update(t *testing.T) {
+	store := &fakeStore{channels: map[uint64][]uint64{}, rows: map[uint64]settings.Overrides{
+		1: {SeriesRollCost: ptr(int32(50))},
+	}}
+	svc := settings.NewService(store, defaults, time.Hour)
+
+	// warm the cache so we can check Update refreshes it
+	_, _ = svc.GuildConfig(t.Context(), 1)
+
+	o, err := svc.Update(t.Context(), 1, settings.Overrides{RollCooldown: ptr(10 * time.Minute)})
+	require.NoError(t, err)
+	assert.Equal(t, ptr(int32(50)), o.SeriesRollCost, "untouched overrides are kept")
+	assert.Equal(t, store.saved, o)
+
+	got, err := svc.GuildConfig(t.Context(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, got.RollCooldown)
+	assert.Equal(t, int32(50), got.SeriesRollCost)
+
+	require.NoError(t, svc.Reset(t.Context(), 1))
+	got, err = svc.GuildConfig(t.Context(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, defaults, got)
+}
+
+func TestOverrides_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		o       settings.Overrides
+		wantErr bool
+	}{
+		{name: "empty", o: settings.Overrides{}},
+		{name: "valid", o: settings.Overrides{RollCooldown: ptr(time.Duration(0)), InteractionNeeded: ptr(int64(1)), SeriesRollCost: ptr(int32(0))}},
+		{name: "negative_cooldown", o: settings.Overrides{RollCooldown: ptr(-time.Second)}, wantErr: true},
+		{name: "cooldown_too_long", o: settings.Overrides{RollCooldown: ptr(settings.MaxRollCooldown + time.Second)}, wantErr: true},
+		{name: "zero_interactions", o: settings.Overrides{InteractionNeeded: ptr(int64(0))}, wantErr: true},
+		{name: "negative_cost", o: settings.Overrides{SeriesRollCost: ptr(int32(-1))}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.o.Validate()
+			if tt.wantErr {
+				assert.ErrorIs(t, err, settings.ErrInvalidSetting)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestService_DropRoute(t *testing.T) {
+	store := &fakeStore{rows: map[uint64]settings.Overrides{}, channels: map[uint64][]uint64{}}
+	svc := settings.NewService(store, defaults, time.Hour)
+	ctx := t.Context()
+
+	route, ok, err := svc.DropRoute(ctx, 1, 10)
+	require.NoError(t, err)
+	assert.True(t, ok, "no allow-list means every channel counts")
+	assert.Equal(t, settings.DropRoute{CounterID: 10, ChannelID: 10}, route)
+
+	require.NoError(t, svc.AllowDropChannel(ctx, 1, 20))
+	_, ok, err = svc.DropRoute(ctx, 1, 10)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	route, ok, err = svc.DropRoute(ctx, 1, 20)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, settings.DropRoute{CounterID: 20, ChannelID: 20}, route)
+
+	_, err = svc.Update(ctx, 1, settings.Overrides{DropChannelID: ptr(uint64(30))})
+	require.NoError(t, err)
+	route, ok, err = svc.DropRoute(ctx, 1, 20)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, settings.DropRoute{CounterID: 1, ChannelID: 30}, route, "counts aggregate per guild")
+
+	removed, err := svc.DenyDropChannel(ctx, 1, 20)
+	require.NoError(t, err)
+	assert.True(t, removed)
+	_, ok, _ = svc.DropRoute(ctx, 1, 10)
+	assert.True(t, ok)
+
+	_, err = svc.Update(ctx, 1, settings.Overrides{DropChannelID: ptr(uint64(0))})
+	require.NoError(t, err)
+	route, _, _ = svc.DropRoute(ctx, 1, 10)
+	assert.Equal(t, settings.DropRoute{CounterID: 10, ChannelID: 10}, route)
+}
@@ -0,0 +1,81 @@
+package settings
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/karitham/waifubot/storage/settingsstore"
+)
+
+type store struct {
+	q settingsstore.Querier
+}
+
+func NewStore(q settingsstore.Querier) Store {
+	return &store{q: q}
+}
+
+func (s *store) GetGuildSettings(ctx context.Context, guildID uint64) (Overrides, error) {
+	row, err := s.q.Get(ctx, guildID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Overrides{}, nil
+		}
+		return Overrides{}, err
+	}
+
+	var o Overrides
+	if row.RollCooldownSeconds.Valid {
+		d := time.Duration(row.RollCooldownSeconds.Int32) * time.Second
+		o.RollCooldown = &d
+	}
+	if row.InteractionNeeded.Valid {
+		o.InteractionNeeded = &row.InteractionNeeded.Int64
+	}
+	if row.SeriesRollCost.Valid {
+		o.SeriesRollCost = &row.SeriesRollCost.Int32
+	}
+	if row.DropChannelID.Valid {
+		id := uint64(row.DropChannelID.Int64)
+		o.DropChannelID = &id
+	}
+	return o, nil
+}
+
+func (s *store) UpsertGuildSettings(ctx context.Context, guildID uint64, o Overrides) error {
+	params := settingsstore.UpsertParams{GuildID: guildID}
+	if o.RollCooldown != nil {
+		params.RollCooldownSeconds = pgtype.Int4{Int32: int32(*o.RollCooldown / time.Second), Valid: true}
+	}
+	if o.InteractionNeeded != nil {
+		params.InteractionNeeded = pgtype.Int8{Int64: *o.InteractionNeeded, Valid: true}
+	}
+	if o.SeriesRollCost != nil {
+		params.SeriesRollCost = pgtype.Int4{Int32: *o.SeriesRollCost, Valid: true}
+	}
+	if o.DropChannelID != nil && *o.DropChannelID != 0 {
+		params.DropChannelID = pgtype.Int8{Int64: int64(*o.DropChannelID), Valid: true}
+	}
+	return s.q.Upsert(ctx, params)
+}
+
+func (s *store) DeleteGuildSettings(ctx context.Context, guildID uint64) error {
+	return s.q.Delete(ctx, guildID)
+}
+
+func (s *store) ListDropChannels(ctx context.Context, guildID uint64) ([]uint64, error) {
+	return s.q.ListDropChannels(ctx, guildID)
+}
+
+func (s *store) AddDropChannel(ctx context.Context, guildID, channelID uint64) error {
+	return s.q.AddDropChannel(ctx, settingsstore.AddDropChannelParams{GuildID: guildID, ChannelID: channelID})
+}
+
+func (s *store) RemoveDropChannel(ctx context.Context, guildID, channelID uint64) (bool, error) {
+	n, err := s.q.RemoveDropChannel(ctx, settingsstore.RemoveDropChannelParams{GuildID: guildID, ChannelID: channelID})
+	return n > 0, err
+}
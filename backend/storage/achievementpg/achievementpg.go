@@ -0,0 +1,69 @@
+package achievementpg
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/storage/achievementstore"
+)
+
+type Pg struct {
+	Q achievementstore.Querier
+}
+
+func New(q achievementstore.Querier) *Pg {
+	return &Pg{Q: q}
+}
+
+func (p *Pg) UnlockAchievement(ctx context.Context, userID collection.UserID, id string, at time.Time) (bool, error) {
+	n, err := p.Q.Unlock(ctx, achievementstore.UnlockParams{
+		UserID:      userID,
+		Achievement: id,
+		UnlockedAt:  pgtype.Timestamp{Time: at.UTC(), Valid: true},
+	})
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+func (p *Pg) ListAchievements(ctx context.Context, userID collection.UserID) ([]collection.AchievementUnlock, error) {
+	rows, err := p.Q.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	unlocks := make([]collection.AchievementUnlock, len(rows))
+	for i, r := range rows {
+		unlocks[i] = collection.AchievementUnlock{
+			ID:         r.Achievement,
+			UnlockedAt: r.UnlockedAt.Time,
+		}
+	}
+	return unlocks, nil
+}
+
+func (p *Pg) CountCompletedSeries(ctx context.Context, userID collection.UserID, minCharacters int32) (int64, error) {
+	return p.Q.CountCompletedSeries(ctx, achievementstore.CountCompletedSeriesParams{
+		UserID:        userID,
+		MinCharacters: minCharacters,
+	})
+}
+
+func (p *Pg) CountCompletedSeriesOf(ctx context.Context, userID collection.UserID, charID int64, minCharacters int32) (int64, error) {
+	return p.Q.CountCompletedSeries(ctx, achievementstore.CountCompletedSeriesParams{
+		UserID:        userID,
+		CharacterID:   pgtype.Int8{Int64: charID, Valid: true},
+		MinCharacters: minCharacters,
+	})
+}
+
+func (p *Pg) CountOwnedFromFavorites(ctx context.Context, userID collection.UserID, minFavorites int32) (int64, error) {
+	return p.Q.CountOwnedFromFavorites(ctx, achievementstore.CountOwnedFromFavoritesParams{
+		UserID:       userID,
+		MinFavorites: minFavorites,
+	})
+}
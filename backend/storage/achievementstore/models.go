@@ -0,0 +1,43 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+
+package achievementstore
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type Character struct {
+	ID               int64
+	Name             string
+	Image            string
+	MediaTitle       string
+	Favorites        int32
+	IsActive         bool
+	UpdatedAt        pgtype.Timestamp
+	AlternativeNames []string
+}
+
+type CharacterMedium struct {
+	CharacterID int64
+	MediaID     int64
+}
+
+type Collection struct {
+	UserID      uint64
+	CharacterID int64
+	Source      string
+	AcquiredAt  pgtype.Timestamp
+}
+
+type Medium struct {
+	ID                 int64
+	CharactersSyncedAt pgtype.Timestamp
+}
+
+type UserAchievement struct {
+	UserID      uint64
+	Achievement string
+	UnlockedAt  pgtype.Timestamp
+}
@@ -0,0 +1,66 @@
+-- name: Unlock :execrows
+INSERT INTO
+  user_achievements (user_id, achievement, unlocked_at)
+VALUES
+  ($1, $2, $3)
+ON CONFLICT DO NOTHING;
+
+-- name: ListByUser :many
+SELECT
+  *
+FROM
+  user_achievements
+WHERE
+  user_id = $1
+ORDER BY
+  unlocked_at,
+  achievement;
+
+-- name: CountCompletedSeries :one
+-- A series is every active character linked to a media, counted once every
+-- character of the media is linked. Only media the user owns at least one
+-- character of are considered, or only those of character_id when set.
+SELECT
+  COUNT(*)
+FROM
+  (
+    SELECT
+      character_media.media_id
+    FROM
+      character_media
+      JOIN media ON media.id = character_media.media_id
+      JOIN characters ON characters.id = character_media.character_id
+      LEFT JOIN collection ON collection.character_id = characters.id
+      AND collection.user_id = sqlc.arg(user_id)
+    WHERE
+      characters.is_active
+      AND media.characters_synced_at IS NOT NULL
+      AND character_media.media_id IN (
+        SELECT
+          owned.media_id
+        FROM
+          collection AS mine
+          JOIN character_media AS owned ON owned.character_id = mine.character_id
+        WHERE
+          mine.user_id = sqlc.arg(user_id)
+          AND (
+            sqlc.narg(character_id)::BIGINT IS NULL
+            OR mine.character_id = sqlc.narg(character_id)::BIGINT
+          )
+      )
+    GROUP BY
+      character_media.media_id
+    HAVING
+      COUNT(*) >= sqlc.arg(min_characters)::INTEGER
+      AND COUNT(collection.character_id) = COUNT(*)
+  ) AS completed;
+
+-- name: CountOwnedFromFavorites :one
+SELECT
+  COUNT(*)
+FROM
+  collection
+  JOIN characters ON characters.id = collection.character_id
+WHERE
+  collection.user_id = $1
+  AND characters.favorites >= sqlc.arg(min_favorites)::INTEGER;
@@ -0,0 +1,35 @@
+CREATE TABLE public.characters (
+  id BIGINT CONSTRAINT characters_new_id_not_null NOT NULL,
+  name CHARACTER VARYING(128) CONSTRAINT characters_new_name_not_null NOT NULL,
+  image CHARACTER VARYING(256) CONSTRAINT characters_new_image_not_null NOT NULL,
+  media_title TEXT NOT NULL DEFAULT '',
+  favorites INTEGER NOT NULL DEFAULT 0,
+  is_active BOOLEAN NOT NULL DEFAULT true,
+  updated_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NOW(),
+  alternative_names TEXT[] NOT NULL DEFAULT '{}'
+);
+
+CREATE TABLE public.collection (
+  user_id BIGINT NOT NULL,
+  character_id BIGINT NOT NULL,
+  source CHARACTER VARYING(50) DEFAULT 'ROLL'::CHARACTER VARYING NOT NULL,
+  acquired_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE public.user_achievements (
+  user_id BIGINT NOT NULL,
+  achievement TEXT NOT NULL,
+  unlocked_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (user_id, achievement)
+);
+
+CREATE TABLE public.media (
+  id BIGINT PRIMARY KEY,
+  characters_synced_at TIMESTAMP WITHOUT TIME ZONE
+);
+
+CREATE TABLE public.character_media (
+  character_id BIGINT NOT NULL,
+  media_id BIGINT NOT NULL,
+  PRIMARY KEY (character_id, media_id)
+);
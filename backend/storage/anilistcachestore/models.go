@@ -0,0 +1,16 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+
+package anilistcachestore
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type AnilistCache struct {
+	Query     string
+	Key       string
+	Value     []byte
+	FetchedAt pgtype.Timestamp
+}
@@ -0,0 +1,23 @@
+-- name: Get :one
+SELECT
+  *
+FROM
+  anilist_cache
+WHERE
+  query = $1
+  AND key = $2;
+
+-- name: Put :exec
+INSERT INTO
+  anilist_cache (query, key, value, fetched_at)
+VALUES
+  ($1, $2, $3, $4)
+ON CONFLICT (query, key) DO UPDATE
+SET
+  value = excluded.value,
+  fetched_at = excluded.fetched_at;
+
+-- name: Prune :execrows
+DELETE FROM anilist_cache
+WHERE
+  fetched_at < $1;
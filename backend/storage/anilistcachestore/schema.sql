@@ -0,0 +1,7 @@
+CREATE TABLE public.anilist_cache (
+  query TEXT NOT NULL,
+  key TEXT NOT NULL,
+  value JSONB NOT NULL,
+  fetched_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+  PRIMARY KEY (query, key)
+);
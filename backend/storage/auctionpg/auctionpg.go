@@ -0,0 +1,125 @@
+package auctionpg
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/storage/auctionstore"
+)
+
+type Pg struct {
+	Q auctionstore.Querier
+}
+
+func New(q auctionstore.Querier) *Pg {
+	return &Pg{Q: q}
+}
+
+func (p *Pg) CreateAuction(ctx context.Context, a collection.Auction) (collection.Auction, error) {
+	row, err := p.Q.Create(ctx, auctionstore.CreateParams{
+		SellerID:    a.SellerID,
+		CharacterID: a.CharacterID,
+		ChannelID:   a.ChannelID,
+		StartPrice:  a.StartPrice,
+		EndsAt:      pgtype.Timestamp{Time: a.EndsAt.UTC(), Valid: true},
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return collection.Auction{}, collection.ErrAuctionExists
+		}
+		return collection.Auction{}, err
+	}
+	return toAuction(row), nil
+}
+
+func (p *Pg) GetAuctionForUpdate(ctx context.Context, id int64) (collection.Auction, error) {
+	row, err := p.Q.GetForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return collection.Auction{}, collection.ErrNotFound
+		}
+		return collection.Auction{}, err
+	}
+	return toAuction(row), nil
+}
+
+func (p *Pg) ListOpenAuctions(ctx context.Context, limit int32) ([]collection.Auction, error) {
+	rows, err := p.Q.ListOpen(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	auctions := make([]collection.Auction, len(rows))
+	for i, r := range rows {
+		auctions[i] = toAuction(r)
+	}
+	return auctions, nil
+}
+
+func (p *Pg) ListDueAuctionIDs(ctx context.Context, now time.Time) ([]int64, error) {
+	return p.Q.ListDueIDs(ctx, pgtype.Timestamp{Time: now.UTC(), Valid: true})
+}
+
+func (p *Pg) SetAuctionHighBid(ctx context.Context, id int64, bidderID collection.UserID, amount int32) error {
+	return p.Q.SetHighBid(ctx, auctionstore.SetHighBidParams{ID: id, HighBid: amount, HighBidderID: bidderID})
+}
+
+func (p *Pg) SetAuctionStatus(ctx context.Context, id int64, status collection.AuctionStatus) error {
+	return p.Q.SetStatus(ctx, auctionstore.SetStatusParams{ID: id, Status: string(status)})
+}
+
+func (p *Pg) GetEscrow(ctx context.Context, auctionID int64, userID collection.UserID) (int32, error) {
+	amount, err := p.Q.GetEscrow(ctx, auctionstore.GetEscrowParams{AuctionID: auctionID, UserID: userID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, collection.ErrNotFound
+		}
+		return 0, err
+	}
+	return amount, nil
+}
+
+func (p *Pg) UpsertEscrow(ctx context.Context, auctionID int64, userID collection.UserID, amount int32) error {
+	return p.Q.UpsertEscrow(ctx, auctionstore.UpsertEscrowParams{AuctionID: auctionID, UserID: userID, Amount: amount})
+}
+
+func (p *Pg) DeleteEscrow(ctx context.Context, auctionID int64, userID collection.UserID) error {
+	return p.Q.DeleteEscrow(ctx, auctionstore.DeleteEscrowParams{AuctionID: auctionID, UserID: userID})
+}
+
+func (p *Pg) ListEscrows(ctx context.Context, auctionID int64) ([]collection.AuctionEscrow, error) {
+	rows, err := p.Q.ListEscrows(ctx, auctionID)
+	if err != nil {
+		return nil, err
+	}
+	escrows := make([]collection.AuctionEscrow, len(rows))
+	for i, r := range rows {
+		escrows[i] = collection.AuctionEscrow{UserID: r.UserID, Amount: r.Amount}
+	}
+	return escrows, nil
+}
+
+func (p *Pg) DeleteEscrows(ctx context.Context, auctionID int64) error {
+	return p.Q.DeleteEscrows(ctx, auctionID)
+}
+
+func toAuction(r auctionstore.Auction) collection.Auction {
+	return collection.Auction{
+		ID:           r.ID,
+		SellerID:     r.SellerID,
+		CharacterID:  r.CharacterID,
+		ChannelID:    r.ChannelID,
+		StartPrice:   r.StartPrice,
+		HighBid:      r.HighBid,
+		HighBidderID: r.HighBidderID,
+		Status:       collection.AuctionStatus(r.Status),
+		CreatedAt:    r.CreatedAt.Time,
+		EndsAt:       r.EndsAt.Time,
+	}
+}
@@ -0,0 +1,29 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+
+package auctionstore
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type Auction struct {
+	ID           int64
+	SellerID     uint64
+	CharacterID  int64
+	ChannelID    uint64
+	StartPrice   int32
+	HighBid      int32
+	HighBidderID uint64
+	Status       string
+	CreatedAt    pgtype.Timestamp
+	EndsAt       pgtype.Timestamp
+}
+
+type AuctionEscrow struct {
+	AuctionID int64
+	UserID    uint64
+	Amount    int32
+	UpdatedAt pgtype.Timestamp
+}
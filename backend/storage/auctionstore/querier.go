@@ -0,0 +1,27 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+
+package auctionstore
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type Querier interface {
+	Create(ctx context.Context, arg CreateParams) (Auction, error)
+	DeleteEscrow(ctx context.Context, arg DeleteEscrowParams) error
+	DeleteEscrows(ctx context.Context, auctionID int64) error
+	GetEscrow(ctx context.Context, arg GetEscrowParams) (int32, error)
+	GetForUpdate(ctx context.Context, id int64) (Auction, error)
+	ListDueIDs(ctx context.Context, endsAt pgtype.Timestamp) ([]int64, error)
+	ListEscrows(ctx context.Context, auctionID int64) ([]ListEscrowsRow, error)
+	ListOpen(ctx context.Context, limit int32) ([]Auction, error)
+	SetHighBid(ctx context.Context, arg SetHighBidParams) error
+	SetStatus(ctx context.Context, arg SetStatusParams) error
+	UpsertEscrow(ctx context.Context, arg UpsertEscrowParams) error
+}
+
+var _ Querier = (*Queries)(nil)
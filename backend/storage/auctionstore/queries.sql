@@ -0,0 +1,93 @@
+-- name: Create :one
+INSERT INTO
+  auctions (seller_id, character_id, channel_id, start_price, ends_at)
+VALUES
+  ($1, $2, $3, $4, $5)
+RETURNING
+  *;
+
+-- name: GetForUpdate :one
+SELECT
+  *
+FROM
+  auctions
+WHERE
+  id = $1
+FOR UPDATE;
+
+-- name: ListOpen :many
+SELECT
+  *
+FROM
+  auctions
+WHERE
+  status = 'open'
+ORDER BY
+  ends_at ASC
+LIMIT
+  $1;
+
+-- name: ListDueIDs :many
+SELECT
+  id
+FROM
+  auctions
+WHERE
+  status = 'open'
+  AND ends_at <= $1
+ORDER BY
+  ends_at ASC;
+
+-- name: SetHighBid :exec
+UPDATE auctions
+SET
+  high_bid = $2,
+  high_bidder_id = $3
+WHERE
+  id = $1;
+
+-- name: SetStatus :exec
+UPDATE auctions
+SET
+  status = $2
+WHERE
+  id = $1;
+
+-- name: GetEscrow :one
+SELECT
+  amount
+FROM
+  auction_escrow
+WHERE
+  auction_id = $1
+  AND user_id = $2;
+
+-- name: UpsertEscrow :exec
+INSERT INTO
+  auction_escrow (auction_id, user_id, amount)
+VALUES
+  ($1, $2, $3)
+ON CONFLICT (auction_id, user_id) DO UPDATE
+SET
+  amount = excluded.amount,
+  updated_at = NOW();
+
+-- name: DeleteEscrow :exec
+DELETE FROM auction_escrow
+WHERE
+  auction_id = $1
+  AND user_id = $2;
+
+-- name: ListEscrows :many
+SELECT
+  user_id,
+  amount
+FROM
+  auction_escrow
+WHERE
+  auction_id = $1;
+
+-- name: DeleteEscrows :exec
+DELETE FROM auction_escrow
+WHERE
+  auction_id = $1;
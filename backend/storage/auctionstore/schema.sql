@@ -0,0 +1,19 @@
+CREATE TABLE public.auctions (
+  id BIGSERIAL PRIMARY KEY,
+  seller_id BIGINT NOT NULL,
+  character_id BIGINT NOT NULL,
+  channel_id BIGINT NOT NULL,
+  start_price INTEGER NOT NULL,
+  high_bid INTEGER NOT NULL DEFAULT 0,
+  high_bidder_id BIGINT NOT NULL DEFAULT 0,
+  status TEXT NOT NULL DEFAULT 'open',
+  created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+  ends_at TIMESTAMP WITHOUT TIME ZONE NOT NULL
+);
+
+CREATE TABLE public.auction_escrow (
+  auction_id BIGINT NOT NULL,
+  user_id BIGINT NOT NULL,
+  amount INTEGER NOT NULL,
+  updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW()
+);
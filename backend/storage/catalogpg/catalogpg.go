@@ -10,6 +10,7 @@ import (
 
 	"github.com/karitham/waifubot/catalog"
 	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/storage/catalogstore"
 	"github.com/karitham/waifubot/storage/collectionstore"
 	"github.com/karitham/waifubot/storage/guildstore"
 )
@@ -17,10 +18,11 @@ import (
 type Pg struct {
 	C collectionstore.Querier
 	G guildstore.Querier
+	M catalogstore.Querier
 }
 
-func New(c collectionstore.Querier, g guildstore.Querier) *Pg {
-	return &Pg{C: c, G: g}
+func New(c collectionstore.Querier, g guildstore.Querier, m catalogstore.Querier) *Pg {
+	return &Pg{C: c, G: g, M: m}
 }
 
 func (p *Pg) UpsertCharacter(ctx context.Context, char catalog.Character) error {
@@ -30,8 +32,56 @@ func (p *Pg) UpsertCharacter(ctx context.Context, char catalog.Character) error
 		Image:      char.Image,
 		MediaTitle: char.MediaTitle,
 		Favorites:  int32(char.Favorites),
+		// Empty aliases leave the stored ones untouched.
+		AlternativeNames: char.Aliases,
+	})
+	if err != nil || char.Description == "" {
+		// Like aliases, an empty description leaves the stored one untouched.
+		return err
+	}
+	return p.M.SetCharacterDescription(ctx, catalogstore.SetCharacterDescriptionParams{
+		ID:          char.ID,
+		Description: char.Description,
 	})
-	return err
+}
+
+func (p *Pg) CharacterDescription(ctx context.Context, charID int64) (string, error) {
+	desc, err := p.M.GetCharacterDescription(ctx, charID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", collection.ErrNotFound
+		}
+		return "", err
+	}
+	return desc, nil
+}
+
+func (p *Pg) CharacterStats(ctx context.Context, charID int64, guildID uint64) (catalog.CharacterStats, error) {
+	row, err := p.M.GetCharacterStats(ctx, catalogstore.GetCharacterStatsParams{
+		CharacterID: charID,
+		GuildID:     guildID,
+	})
+	if err != nil {
+		return catalog.CharacterStats{}, err
+	}
+	return catalog.CharacterStats{
+		Owners:       int(row.Owners),
+		GuildOwners:  int(row.GuildOwners),
+		Wishlisted:   int(row.Wishlisted),
+		FirstClaimed: row.FirstClaimed.Time,
+	}, nil
+}
+
+func (p *Pg) CharactersStats(ctx context.Context, charIDs []int64) (map[int64]catalog.CharacterStats, error) {
+	rows, err := p.M.GetCharactersStats(ctx, charIDs)
+	if err != nil {
+		return nil, err
+	}
+	stats := make(map[int64]catalog.CharacterStats, len(rows))
+	for _, r := range rows {
+		stats[r.CharacterID] = catalog.CharacterStats{Owners: int(r.Owners), Wishlisted: int(r.Wishlisted)}
+	}
+	return stats, nil
 }
 
 func (p *Pg) GetCharacterByID(ctx context.Context, charID int64) (catalog.Character, error) {
@@ -42,7 +92,7 @@ func (p *Pg) GetCharacterByID(ctx context.Context, charID int64) (catalog.Charac
 		}
 		return catalog.Character{}, err
 	}
-	return catalog.Character{ID: c.ID, Name: c.Name, Image: c.Image, MediaTitle: c.MediaTitle, Favorites: int(c.Favorites), UpdatedAt: c.UpdatedAt.Time}, nil
+	return catalog.Character{ID: c.ID, Name: c.Name, Image: c.Image, MediaTitle: c.MediaTitle, Favorites: int(c.Favorites), UpdatedAt: c.UpdatedAt.Time, IsActive: c.IsActive}, nil
 }
 
 func (p *Pg) SearchCharacters(ctx context.Context, userID uint64, term string) ([]catalog.Character, error) {
@@ -69,7 +119,7 @@ func (p *Pg) SearchGlobalCharacters(ctx context.Context, term string) ([]catalog
 	}
 	chars := make([]catalog.Character, len(rows))
 	for i, r := range rows {
-		chars[i] = catalog.Character{ID: r.ID, Name: r.Name, Image: r.Image, MediaTitle: r.MediaTitle, Favorites: int(r.Favorites)}
+		chars[i] = catalog.Character{ID: r.ID, Name: r.Name, Image: r.Image, MediaTitle: r.MediaTitle, Favorites: int(r.Favorites), IsActive: r.IsActive}
 	}
 	return chars, nil
 }
@@ -130,3 +180,145 @@ func (p *Pg) MarkCharactersInactive(ctx context.Context, ids []int64) error {
 func (p *Pg) GetActiveIDs(ctx context.Context) ([]int64, error) {
 	return p.C.GetActiveIDs(ctx)
 }
+
+func (p *Pg) UpsertMedia(ctx context.Context, media []catalog.Media) error {
+	if len(media) == 0 {
+		return nil
+	}
+	params := catalogstore.UpsertMediaParams{
+		UpdatedAt: pgtype.Timestamp{Time: time.Now().UTC(), Valid: true},
+	}
+	for _, m := range media {
+		params.Ids = append(params.Ids, m.ID)
+		params.Titles = append(params.Titles, m.Title)
+		params.Types = append(params.Types, m.Type)
+		params.CoverImages = append(params.CoverImages, m.CoverImage)
+		params.Popularities = append(params.Popularities, int32(m.Popularity))
+		params.MalIds = append(params.MalIds, m.MalID)
+	}
+	return p.M.UpsertMedia(ctx, params)
+}
+
+func (p *Pg) SetCharacterMedia(ctx context.Context, charID int64, mediaIDs []int64) error {
+	if mediaIDs == nil {
+		// A NULL array would match no link and leave stale ones behind.
+		mediaIDs = []int64{}
+	}
+	return p.M.SetCharacterMedia(ctx, catalogstore.SetCharacterMediaParams{
+		CharacterID: charID,
+		MediaIds:    mediaIDs,
+	})
+}
+
+// SetMediaCharacters runs several statements, call it inside a transaction.
+func (p *Pg) SetMediaCharacters(ctx context.Context, media catalog.Media, chars []catalog.Character, syncedAt time.Time) error {
+	var missing catalogstore.AddMissingCharactersParams
+	for _, c := range chars {
+		missing.Ids = append(missing.Ids, c.ID)
+		missing.Names = append(missing.Names, c.Name)
+		missing.Images = append(missing.Images, c.Image)
+		missing.MediaTitles = append(missing.MediaTitles, c.MediaTitle)
+		missing.Favorites = append(missing.Favorites, int32(c.Favorites))
+	}
+	if len(chars) > 0 {
+		if err := p.M.AddMissingCharacters(ctx, missing); err != nil {
+			return err
+		}
+	}
+
+	if err := p.M.MarkMediaCharactersSynced(ctx, catalogstore.MarkMediaCharactersSyncedParams{
+		ID:       media.ID,
+		Title:    media.Title,
+		SyncedAt: pgtype.Timestamp{Time: syncedAt.UTC(), Valid: true},
+	}); err != nil {
+		return err
+	}
+
+	return p.M.SetMediaCharacters(ctx, catalogstore.SetMediaCharactersParams{
+		MediaID:      media.ID,
+		CharacterIds: missing.Ids,
+	})
+}
+
+func (p *Pg) GetMedia(ctx context.Context, mediaID int64) (catalog.Media, error) {
+	m, err := p.M.GetMedia(ctx, mediaID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return catalog.Media{}, collection.ErrNotFound
+		}
+		return catalog.Media{}, err
+	}
+	return mediaFromRow(m), nil
+}
+
+func (p *Pg) MediaByMalID(ctx context.Context, malID int64, mediaType string) (catalog.Media, error) {
+	m, err := p.M.GetMediaByMalID(ctx, catalogstore.GetMediaByMalIDParams{
+		MalID: pgtype.Int8{Int64: malID, Valid: true},
+		Type:  mediaType,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return catalog.Media{}, collection.ErrNotFound
+		}
+		return catalog.Media{}, err
+	}
+	return mediaFromRow(m), nil
+}
+
+func (p *Pg) SearchMedia(ctx context.Context, term string) ([]catalog.Media, error) {
+	rows, err := p.M.SearchMedia(ctx, catalogstore.SearchMediaParams{Term: term, Lim: 25})
+	if err != nil {
+		return nil, err
+	}
+	media := make([]catalog.Media, len(rows))
+	for i, r := range rows {
+		media[i] = mediaFromRow(r)
+	}
+	return media, nil
+}
+
+func (p *Pg) CharactersByMedia(ctx context.Context, mediaID int64) ([]catalog.Character, error) {
+	rows, err := p.M.ListCharactersByMedia(ctx, mediaID)
+	if err != nil {
+		return nil, err
+	}
+	chars := make([]catalog.Character, len(rows))
+	for i, r := range rows {
+		chars[i] = catalog.Character{
+			ID:         r.ID,
+			Name:       r.Name,
+			Image:      r.Image,
+			MediaTitle: r.MediaTitle,
+			Favorites:  int(r.Favorites),
+			UpdatedAt:  r.UpdatedAt.Time,
+			IsActive:   r.IsActive,
+			Aliases:    r.AlternativeNames,
+		}
+	}
+	return chars, nil
+}
+
+func (p *Pg) MediaByCharacter(ctx context.Context, charID int64) ([]catalog.Media, error) {
+	rows, err := p.M.ListMediaByCharacter(ctx, charID)
+	if err != nil {
+		return nil, err
+	}
+	media := make([]catalog.Media, len(rows))
+	for i, r := range rows {
+		media[i] = mediaFromRow(r)
+	}
+	return media, nil
+}
+
+func mediaFromRow(m catalogstore.Medium) catalog.Media {
+	return catalog.Media{
+		ID:                 m.ID,
+		Title:              m.Title,
+		Type:               m.Type,
+		CoverImage:         m.CoverImage,
+		Popularity:         int(m.Popularity),
+		MalID:              m.MalID.Int64,
+		UpdatedAt:          m.UpdatedAt.Time,
+		CharactersSyncedAt: m.CharactersSyncedAt.Time,
+	}
+}
@@ -0,0 +1,67 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+
+package catalogstore
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type Character struct {
+	ID               int64
+	Name             string
+	Image            string
+	MediaTitle       string
+	Favorites        int32
+	IsActive         bool
+	UpdatedAt        pgtype.Timestamp
+	AlternativeNames []string
+	Description      string
+}
+
+type CharacterMedium struct {
+	CharacterID int64
+	MediaID     int64
+}
+
+type CharacterWishlist struct {
+	UserID      uint64
+	CharacterID int64
+	CreatedAt   pgtype.Timestamp
+}
+
+type Collection struct {
+	UserID      uint64
+	CharacterID int64
+	Source      string
+	AcquiredAt  pgtype.Timestamp
+}
+
+type GuildMember struct {
+	GuildID   uint64
+	UserID    uint64
+	IndexedAt pgtype.Timestamp
+}
+
+type Medium struct {
+	ID                 int64
+	Title              string
+	Type               string
+	CoverImage         string
+	Popularity         int32
+	UpdatedAt          pgtype.Timestamp
+	CharactersSyncedAt pgtype.Timestamp
+	MalID              pgtype.Int8
+}
+
+type OwnershipEvent struct {
+	ID          int64
+	CharacterID int64
+	Kind        string
+	FromUserID  pgtype.Int8
+	ToUserID    pgtype.Int8
+	Tokens      int32
+	ReferenceID pgtype.Int8
+	CreatedAt   pgtype.Timestamp
+}
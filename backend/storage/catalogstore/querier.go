@@ -0,0 +1,38 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+
+package catalogstore
+
+import (
+	"context"
+)
+
+type Querier interface {
+	// The arrays are parallel, one entry per character. Stored characters are left as they are.
+	AddMissingCharacters(ctx context.Context, arg AddMissingCharactersParams) error
+	GetCharacterDescription(ctx context.Context, id int64) (string, error)
+	// first_claimed is NULL when nobody ever held the character. Characters held
+	// before the ownership ledger existed fall back to their oldest collection entry.
+	GetCharacterStats(ctx context.Context, arg GetCharacterStatsParams) (GetCharacterStatsRow, error)
+	// Characters nobody owns or wishes for are left out.
+	GetCharactersStats(ctx context.Context, characterIds []int64) ([]GetCharactersStatsRow, error)
+	GetMedia(ctx context.Context, id int64) (Medium, error)
+	GetMediaByMalID(ctx context.Context, arg GetMediaByMalIDParams) (Medium, error)
+	ListCharactersByMedia(ctx context.Context, mediaID int64) ([]Character, error)
+	ListMediaByCharacter(ctx context.Context, characterID int64) ([]Medium, error)
+	// Adds the media when it isn't stored yet.
+	MarkMediaCharactersSynced(ctx context.Context, arg MarkMediaCharactersSyncedParams) error
+	SearchMedia(ctx context.Context, arg SearchMediaParams) ([]Medium, error)
+	SetCharacterDescription(ctx context.Context, arg SetCharacterDescriptionParams) error
+	// Replaces the media linked to a character with media_ids. Links to media with
+	// synced characters are kept, SetMediaCharacters owns those.
+	SetCharacterMedia(ctx context.Context, arg SetCharacterMediaParams) error
+	// Replaces the characters linked to a media with character_ids.
+	SetMediaCharacters(ctx context.Context, arg SetMediaCharactersParams) error
+	// The arrays are parallel, one entry per media. IDs must be unique.
+	// A MyAnimeList ID of 0 is stored as NULL.
+	UpsertMedia(ctx context.Context, arg UpsertMediaParams) error
+}
+
+var _ Querier = (*Queries)(nil)
@@ -0,0 +1,246 @@
+-- name: UpsertMedia :exec
+-- The arrays are parallel, one entry per media. IDs must be unique.
+-- A MyAnimeList ID of 0 is stored as NULL.
+INSERT INTO
+  media (id, title, type, cover_image, popularity, mal_id, updated_at)
+SELECT
+  unnest(sqlc.arg(ids)::BIGINT[]),
+  unnest(sqlc.arg(titles)::TEXT[]),
+  unnest(sqlc.arg(types)::TEXT[]),
+  unnest(sqlc.arg(cover_images)::TEXT[]),
+  unnest(sqlc.arg(popularities)::INTEGER[]),
+  NULLIF(unnest(sqlc.arg(mal_ids)::BIGINT[]), 0),
+  sqlc.arg(updated_at)::TIMESTAMP
+ON CONFLICT (id) DO UPDATE
+SET
+  title = excluded.title,
+  type = excluded.type,
+  cover_image = excluded.cover_image,
+  popularity = excluded.popularity,
+  mal_id = excluded.mal_id,
+  updated_at = excluded.updated_at;
+
+-- name: SetCharacterMedia :exec
+-- Replaces the media linked to a character with media_ids. Links to media with
+-- synced characters are kept, SetMediaCharacters owns those.
+WITH removed AS (
+  DELETE FROM character_media
+  WHERE
+    character_media.character_id = sqlc.arg(character_id)
+    AND NOT (character_media.media_id = ANY (sqlc.arg(media_ids)::BIGINT[]))
+    AND NOT EXISTS (
+      SELECT
+        1
+      FROM
+        media
+      WHERE
+        media.id = character_media.media_id
+        AND media.characters_synced_at IS NOT NULL
+    )
+)
+INSERT INTO
+  character_media (character_id, media_id)
+SELECT
+  sqlc.arg(character_id),
+  unnest(sqlc.arg(media_ids)::BIGINT[])
+ON CONFLICT DO NOTHING;
+
+-- name: AddMissingCharacters :exec
+-- The arrays are parallel, one entry per character. Stored characters are left as they are.
+INSERT INTO
+  characters (id, name, image, media_title, favorites)
+SELECT
+  unnest(sqlc.arg(ids)::BIGINT[]),
+  unnest(sqlc.arg(names)::TEXT[]),
+  unnest(sqlc.arg(images)::TEXT[]),
+  unnest(sqlc.arg(media_titles)::TEXT[]),
+  unnest(sqlc.arg(favorites)::INTEGER[])
+ON CONFLICT (id) DO NOTHING;
+
+-- name: MarkMediaCharactersSynced :exec
+-- Adds the media when it isn't stored yet.
+INSERT INTO
+  media (id, title, updated_at, characters_synced_at)
+VALUES
+  (
+    sqlc.arg(id),
+    sqlc.arg(title),
+    sqlc.arg(synced_at)::TIMESTAMP,
+    sqlc.arg(synced_at)::TIMESTAMP
+  )
+ON CONFLICT (id) DO UPDATE
+SET
+  characters_synced_at = excluded.characters_synced_at;
+
+-- name: SetMediaCharacters :exec
+-- Replaces the characters linked to a media with character_ids.
+WITH removed AS (
+  DELETE FROM character_media
+  WHERE
+    character_media.media_id = sqlc.arg(media_id)
+    AND NOT (character_media.character_id = ANY (sqlc.arg(character_ids)::BIGINT[]))
+)
+INSERT INTO
+  character_media (character_id, media_id)
+SELECT
+  unnest(sqlc.arg(character_ids)::BIGINT[]),
+  sqlc.arg(media_id)
+ON CONFLICT DO NOTHING;
+
+-- name: GetMedia :one
+SELECT
+  *
+FROM
+  media
+WHERE
+  id = $1;
+
+-- name: GetMediaByMalID :one
+SELECT
+  *
+FROM
+  media
+WHERE
+  mal_id = $1
+  AND type = $2
+ORDER BY
+  popularity DESC
+LIMIT
+  1;
+
+-- name: SearchMedia :many
+SELECT
+  *
+FROM
+  media
+WHERE
+  id::VARCHAR LIKE sqlc.arg(term)::VARCHAR || '%'
+  OR title ILIKE '%' || sqlc.arg(term) || '%'
+ORDER BY
+  popularity DESC,
+  id
+LIMIT
+  sqlc.arg(lim);
+
+-- name: ListCharactersByMedia :many
+SELECT
+  c.*
+FROM
+  characters c
+  JOIN character_media cm ON cm.character_id = c.id
+WHERE
+  cm.media_id = $1
+  AND c.is_active
+ORDER BY
+  c.favorites DESC,
+  c.id;
+
+-- name: ListMediaByCharacter :many
+SELECT
+  m.*
+FROM
+  media m
+  JOIN character_media cm ON cm.media_id = m.id
+WHERE
+  cm.character_id = $1
+ORDER BY
+  m.popularity DESC,
+  m.id;
+
+-- name: SetCharacterDescription :exec
+UPDATE characters
+SET
+  description = $2
+WHERE
+  id = $1;
+
+-- name: GetCharacterDescription :one
+SELECT
+  description
+FROM
+  characters
+WHERE
+  id = $1;
+
+-- name: GetCharacterStats :one
+-- first_claimed is NULL when nobody ever held the character. Characters held
+-- before the ownership ledger existed fall back to their oldest collection entry.
+SELECT
+  (
+    SELECT
+      COUNT(DISTINCT c.user_id)
+    FROM
+      collection c
+    WHERE
+      c.character_id = sqlc.arg(character_id)
+  )::BIGINT AS owners,
+  (
+    SELECT
+      COUNT(DISTINCT c.user_id)
+    FROM
+      collection c
+      JOIN guild_members gm ON gm.user_id = c.user_id
+    WHERE
+      c.character_id = sqlc.arg(character_id)
+      AND gm.guild_id = sqlc.arg(guild_id)
+  )::BIGINT AS guild_owners,
+  (
+    SELECT
+      COUNT(*)
+    FROM
+      character_wishlist w
+    WHERE
+      w.character_id = sqlc.arg(character_id)
+  )::BIGINT AS wishlisted,
+  LEAST(
+    (
+      SELECT
+        MIN(e.created_at)
+      FROM
+        ownership_events e
+      WHERE
+        e.character_id = sqlc.arg(character_id)
+        AND e.from_user_id IS NULL
+    ),
+    (
+      SELECT
+        MIN(c.acquired_at)
+      FROM
+        collection c
+      WHERE
+        c.character_id = sqlc.arg(character_id)
+    )
+  )::TIMESTAMP AS first_claimed;
+
+-- name: GetCharactersStats :many
+-- Characters nobody owns or wishes for are left out.
+WITH
+  owners AS (
+    SELECT
+      c.character_id,
+      COUNT(DISTINCT c.user_id) AS n
+    FROM
+      collection c
+    WHERE
+      c.character_id = ANY (sqlc.arg(character_ids)::BIGINT[])
+    GROUP BY
+      c.character_id
+  ),
+  wishes AS (
+    SELECT
+      w.character_id,
+      COUNT(*) AS n
+    FROM
+      character_wishlist w
+    WHERE
+      w.character_id = ANY (sqlc.arg(character_ids)::BIGINT[])
+    GROUP BY
+      w.character_id
+  )
+SELECT
+  COALESCE(o.character_id, w.character_id)::BIGINT AS character_id,
+  COALESCE(o.n, 0)::BIGINT AS owners,
+  COALESCE(w.n, 0)::BIGINT AS wishlisted
+FROM
+  owners o
+  FULL JOIN wishes w ON w.character_id = o.character_id;
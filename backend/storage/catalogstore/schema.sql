@@ -0,0 +1,58 @@
+CREATE TABLE public.characters (
+  id BIGINT CONSTRAINT characters_new_id_not_null NOT NULL,
+  name CHARACTER VARYING(128) CONSTRAINT characters_new_name_not_null NOT NULL,
+  image CHARACTER VARYING(256) CONSTRAINT characters_new_image_not_null NOT NULL,
+  media_title TEXT NOT NULL DEFAULT '',
+  favorites INTEGER NOT NULL DEFAULT 0,
+  is_active BOOLEAN NOT NULL DEFAULT true,
+  updated_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NOW(),
+  alternative_names TEXT[] NOT NULL DEFAULT '{}',
+  description TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE public.media (
+  id BIGINT PRIMARY KEY,
+  title TEXT NOT NULL,
+  type TEXT NOT NULL DEFAULT '',
+  cover_image TEXT NOT NULL DEFAULT '',
+  popularity INTEGER NOT NULL DEFAULT 0,
+  updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+  characters_synced_at TIMESTAMP WITHOUT TIME ZONE,
+  mal_id BIGINT
+);
+
+CREATE TABLE public.character_media (
+  character_id BIGINT NOT NULL REFERENCES public.characters (id) ON DELETE CASCADE,
+  media_id BIGINT NOT NULL REFERENCES public.media (id) ON DELETE CASCADE,
+  PRIMARY KEY (character_id, media_id)
+);
+
+CREATE TABLE public.collection (
+  user_id BIGINT NOT NULL,
+  character_id BIGINT NOT NULL,
+  source CHARACTER VARYING(50) DEFAULT 'ROLL'::CHARACTER VARYING NOT NULL,
+  acquired_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE public.guild_members (
+  guild_id BIGINT NOT NULL,
+  user_id BIGINT NOT NULL,
+  indexed_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NOW() NOT NULL
+);
+
+CREATE TABLE public.character_wishlist (
+  user_id BIGINT NOT NULL,
+  character_id BIGINT NOT NULL,
+  created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NOW() NOT NULL
+);
+
+CREATE TABLE public.ownership_events (
+  id BIGSERIAL PRIMARY KEY,
+  character_id BIGINT NOT NULL,
+  kind TEXT NOT NULL,
+  from_user_id BIGINT,
+  to_user_id BIGINT,
+  tokens INTEGER NOT NULL DEFAULT 0,
+  reference_id BIGINT,
+  created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW()
+);
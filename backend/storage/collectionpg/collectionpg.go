@@ -3,6 +3,8 @@ package collectionpg
 import (
 	"context"
 	"errors"
+	"math"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -41,6 +43,83 @@ func (p *Pg) GetCollection(ctx context.Context, userID collection.UserID) ([]col
 	return chars, nil
 }
 
+func (p *Pg) ListCollectionPage(ctx context.Context, userID collection.UserID, q collection.CollectionPageQuery) ([]collection.OwnedCharacter, error) {
+	f := pageFilter(userID, q.Filter)
+	params := collectionstore.ListPageParams{
+		UserID:       f.UserID,
+		MinFavorites: f.MinFavorites,
+		MaxFavorites: f.MaxFavorites,
+		Media:        f.Media,
+		Source:       f.Source,
+		AcquiredFrom: f.AcquiredFrom,
+		AcquiredTo:   f.AcquiredTo,
+		Term:         f.Term,
+		Sort:         string(q.Sort),
+		Descending:   q.Descending,
+		Lim:          int32(min(q.Limit, math.MaxInt32)),
+	}
+	if a := q.After; a != nil {
+		params.AfterID = pgtype.Int8{Int64: a.ID, Valid: true}
+		params.AfterDate = pgtype.Timestamp{Time: a.Date.UTC(), Valid: true}
+		params.AfterName = pgtype.Text{String: a.Name, Valid: true}
+		params.AfterMedia = pgtype.Text{String: a.MediaTitle, Valid: true}
+		params.AfterFavorites = pgtype.Int4{Int32: int32(a.Favorites), Valid: true}
+	}
+
+	rows, err := p.C.ListPage(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	chars := make([]collection.OwnedCharacter, len(rows))
+	for i, r := range rows {
+		chars[i] = collection.OwnedCharacter{
+			Character: collection.Character{ID: r.ID, Name: r.Name, Image: r.Image, MediaTitle: r.MediaTitle, Favorites: int(r.Favorites)},
+			Date:      r.Date.Time,
+			Source:    r.Source,
+			UserID:    userID,
+		}
+	}
+	return chars, nil
+}
+
+func (p *Pg) CountCollectionPage(ctx context.Context, userID collection.UserID, f collection.CollectionFilter) (int64, error) {
+	return p.C.CountPage(ctx, pageFilter(userID, f))
+}
+
+// pageFilter maps a filter to query params, leaving its zero values NULL.
+func pageFilter(userID collection.UserID, f collection.CollectionFilter) collectionstore.CountPageParams {
+	params := collectionstore.CountPageParams{
+		UserID: userID,
+		Media:  optText(escapeLike(strings.TrimSpace(f.Media))),
+		Source: optText(f.Source),
+		Term:   optText(escapeLike(strings.TrimSpace(f.Search))),
+	}
+	if f.Rarity != nil {
+		lo, hi := f.Rarity.FavoritesRange()
+		params.MinFavorites = pgtype.Int4{Int32: int32(lo), Valid: true}
+		params.MaxFavorites = pgtype.Int4{Int32: int32(hi), Valid: hi > 0}
+	}
+	if !f.AcquiredFrom.IsZero() {
+		params.AcquiredFrom = pgtype.Timestamp{Time: f.AcquiredFrom.UTC(), Valid: true}
+	}
+	if !f.AcquiredTo.IsZero() {
+		params.AcquiredTo = pgtype.Timestamp{Time: f.AcquiredTo.UTC(), Valid: true}
+	}
+	return params
+}
+
+// likeEscaper escapes the LIKE wildcards with Postgres' default escape character,
+// so that user input only matches literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+func optText(s string) pgtype.Text {
+	return pgtype.Text{String: s, Valid: s != ""}
+}
+
 func (p *Pg) GetCollectionIDs(ctx context.Context, userID collection.UserID) ([]int64, error) {
 	return p.C.ListIDs(ctx, userID)
 }
@@ -113,11 +192,17 @@ func (p *Pg) CountCollection(ctx context.Context, userID collection.UserID) (int
 }
 
 func (p *Pg) RemoveFromWishlist(ctx context.Context, userID collection.UserID, charID int64) error {
-	_ = p.W.RemoveCharactersFromWishlist(ctx, wishliststore.RemoveCharactersFromWishlistParams{
+	return p.W.RemoveCharactersFromWishlist(ctx, wishliststore.RemoveCharactersFromWishlistParams{
 		UserID:  userID,
 		Column2: []int64{charID},
 	})
-	return nil
+}
+
+func (p *Pg) AddToWishlist(ctx context.Context, userID collection.UserID, charIDs []int64) error {
+	return p.W.AddCharactersToWishlist(ctx, wishliststore.AddCharactersToWishlistParams{
+		UserID:  userID,
+		Column2: charIDs,
+	})
 }
 
 func (p *Pg) RandomCharNotOwned(ctx context.Context, userID collection.UserID, weightExponent float64) (catalog.Character, error) {
@@ -142,5 +227,5 @@ func (p *Pg) RandomActiveChar(ctx context.Context, weightExponent float64) (cata
 		}
 		return catalog.Character{}, err
 	}
-	return catalog.Character{ID: c.ID, Name: c.Name, Image: c.Image, MediaTitle: c.MediaTitle, Favorites: int(c.Favorites), UpdatedAt: c.UpdatedAt.Time, IsActive: c.IsActive}, nil
+	return catalog.Character{ID: c.ID, Name: c.Name, Image: c.Image, MediaTitle: c.MediaTitle, Favorites: int(c.Favorites), UpdatedAt: c.UpdatedAt.Time, IsActive: c.IsActive, Aliases: c.AlternativeNames}, nil
 }
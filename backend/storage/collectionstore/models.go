@@ -9,13 +9,14 @@ import (
 )
 
 type Character struct {
-	ID         int64
-	Name       string
-	Image      string
-	MediaTitle string
-	Favorites  int32
-	IsActive   bool
-	UpdatedAt  pgtype.Timestamp
+	ID               int64
+	Name             string
+	Image            string
+	MediaTitle       string
+	Favorites        int32
+	IsActive         bool
+	UpdatedAt        pgtype.Timestamp
+	AlternativeNames []string
 }
 
 type Collection struct {
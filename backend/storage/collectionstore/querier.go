@@ -10,6 +10,7 @@ import (
 
 type Querier interface {
 	Count(ctx context.Context, userID uint64) (int64, error)
+	CountPage(ctx context.Context, arg CountPageParams) (int64, error)
 	Delete(ctx context.Context, arg DeleteParams) (Collection, error)
 	Get(ctx context.Context, arg GetParams) (GetRow, error)
 	GetActiveIDs(ctx context.Context) ([]int64, error)
@@ -18,7 +19,10 @@ type Querier interface {
 	Insert(ctx context.Context, arg InsertParams) (Collection, error)
 	List(ctx context.Context, userID uint64) ([]ListRow, error)
 	ListIDs(ctx context.Context, userID uint64) ([]int64, error)
+	ListPage(ctx context.Context, arg ListPageParams) ([]ListPageRow, error)
 	MarkCharactersInactive(ctx context.Context, ids []int64) error
+	// Excludes the default AniList placeholder image (set by AniList when a
+	// character has no custom artwork) since drops embed the image publicly.
 	RandomActiveChar(ctx context.Context, weightExponent float64) (Character, error)
 	RandomCharNotOwned(ctx context.Context, arg RandomCharNotOwnedParams) (RandomCharNotOwnedRow, error)
 	SearchCharacters(ctx context.Context, arg SearchCharactersParams) ([]SearchCharactersRow, error)
@@ -48,6 +48,140 @@ LIMIT
 OFFSET
   sqlc.arg (off);
 
+-- name: ListPage :many
+WITH
+  owned AS (
+    SELECT
+      c.id,
+      c.name,
+      c.image,
+      c.media_title,
+      c.favorites,
+      col.source,
+      COALESCE(col.acquired_at, '0001-01-01')::TIMESTAMP AS date
+    FROM
+      collection col
+      JOIN characters c ON col.character_id = c.id
+    WHERE
+      col.user_id = sqlc.arg (user_id)
+      AND (
+        sqlc.narg (min_favorites)::INT IS NULL
+        OR c.favorites >= sqlc.narg (min_favorites)::INT
+      )
+      AND (
+        sqlc.narg (max_favorites)::INT IS NULL
+        OR c.favorites < sqlc.narg (max_favorites)::INT
+      )
+      AND (
+        sqlc.narg (media)::TEXT IS NULL
+        OR c.media_title ILIKE '%' || sqlc.narg (media)::TEXT || '%'
+      )
+      AND (
+        sqlc.narg (source)::TEXT IS NULL
+        OR col.source = sqlc.narg (source)::TEXT
+      )
+      AND (
+        sqlc.narg (acquired_from)::TIMESTAMP IS NULL
+        OR col.acquired_at >= sqlc.narg (acquired_from)::TIMESTAMP
+      )
+      AND (
+        sqlc.narg (acquired_to)::TIMESTAMP IS NULL
+        OR col.acquired_at < sqlc.narg (acquired_to)::TIMESTAMP
+      )
+      AND (
+        sqlc.narg (term)::TEXT IS NULL
+        OR c.id::VARCHAR LIKE sqlc.narg (term)::TEXT || '%'
+        OR c.name ILIKE '%' || sqlc.narg (term)::TEXT || '%'
+      )
+  )
+SELECT
+  o.id,
+  o.name,
+  o.image,
+  o.media_title,
+  o.favorites,
+  o.source,
+  o.date
+FROM
+  owned o
+WHERE
+  sqlc.narg (after_id)::BIGINT IS NULL
+  OR CASE
+    WHEN sqlc.arg (descending)::BOOLEAN THEN CASE sqlc.arg (sort)::TEXT
+      WHEN 'date' THEN (o.date, o.id) < (sqlc.narg (after_date)::TIMESTAMP, sqlc.narg (after_id)::BIGINT)
+      WHEN 'name' THEN (LOWER(o.name), o.id) < (LOWER(sqlc.narg (after_name)::TEXT), sqlc.narg (after_id)::BIGINT)
+      WHEN 'rarity' THEN (o.favorites, o.id) < (sqlc.narg (after_favorites)::INT, sqlc.narg (after_id)::BIGINT)
+      WHEN 'media' THEN (LOWER(o.media_title), LOWER(o.name), o.id) < (
+        LOWER(sqlc.narg (after_media)::TEXT),
+        LOWER(sqlc.narg (after_name)::TEXT),
+        sqlc.narg (after_id)::BIGINT
+      )
+      ELSE o.id < sqlc.narg (after_id)::BIGINT
+    END
+    ELSE CASE sqlc.arg (sort)::TEXT
+      WHEN 'date' THEN (o.date, o.id) > (sqlc.narg (after_date)::TIMESTAMP, sqlc.narg (after_id)::BIGINT)
+      WHEN 'name' THEN (LOWER(o.name), o.id) > (LOWER(sqlc.narg (after_name)::TEXT), sqlc.narg (after_id)::BIGINT)
+      WHEN 'rarity' THEN (o.favorites, o.id) > (sqlc.narg (after_favorites)::INT, sqlc.narg (after_id)::BIGINT)
+      WHEN 'media' THEN (LOWER(o.media_title), LOWER(o.name), o.id) > (
+        LOWER(sqlc.narg (after_media)::TEXT),
+        LOWER(sqlc.narg (after_name)::TEXT),
+        sqlc.narg (after_id)::BIGINT
+      )
+      ELSE o.id > sqlc.narg (after_id)::BIGINT
+    END
+  END
+ORDER BY
+  CASE WHEN sqlc.arg (sort)::TEXT = 'date' AND sqlc.arg (descending)::BOOLEAN THEN o.date END DESC,
+  CASE WHEN sqlc.arg (sort)::TEXT = 'date' AND NOT sqlc.arg (descending)::BOOLEAN THEN o.date END ASC,
+  CASE WHEN sqlc.arg (sort)::TEXT = 'rarity' AND sqlc.arg (descending)::BOOLEAN THEN o.favorites END DESC,
+  CASE WHEN sqlc.arg (sort)::TEXT = 'rarity' AND NOT sqlc.arg (descending)::BOOLEAN THEN o.favorites END ASC,
+  CASE WHEN sqlc.arg (sort)::TEXT = 'media' AND sqlc.arg (descending)::BOOLEAN THEN LOWER(o.media_title) END DESC,
+  CASE WHEN sqlc.arg (sort)::TEXT = 'media' AND NOT sqlc.arg (descending)::BOOLEAN THEN LOWER(o.media_title) END ASC,
+  CASE WHEN sqlc.arg (sort)::TEXT IN ('name', 'media') AND sqlc.arg (descending)::BOOLEAN THEN LOWER(o.name) END DESC,
+  CASE WHEN sqlc.arg (sort)::TEXT IN ('name', 'media') AND NOT sqlc.arg (descending)::BOOLEAN THEN LOWER(o.name) END ASC,
+  CASE WHEN sqlc.arg (descending)::BOOLEAN THEN o.id END DESC,
+  CASE WHEN NOT sqlc.arg (descending)::BOOLEAN THEN o.id END ASC
+LIMIT
+  sqlc.arg (lim);
+
+-- name: CountPage :one
+SELECT
+  COUNT(col.character_id)
+FROM
+  collection col
+  JOIN characters c ON col.character_id = c.id
+WHERE
+  col.user_id = sqlc.arg (user_id)
+  AND (
+    sqlc.narg (min_favorites)::INT IS NULL
+    OR c.favorites >= sqlc.narg (min_favorites)::INT
+  )
+  AND (
+    sqlc.narg (max_favorites)::INT IS NULL
+    OR c.favorites < sqlc.narg (max_favorites)::INT
+  )
+  AND (
+    sqlc.narg (media)::TEXT IS NULL
+    OR c.media_title ILIKE '%' || sqlc.narg (media)::TEXT || '%'
+  )
+  AND (
+    sqlc.narg (source)::TEXT IS NULL
+    OR col.source = sqlc.narg (source)::TEXT
+  )
+  AND (
+    sqlc.narg (acquired_from)::TIMESTAMP IS NULL
+    OR col.acquired_at >= sqlc.narg (acquired_from)::TIMESTAMP
+  )
+  AND (
+    sqlc.narg (acquired_to)::TIMESTAMP IS NULL
+    OR col.acquired_at < sqlc.narg (acquired_to)::TIMESTAMP
+  )
+  AND (
+    sqlc.narg (term)::TEXT IS NULL
+    OR c.id::VARCHAR LIKE sqlc.narg (term)::TEXT || '%'
+    OR c.name ILIKE '%' || sqlc.narg (term)::TEXT || '%'
+  );
+
 -- name: Get :one
 SELECT
   c.id,
@@ -71,7 +205,8 @@ SELECT
   image,
   media_title,
   favorites,
-  updated_at
+  updated_at,
+  is_active
 FROM
   characters
 WHERE
@@ -159,15 +294,19 @@ WHERE
 
 -- name: UpsertCharacter :one
 INSERT INTO
-  characters (id, name, image, media_title, favorites)
+  characters (id, name, image, media_title, favorites, alternative_names)
 VALUES
-  ($1, $2, $3, $4, $5)
+  ($1, $2, $3, $4, $5, COALESCE(sqlc.arg(alternative_names)::TEXT[], '{}'))
 ON CONFLICT (id) DO UPDATE
 SET
   name = excluded.name,
   image = excluded.image,
   media_title = excluded.media_title,
-  favorites = excluded.favorites
+  favorites = excluded.favorites,
+  alternative_names = CASE
+    WHEN cardinality(excluded.alternative_names) > 0 THEN excluded.alternative_names
+    ELSE characters.alternative_names
+  END
 RETURNING
   *;
 
@@ -185,7 +324,7 @@ LIMIT 1;
 -- name: RandomActiveChar :one
 -- Excludes the default AniList placeholder image (set by AniList when a
 -- character has no custom artwork) since drops embed the image publicly.
-SELECT id, name, image, media_title, favorites, is_active, updated_at
+SELECT id, name, image, media_title, favorites, is_active, updated_at, alternative_names
 FROM characters
 WHERE is_active = true
   AND image != 'https://s4.anilist.co/file/anilistcdn/character/large/default.jpg'
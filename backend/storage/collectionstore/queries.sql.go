@@ -27,6 +27,72 @@ func (q *Queries) Count(ctx context.Context, userID uint64) (int64, error) {
 	return count, err
 }
 
+const countPage = `-- name: CountPage :one
+SELECT
+  COUNT(col.character_id)
+FROM
+  collection col
+  JOIN characters c ON col.character_id = c.id
+WHERE
+  col.user_id = $1
+  AND (
+    $2::INT IS NULL
+    OR c.favorites >= $2::INT
+  )
+  AND (
+    $3::INT IS NULL
+    OR c.favorites < $3::INT
+  )
+  AND (
+    $4::TEXT IS NULL
+    OR c.media_title ILIKE '%' || $4::TEXT || '%'
+  )
+  AND (
+    $5::TEXT IS NULL
+    OR col.source = $5::TEXT
+  )
+  AND (
+    $6::TIMESTAMP IS NULL
+    OR col.acquired_at >= $6::TIMESTAMP
+  )
+  AND (
+    $7::TIMESTAMP IS NULL
+    OR col.acquired_at < $7::TIMESTAMP
+  )
+  AND (
+    $8::TEXT IS NULL
+    OR c.id::VARCHAR LIKE $8::TEXT || '%'
+    OR c.name ILIKE '%' || $8::TEXT || '%'
+  )
+`
+
+type CountPageParams struct {
+	UserID       uint64
+	MinFavorites pgtype.Int4
+	MaxFavorites pgtype.Int4
+	Media        pgtype.Text
+	Source       pgtype.Text
+	AcquiredFrom pgtype.Timestamp
+	AcquiredTo   pgtype.Timestamp
+	Term         pgtype.Text
+}
+
+func (q *Queries) CountPage(ctx context.Context, arg CountPageParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countPage,
+		arg.UserID,
+		arg.MinFavorites,
+		arg.MaxFavorites,
+		arg.Media,
+		arg.Source,
+		arg.AcquiredFrom,
+		arg.AcquiredTo,
+		arg.Term,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const delete = `-- name: Delete :one
 DELETE FROM collection col
 WHERE
@@ -134,7 +200,8 @@ SELECT
   image,
   media_title,
   favorites,
-  updated_at
+  updated_at,
+  is_active
 FROM
   characters
 WHERE
@@ -150,6 +217,7 @@ type GetByIDRow struct {
 	MediaTitle string
 	Favorites  int32
 	UpdatedAt  pgtype.Timestamp
+	IsActive   bool
 }
 
 func (q *Queries) GetByID(ctx context.Context, id int64) (GetByIDRow, error) {
@@ -162,6 +230,7 @@ func (q *Queries) GetByID(ctx context.Context, id int64) (GetByIDRow, error) {
 		&i.MediaTitle,
 		&i.Favorites,
 		&i.UpdatedAt,
+		&i.IsActive,
 	)
 	return i, err
 }
@@ -321,6 +390,177 @@ func (q *Queries) ListIDs(ctx context.Context, userID uint64) ([]int64, error) {
 	return items, nil
 }
 
+const listPage = `-- name: ListPage :many
+WITH
+  owned AS (
+    SELECT
+      c.id,
+      c.name,
+      c.image,
+      c.media_title,
+      c.favorites,
+      col.source,
+      COALESCE(col.acquired_at, '0001-01-01')::TIMESTAMP AS date
+    FROM
+      collection col
+      JOIN characters c ON col.character_id = c.id
+    WHERE
+      col.user_id = $9
+      AND (
+        $10::INT IS NULL
+        OR c.favorites >= $10::INT
+      )
+      AND (
+        $11::INT IS NULL
+        OR c.favorites < $11::INT
+      )
+      AND (
+        $12::TEXT IS NULL
+        OR c.media_title ILIKE '%' || $12::TEXT || '%'
+      )
+      AND (
+        $13::TEXT IS NULL
+        OR col.source = $13::TEXT
+      )
+      AND (
+        $14::TIMESTAMP IS NULL
+        OR col.acquired_at >= $14::TIMESTAMP
+      )
+      AND (
+        $15::TIMESTAMP IS NULL
+        OR col.acquired_at < $15::TIMESTAMP
+      )
+      AND (
+        $16::TEXT IS NULL
+        OR c.id::VARCHAR LIKE $16::TEXT || '%'
+        OR c.name ILIKE '%' || $16::TEXT || '%'
+      )
+  )
+SELECT
+  o.id,
+  o.name,
+  o.image,
+  o.media_title,
+  o.favorites,
+  o.source,
+  o.date
+FROM
+  owned o
+WHERE
+  $1::BIGINT IS NULL
+  OR CASE
+    WHEN $2::BOOLEAN THEN CASE $3::TEXT
+      WHEN 'date' THEN (o.date, o.id) < ($4::TIMESTAMP, $1::BIGINT)
+      WHEN 'name' THEN (LOWER(o.name), o.id) < (LOWER($5::TEXT), $1::BIGINT)
+      WHEN 'rarity' THEN (o.favorites, o.id) < ($6::INT, $1::BIGINT)
+      WHEN 'media' THEN (LOWER(o.media_title), LOWER(o.name), o.id) < (
+        LOWER($7::TEXT),
+        LOWER($5::TEXT),
+        $1::BIGINT
+      )
+      ELSE o.id < $1::BIGINT
+    END
+    ELSE CASE $3::TEXT
+      WHEN 'date' THEN (o.date, o.id) > ($4::TIMESTAMP, $1::BIGINT)
+      WHEN 'name' THEN (LOWER(o.name), o.id) > (LOWER($5::TEXT), $1::BIGINT)
+      WHEN 'rarity' THEN (o.favorites, o.id) > ($6::INT, $1::BIGINT)
+      WHEN 'media' THEN (LOWER(o.media_title), LOWER(o.name), o.id) > (
+        LOWER($7::TEXT),
+        LOWER($5::TEXT),
+        $1::BIGINT
+      )
+      ELSE o.id > $1::BIGINT
+    END
+  END
+ORDER BY
+  CASE WHEN $3::TEXT = 'date' AND $2::BOOLEAN THEN o.date END DESC,
+  CASE WHEN $3::TEXT = 'date' AND NOT $2::BOOLEAN THEN o.date END ASC,
+  CASE WHEN $3::TEXT = 'rarity' AND $2::BOOLEAN THEN o.favorites END DESC,
+  CASE WHEN $3::TEXT = 'rarity' AND NOT $2::BOOLEAN THEN o.favorites END ASC,
+  CASE WHEN $3::TEXT = 'media' AND $2::BOOLEAN THEN LOWER(o.media_title) END DESC,
+  CASE WHEN $3::TEXT = 'media' AND NOT $2::BOOLEAN THEN LOWER(o.media_title) END ASC,
+  CASE WHEN $3::TEXT IN ('name', 'media') AND $2::BOOLEAN THEN LOWER(o.name) END DESC,
+  CASE WHEN $3::TEXT IN ('name', 'media') AND NOT $2::BOOLEAN THEN LOWER(o.name) END ASC,
+  CASE WHEN $2::BOOLEAN THEN o.id END DESC,
+  CASE WHEN NOT $2::BOOLEAN THEN o.id END ASC
+LIMIT
+  $8
+`
+
+type ListPageParams struct {
+	AfterID        pgtype.Int8
+	Descending     bool
+	Sort           string
+	AfterDate      pgtype.Timestamp
+	AfterName      pgtype.Text
+	AfterFavorites pgtype.Int4
+	AfterMedia     pgtype.Text
+	Lim            int32
+	UserID         uint64
+	MinFavorites   pgtype.Int4
+	MaxFavorites   pgtype.Int4
+	Media          pgtype.Text
+	Source         pgtype.Text
+	AcquiredFrom   pgtype.Timestamp
+	AcquiredTo     pgtype.Timestamp
+	Term           pgtype.Text
+}
+
+type ListPageRow struct {
+	ID         int64
+	Name       string
+	Image      string
+	MediaTitle string
+	Favorites  int32
+	Source     string
+	Date       pgtype.Timestamp
+}
+
+func (q *Queries) ListPage(ctx context.Context, arg ListPageParams) ([]ListPageRow, error) {
+	rows, err := q.db.Query(ctx, listPage,
+		arg.AfterID,
+		arg.Descending,
+		arg.Sort,
+		arg.AfterDate,
+		arg.AfterName,
+		arg.AfterFavorites,
+		arg.AfterMedia,
+		arg.Lim,
+		arg.UserID,
+		arg.MinFavorites,
+		arg.MaxFavorites,
+		arg.Media,
+		arg.Source,
+		arg.AcquiredFrom,
+		arg.AcquiredTo,
+		arg.Term,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPageRow
+	for rows.Next() {
+		var i ListPageRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Image,
+			&i.MediaTitle,
+			&i.Favorites,
+			&i.Source,
+			&i.Date,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const markCharactersInactive = `-- name: MarkCharactersInactive :exec
 UPDATE characters SET is_active = false WHERE id = ANY($1::BIGINT[]) AND is_active = true
 `
@@ -331,7 +571,7 @@ func (q *Queries) MarkCharactersInactive(ctx context.Context, ids []int64) error
 }
 
 const randomActiveChar = `-- name: RandomActiveChar :one
-SELECT id, name, image, media_title, favorites, is_active, updated_at
+SELECT id, name, image, media_title, favorites, is_active, updated_at, alternative_names
 FROM characters
 WHERE is_active = true
   AND image != 'https://s4.anilist.co/file/anilistcdn/character/large/default.jpg'
@@ -339,6 +579,8 @@ ORDER BY -ln(random()) / pow(ln(favorites + 10), $1::double precision)
 LIMIT 1
 `
 
+// Excludes the default AniList placeholder image (set by AniList when a
+// character has no custom artwork) since drops embed the image publicly.
 func (q *Queries) RandomActiveChar(ctx context.Context, weightExponent float64) (Character, error) {
 	row := q.db.QueryRow(ctx, randomActiveChar, weightExponent)
 	var i Character
@@ -350,6 +592,7 @@ func (q *Queries) RandomActiveChar(ctx context.Context, weightExponent float64)
 		&i.Favorites,
 		&i.IsActive,
 		&i.UpdatedAt,
+		&i.AlternativeNames,
 	)
 	return i, err
 }
@@ -470,7 +713,7 @@ func (q *Queries) SearchCharacters(ctx context.Context, arg SearchCharactersPara
 
 const searchGlobalCharacters = `-- name: SearchGlobalCharacters :many
 SELECT DISTINCT
-  id, name, image, media_title, favorites, is_active, updated_at
+  id, name, image, media_title, favorites, is_active, updated_at, alternative_names
 FROM
   characters c
 WHERE
@@ -505,6 +748,7 @@ func (q *Queries) SearchGlobalCharacters(ctx context.Context, arg SearchGlobalCh
 			&i.Favorites,
 			&i.IsActive,
 			&i.UpdatedAt,
+			&i.AlternativeNames,
 		); err != nil {
 			return nil, err
 		}
@@ -524,7 +768,7 @@ SET
 WHERE
   c.id = $3
 RETURNING
-  id, name, image, media_title, favorites, is_active, updated_at
+  id, name, image, media_title, favorites, is_active, updated_at, alternative_names
 `
 
 type UpdateImageNameParams struct {
@@ -544,31 +788,37 @@ func (q *Queries) UpdateImageName(ctx context.Context, arg UpdateImageNameParams
 		&i.Favorites,
 		&i.IsActive,
 		&i.UpdatedAt,
+		&i.AlternativeNames,
 	)
 	return i, err
 }
 
 const upsertCharacter = `-- name: UpsertCharacter :one
 INSERT INTO
-  characters (id, name, image, media_title, favorites)
+  characters (id, name, image, media_title, favorites, alternative_names)
 VALUES
-  ($1, $2, $3, $4, $5)
+  ($1, $2, $3, $4, $5, COALESCE($6::TEXT[], '{}'))
 ON CONFLICT (id) DO UPDATE
 SET
   name = excluded.name,
   image = excluded.image,
   media_title = excluded.media_title,
-  favorites = excluded.favorites
+  favorites = excluded.favorites,
+  alternative_names = CASE
+    WHEN cardinality(excluded.alternative_names) > 0 THEN excluded.alternative_names
+    ELSE characters.alternative_names
+  END
 RETURNING
-  id, name, image, media_title, favorites, is_active, updated_at
+  id, name, image, media_title, favorites, is_active, updated_at, alternative_names
 `
 
 type UpsertCharacterParams struct {
-	ID         int64
-	Name       string
-	Image      string
-	MediaTitle string
-	Favorites  int32
+	ID               int64
+	Name             string
+	Image            string
+	MediaTitle       string
+	Favorites        int32
+	AlternativeNames []string
 }
 
 func (q *Queries) UpsertCharacter(ctx context.Context, arg UpsertCharacterParams) (Character, error) {
@@ -578,6 +828,7 @@ func (q *Queries) UpsertCharacter(ctx context.Context, arg UpsertCharacterParams
 		arg.Image,
 		arg.MediaTitle,
 		arg.Favorites,
+		arg.AlternativeNames,
 	)
 	var i Character
 	err := row.Scan(
@@ -588,6 +839,7 @@ func (q *Queries) UpsertCharacter(ctx context.Context, arg UpsertCharacterParams
 		&i.Favorites,
 		&i.IsActive,
 		&i.UpdatedAt,
+		&i.AlternativeNames,
 	)
 	return i, err
 }
@@ -5,7 +5,8 @@ CREATE TABLE public.characters (
   media_title TEXT NOT NULL DEFAULT '',
   favorites INTEGER NOT NULL DEFAULT 0,
   is_active BOOLEAN NOT NULL DEFAULT true,
-  updated_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NOW()
+  updated_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NOW(),
+  alternative_names TEXT[] NOT NULL DEFAULT '{}'
 );
 
 CREATE TABLE public.collection (
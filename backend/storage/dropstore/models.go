@@ -4,15 +4,24 @@
 
 package dropstore
 
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
 type ChannelDrop struct {
-	ChannelID   uint64
-	CharacterID int64
+	ChannelID      uint64
+	CharacterID    int64
+	MessageID      pgtype.Int8
+	ExpiresAt      pgtype.Timestamp
+	FailedAttempts int32
+	HintsShown     int32
 }
 
 type Character struct {
-	ID         int64
-	Name       string
-	Image      string
-	MediaTitle string
-	Favorites  int32
+	ID               int64
+	Name             string
+	Image            string
+	MediaTitle       string
+	Favorites        int32
+	AlternativeNames []string
 }
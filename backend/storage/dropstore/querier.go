@@ -9,10 +9,15 @@ import (
 )
 
 type Querier interface {
+	AdvanceDropHint(ctx context.Context, arg AdvanceDropHintParams) (int64, error)
 	DeleteDrop(ctx context.Context, channelID uint64) error
-	GetDrop(ctx context.Context, channelID uint64) (Character, error)
-	GetDropForUpdate(ctx context.Context, channelID uint64) (Character, error)
+	DeleteExpiredDrop(ctx context.Context, arg DeleteExpiredDropParams) (int64, error)
+	GetDrop(ctx context.Context, channelID uint64) (GetDropRow, error)
+	GetDropForUpdate(ctx context.Context, channelID uint64) (GetDropForUpdateRow, error)
+	ListExpiredDrops(ctx context.Context, arg ListExpiredDropsParams) ([]ListExpiredDropsRow, error)
+	RecordDropMiss(ctx context.Context, channelID uint64) (RecordDropMissRow, error)
 	SetDrop(ctx context.Context, arg SetDropParams) error
+	SetDropMessage(ctx context.Context, arg SetDropMessageParams) error
 	UpsertCharacter(ctx context.Context, arg UpsertCharacterParams) error
 }
 
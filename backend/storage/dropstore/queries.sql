@@ -1,23 +1,39 @@
 -- name: UpsertCharacter :exec
 INSERT INTO
-  characters (id, name, image, media_title, favorites)
+  characters (id, name, image, media_title, favorites, alternative_names)
 VALUES
-  ($1, $2, $3, $4, $5)
+  ($1, $2, $3, $4, $5, COALESCE(sqlc.arg(alternative_names)::TEXT[], '{}'))
 ON CONFLICT (id) DO UPDATE
 SET
   name = excluded.name,
   image = excluded.image,
   media_title = excluded.media_title,
-  favorites = excluded.favorites;
+  favorites = excluded.favorites,
+  alternative_names = CASE
+    WHEN cardinality(excluded.alternative_names) > 0 THEN excluded.alternative_names
+    ELSE characters.alternative_names
+  END;
 
 -- name: SetDrop :exec
 INSERT INTO
-  channel_drops (channel_id, character_id)
+  channel_drops (channel_id, character_id, expires_at)
 VALUES
-  ($1, $2)
+  ($1, $2, $3)
 ON CONFLICT (channel_id) DO UPDATE
 SET
-  character_id = excluded.character_id;
+  character_id = excluded.character_id,
+  expires_at = excluded.expires_at,
+  message_id = NULL,
+  failed_attempts = 0,
+  hints_shown = 0;
+
+-- name: SetDropMessage :exec
+UPDATE channel_drops
+SET
+  message_id = $3
+WHERE
+  channel_id = $1
+  AND character_id = $2;
 
 -- name: GetDrop :one
 SELECT
@@ -25,7 +41,8 @@ SELECT
   c.name,
   c.image,
   c.media_title,
-  c.favorites
+  c.favorites,
+  cd.expires_at
 FROM
   channel_drops cd
   JOIN characters c ON cd.character_id = c.id
@@ -43,10 +60,62 @@ SELECT
   c.name,
   c.image,
   c.media_title,
-  c.favorites
+  c.favorites,
+  cd.expires_at,
+  c.alternative_names
 FROM
   channel_drops cd
   JOIN characters c ON cd.character_id = c.id
 WHERE
   cd.channel_id = $1
 FOR UPDATE;
+
+-- name: ListExpiredDrops :many
+SELECT
+  cd.channel_id,
+  cd.message_id,
+  cd.expires_at,
+  c.id,
+  c.name,
+  c.image,
+  c.media_title,
+  c.favorites
+FROM
+  channel_drops cd
+  JOIN characters c ON cd.character_id = c.id
+WHERE
+  cd.expires_at <= $1
+ORDER BY
+  cd.expires_at
+LIMIT
+  $2;
+
+-- name: DeleteExpiredDrop :execrows
+DELETE FROM channel_drops
+WHERE
+  channel_id = $1
+  AND expires_at <= $2;
+
+-- name: RecordDropMiss :one
+UPDATE channel_drops cd
+SET
+  failed_attempts = cd.failed_attempts + 1
+FROM
+  characters c
+WHERE
+  cd.channel_id = $1
+  AND c.id = cd.character_id
+RETURNING
+  cd.failed_attempts,
+  cd.hints_shown,
+  c.id,
+  c.name,
+  c.media_title;
+
+-- name: AdvanceDropHint :execrows
+UPDATE channel_drops
+SET
+  hints_shown = $2
+WHERE
+  channel_id = $1
+  AND hints_shown < $2;
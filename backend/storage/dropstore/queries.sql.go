@@ -7,8 +7,32 @@ package dropstore
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const advanceDropHint = `-- name: AdvanceDropHint :execrows
+UPDATE channel_drops
+SET
+  hints_shown = $2
+WHERE
+  channel_id = $1
+  AND hints_shown < $2
+`
+
+type AdvanceDropHintParams struct {
+	ChannelID  uint64
+	HintsShown int32
+}
+
+func (q *Queries) AdvanceDropHint(ctx context.Context, arg AdvanceDropHintParams) (int64, error) {
+	result, err := q.db.Exec(ctx, advanceDropHint, arg.ChannelID, arg.HintsShown)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const deleteDrop = `-- name: DeleteDrop :exec
 DELETE FROM channel_drops
 WHERE
@@ -20,13 +44,34 @@ func (q *Queries) DeleteDrop(ctx context.Context, channelID uint64) error {
 	return err
 }
 
+const deleteExpiredDrop = `-- name: DeleteExpiredDrop :execrows
+DELETE FROM channel_drops
+WHERE
+  channel_id = $1
+  AND expires_at <= $2
+`
+
+type DeleteExpiredDropParams struct {
+	ChannelID uint64
+	ExpiresAt pgtype.Timestamp
+}
+
+func (q *Queries) DeleteExpiredDrop(ctx context.Context, arg DeleteExpiredDropParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredDrop, arg.ChannelID, arg.ExpiresAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getDrop = `-- name: GetDrop :one
 SELECT
   c.id,
   c.name,
   c.image,
   c.media_title,
-  c.favorites
+  c.favorites,
+  cd.expires_at
 FROM
   channel_drops cd
   JOIN characters c ON cd.character_id = c.id
@@ -34,15 +79,25 @@ WHERE
   cd.channel_id = $1
 `
 
-func (q *Queries) GetDrop(ctx context.Context, channelID uint64) (Character, error) {
+type GetDropRow struct {
+	ID         int64
+	Name       string
+	Image      string
+	MediaTitle string
+	Favorites  int32
+	ExpiresAt  pgtype.Timestamp
+}
+
+func (q *Queries) GetDrop(ctx context.Context, channelID uint64) (GetDropRow, error) {
 	row := q.db.QueryRow(ctx, getDrop, channelID)
-	var i Character
+	var i GetDropRow
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
 		&i.Image,
 		&i.MediaTitle,
 		&i.Favorites,
+		&i.ExpiresAt,
 	)
 	return i, err
 }
@@ -53,7 +108,9 @@ SELECT
   c.name,
   c.image,
   c.media_title,
-  c.favorites
+  c.favorites,
+  cd.expires_at,
+  c.alternative_names
 FROM
   channel_drops cd
   JOIN characters c ON cd.character_id = c.id
@@ -62,58 +119,204 @@ WHERE
 FOR UPDATE
 `
 
-func (q *Queries) GetDropForUpdate(ctx context.Context, channelID uint64) (Character, error) {
+type GetDropForUpdateRow struct {
+	ID               int64
+	Name             string
+	Image            string
+	MediaTitle       string
+	Favorites        int32
+	ExpiresAt        pgtype.Timestamp
+	AlternativeNames []string
+}
+
+func (q *Queries) GetDropForUpdate(ctx context.Context, channelID uint64) (GetDropForUpdateRow, error) {
 	row := q.db.QueryRow(ctx, getDropForUpdate, channelID)
-	var i Character
+	var i GetDropForUpdateRow
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
 		&i.Image,
 		&i.MediaTitle,
 		&i.Favorites,
+		&i.ExpiresAt,
+		&i.AlternativeNames,
+	)
+	return i, err
+}
+
+const listExpiredDrops = `-- name: ListExpiredDrops :many
+SELECT
+  cd.channel_id,
+  cd.message_id,
+  cd.expires_at,
+  c.id,
+  c.name,
+  c.image,
+  c.media_title,
+  c.favorites
+FROM
+  channel_drops cd
+  JOIN characters c ON cd.character_id = c.id
+WHERE
+  cd.expires_at <= $1
+ORDER BY
+  cd.expires_at
+LIMIT
+  $2
+`
+
+type ListExpiredDropsParams struct {
+	ExpiresAt pgtype.Timestamp
+	Limit     int32
+}
+
+type ListExpiredDropsRow struct {
+	ChannelID  uint64
+	MessageID  pgtype.Int8
+	ExpiresAt  pgtype.Timestamp
+	ID         int64
+	Name       string
+	Image      string
+	MediaTitle string
+	Favorites  int32
+}
+
+func (q *Queries) ListExpiredDrops(ctx context.Context, arg ListExpiredDropsParams) ([]ListExpiredDropsRow, error) {
+	rows, err := q.db.Query(ctx, listExpiredDrops, arg.ExpiresAt, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListExpiredDropsRow
+	for rows.Next() {
+		var i ListExpiredDropsRow
+		if err := rows.Scan(
+			&i.ChannelID,
+			&i.MessageID,
+			&i.ExpiresAt,
+			&i.ID,
+			&i.Name,
+			&i.Image,
+			&i.MediaTitle,
+			&i.Favorites,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const recordDropMiss = `-- name: RecordDropMiss :one
+UPDATE channel_drops cd
+SET
+  failed_attempts = cd.failed_attempts + 1
+FROM
+  characters c
+WHERE
+  cd.channel_id = $1
+  AND c.id = cd.character_id
+RETURNING
+  cd.failed_attempts,
+  cd.hints_shown,
+  c.id,
+  c.name,
+  c.media_title
+`
+
+type RecordDropMissRow struct {
+	FailedAttempts int32
+	HintsShown     int32
+	ID             int64
+	Name           string
+	MediaTitle     string
+}
+
+func (q *Queries) RecordDropMiss(ctx context.Context, channelID uint64) (RecordDropMissRow, error) {
+	row := q.db.QueryRow(ctx, recordDropMiss, channelID)
+	var i RecordDropMissRow
+	err := row.Scan(
+		&i.FailedAttempts,
+		&i.HintsShown,
+		&i.ID,
+		&i.Name,
+		&i.MediaTitle,
 	)
 	return i, err
 }
 
 const setDrop = `-- name: SetDrop :exec
 INSERT INTO
-  channel_drops (channel_id, character_id)
+  channel_drops (channel_id, character_id, expires_at)
 VALUES
-  ($1, $2)
+  ($1, $2, $3)
 ON CONFLICT (channel_id) DO UPDATE
 SET
-  character_id = excluded.character_id
+  character_id = excluded.character_id,
+  expires_at = excluded.expires_at,
+  message_id = NULL,
+  failed_attempts = 0,
+  hints_shown = 0
 `
 
 type SetDropParams struct {
 	ChannelID   uint64
 	CharacterID int64
+	ExpiresAt   pgtype.Timestamp
 }
 
 func (q *Queries) SetDrop(ctx context.Context, arg SetDropParams) error {
-	_, err := q.db.Exec(ctx, setDrop, arg.ChannelID, arg.CharacterID)
+	_, err := q.db.Exec(ctx, setDrop, arg.ChannelID, arg.CharacterID, arg.ExpiresAt)
+	return err
+}
+
+const setDropMessage = `-- name: SetDropMessage :exec
+UPDATE channel_drops
+SET
+  message_id = $3
+WHERE
+  channel_id = $1
+  AND character_id = $2
+`
+
+type SetDropMessageParams struct {
+	ChannelID   uint64
+	CharacterID int64
+	MessageID   pgtype.Int8
+}
+
+func (q *Queries) SetDropMessage(ctx context.Context, arg SetDropMessageParams) error {
+	_, err := q.db.Exec(ctx, setDropMessage, arg.ChannelID, arg.CharacterID, arg.MessageID)
 	return err
 }
 
 const upsertCharacter = `-- name: UpsertCharacter :exec
 INSERT INTO
-  characters (id, name, image, media_title, favorites)
+  characters (id, name, image, media_title, favorites, alternative_names)
 VALUES
-  ($1, $2, $3, $4, $5)
+  ($1, $2, $3, $4, $5, COALESCE($6::TEXT[], '{}'))
 ON CONFLICT (id) DO UPDATE
 SET
   name = excluded.name,
   image = excluded.image,
   media_title = excluded.media_title,
-  favorites = excluded.favorites
+  favorites = excluded.favorites,
+  alternative_names = CASE
+    WHEN cardinality(excluded.alternative_names) > 0 THEN excluded.alternative_names
+    ELSE characters.alternative_names
+  END
 `
 
 type UpsertCharacterParams struct {
-	ID         int64
-	Name       string
-	Image      string
-	MediaTitle string
-	Favorites  int32
+	ID               int64
+	Name             string
+	Image            string
+	MediaTitle       string
+	Favorites        int32
+	AlternativeNames []string
 }
 
 func (q *Queries) UpsertCharacter(ctx context.Context, arg UpsertCharacterParams) error {
@@ -123,6 +326,7 @@ func (q *Queries) UpsertCharacter(ctx context.Context, arg UpsertCharacterParams
 		arg.Image,
 		arg.MediaTitle,
 		arg.Favorites,
+		arg.AlternativeNames,
 	)
 	return err
 }
@@ -3,10 +3,17 @@ CREATE TABLE public.characters (
   name CHARACTER VARYING(128) CONSTRAINT characters_new_name_not_null NOT NULL,
   image CHARACTER VARYING(256) CONSTRAINT characters_new_image_not_null NOT NULL,
   media_title TEXT NOT NULL DEFAULT '',
-  favorites INTEGER NOT NULL DEFAULT 0
+  favorites INTEGER NOT NULL DEFAULT 0,
+  alternative_names TEXT[] NOT NULL DEFAULT '{}'
 );
 
 CREATE TABLE public.channel_drops (
   channel_id BIGINT PRIMARY KEY,
-  character_id BIGINT NOT NULL REFERENCES public.characters (id) ON DELETE CASCADE
+  character_id BIGINT NOT NULL REFERENCES public.characters (id) ON DELETE CASCADE,
+  message_id BIGINT,
+  expires_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+  failed_attempts INTEGER NOT NULL DEFAULT 0,
+  hints_shown INTEGER NOT NULL DEFAULT 0
 );
+
+CREATE INDEX idx_channel_drops_expires_at ON public.channel_drops (expires_at);
@@ -3,9 +3,11 @@ package dropstore
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/Karitham/corde"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Drop struct {
@@ -14,12 +16,59 @@ type Drop struct {
 	ImageURL   string
 	MediaTitle string
 	Favorites  int
+	Aliases    []string
+	ExpiresAt  time.Time
+}
+
+// ExpiredDrop is a drop that was not claimed in time.
+// MessageID is 0 when the drop message was never recorded.
+type ExpiredDrop struct {
+	Drop
+	ChannelID corde.Snowflake
+	MessageID corde.Snowflake
+}
+
+// Miss is the state of a drop right after a failed claim attempt.
+type Miss struct {
+	Drop
+	// Attempts counts the failed claims on the drop, including this one.
+	Attempts int
+	// HintsShown is how many hints were already posted for the drop.
+	HintsShown int
+}
+
+// DefaultHintThresholds are the failed attempts needed to unlock each hint.
+var DefaultHintThresholds = []int{3, 6, 9, 12}
+
+// HintLevel returns how many hints are unlocked after misses failed attempts.
+// thresholds must be sorted in ascending order.
+func HintLevel(misses int, thresholds []int) int {
+	level := 0
+	for _, t := range thresholds {
+		if misses < t {
+			break
+		}
+		level++
+	}
+	return level
 }
 
 type Store interface {
 	Delete(ctx context.Context, id corde.Snowflake) error
 	Get(ctx context.Context, id corde.Snowflake) (*Drop, error)
 	Set(ctx context.Context, id corde.Snowflake, data Drop) error
+	// SetMessage records the message announcing the drop of characterID in the channel.
+	SetMessage(ctx context.Context, id corde.Snowflake, characterID int64, messageID corde.Snowflake) error
+	// Expired lists up to limit drops whose expiry is at or before now, oldest first.
+	Expired(ctx context.Context, now time.Time, limit int32) ([]ExpiredDrop, error)
+	// DeleteExpired removes the channel's drop if it is expired at now.
+	// It reports false when the drop was claimed or replaced in the meantime.
+	DeleteExpired(ctx context.Context, id corde.Snowflake, now time.Time) (bool, error)
+	// RecordMiss counts a failed claim on the channel's drop.
+	RecordMiss(ctx context.Context, id corde.Snowflake) (Miss, error)
+	// AdvanceHint marks hints up to level as shown. It reports false when
+	// another attempt already showed that hint.
+	AdvanceHint(ctx context.Context, id corde.Snowflake, level int) (bool, error)
 }
 
 type PostgresStore struct {
@@ -34,11 +83,12 @@ func NewPostgresStore(q Querier) *PostgresStore {
 
 func (p *PostgresStore) Set(ctx context.Context, id corde.Snowflake, data Drop) error {
 	err := p.q.UpsertCharacter(ctx, UpsertCharacterParams{
-		ID:         data.ID,
-		Name:       data.Name,
-		Image:      data.ImageURL,
-		MediaTitle: data.MediaTitle,
-		Favorites:  int32(data.Favorites),
+		ID:               data.ID,
+		Name:             data.Name,
+		Image:            data.ImageURL,
+		MediaTitle:       data.MediaTitle,
+		Favorites:        int32(data.Favorites),
+		AlternativeNames: data.Aliases,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to upsert character: %w", err)
@@ -47,9 +97,56 @@ func (p *PostgresStore) Set(ctx context.Context, id corde.Snowflake, data Drop)
 	return p.q.SetDrop(ctx, SetDropParams{
 		ChannelID:   uint64(id),
 		CharacterID: data.ID,
+		ExpiresAt:   pgtype.Timestamp{Time: data.ExpiresAt.UTC(), Valid: true},
+	})
+}
+
+func (p *PostgresStore) SetMessage(ctx context.Context, id corde.Snowflake, characterID int64, messageID corde.Snowflake) error {
+	return p.q.SetDropMessage(ctx, SetDropMessageParams{
+		ChannelID:   uint64(id),
+		CharacterID: characterID,
+		MessageID:   pgtype.Int8{Int64: int64(messageID), Valid: true},
 	})
 }
 
+func (p *PostgresStore) Expired(ctx context.Context, now time.Time, limit int32) ([]ExpiredDrop, error) {
+	rows, err := p.q.ListExpiredDrops(ctx, ListExpiredDropsParams{
+		ExpiresAt: pgtype.Timestamp{Time: now.UTC(), Valid: true},
+		Limit:     limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expired drops: %w", err)
+	}
+
+	drops := make([]ExpiredDrop, len(rows))
+	for i, row := range rows {
+		drops[i] = ExpiredDrop{
+			Drop: Drop{
+				ID:         row.ID,
+				Name:       row.Name,
+				ImageURL:   row.Image,
+				MediaTitle: row.MediaTitle,
+				Favorites:  int(row.Favorites),
+				ExpiresAt:  row.ExpiresAt.Time,
+			},
+			ChannelID: corde.Snowflake(row.ChannelID),
+			MessageID: corde.Snowflake(row.MessageID.Int64),
+		}
+	}
+	return drops, nil
+}
+
+func (p *PostgresStore) DeleteExpired(ctx context.Context, id corde.Snowflake, now time.Time) (bool, error) {
+	n, err := p.q.DeleteExpiredDrop(ctx, DeleteExpiredDropParams{
+		ChannelID: uint64(id),
+		ExpiresAt: pgtype.Timestamp{Time: now.UTC(), Valid: true},
+	})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
 func (p *PostgresStore) Get(ctx context.Context, id corde.Snowflake) (*Drop, error) {
 	row, err := p.q.GetDrop(ctx, uint64(id))
 	if err != nil {
@@ -65,9 +162,37 @@ func (p *PostgresStore) Get(ctx context.Context, id corde.Snowflake) (*Drop, err
 		ImageURL:   row.Image,
 		MediaTitle: row.MediaTitle,
 		Favorites:  int(row.Favorites),
+		ExpiresAt:  row.ExpiresAt.Time,
 	}, nil
 }
 
 func (p *PostgresStore) Delete(ctx context.Context, id corde.Snowflake) error {
 	return p.q.DeleteDrop(ctx, uint64(id))
 }
+
+func (p *PostgresStore) RecordMiss(ctx context.Context, id corde.Snowflake) (Miss, error) {
+	row, err := p.q.RecordDropMiss(ctx, uint64(id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return Miss{}, fmt.Errorf("no drop found")
+		}
+		return Miss{}, fmt.Errorf("failed to record drop miss: %w", err)
+	}
+
+	return Miss{
+		Drop:       Drop{ID: row.ID, Name: row.Name, MediaTitle: row.MediaTitle},
+		Attempts:   int(row.FailedAttempts),
+		HintsShown: int(row.HintsShown),
+	}, nil
+}
+
+func (p *PostgresStore) AdvanceHint(ctx context.Context, id corde.Snowflake, level int) (bool, error) {
+	n, err := p.q.AdvanceDropHint(ctx, AdvanceDropHintParams{
+		ChannelID:  uint64(id),
+		HintsShown: int32(level),
+	})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
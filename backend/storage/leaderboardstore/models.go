@@ -0,0 +1,53 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+
+package leaderboardstore
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type Character struct {
+	ID        int64
+	Favorites int32
+	IsActive  bool
+}
+
+type CharacterMedium struct {
+	CharacterID int64
+	MediaID     int64
+}
+
+type Collection struct {
+	UserID      uint64
+	CharacterID int64
+}
+
+type GuildMember struct {
+	GuildID uint64
+	UserID  uint64
+}
+
+type LeaderboardStat struct {
+	UserID          int64
+	CollectionSize  int64
+	RarityScore     int64
+	LegendaryCount  int64
+	Tokens          int64
+	SeriesCompleted int64
+	RefreshedAt     pgtype.Timestamp
+}
+
+type Medium struct {
+	ID                 int64
+	CharactersSyncedAt pgtype.Timestamp
+}
+
+type User struct {
+	ID              int32
+	UserID          uint64
+	Tokens          int32
+	DiscordUsername string
+	DiscordAvatar   string
+}
@@ -0,0 +1,105 @@
+-- name: Refresh :exec
+REFRESH MATERIALIZED VIEW CONCURRENTLY leaderboard_stats;
+
+-- name: RefreshedAt :one
+SELECT
+  MAX(refreshed_at)::TIMESTAMP AS refreshed_at
+FROM
+  leaderboard_stats;
+
+-- name: Top :many
+-- Users with nothing to show in the category are left out. guild_id limits the
+-- board to the indexed members of a guild.
+WITH
+  ranked AS (
+    SELECT
+      s.user_id,
+      (
+        CASE sqlc.arg(category)::TEXT
+          WHEN 'collection' THEN s.collection_size
+          WHEN 'rarity' THEN s.rarity_score
+          WHEN 'legendary' THEN s.legendary_count
+          WHEN 'tokens' THEN s.tokens
+          WHEN 'series' THEN s.series_completed
+        END
+      )::BIGINT AS value
+    FROM
+      leaderboard_stats s
+    WHERE
+      sqlc.narg(guild_id)::BIGINT IS NULL
+      OR EXISTS (
+        SELECT
+          1
+        FROM
+          guild_members gm
+        WHERE
+          gm.user_id = s.user_id
+          AND gm.guild_id = sqlc.narg(guild_id)::BIGINT
+      )
+  )
+SELECT
+  r.user_id,
+  r.value,
+  RANK() OVER (
+    ORDER BY
+      r.value DESC
+  )::BIGINT AS rank,
+  u.discord_username,
+  u.discord_avatar
+FROM
+  ranked r
+  JOIN users u ON u.user_id = r.user_id
+WHERE
+  r.value > 0
+ORDER BY
+  r.value DESC,
+  r.user_id
+LIMIT
+  sqlc.arg(lim);
+
+-- name: Rank :one
+WITH
+  ranked AS (
+    SELECT
+      s.user_id,
+      (
+        CASE sqlc.arg(category)::TEXT
+          WHEN 'collection' THEN s.collection_size
+          WHEN 'rarity' THEN s.rarity_score
+          WHEN 'legendary' THEN s.legendary_count
+          WHEN 'tokens' THEN s.tokens
+          WHEN 'series' THEN s.series_completed
+        END
+      )::BIGINT AS value
+    FROM
+      leaderboard_stats s
+    WHERE
+      sqlc.narg(guild_id)::BIGINT IS NULL
+      OR EXISTS (
+        SELECT
+          1
+        FROM
+          guild_members gm
+        WHERE
+          gm.user_id = s.user_id
+          AND gm.guild_id = sqlc.narg(guild_id)::BIGINT
+      )
+  ),
+  ranks AS (
+    SELECT
+      r.user_id,
+      r.value,
+      RANK() OVER (
+        ORDER BY
+          r.value DESC
+      )::BIGINT AS rank
+    FROM
+      ranked r
+  )
+SELECT
+  x.value,
+  x.rank
+FROM
+  ranks x
+WHERE
+  x.user_id = sqlc.arg(user_id)::BIGINT;
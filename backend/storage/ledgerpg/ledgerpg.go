@@ -0,0 +1,108 @@
+package ledgerpg
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/storage/ledgerstore"
+)
+
+type Pg struct {
+	Q ledgerstore.Querier
+}
+
+func New(q ledgerstore.Querier) *Pg {
+	return &Pg{Q: q}
+}
+
+func (p *Pg) RecordOwnershipEvent(ctx context.Context, e collection.OwnershipEvent) error {
+	return p.Q.Record(ctx, ledgerstore.RecordParams{
+		CharacterID: e.CharacterID,
+		Kind:        string(e.Kind),
+		FromUserID:  optionalID(e.FromUserID),
+		ToUserID:    optionalID(e.ToUserID),
+		Tokens:      e.Tokens,
+		ReferenceID: optionalID(uint64(e.ReferenceID)),
+		CreatedAt:   pgtype.Timestamp{Time: e.CreatedAt.UTC(), Valid: true},
+	})
+}
+
+func (p *Pg) ListOwnershipEvents(ctx context.Context, charID int64, limit int32) ([]collection.OwnershipEvent, error) {
+	rows, err := p.Q.ListByCharacter(ctx, ledgerstore.ListByCharacterParams{CharacterID: charID, Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]collection.OwnershipEvent, len(rows))
+	for i, r := range rows {
+		events[i] = collection.OwnershipEvent{
+			ID:          r.ID,
+			CharacterID: r.CharacterID,
+			Kind:        collection.EventKind(r.Kind),
+			FromUserID:  uint64(r.FromUserID.Int64),
+			ToUserID:    uint64(r.ToUserID.Int64),
+			Tokens:      r.Tokens,
+			ReferenceID: r.ReferenceID.Int64,
+			CreatedAt:   r.CreatedAt.Time,
+		}
+	}
+	return events, nil
+}
+
+func (p *Pg) CountReceivedEvents(ctx context.Context, userID collection.UserID, kind collection.EventKind) (int64, error) {
+	return p.Q.CountReceived(ctx, ledgerstore.CountReceivedParams{ToUserID: optionalID(userID), Kind: string(kind)})
+}
+
+// optionalID stores 0 as NULL.
+func optionalID(id uint64) pgtype.Int8 {
+	return pgtype.Int8{Int64: int64(id), Valid: id != 0}
+}
+
+func (p *Pg) RecordTokenChange(ctx context.Context, c collection.TokenChange) error {
+	return p.Q.RecordTokenChange(ctx, ledgerstore.RecordTokenChangeParams{
+		UserID:         c.UserID,
+		Amount:         c.Amount,
+		Balance:        c.Balance,
+		Reason:         string(c.Reason),
+		CounterpartyID: optionalID(c.CounterpartyID),
+		ReferenceID:    optionalID(uint64(c.ReferenceID)),
+		CreatedAt:      pgtype.Timestamp{Time: c.CreatedAt.UTC(), Valid: true},
+	})
+}
+
+func (p *Pg) ListTokenChanges(ctx context.Context, userID collection.UserID, limit int32) ([]collection.TokenChange, error) {
+	rows, err := p.Q.ListTokenChanges(ctx, ledgerstore.ListTokenChangesParams{UserID: userID, Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]collection.TokenChange, len(rows))
+	for i, r := range rows {
+		changes[i] = collection.TokenChange{
+			ID:             r.ID,
+			UserID:         r.UserID,
+			Amount:         r.Amount,
+			Balance:        r.Balance,
+			Reason:         collection.TokenReason(r.Reason),
+			CounterpartyID: uint64(r.CounterpartyID.Int64),
+			ReferenceID:    r.ReferenceID.Int64,
+			CreatedAt:      r.CreatedAt.Time,
+		}
+	}
+	return changes, nil
+}
+
+func (p *Pg) TokenBalanceMismatches(ctx context.Context) ([]collection.TokenMismatch, error) {
+	rows, err := p.Q.TokenBalanceMismatches(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mismatches := make([]collection.TokenMismatch, len(rows))
+	for i, r := range rows {
+		mismatches[i] = collection.TokenMismatch{UserID: r.UserID, Balance: r.Tokens, LedgerTotal: r.LedgerTotal}
+	}
+	return mismatches, nil
+}
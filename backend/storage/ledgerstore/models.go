@@ -0,0 +1,57 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+
+package ledgerstore
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type Character struct {
+	ID        int64
+	Name      string
+	Image     string
+	Favorites int32
+}
+
+type GuildMember struct {
+	GuildID   uint64
+	UserID    uint64
+	IndexedAt pgtype.Timestamp
+}
+
+type OwnershipEvent struct {
+	ID          int64
+	CharacterID int64
+	Kind        string
+	FromUserID  pgtype.Int8
+	ToUserID    pgtype.Int8
+	Tokens      int32
+	ReferenceID pgtype.Int8
+	CreatedAt   pgtype.Timestamp
+}
+
+type TokenLedger struct {
+	ID             int64
+	UserID         uint64
+	Amount         int32
+	Balance        int32
+	Reason         string
+	CounterpartyID pgtype.Int8
+	ReferenceID    pgtype.Int8
+	CreatedAt      pgtype.Timestamp
+}
+
+type User struct {
+	ID              int32
+	UserID          uint64
+	Quote           string
+	Date            pgtype.Timestamp
+	Favorite        pgtype.Int8
+	Tokens          int32
+	AnilistUrl      string
+	DiscordUsername string
+	DiscordAvatar   string
+	LastUpdated     pgtype.Timestamp
+}
@@ -0,0 +1,109 @@
+-- name: Record :exec
+INSERT INTO
+  ownership_events (character_id, kind, from_user_id, to_user_id, tokens, reference_id, created_at)
+VALUES
+  ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: ListByCharacter :many
+SELECT
+  *
+FROM
+  ownership_events
+WHERE
+  character_id = $1
+ORDER BY
+  id DESC
+LIMIT
+  $2;
+
+-- name: RecordTokenChange :exec
+INSERT INTO
+  token_ledger (user_id, amount, balance, reason, counterparty_id, reference_id, created_at)
+VALUES
+  ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: ListTokenChanges :many
+SELECT
+  *
+FROM
+  token_ledger
+WHERE
+  user_id = $1
+ORDER BY
+  id DESC
+LIMIT
+  $2;
+
+-- name: TokenBalanceMismatches :many
+SELECT
+  users.user_id,
+  users.tokens,
+  COALESCE(SUM(token_ledger.amount), 0)::INTEGER AS ledger_total
+FROM
+  users
+  LEFT JOIN token_ledger ON token_ledger.user_id = users.user_id
+GROUP BY
+  users.user_id,
+  users.tokens
+HAVING
+  users.tokens <> COALESCE(SUM(token_ledger.amount), 0);
+
+-- name: CountReceived :one
+SELECT
+  COUNT(*)
+FROM
+  ownership_events
+WHERE
+  to_user_id = $1
+  AND kind = $2;
+
+-- name: ListAfter :many
+-- guild_ids are the indexed guilds either user of the event is a member of.
+SELECT
+  e.id,
+  e.character_id,
+  e.kind,
+  e.from_user_id,
+  e.to_user_id,
+  e.tokens,
+  e.reference_id,
+  e.created_at,
+  COALESCE(c.name, '')::TEXT AS character_name,
+  COALESCE(c.image, '')::TEXT AS character_image,
+  COALESCE(c.favorites, 0)::INTEGER AS character_favorites,
+  COALESCE(fu.discord_username, '')::TEXT AS from_username,
+  COALESCE(tu.discord_username, '')::TEXT AS to_username,
+  COALESCE(ARRAY_AGG(DISTINCT gm.guild_id) FILTER (WHERE gm.guild_id IS NOT NULL), '{}')::BIGINT[] AS guild_ids
+FROM
+  ownership_events e
+  LEFT JOIN characters c ON c.id = e.character_id
+  LEFT JOIN users fu ON fu.user_id = e.from_user_id
+  LEFT JOIN users tu ON tu.user_id = e.to_user_id
+  LEFT JOIN guild_members gm ON gm.user_id IN (e.from_user_id, e.to_user_id)
+WHERE
+  e.id > sqlc.arg(after_id)
+  AND (
+    sqlc.narg(user_id)::BIGINT IS NULL
+    OR e.from_user_id = sqlc.narg(user_id)::BIGINT
+    OR e.to_user_id = sqlc.narg(user_id)::BIGINT
+  )
+GROUP BY
+  e.id,
+  c.name,
+  c.image,
+  c.favorites,
+  fu.discord_username,
+  tu.discord_username
+HAVING
+  sqlc.narg(guild_id)::BIGINT IS NULL
+  OR sqlc.narg(guild_id)::BIGINT = ANY (ARRAY_AGG(gm.guild_id))
+ORDER BY
+  e.id
+LIMIT
+  sqlc.arg(lim);
+
+-- name: LatestEventID :one
+SELECT
+  COALESCE(MAX(id), 0)::BIGINT
+FROM
+  ownership_events;
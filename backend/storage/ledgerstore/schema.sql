@@ -0,0 +1,54 @@
+CREATE TABLE public.users (
+  id INTEGER NOT NULL,
+  user_id BIGINT NOT NULL,
+  quote TEXT DEFAULT ''::TEXT NOT NULL,
+  date TIMESTAMP WITHOUT TIME ZONE DEFAULT '1970-01-01 00:00:00'::TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+  favorite BIGINT,
+  tokens INTEGER DEFAULT 0 NOT NULL,
+  anilist_url CHARACTER VARYING(255) DEFAULT ''::CHARACTER VARYING NOT NULL,
+  discord_username CHARACTER VARYING(32) DEFAULT ''::CHARACTER VARYING NOT NULL UNIQUE,
+  discord_avatar CHARACTER VARYING(34) DEFAULT ''::CHARACTER VARYING NOT NULL,
+  last_updated TIMESTAMP WITHOUT TIME ZONE DEFAULT '1970-01-01 00:00:00'::TIMESTAMP WITHOUT TIME ZONE NOT NULL
+);
+
+CREATE TABLE public.ownership_events (
+  id BIGSERIAL PRIMARY KEY,
+  character_id BIGINT NOT NULL,
+  kind TEXT NOT NULL,
+  from_user_id BIGINT,
+  to_user_id BIGINT,
+  tokens INTEGER NOT NULL DEFAULT 0,
+  reference_id BIGINT,
+  created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+  CHECK (from_user_id IS NOT NULL OR to_user_id IS NOT NULL)
+);
+
+CREATE INDEX idx_ownership_events_character ON public.ownership_events (character_id, id DESC);
+CREATE INDEX idx_ownership_events_from_user ON public.ownership_events (from_user_id) WHERE from_user_id IS NOT NULL;
+CREATE INDEX idx_ownership_events_to_user ON public.ownership_events (to_user_id) WHERE to_user_id IS NOT NULL;
+
+CREATE TABLE public.token_ledger (
+  id BIGSERIAL PRIMARY KEY,
+  user_id BIGINT NOT NULL,
+  amount INTEGER NOT NULL CHECK (amount <> 0),
+  balance INTEGER NOT NULL,
+  reason TEXT NOT NULL,
+  counterparty_id BIGINT,
+  reference_id BIGINT,
+  created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_token_ledger_user ON public.token_ledger (user_id, id DESC);
+
+CREATE TABLE public.characters (
+  id BIGINT NOT NULL,
+  name CHARACTER VARYING(128) NOT NULL,
+  image CHARACTER VARYING(256) NOT NULL,
+  favorites INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE public.guild_members (
+  guild_id BIGINT NOT NULL,
+  user_id BIGINT NOT NULL,
+  indexed_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NOW() NOT NULL
+);
@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Listen holds a connection of the pool listening on channel. notify is called
+// with an empty payload once listening starts, so callers can catch up on what
+// they missed, then with the payload of every notification. It returns nil when
+// ctx is done, and the error otherwise.
+func (s *DBStore) Listen(ctx context.Context, channel string, notify func(payload string)) error {
+	pool, ok := s.db.(*pgxpool.Pool)
+	if !ok {
+		return errors.New("listening needs a connection pool, not a transaction")
+	}
+
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// A closed connection is dropped by the pool, a live one must stop listening
+	// before another caller gets it.
+	defer conn.Release()
+	defer func() { _, _ = conn.Exec(context.WithoutCancel(ctx), "UNLISTEN *") }()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return err
+	}
+	notify("")
+
+	for {
+		n, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		notify(n.Payload)
+	}
+}
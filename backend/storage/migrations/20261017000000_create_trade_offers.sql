@@ -0,0 +1,21 @@
+-- migrate:up
+CREATE TABLE IF NOT EXISTS trade_offers (
+  id BIGSERIAL PRIMARY KEY,
+  from_user_id BIGINT NOT NULL,
+  to_user_id BIGINT NOT NULL,
+  offered_character_ids BIGINT[] NOT NULL DEFAULT '{}',
+  requested_character_ids BIGINT[] NOT NULL DEFAULT '{}',
+  offered_tokens INTEGER NOT NULL DEFAULT 0 CHECK (offered_tokens >= 0),
+  requested_tokens INTEGER NOT NULL DEFAULT 0 CHECK (requested_tokens >= 0),
+  status TEXT NOT NULL DEFAULT 'pending',
+  created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+  expires_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+  CHECK (from_user_id <> to_user_id)
+);
+CREATE INDEX IF NOT EXISTS idx_trade_offers_pending_from ON trade_offers (from_user_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_trade_offers_pending_to ON trade_offers (to_user_id) WHERE status = 'pending';
+
+-- migrate:down
+DROP INDEX IF EXISTS idx_trade_offers_pending_to;
+DROP INDEX IF EXISTS idx_trade_offers_pending_from;
+DROP TABLE IF EXISTS trade_offers;
@@ -0,0 +1,29 @@
+-- migrate:up
+CREATE TABLE IF NOT EXISTS auctions (
+  id BIGSERIAL PRIMARY KEY,
+  seller_id BIGINT NOT NULL,
+  character_id BIGINT NOT NULL,
+  channel_id BIGINT NOT NULL,
+  start_price INTEGER NOT NULL CHECK (start_price >= 0),
+  high_bid INTEGER NOT NULL DEFAULT 0,
+  high_bidder_id BIGINT NOT NULL DEFAULT 0,
+  status TEXT NOT NULL DEFAULT 'open',
+  created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+  ends_at TIMESTAMP WITHOUT TIME ZONE NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_auctions_open_seller_character ON auctions (seller_id, character_id) WHERE status = 'open';
+CREATE INDEX IF NOT EXISTS idx_auctions_open_ends_at ON auctions (ends_at) WHERE status = 'open';
+
+CREATE TABLE IF NOT EXISTS auction_escrow (
+  auction_id BIGINT NOT NULL REFERENCES auctions (id) ON DELETE CASCADE,
+  user_id BIGINT NOT NULL,
+  amount INTEGER NOT NULL CHECK (amount > 0),
+  updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (auction_id, user_id)
+);
+
+-- migrate:down
+DROP TABLE IF EXISTS auction_escrow;
+DROP INDEX IF EXISTS idx_auctions_open_ends_at;
+DROP INDEX IF EXISTS idx_auctions_open_seller_character;
+DROP TABLE IF EXISTS auctions;
@@ -0,0 +1,11 @@
+-- migrate:up
+CREATE TABLE IF NOT EXISTS guild_settings (
+  guild_id BIGINT PRIMARY KEY,
+  roll_cooldown_seconds INTEGER CHECK (roll_cooldown_seconds >= 0),
+  interaction_needed BIGINT CHECK (interaction_needed > 0),
+  series_roll_cost INTEGER CHECK (series_roll_cost >= 0),
+  updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- migrate:down
+DROP TABLE IF EXISTS guild_settings;
@@ -0,0 +1,13 @@
+-- migrate:up
+ALTER TABLE guild_settings ADD COLUMN IF NOT EXISTS drop_channel_id BIGINT;
+
+CREATE TABLE IF NOT EXISTS guild_drop_channels (
+  guild_id BIGINT NOT NULL,
+  channel_id BIGINT NOT NULL,
+  created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (guild_id, channel_id)
+);
+
+-- migrate:down
+DROP TABLE IF EXISTS guild_drop_channels;
+ALTER TABLE guild_settings DROP COLUMN IF EXISTS drop_channel_id;
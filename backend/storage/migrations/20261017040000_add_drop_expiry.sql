@@ -0,0 +1,15 @@
+-- migrate:up
+ALTER TABLE public.channel_drops
+ADD COLUMN IF NOT EXISTS message_id BIGINT,
+ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (NOW() AT TIME ZONE 'utc') + INTERVAL '30 minutes';
+
+ALTER TABLE public.channel_drops
+ALTER COLUMN expires_at DROP DEFAULT;
+
+CREATE INDEX IF NOT EXISTS idx_channel_drops_expires_at ON public.channel_drops (expires_at);
+
+-- migrate:down
+DROP INDEX IF EXISTS idx_channel_drops_expires_at;
+ALTER TABLE public.channel_drops
+DROP COLUMN IF EXISTS expires_at,
+DROP COLUMN IF EXISTS message_id;
@@ -0,0 +1,7 @@
+-- migrate:up
+ALTER TABLE public.characters
+ADD COLUMN IF NOT EXISTS alternative_names TEXT[] NOT NULL DEFAULT '{}';
+
+-- migrate:down
+ALTER TABLE public.characters
+DROP COLUMN IF EXISTS alternative_names;
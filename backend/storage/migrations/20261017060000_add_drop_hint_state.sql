@@ -0,0 +1,9 @@
+-- migrate:up
+ALTER TABLE public.channel_drops
+ADD COLUMN IF NOT EXISTS failed_attempts INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS hints_shown INTEGER NOT NULL DEFAULT 0;
+
+-- migrate:down
+ALTER TABLE public.channel_drops
+DROP COLUMN IF EXISTS hints_shown,
+DROP COLUMN IF EXISTS failed_attempts;
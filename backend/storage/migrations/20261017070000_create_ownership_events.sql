@@ -0,0 +1,34 @@
+-- migrate:up
+CREATE TABLE IF NOT EXISTS ownership_events (
+  id BIGSERIAL PRIMARY KEY,
+  character_id BIGINT NOT NULL,
+  kind TEXT NOT NULL,
+  from_user_id BIGINT,
+  to_user_id BIGINT,
+  tokens INTEGER NOT NULL DEFAULT 0,
+  reference_id BIGINT,
+  created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+  CHECK (from_user_id IS NOT NULL OR to_user_id IS NOT NULL)
+);
+CREATE INDEX IF NOT EXISTS idx_ownership_events_character ON ownership_events (character_id, id DESC);
+CREATE INDEX IF NOT EXISTS idx_ownership_events_from_user ON ownership_events (from_user_id) WHERE from_user_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_ownership_events_to_user ON ownership_events (to_user_id) WHERE to_user_id IS NOT NULL;
+
+-- The ledger settles disputes, so rows can only ever be appended.
+CREATE OR REPLACE FUNCTION ownership_events_append_only() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION 'ownership_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER ownership_events_append_only
+BEFORE UPDATE OR DELETE ON ownership_events
+FOR EACH ROW EXECUTE FUNCTION ownership_events_append_only();
+
+-- migrate:down
+DROP TRIGGER IF EXISTS ownership_events_append_only ON ownership_events;
+DROP FUNCTION IF EXISTS ownership_events_append_only();
+DROP INDEX IF EXISTS idx_ownership_events_to_user;
+DROP INDEX IF EXISTS idx_ownership_events_from_user;
+DROP INDEX IF EXISTS idx_ownership_events_character;
+DROP TABLE IF EXISTS ownership_events;
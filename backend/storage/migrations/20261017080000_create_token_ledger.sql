@@ -0,0 +1,41 @@
+-- migrate:up
+CREATE TABLE IF NOT EXISTS token_ledger (
+  id BIGSERIAL PRIMARY KEY,
+  user_id BIGINT NOT NULL,
+  amount INTEGER NOT NULL CHECK (amount <> 0),
+  balance INTEGER NOT NULL,
+  reason TEXT NOT NULL,
+  counterparty_id BIGINT,
+  reference_id BIGINT,
+  created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_token_ledger_user ON token_ledger (user_id, id DESC);
+
+-- Balances that predate the ledger are carried over as one opening entry per user.
+INSERT INTO
+  token_ledger (user_id, amount, balance, reason)
+SELECT
+  user_id,
+  tokens,
+  tokens,
+  'opening_balance'
+FROM
+  users
+WHERE
+  tokens <> 0;
+
+CREATE OR REPLACE FUNCTION token_ledger_append_only() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION 'token_ledger is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER token_ledger_append_only
+BEFORE UPDATE OR DELETE ON token_ledger
+FOR EACH ROW EXECUTE FUNCTION token_ledger_append_only();
+
+-- migrate:down
+DROP TRIGGER IF EXISTS token_ledger_append_only ON token_ledger;
+DROP FUNCTION IF EXISTS token_ledger_append_only();
+DROP INDEX IF EXISTS idx_token_ledger_user;
+DROP TABLE IF EXISTS token_ledger;
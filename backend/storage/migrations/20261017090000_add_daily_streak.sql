@@ -0,0 +1,9 @@
+-- migrate:up
+ALTER TABLE users
+ADD COLUMN IF NOT EXISTS daily_streak INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS last_daily TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT '1970-01-01 00:00:00'::TIMESTAMP WITHOUT TIME ZONE;
+
+-- migrate:down
+ALTER TABLE users
+DROP COLUMN IF EXISTS last_daily,
+DROP COLUMN IF EXISTS daily_streak;
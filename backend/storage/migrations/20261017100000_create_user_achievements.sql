@@ -0,0 +1,10 @@
+-- migrate:up
+CREATE TABLE IF NOT EXISTS user_achievements (
+  user_id BIGINT NOT NULL,
+  achievement TEXT NOT NULL,
+  unlocked_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (user_id, achievement)
+);
+
+-- migrate:down
+DROP TABLE IF EXISTS user_achievements;
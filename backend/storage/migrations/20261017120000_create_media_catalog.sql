@@ -0,0 +1,26 @@
+-- migrate:up
+CREATE TABLE IF NOT EXISTS media (
+  id BIGINT PRIMARY KEY,
+  title TEXT NOT NULL,
+  type TEXT NOT NULL DEFAULT '',
+  cover_image TEXT NOT NULL DEFAULT '',
+  popularity INTEGER NOT NULL DEFAULT 0,
+  updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+  -- characters_synced_at is when every character of the media was last linked,
+  -- NULL while character_media only holds the links the sync worker found.
+  characters_synced_at TIMESTAMP WITHOUT TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS media_title_trgm_idx ON media USING GIN (title gin_trgm_ops);
+
+CREATE TABLE IF NOT EXISTS character_media (
+  character_id BIGINT NOT NULL REFERENCES characters (id) ON DELETE CASCADE,
+  media_id BIGINT NOT NULL REFERENCES media (id) ON DELETE CASCADE,
+  PRIMARY KEY (character_id, media_id)
+);
+
+CREATE INDEX IF NOT EXISTS character_media_media_id_idx ON character_media (media_id);
+
+-- migrate:down
+DROP TABLE IF EXISTS character_media;
+DROP TABLE IF EXISTS media;
@@ -0,0 +1,13 @@
+-- migrate:up
+CREATE TABLE IF NOT EXISTS anilist_cache (
+  query TEXT NOT NULL,
+  key TEXT NOT NULL,
+  value JSONB NOT NULL,
+  fetched_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+  PRIMARY KEY (query, key)
+);
+
+CREATE INDEX IF NOT EXISTS anilist_cache_fetched_at_idx ON anilist_cache (fetched_at);
+
+-- migrate:down
+DROP TABLE IF EXISTS anilist_cache;
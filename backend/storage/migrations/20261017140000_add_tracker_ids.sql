@@ -0,0 +1,18 @@
+-- migrate:up
+ALTER TABLE media ADD COLUMN IF NOT EXISTS mal_id BIGINT;
+
+CREATE INDEX IF NOT EXISTS media_mal_id_idx ON media (mal_id, type);
+
+CREATE TABLE IF NOT EXISTS tracker_characters (
+  provider TEXT NOT NULL,
+  external_id BIGINT NOT NULL,
+  character_id BIGINT NOT NULL REFERENCES characters (id) ON DELETE CASCADE,
+  PRIMARY KEY (provider, external_id)
+);
+
+-- migrate:down
+DROP TABLE IF EXISTS tracker_characters;
+
+DROP INDEX IF EXISTS media_mal_id_idx;
+
+ALTER TABLE media DROP COLUMN IF EXISTS mal_id;
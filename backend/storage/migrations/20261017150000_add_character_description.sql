@@ -0,0 +1,5 @@
+-- migrate:up
+ALTER TABLE characters ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '';
+
+-- migrate:down
+ALTER TABLE characters DROP COLUMN IF EXISTS description;
@@ -0,0 +1,103 @@
+-- migrate:up
+-- rarity_tier classifies a favorites count like collection.RarityFromFavorites:
+-- 0 is Common, 1 Uncommon, 2 Rare and 3 Legendary. Change both together,
+-- TestIntegration_RarityTier checks they agree.
+CREATE FUNCTION rarity_tier(favorites INTEGER) RETURNS SMALLINT AS $$
+  SELECT
+    CASE
+      WHEN favorites >= 5000 THEN 3
+      WHEN favorites >= 1000 THEN 2
+      WHEN favorites >= 100 THEN 1
+      ELSE 0
+    END::SMALLINT;
+$$ LANGUAGE SQL IMMUTABLE PARALLEL SAFE;
+
+-- Rarity points are 1 for Common, 2 for Uncommon, 5 for Rare and 10 for
+-- Legendary. Series are completed as in achievements: every active character
+-- of a media with at least collection.SeriesMinCharacters of them is owned.
+CREATE MATERIALIZED VIEW leaderboard_stats AS
+WITH
+  owned AS (
+    SELECT
+      c.user_id,
+      COUNT(*) AS collection_size,
+      SUM(
+        CASE rarity_tier(ch.favorites)
+          WHEN 3 THEN 10
+          WHEN 2 THEN 5
+          WHEN 1 THEN 2
+          ELSE 1
+        END
+      ) AS rarity_score,
+      COUNT(*) FILTER (
+        WHERE
+          rarity_tier(ch.favorites) = 3
+      ) AS legendary_count
+    FROM
+      collection c
+      JOIN characters ch ON ch.id = c.character_id
+    GROUP BY
+      c.user_id
+  ),
+  series_size AS (
+    SELECT
+      cm.media_id,
+      COUNT(*) AS total
+    FROM
+      character_media cm
+      JOIN media m ON m.id = cm.media_id
+      JOIN characters ch ON ch.id = cm.character_id
+    WHERE
+      ch.is_active
+      AND m.characters_synced_at IS NOT NULL
+    GROUP BY
+      cm.media_id
+    HAVING
+      COUNT(*) >= 5
+  ),
+  series_owned AS (
+    SELECT
+      c.user_id,
+      cm.media_id,
+      COUNT(*) AS owned
+    FROM
+      collection c
+      JOIN character_media cm ON cm.character_id = c.character_id
+      JOIN characters ch ON ch.id = c.character_id
+    WHERE
+      ch.is_active
+    GROUP BY
+      c.user_id,
+      cm.media_id
+  ),
+  completed AS (
+    SELECT
+      so.user_id,
+      COUNT(*) AS series_completed
+    FROM
+      series_owned so
+      JOIN series_size ss ON ss.media_id = so.media_id
+      AND so.owned = ss.total
+    GROUP BY
+      so.user_id
+  )
+SELECT
+  u.user_id,
+  COALESCE(o.collection_size, 0)::BIGINT AS collection_size,
+  COALESCE(o.rarity_score, 0)::BIGINT AS rarity_score,
+  COALESCE(o.legendary_count, 0)::BIGINT AS legendary_count,
+  u.tokens::BIGINT AS tokens,
+  COALESCE(s.series_completed, 0)::BIGINT AS series_completed,
+  NOW()::TIMESTAMP AS refreshed_at
+FROM
+  users u
+  LEFT JOIN owned o ON o.user_id = u.user_id
+  LEFT JOIN completed s ON s.user_id = u.user_id;
+
+-- REFRESH ... CONCURRENTLY needs a unique index.
+CREATE UNIQUE INDEX leaderboard_stats_user_id_idx ON leaderboard_stats (user_id);
+
+-- migrate:down
+DROP MATERIALIZED VIEW IF EXISTS leaderboard_stats;
+
+DROP FUNCTION IF EXISTS rarity_tier(INTEGER);
@@ -0,0 +1,10 @@
+-- migrate:up
+CREATE TABLE IF NOT EXISTS collection_value_history (
+  user_id BIGINT NOT NULL,
+  day DATE NOT NULL,
+  value BIGINT NOT NULL,
+  PRIMARY KEY (user_id, day)
+);
+
+-- migrate:down
+DROP TABLE IF EXISTS collection_value_history;
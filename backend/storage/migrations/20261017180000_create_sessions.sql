@@ -0,0 +1,14 @@
+-- migrate:up
+CREATE TABLE IF NOT EXISTS sessions (
+  token_hash BYTEA PRIMARY KEY,
+  user_id BIGINT NOT NULL,
+  created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+  expires_at TIMESTAMP WITHOUT TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS sessions_user_id_idx ON sessions (user_id);
+
+CREATE INDEX IF NOT EXISTS sessions_expires_at_idx ON sessions (expires_at);
+
+-- migrate:down
+DROP TABLE IF EXISTS sessions;
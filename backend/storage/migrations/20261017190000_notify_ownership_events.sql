@@ -0,0 +1,15 @@
+-- migrate:up
+CREATE FUNCTION public.notify_ownership_event() RETURNS TRIGGER AS $$
+BEGIN
+  PERFORM pg_notify('ownership_events', NEW.id::TEXT);
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER ownership_events_notify
+AFTER INSERT ON public.ownership_events
+FOR EACH ROW EXECUTE FUNCTION public.notify_ownership_event();
+
+-- migrate:down
+DROP TRIGGER IF EXISTS ownership_events_notify ON public.ownership_events;
+DROP FUNCTION IF EXISTS public.notify_ownership_event();
@@ -9,7 +9,9 @@ CREATE TABLE public.characters (
   name CHARACTER VARYING(128) CONSTRAINT characters_new_name_not_null NOT NULL,
   image CHARACTER VARYING(256) CONSTRAINT characters_new_image_not_null NOT NULL,
   media_title TEXT NOT NULL DEFAULT '',
-  favorites INTEGER NOT NULL DEFAULT 0
+  favorites INTEGER NOT NULL DEFAULT 0,
+  alternative_names TEXT[] NOT NULL DEFAULT '{}',
+  description TEXT NOT NULL DEFAULT ''
 );
 
 CREATE TABLE public.characters_backup (
@@ -52,7 +54,9 @@ CREATE TABLE public.users (
   anilist_url CHARACTER VARYING(255) DEFAULT ''::CHARACTER VARYING NOT NULL,
   discord_username CHARACTER VARYING(32) DEFAULT ''::CHARACTER VARYING NOT NULL UNIQUE,
   discord_avatar CHARACTER VARYING(34) DEFAULT ''::CHARACTER VARYING NOT NULL,
-  last_updated TIMESTAMP WITHOUT TIME ZONE DEFAULT '1970-01-01 00:00:00'::TIMESTAMP WITHOUT TIME ZONE NOT NULL
+  last_updated TIMESTAMP WITHOUT TIME ZONE DEFAULT '1970-01-01 00:00:00'::TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+  daily_streak INTEGER NOT NULL DEFAULT 0,
+  last_daily TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT '1970-01-01 00:00:00'::TIMESTAMP WITHOUT TIME ZONE
 );
 
 CREATE TABLE public.character_wishlist (
@@ -70,5 +74,259 @@ CREATE TABLE IF NOT EXISTS public.channel_interactions (channel_id BIGINT PRIMAR
 
 CREATE TABLE IF NOT EXISTS public.channel_drops (
   channel_id BIGINT PRIMARY KEY,
-  character_id BIGINT NOT NULL REFERENCES public.characters (id) ON DELETE CASCADE
+  character_id BIGINT NOT NULL REFERENCES public.characters (id) ON DELETE CASCADE,
+  message_id BIGINT,
+  expires_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+  failed_attempts INTEGER NOT NULL DEFAULT 0,
+  hints_shown INTEGER NOT NULL DEFAULT 0
 );
+
+CREATE INDEX IF NOT EXISTS idx_channel_drops_expires_at ON public.channel_drops (expires_at);
+
+CREATE TABLE public.trade_offers (
+  id BIGSERIAL PRIMARY KEY,
+  from_user_id BIGINT NOT NULL,
+  to_user_id BIGINT NOT NULL,
+  offered_character_ids BIGINT[] NOT NULL DEFAULT '{}',
+  requested_character_ids BIGINT[] NOT NULL DEFAULT '{}',
+  offered_tokens INTEGER NOT NULL DEFAULT 0,
+  requested_tokens INTEGER NOT NULL DEFAULT 0,
+  status TEXT NOT NULL DEFAULT 'pending',
+  created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+  expires_at TIMESTAMP WITHOUT TIME ZONE NOT NULL
+);
+
+CREATE TABLE public.auctions (
+  id BIGSERIAL PRIMARY KEY,
+  seller_id BIGINT NOT NULL,
+  character_id BIGINT NOT NULL,
+  channel_id BIGINT NOT NULL,
+  start_price INTEGER NOT NULL,
+  high_bid INTEGER NOT NULL DEFAULT 0,
+  high_bidder_id BIGINT NOT NULL DEFAULT 0,
+  status TEXT NOT NULL DEFAULT 'open',
+  created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+  ends_at TIMESTAMP WITHOUT TIME ZONE NOT NULL
+);
+
+CREATE TABLE public.auction_escrow (
+  auction_id BIGINT NOT NULL,
+  user_id BIGINT NOT NULL,
+  amount INTEGER NOT NULL,
+  updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS guild_settings (
+  guild_id BIGINT PRIMARY KEY,
+  roll_cooldown_seconds INTEGER CHECK (roll_cooldown_seconds >= 0),
+  interaction_needed BIGINT CHECK (interaction_needed > 0),
+  series_roll_cost INTEGER CHECK (series_roll_cost >= 0),
+  updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+  drop_channel_id BIGINT
+);
+
+CREATE TABLE IF NOT EXISTS guild_drop_channels (
+  guild_id BIGINT NOT NULL,
+  channel_id BIGINT NOT NULL,
+  created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (guild_id, channel_id)
+);
+
+CREATE TABLE public.ownership_events (
+  id BIGSERIAL PRIMARY KEY,
+  character_id BIGINT NOT NULL,
+  kind TEXT NOT NULL,
+  from_user_id BIGINT,
+  to_user_id BIGINT,
+  tokens INTEGER NOT NULL DEFAULT 0,
+  reference_id BIGINT,
+  created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+  CHECK (from_user_id IS NOT NULL OR to_user_id IS NOT NULL)
+);
+
+CREATE INDEX idx_ownership_events_character ON public.ownership_events (character_id, id DESC);
+CREATE INDEX idx_ownership_events_from_user ON public.ownership_events (from_user_id) WHERE from_user_id IS NOT NULL;
+CREATE INDEX idx_ownership_events_to_user ON public.ownership_events (to_user_id) WHERE to_user_id IS NOT NULL;
+
+CREATE TABLE public.token_ledger (
+  id BIGSERIAL PRIMARY KEY,
+  user_id BIGINT NOT NULL,
+  amount INTEGER NOT NULL CHECK (amount <> 0),
+  balance INTEGER NOT NULL,
+  reason TEXT NOT NULL,
+  counterparty_id BIGINT,
+  reference_id BIGINT,
+  created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_token_ledger_user ON public.token_ledger (user_id, id DESC);
+
+CREATE TABLE public.user_achievements (
+  user_id BIGINT NOT NULL,
+  achievement TEXT NOT NULL,
+  unlocked_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (user_id, achievement)
+);
+
+CREATE TABLE public.media (
+  id BIGINT PRIMARY KEY,
+  title TEXT NOT NULL,
+  type TEXT NOT NULL DEFAULT '',
+  cover_image TEXT NOT NULL DEFAULT '',
+  popularity INTEGER NOT NULL DEFAULT 0,
+  updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+  characters_synced_at TIMESTAMP WITHOUT TIME ZONE,
+  mal_id BIGINT
+);
+
+CREATE INDEX media_title_trgm_idx ON public.media USING GIN (title gin_trgm_ops);
+
+CREATE INDEX media_mal_id_idx ON public.media (mal_id, type);
+
+CREATE TABLE public.character_media (
+  character_id BIGINT NOT NULL REFERENCES public.characters (id) ON DELETE CASCADE,
+  media_id BIGINT NOT NULL REFERENCES public.media (id) ON DELETE CASCADE,
+  PRIMARY KEY (character_id, media_id)
+);
+
+CREATE INDEX character_media_media_id_idx ON public.character_media (media_id);
+
+CREATE TABLE public.anilist_cache (
+  query TEXT NOT NULL,
+  key TEXT NOT NULL,
+  value JSONB NOT NULL,
+  fetched_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+  PRIMARY KEY (query, key)
+);
+
+CREATE INDEX anilist_cache_fetched_at_idx ON public.anilist_cache (fetched_at);
+
+CREATE TABLE public.tracker_characters (
+  provider TEXT NOT NULL,
+  external_id BIGINT NOT NULL,
+  character_id BIGINT NOT NULL REFERENCES public.characters (id) ON DELETE CASCADE,
+  PRIMARY KEY (provider, external_id)
+);
+
+-- rarity_tier classifies a favorites count like collection.RarityFromFavorites:
+-- 0 is Common, 1 Uncommon, 2 Rare and 3 Legendary. Change both together,
+-- TestIntegration_RarityTier checks they agree.
+CREATE FUNCTION public.rarity_tier(favorites INTEGER) RETURNS SMALLINT AS $$
+  SELECT
+    CASE
+      WHEN favorites >= 5000 THEN 3
+      WHEN favorites >= 1000 THEN 2
+      WHEN favorites >= 100 THEN 1
+      ELSE 0
+    END::SMALLINT;
+$$ LANGUAGE SQL IMMUTABLE PARALLEL SAFE;
+
+CREATE MATERIALIZED VIEW public.leaderboard_stats AS
+WITH
+  owned AS (
+    SELECT
+      c.user_id,
+      COUNT(*) AS collection_size,
+      SUM(
+        CASE public.rarity_tier(ch.favorites)
+          WHEN 3 THEN 10
+          WHEN 2 THEN 5
+          WHEN 1 THEN 2
+          ELSE 1
+        END
+      ) AS rarity_score,
+      COUNT(*) FILTER (
+        WHERE
+          public.rarity_tier(ch.favorites) = 3
+      ) AS legendary_count
+    FROM
+      public.collection c
+      JOIN public.characters ch ON ch.id = c.character_id
+    GROUP BY
+      c.user_id
+  ),
+  series_size AS (
+    SELECT
+      cm.media_id,
+      COUNT(*) AS total
+    FROM
+      public.character_media cm
+      JOIN public.media m ON m.id = cm.media_id
+      JOIN public.characters ch ON ch.id = cm.character_id
+    WHERE
+      ch.is_active
+      AND m.characters_synced_at IS NOT NULL
+    GROUP BY
+      cm.media_id
+    HAVING
+      COUNT(*) >= 5
+  ),
+  series_owned AS (
+    SELECT
+      c.user_id,
+      cm.media_id,
+      COUNT(*) AS owned
+    FROM
+      public.collection c
+      JOIN public.character_media cm ON cm.character_id = c.character_id
+      JOIN public.characters ch ON ch.id = c.character_id
+    WHERE
+      ch.is_active
+    GROUP BY
+      c.user_id,
+      cm.media_id
+  ),
+  completed AS (
+    SELECT
+      so.user_id,
+      COUNT(*) AS series_completed
+    FROM
+      series_owned so
+      JOIN series_size ss ON ss.media_id = so.media_id
+      AND so.owned = ss.total
+    GROUP BY
+      so.user_id
+  )
+SELECT
+  u.user_id,
+  COALESCE(o.collection_size, 0)::BIGINT AS collection_size,
+  COALESCE(o.rarity_score, 0)::BIGINT AS rarity_score,
+  COALESCE(o.legendary_count, 0)::BIGINT AS legendary_count,
+  u.tokens::BIGINT AS tokens,
+  COALESCE(s.series_completed, 0)::BIGINT AS series_completed,
+  NOW()::TIMESTAMP AS refreshed_at
+FROM
+  public.users u
+  LEFT JOIN owned o ON o.user_id = u.user_id
+  LEFT JOIN completed s ON s.user_id = u.user_id;
+
+CREATE UNIQUE INDEX leaderboard_stats_user_id_idx ON public.leaderboard_stats (user_id);
+
+CREATE TABLE public.collection_value_history (
+  user_id BIGINT NOT NULL,
+  day DATE NOT NULL,
+  value BIGINT NOT NULL,
+  PRIMARY KEY (user_id, day)
+);
+
+CREATE TABLE public.sessions (
+  token_hash BYTEA PRIMARY KEY,
+  user_id BIGINT NOT NULL,
+  created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+  expires_at TIMESTAMP WITHOUT TIME ZONE NOT NULL
+);
+
+CREATE INDEX sessions_user_id_idx ON public.sessions (user_id);
+
+CREATE INDEX sessions_expires_at_idx ON public.sessions (expires_at);
+
+CREATE FUNCTION public.notify_ownership_event() RETURNS TRIGGER AS $$
+BEGIN
+  PERFORM pg_notify('ownership_events', NEW.id::TEXT);
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER ownership_events_notify
+AFTER INSERT ON public.ownership_events
+FOR EACH ROW EXECUTE FUNCTION public.notify_ownership_event();
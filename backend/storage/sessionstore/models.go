@@ -0,0 +1,16 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+
+package sessionstore
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type Session struct {
+	TokenHash []byte
+	UserID    uint64
+	CreatedAt pgtype.Timestamp
+	ExpiresAt pgtype.Timestamp
+}
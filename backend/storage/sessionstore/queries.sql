@@ -0,0 +1,25 @@
+-- name: CreateSession :exec
+INSERT INTO
+  sessions (token_hash, user_id, expires_at)
+VALUES
+  ($1, $2, $3);
+
+-- name: GetSession :one
+SELECT
+  user_id,
+  expires_at
+FROM
+  sessions
+WHERE
+  token_hash = $1
+  AND expires_at > sqlc.arg(now)::TIMESTAMP;
+
+-- name: DeleteSession :exec
+DELETE FROM sessions
+WHERE
+  token_hash = $1;
+
+-- name: DeleteExpiredSessions :execrows
+DELETE FROM sessions
+WHERE
+  expires_at <= sqlc.arg(now)::TIMESTAMP;
@@ -0,0 +1,6 @@
+CREATE TABLE public.sessions (
+  token_hash BYTEA PRIMARY KEY,
+  user_id BIGINT NOT NULL,
+  created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+  expires_at TIMESTAMP WITHOUT TIME ZONE NOT NULL
+);
@@ -0,0 +1,24 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+
+package settingsstore
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type GuildDropChannel struct {
+	GuildID   uint64
+	ChannelID uint64
+	CreatedAt pgtype.Timestamp
+}
+
+type GuildSetting struct {
+	GuildID             uint64
+	RollCooldownSeconds pgtype.Int4
+	InteractionNeeded   pgtype.Int8
+	SeriesRollCost      pgtype.Int4
+	UpdatedAt           pgtype.Timestamp
+	DropChannelID       pgtype.Int8
+}
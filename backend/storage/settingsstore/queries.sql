@@ -0,0 +1,48 @@
+-- name: Get :one
+SELECT
+  *
+FROM
+  guild_settings
+WHERE
+  guild_id = $1;
+
+-- name: Upsert :exec
+INSERT INTO
+  guild_settings (guild_id, roll_cooldown_seconds, interaction_needed, series_roll_cost, drop_channel_id)
+VALUES
+  ($1, $2, $3, $4, $5)
+ON CONFLICT (guild_id) DO UPDATE
+SET
+  roll_cooldown_seconds = excluded.roll_cooldown_seconds,
+  interaction_needed = excluded.interaction_needed,
+  series_roll_cost = excluded.series_roll_cost,
+  drop_channel_id = excluded.drop_channel_id,
+  updated_at = NOW();
+
+-- name: Delete :exec
+DELETE FROM guild_settings
+WHERE
+  guild_id = $1;
+
+-- name: ListDropChannels :many
+SELECT
+  channel_id
+FROM
+  guild_drop_channels
+WHERE
+  guild_id = $1
+ORDER BY
+  channel_id;
+
+-- name: AddDropChannel :exec
+INSERT INTO
+  guild_drop_channels (guild_id, channel_id)
+VALUES
+  ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: RemoveDropChannel :execrows
+DELETE FROM guild_drop_channels
+WHERE
+  guild_id = $1
+  AND channel_id = $2;
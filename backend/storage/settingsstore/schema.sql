@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS guild_settings (
+  guild_id BIGINT PRIMARY KEY,
+  roll_cooldown_seconds INTEGER CHECK (roll_cooldown_seconds >= 0),
+  interaction_needed BIGINT CHECK (interaction_needed > 0),
+  series_roll_cost INTEGER CHECK (series_roll_cost >= 0),
+  updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+  drop_channel_id BIGINT
+);
+
+CREATE TABLE IF NOT EXISTS guild_drop_channels (
+  guild_id BIGINT NOT NULL,
+  channel_id BIGINT NOT NULL,
+  created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (guild_id, channel_id)
+);
@@ -10,12 +10,23 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/tracelog"
 
+	"github.com/karitham/waifubot/storage/achievementstore"
+	"github.com/karitham/waifubot/storage/anilistcachestore"
+	"github.com/karitham/waifubot/storage/auctionstore"
+	"github.com/karitham/waifubot/storage/catalogstore"
 	"github.com/karitham/waifubot/storage/collectionstore"
 	"github.com/karitham/waifubot/storage/commandstore"
 	"github.com/karitham/waifubot/storage/dropstore"
 	"github.com/karitham/waifubot/storage/guildstore"
 	"github.com/karitham/waifubot/storage/interactionstore"
+	"github.com/karitham/waifubot/storage/leaderboardstore"
+	"github.com/karitham/waifubot/storage/ledgerstore"
+	"github.com/karitham/waifubot/storage/sessionstore"
+	"github.com/karitham/waifubot/storage/settingsstore"
+	"github.com/karitham/waifubot/storage/trackerstore"
+	"github.com/karitham/waifubot/storage/tradestore"
 	"github.com/karitham/waifubot/storage/userstore"
+	"github.com/karitham/waifubot/storage/valuestore"
 	"github.com/karitham/waifubot/storage/wishliststore"
 )
 
@@ -33,21 +44,43 @@ type Store interface {
 	GuildStore() guildstore.Querier
 	WishlistStore() wishliststore.Querier
 	CommandStore() commandstore.Querier
+	TradeStore() tradestore.Querier
+	LedgerStore() ledgerstore.Querier
+	AuctionStore() auctionstore.Querier
+	SettingsStore() settingsstore.Querier
+	AchievementStore() achievementstore.Querier
+	CatalogStore() catalogstore.Querier
+	AnilistCacheStore() anilistcachestore.Querier
+	TrackerStore() trackerstore.Querier
+	LeaderboardStore() leaderboardstore.Querier
+	ValueStore() valuestore.Querier
+	SessionStore() sessionstore.Querier
 	Tx(ctx context.Context) (Store, error)
 	Commit(ctx context.Context) error
 	Rollback(ctx context.Context) error
 }
 
 type DBStore struct {
-	interactionStore *interactionstore.Queries
-	dropStore        *dropstore.Queries
-	userStore        *userstore.Queries
-	collectionStore  *collectionstore.Queries
-	guildStore       *guildstore.Queries
-	wishlistStore    *wishliststore.Queries
-	commandStore     *commandstore.Queries
-	db               TXer
-	tx               pgx.Tx
+	interactionStore  *interactionstore.Queries
+	dropStore         *dropstore.Queries
+	userStore         *userstore.Queries
+	collectionStore   *collectionstore.Queries
+	guildStore        *guildstore.Queries
+	wishlistStore     *wishliststore.Queries
+	commandStore      *commandstore.Queries
+	tradeStore        *tradestore.Queries
+	ledgerStore       *ledgerstore.Queries
+	auctionStore      *auctionstore.Queries
+	settingsStore     *settingsstore.Queries
+	achievementStore  *achievementstore.Queries
+	catalogStore      *catalogstore.Queries
+	anilistCacheStore *anilistcachestore.Queries
+	trackerStore      *trackerstore.Queries
+	leaderboardStore  *leaderboardstore.Queries
+	valueStore        *valuestore.Queries
+	sessionStore      *sessionstore.Queries
+	db                TXer
+	tx                pgx.Tx
 }
 
 func NewStore(ctx context.Context, url string) (*DBStore, error) {
@@ -72,28 +105,50 @@ func NewStore(ctx context.Context, url string) (*DBStore, error) {
 	}
 
 	return &DBStore{
-		userStore:        userstore.New(conn),
-		collectionStore:  collectionstore.New(conn),
-		guildStore:       guildstore.New(conn),
-		wishlistStore:    wishliststore.New(conn),
-		commandStore:     commandstore.New(conn),
-		db:               conn,
-		interactionStore: interactionstore.New(conn),
-		dropStore:        dropstore.New(conn),
+		userStore:         userstore.New(conn),
+		collectionStore:   collectionstore.New(conn),
+		guildStore:        guildstore.New(conn),
+		wishlistStore:     wishliststore.New(conn),
+		commandStore:      commandstore.New(conn),
+		db:                conn,
+		interactionStore:  interactionstore.New(conn),
+		dropStore:         dropstore.New(conn),
+		tradeStore:        tradestore.New(conn),
+		ledgerStore:       ledgerstore.New(conn),
+		auctionStore:      auctionstore.New(conn),
+		settingsStore:     settingsstore.New(conn),
+		achievementStore:  achievementstore.New(conn),
+		catalogStore:      catalogstore.New(conn),
+		anilistCacheStore: anilistcachestore.New(conn),
+		trackerStore:      trackerstore.New(conn),
+		leaderboardStore:  leaderboardstore.New(conn),
+		valueStore:        valuestore.New(conn),
+		sessionStore:      sessionstore.New(conn),
 	}, nil
 }
 
 func (s *DBStore) withTx(tx pgx.Tx) *DBStore {
 	return &DBStore{
-		userStore:        s.userStore.WithTx(tx),
-		collectionStore:  s.collectionStore.WithTx(tx),
-		guildStore:       s.guildStore.WithTx(tx),
-		wishlistStore:    s.wishlistStore.WithTx(tx),
-		commandStore:     s.commandStore.WithTx(tx),
-		db:               tx,
-		interactionStore: s.interactionStore.WithTx(tx),
-		dropStore:        s.dropStore.WithTx(tx),
-		tx:               tx,
+		userStore:         s.userStore.WithTx(tx),
+		collectionStore:   s.collectionStore.WithTx(tx),
+		guildStore:        s.guildStore.WithTx(tx),
+		wishlistStore:     s.wishlistStore.WithTx(tx),
+		commandStore:      s.commandStore.WithTx(tx),
+		db:                tx,
+		interactionStore:  s.interactionStore.WithTx(tx),
+		dropStore:         s.dropStore.WithTx(tx),
+		tradeStore:        s.tradeStore.WithTx(tx),
+		ledgerStore:       s.ledgerStore.WithTx(tx),
+		auctionStore:      s.auctionStore.WithTx(tx),
+		settingsStore:     s.settingsStore.WithTx(tx),
+		achievementStore:  s.achievementStore.WithTx(tx),
+		catalogStore:      s.catalogStore.WithTx(tx),
+		anilistCacheStore: s.anilistCacheStore.WithTx(tx),
+		trackerStore:      s.trackerStore.WithTx(tx),
+		leaderboardStore:  s.leaderboardStore.WithTx(tx),
+		valueStore:        s.valueStore.WithTx(tx),
+		sessionStore:      s.sessionStore.WithTx(tx),
+		tx:                tx,
 	}
 }
 
@@ -169,6 +224,50 @@ func (s *DBStore) CommandStore() commandstore.Querier {
 	return s.commandStore
 }
 
+func (s *DBStore) TradeStore() tradestore.Querier {
+	return s.tradeStore
+}
+
+func (s *DBStore) LedgerStore() ledgerstore.Querier {
+	return s.ledgerStore
+}
+
+func (s *DBStore) AuctionStore() auctionstore.Querier {
+	return s.auctionStore
+}
+
+func (s *DBStore) SettingsStore() settingsstore.Querier {
+	return s.settingsStore
+}
+
+func (s *DBStore) AchievementStore() achievementstore.Querier {
+	return s.achievementStore
+}
+
+func (s *DBStore) CatalogStore() catalogstore.Querier {
+	return s.catalogStore
+}
+
+func (s *DBStore) AnilistCacheStore() anilistcachestore.Querier {
+	return s.anilistCacheStore
+}
+
+func (s *DBStore) TrackerStore() trackerstore.Querier {
+	return s.trackerStore
+}
+
+func (s *DBStore) LeaderboardStore() leaderboardstore.Querier {
+	return s.leaderboardStore
+}
+
+func (s *DBStore) ValueStore() valuestore.Querier {
+	return s.valueStore
+}
+
+func (s *DBStore) SessionStore() sessionstore.Querier {
+	return s.sessionStore
+}
+
 func (s *DBStore) Tx(ctx context.Context) (Store, error) {
 	tx, err := s.db.Begin(ctx)
 	if err != nil {
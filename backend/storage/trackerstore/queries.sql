@@ -0,0 +1,21 @@
+-- name: GetCharacterIDs :many
+SELECT
+  external_id,
+  character_id
+FROM
+  tracker_characters
+WHERE
+  provider = $1
+  AND external_id = ANY (sqlc.arg(external_ids)::BIGINT[]);
+
+-- name: PutCharacterIDs :exec
+-- The arrays are parallel, one entry per character.
+INSERT INTO
+  tracker_characters (provider, external_id, character_id)
+SELECT
+  sqlc.arg(provider),
+  unnest(sqlc.arg(external_ids)::BIGINT[]),
+  unnest(sqlc.arg(character_ids)::BIGINT[])
+ON CONFLICT (provider, external_id) DO UPDATE
+SET
+  character_id = excluded.character_id;
@@ -0,0 +1,75 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: queries.sql
+
+package trackerstore
+
+import (
+	"context"
+)
+
+const getCharacterIDs = `-- name: GetCharacterIDs :many
+SELECT
+  external_id,
+  character_id
+FROM
+  tracker_characters
+WHERE
+  provider = $1
+  AND external_id = ANY ($2::BIGINT[])
+`
+
+type GetCharacterIDsParams struct {
+	Provider    string
+	ExternalIds []int64
+}
+
+type GetCharacterIDsRow struct {
+	ExternalID  int64
+	CharacterID int64
+}
+
+func (q *Queries) GetCharacterIDs(ctx context.Context, arg GetCharacterIDsParams) ([]GetCharacterIDsRow, error) {
+	rows, err := q.db.Query(ctx, getCharacterIDs, arg.Provider, arg.ExternalIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCharacterIDsRow
+	for rows.Next() {
+		var i GetCharacterIDsRow
+		if err := rows.Scan(&i.ExternalID, &i.CharacterID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const putCharacterIDs = `-- name: PutCharacterIDs :exec
+INSERT INTO
+  tracker_characters (provider, external_id, character_id)
+SELECT
+  $1,
+  unnest($2::BIGINT[]),
+  unnest($3::BIGINT[])
+ON CONFLICT (provider, external_id) DO UPDATE
+SET
+  character_id = excluded.character_id
+`
+
+type PutCharacterIDsParams struct {
+	Provider     string
+	ExternalIds  []int64
+	CharacterIds []int64
+}
+
+// The arrays are parallel, one entry per character.
+func (q *Queries) PutCharacterIDs(ctx context.Context, arg PutCharacterIDsParams) error {
+	_, err := q.db.Exec(ctx, putCharacterIDs, arg.Provider, arg.ExternalIds, arg.CharacterIds)
+	return err
+}
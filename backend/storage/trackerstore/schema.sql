@@ -0,0 +1,6 @@
+CREATE TABLE public.tracker_characters (
+  provider TEXT NOT NULL,
+  external_id BIGINT NOT NULL,
+  character_id BIGINT NOT NULL,
+  PRIMARY KEY (provider, external_id)
+);
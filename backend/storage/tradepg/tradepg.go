@@ -0,0 +1,94 @@
+package tradepg
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/storage/tradestore"
+)
+
+type Pg struct {
+	Q tradestore.Querier
+}
+
+func New(q tradestore.Querier) *Pg {
+	return &Pg{Q: q}
+}
+
+func (p *Pg) CreateTradeOffer(ctx context.Context, offer collection.TradeOffer) (collection.TradeOffer, error) {
+	row, err := p.Q.Create(ctx, tradestore.CreateParams{
+		FromUserID:            offer.FromUserID,
+		ToUserID:              offer.ToUserID,
+		OfferedCharacterIds:   nonNil(offer.OfferedCharacters),
+		RequestedCharacterIds: nonNil(offer.RequestedCharacters),
+		OfferedTokens:         offer.OfferedTokens,
+		RequestedTokens:       offer.RequestedTokens,
+		ExpiresAt:             pgtype.Timestamp{Time: offer.ExpiresAt.UTC(), Valid: true},
+	})
+	if err != nil {
+		return collection.TradeOffer{}, err
+	}
+	return toTradeOffer(row), nil
+}
+
+func (p *Pg) GetTradeOfferForUpdate(ctx context.Context, id int64) (collection.TradeOffer, error) {
+	row, err := p.Q.GetForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return collection.TradeOffer{}, collection.ErrNotFound
+		}
+		return collection.TradeOffer{}, err
+	}
+	return toTradeOffer(row), nil
+}
+
+func (p *Pg) ListTradeOffers(ctx context.Context, userID collection.UserID, now time.Time) ([]collection.TradeOffer, error) {
+	rows, err := p.Q.ListPending(ctx, tradestore.ListPendingParams{
+		Now:    pgtype.Timestamp{Time: now.UTC(), Valid: true},
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	offers := make([]collection.TradeOffer, len(rows))
+	for i, r := range rows {
+		offers[i] = toTradeOffer(r)
+	}
+	return offers, nil
+}
+
+func (p *Pg) SetTradeOfferStatus(ctx context.Context, id int64, status collection.TradeStatus) error {
+	return p.Q.SetStatus(ctx, tradestore.SetStatusParams{ID: id, Status: string(status)})
+}
+
+func (p *Pg) ExpireTradeOffers(ctx context.Context, now time.Time) (int64, error) {
+	return p.Q.ExpirePending(ctx, pgtype.Timestamp{Time: now.UTC(), Valid: true})
+}
+
+func toTradeOffer(r tradestore.TradeOffer) collection.TradeOffer {
+	return collection.TradeOffer{
+		ID:                  r.ID,
+		FromUserID:          r.FromUserID,
+		ToUserID:            r.ToUserID,
+		OfferedCharacters:   r.OfferedCharacterIds,
+		RequestedCharacters: r.RequestedCharacterIds,
+		OfferedTokens:       r.OfferedTokens,
+		RequestedTokens:     r.RequestedTokens,
+		Status:              collection.TradeStatus(r.Status),
+		CreatedAt:           r.CreatedAt.Time,
+		ExpiresAt:           r.ExpiresAt.Time,
+	}
+}
+
+// nonNil avoids sending NULL for the NOT NULL array columns.
+func nonNil(ids []int64) []int64 {
+	if ids == nil {
+		return []int64{}
+	}
+	return ids
+}
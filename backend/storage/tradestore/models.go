@@ -0,0 +1,22 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+
+package tradestore
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type TradeOffer struct {
+	ID                    int64
+	FromUserID            uint64
+	ToUserID              uint64
+	OfferedCharacterIds   []int64
+	RequestedCharacterIds []int64
+	OfferedTokens         int32
+	RequestedTokens       int32
+	Status                string
+	CreatedAt             pgtype.Timestamp
+	ExpiresAt             pgtype.Timestamp
+}
@@ -0,0 +1,54 @@
+-- name: Create :one
+INSERT INTO
+  trade_offers (
+    from_user_id,
+    to_user_id,
+    offered_character_ids,
+    requested_character_ids,
+    offered_tokens,
+    requested_tokens,
+    expires_at
+  )
+VALUES
+  ($1, $2, $3, $4, $5, $6, $7)
+RETURNING
+  *;
+
+-- name: GetForUpdate :one
+SELECT
+  *
+FROM
+  trade_offers
+WHERE
+  id = $1
+FOR UPDATE;
+
+-- name: ListPending :many
+SELECT
+  *
+FROM
+  trade_offers
+WHERE
+  status = 'pending'
+  AND expires_at > sqlc.arg(now)::TIMESTAMP
+  AND (
+    from_user_id = sqlc.arg(user_id)
+    OR to_user_id = sqlc.arg(user_id)
+  )
+ORDER BY
+  created_at DESC;
+
+-- name: SetStatus :exec
+UPDATE trade_offers
+SET
+  status = $2
+WHERE
+  id = $1;
+
+-- name: ExpirePending :execrows
+UPDATE trade_offers
+SET
+  status = 'expired'
+WHERE
+  status = 'pending'
+  AND expires_at <= $1;
@@ -0,0 +1,180 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: queries.sql
+
+package tradestore
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const create = `-- name: Create :one
+INSERT INTO
+  trade_offers (
+    from_user_id,
+    to_user_id,
+    offered_character_ids,
+    requested_character_ids,
+    offered_tokens,
+    requested_tokens,
+    expires_at
+  )
+VALUES
+  ($1, $2, $3, $4, $5, $6, $7)
+RETURNING
+  id, from_user_id, to_user_id, offered_character_ids, requested_character_ids, offered_tokens, requested_tokens, status, created_at, expires_at
+`
+
+type CreateParams struct {
+	FromUserID            uint64
+	ToUserID              uint64
+	OfferedCharacterIds   []int64
+	RequestedCharacterIds []int64
+	OfferedTokens         int32
+	RequestedTokens       int32
+	ExpiresAt             pgtype.Timestamp
+}
+
+func (q *Queries) Create(ctx context.Context, arg CreateParams) (TradeOffer, error) {
+	row := q.db.QueryRow(ctx, create,
+		arg.FromUserID,
+		arg.ToUserID,
+		arg.OfferedCharacterIds,
+		arg.RequestedCharacterIds,
+		arg.OfferedTokens,
+		arg.RequestedTokens,
+		arg.ExpiresAt,
+	)
+	var i TradeOffer
+	err := row.Scan(
+		&i.ID,
+		&i.FromUserID,
+		&i.ToUserID,
+		&i.OfferedCharacterIds,
+		&i.RequestedCharacterIds,
+		&i.OfferedTokens,
+		&i.RequestedTokens,
+		&i.Status,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const expirePending = `-- name: ExpirePending :execrows
+UPDATE trade_offers
+SET
+  status = 'expired'
+WHERE
+  status = 'pending'
+  AND expires_at <= $1
+`
+
+func (q *Queries) ExpirePending(ctx context.Context, expiresAt pgtype.Timestamp) (int64, error) {
+	result, err := q.db.Exec(ctx, expirePending, expiresAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getForUpdate = `-- name: GetForUpdate :one
+SELECT
+  id, from_user_id, to_user_id, offered_character_ids, requested_character_ids, offered_tokens, requested_tokens, status, created_at, expires_at
+FROM
+  trade_offers
+WHERE
+  id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetForUpdate(ctx context.Context, id int64) (TradeOffer, error) {
+	row := q.db.QueryRow(ctx, getForUpdate, id)
+	var i TradeOffer
+	err := row.Scan(
+		&i.ID,
+		&i.FromUserID,
+		&i.ToUserID,
+		&i.OfferedCharacterIds,
+		&i.RequestedCharacterIds,
+		&i.OfferedTokens,
+		&i.RequestedTokens,
+		&i.Status,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const listPending = `-- name: ListPending :many
+SELECT
+  id, from_user_id, to_user_id, offered_character_ids, requested_character_ids, offered_tokens, requested_tokens, status, created_at, expires_at
+FROM
+  trade_offers
+WHERE
+  status = 'pending'
+  AND expires_at > $1::TIMESTAMP
+  AND (
+    from_user_id = $2
+    OR to_user_id = $2
+  )
+ORDER BY
+  created_at DESC
+`
+
+type ListPendingParams struct {
+	Now    pgtype.Timestamp
+	UserID uint64
+}
+
+func (q *Queries) ListPending(ctx context.Context, arg ListPendingParams) ([]TradeOffer, error) {
+	rows, err := q.db.Query(ctx, listPending, arg.Now, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TradeOffer
+	for rows.Next() {
+		var i TradeOffer
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromUserID,
+			&i.ToUserID,
+			&i.OfferedCharacterIds,
+			&i.RequestedCharacterIds,
+			&i.OfferedTokens,
+			&i.RequestedTokens,
+			&i.Status,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setStatus = `-- name: SetStatus :exec
+UPDATE trade_offers
+SET
+  status = $2
+WHERE
+  id = $1
+`
+
+type SetStatusParams struct {
+	ID     int64
+	Status string
+}
+
+func (q *Queries) SetStatus(ctx context.Context, arg SetStatusParams) error {
+	_, err := q.db.Exec(ctx, setStatus, arg.ID, arg.Status)
+	return err
+}
@@ -0,0 +1,12 @@
+CREATE TABLE public.trade_offers (
+  id BIGSERIAL PRIMARY KEY,
+  from_user_id BIGINT NOT NULL,
+  to_user_id BIGINT NOT NULL,
+  offered_character_ids BIGINT[] NOT NULL DEFAULT '{}',
+  requested_character_ids BIGINT[] NOT NULL DEFAULT '{}',
+  offered_tokens INTEGER NOT NULL DEFAULT 0,
+  requested_tokens INTEGER NOT NULL DEFAULT 0,
+  status TEXT NOT NULL DEFAULT 'pending',
+  created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+  expires_at TIMESTAMP WITHOUT TIME ZONE NOT NULL
+);
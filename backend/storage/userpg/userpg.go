@@ -119,6 +119,16 @@ func (p *Pg) UpdateDiscordInfo(ctx context.Context, userID collection.UserID, us
 	})
 }
 
+func (p *Pg) UpdateDailyStreak(ctx context.Context, userID collection.UserID, streak int32, previous, claimedAt time.Time) (bool, error) {
+	n, err := p.Q.UpdateDailyStreak(ctx, userstore.UpdateDailyStreakParams{
+		Streak:        streak,
+		ClaimedAt:     pgtype.Timestamp{Time: claimedAt.UTC(), Valid: true},
+		UserID:        userID,
+		PreviousClaim: pgtype.Timestamp{Time: previous.UTC(), Valid: true},
+	})
+	return n == 1, err
+}
+
 func toUser(u userstore.User) collection.User {
 	return collection.User{
 		UserID:          u.UserID,
@@ -130,5 +140,7 @@ func toUser(u userstore.User) collection.User {
 		DiscordUsername: u.DiscordUsername,
 		DiscordAvatar:   u.DiscordAvatar,
 		LastUpdated:     u.LastUpdated.Time,
+		DailyStreak:     u.DailyStreak,
+		LastDaily:       u.LastDaily.Time,
 	}
 }
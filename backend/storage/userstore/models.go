@@ -19,4 +19,6 @@ type User struct {
 	DiscordUsername string
 	DiscordAvatar   string
 	LastUpdated     pgtype.Timestamp
+	DailyStreak     int32
+	LastDaily       pgtype.Timestamp
 }
@@ -15,6 +15,7 @@ type Querier interface {
 	GetByDiscordUsername(ctx context.Context, discordUsername string) (User, error)
 	SpendTokens(ctx context.Context, arg SpendTokensParams) (User, error)
 	UpdateAnilistURL(ctx context.Context, arg UpdateAnilistURLParams) error
+	UpdateDailyStreak(ctx context.Context, arg UpdateDailyStreakParams) (int64, error)
 	UpdateDate(ctx context.Context, arg UpdateDateParams) error
 	UpdateDiscordInfo(ctx context.Context, arg UpdateDiscordInfoParams) error
 	UpdateFavorite(ctx context.Context, arg UpdateFavoriteParams) error
@@ -84,3 +84,12 @@ SET
   last_updated = $3
 WHERE
   user_id = $4;
+
+-- name: UpdateDailyStreak :execrows
+UPDATE users
+SET
+  daily_streak = sqlc.arg(streak),
+  last_daily = sqlc.arg(claimed_at)
+WHERE
+  user_id = sqlc.arg(user_id)
+  AND last_daily = sqlc.arg(previous_claim);
@@ -25,7 +25,7 @@ func (q *Queries) Create(ctx context.Context, userID uint64) error {
 
 const get = `-- name: Get :one
 SELECT
-  id, user_id, quote, date, favorite, tokens, anilist_url, discord_username, discord_avatar, last_updated
+  id, user_id, quote, date, favorite, tokens, anilist_url, discord_username, discord_avatar, last_updated, daily_streak, last_daily
 FROM
   users
 WHERE
@@ -46,13 +46,15 @@ func (q *Queries) Get(ctx context.Context, userID uint64) (User, error) {
 		&i.DiscordUsername,
 		&i.DiscordAvatar,
 		&i.LastUpdated,
+		&i.DailyStreak,
+		&i.LastDaily,
 	)
 	return i, err
 }
 
 const getByAnilist = `-- name: GetByAnilist :one
 SELECT
-  id, user_id, quote, date, favorite, tokens, anilist_url, discord_username, discord_avatar, last_updated
+  id, user_id, quote, date, favorite, tokens, anilist_url, discord_username, discord_avatar, last_updated, daily_streak, last_daily
 FROM
   users
 WHERE
@@ -73,13 +75,15 @@ func (q *Queries) GetByAnilist(ctx context.Context, lower string) (User, error)
 		&i.DiscordUsername,
 		&i.DiscordAvatar,
 		&i.LastUpdated,
+		&i.DailyStreak,
+		&i.LastDaily,
 	)
 	return i, err
 }
 
 const getByDiscordUsername = `-- name: GetByDiscordUsername :one
 SELECT
-  id, user_id, quote, date, favorite, tokens, anilist_url, discord_username, discord_avatar, last_updated
+  id, user_id, quote, date, favorite, tokens, anilist_url, discord_username, discord_avatar, last_updated, daily_streak, last_daily
 FROM
   users
 WHERE
@@ -101,6 +105,8 @@ func (q *Queries) GetByDiscordUsername(ctx context.Context, discordUsername stri
 		&i.DiscordUsername,
 		&i.DiscordAvatar,
 		&i.LastUpdated,
+		&i.DailyStreak,
+		&i.LastDaily,
 	)
 	return i, err
 }
@@ -113,7 +119,7 @@ WHERE
   user_id = $2
   AND tokens >= $1
 RETURNING
-  id, user_id, quote, date, favorite, tokens, anilist_url, discord_username, discord_avatar, last_updated
+  id, user_id, quote, date, favorite, tokens, anilist_url, discord_username, discord_avatar, last_updated, daily_streak, last_daily
 `
 
 type SpendTokensParams struct {
@@ -135,6 +141,8 @@ func (q *Queries) SpendTokens(ctx context.Context, arg SpendTokensParams) (User,
 		&i.DiscordUsername,
 		&i.DiscordAvatar,
 		&i.LastUpdated,
+		&i.DailyStreak,
+		&i.LastDaily,
 	)
 	return i, err
 }
@@ -157,6 +165,36 @@ func (q *Queries) UpdateAnilistURL(ctx context.Context, arg UpdateAnilistURLPara
 	return err
 }
 
+const updateDailyStreak = `-- name: UpdateDailyStreak :execrows
+UPDATE users
+SET
+  daily_streak = $1,
+  last_daily = $2
+WHERE
+  user_id = $3
+  AND last_daily = $4
+`
+
+type UpdateDailyStreakParams struct {
+	Streak        int32
+	ClaimedAt     pgtype.Timestamp
+	UserID        uint64
+	PreviousClaim pgtype.Timestamp
+}
+
+func (q *Queries) UpdateDailyStreak(ctx context.Context, arg UpdateDailyStreakParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateDailyStreak,
+		arg.Streak,
+		arg.ClaimedAt,
+		arg.UserID,
+		arg.PreviousClaim,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateDate = `-- name: UpdateDate :exec
 UPDATE users
 SET
@@ -245,7 +283,7 @@ SET
 WHERE
   user_id = $2
 RETURNING
-  id, user_id, quote, date, favorite, tokens, anilist_url, discord_username, discord_avatar, last_updated
+  id, user_id, quote, date, favorite, tokens, anilist_url, discord_username, discord_avatar, last_updated, daily_streak, last_daily
 `
 
 type UpdateTokensParams struct {
@@ -267,6 +305,8 @@ func (q *Queries) UpdateTokens(ctx context.Context, arg UpdateTokensParams) (Use
 		&i.DiscordUsername,
 		&i.DiscordAvatar,
 		&i.LastUpdated,
+		&i.DailyStreak,
+		&i.LastDaily,
 	)
 	return i, err
 }
@@ -8,5 +8,7 @@ CREATE TABLE public.users (
   anilist_url CHARACTER VARYING(255) DEFAULT ''::CHARACTER VARYING NOT NULL,
   discord_username CHARACTER VARYING(32) DEFAULT ''::CHARACTER VARYING NOT NULL UNIQUE,
   discord_avatar CHARACTER VARYING(34) DEFAULT ''::CHARACTER VARYING NOT NULL,
-  last_updated TIMESTAMP WITHOUT TIME ZONE DEFAULT '1970-01-01 00:00:00'::TIMESTAMP WITHOUT TIME ZONE NOT NULL
+  last_updated TIMESTAMP WITHOUT TIME ZONE DEFAULT '1970-01-01 00:00:00'::TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+  daily_streak INTEGER NOT NULL DEFAULT 0,
+  last_daily TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT '1970-01-01 00:00:00'::TIMESTAMP WITHOUT TIME ZONE
 );
@@ -0,0 +1,43 @@
+package valuepg
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/storage/valuestore"
+)
+
+type Pg struct {
+	Q valuestore.Querier
+}
+
+func New(q valuestore.Querier) *Pg {
+	return &Pg{Q: q}
+}
+
+func (p *Pg) CollectionValue(ctx context.Context, userID collection.UserID) (int64, error) {
+	return p.Q.CollectionValue(ctx, userID)
+}
+
+func (p *Pg) RecordCollectionValues(ctx context.Context, day time.Time) (int64, error) {
+	return p.Q.RecordAll(ctx, pgtype.Date{Time: day.UTC(), Valid: true})
+}
+
+func (p *Pg) CollectionValueHistory(ctx context.Context, userID collection.UserID, since time.Time) ([]collection.ValuePoint, error) {
+	rows, err := p.Q.ListHistory(ctx, valuestore.ListHistoryParams{
+		UserID: userID,
+		Since:  pgtype.Date{Time: since.UTC(), Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]collection.ValuePoint, len(rows))
+	for i, r := range rows {
+		points[i] = collection.ValuePoint{Day: r.Day.Time, Value: r.Value}
+	}
+	return points, nil
+}
@@ -0,0 +1,30 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+
+package valuestore
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type Character struct {
+	ID         int64
+	Name       string
+	Image      string
+	MediaTitle string
+	Favorites  int32
+}
+
+type Collection struct {
+	UserID      uint64
+	CharacterID int64
+	Source      string
+	AcquiredAt  pgtype.Timestamp
+}
+
+type CollectionValueHistory struct {
+	UserID uint64
+	Day    pgtype.Date
+	Value  int64
+}
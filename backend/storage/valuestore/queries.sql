@@ -0,0 +1,38 @@
+-- name: CollectionValue :one
+-- Character values follow collection.CharacterValue: 1 + floor(sqrt(favorites)).
+SELECT
+  COALESCE(SUM(1 + FLOOR(SQRT(GREATEST(characters.favorites, 0)))), 0)::BIGINT AS value
+FROM
+  collection
+  JOIN characters ON characters.id = collection.character_id
+WHERE
+  collection.user_id = $1;
+
+-- name: RecordAll :execrows
+-- Recording a day again replaces its values, so the last record of a day wins.
+INSERT INTO
+  collection_value_history (user_id, day, value)
+SELECT
+  collection.user_id,
+  sqlc.arg(day)::DATE,
+  SUM(1 + FLOOR(SQRT(GREATEST(characters.favorites, 0))))::BIGINT
+FROM
+  collection
+  JOIN characters ON characters.id = collection.character_id
+GROUP BY
+  collection.user_id
+ON CONFLICT (user_id, day) DO UPDATE
+SET
+  value = excluded.value;
+
+-- name: ListHistory :many
+SELECT
+  day,
+  value
+FROM
+  collection_value_history
+WHERE
+  user_id = $1
+  AND day >= sqlc.arg(since)::DATE
+ORDER BY
+  day;
@@ -9,10 +9,11 @@ import (
 )
 
 type Character struct {
-	ID        int64
-	Name      string
-	Image     string
-	Favorites int32
+	ID               int64
+	Name             string
+	Image            string
+	Favorites        int32
+	AlternativeNames []string
 }
 
 type CharacterWishlist struct {
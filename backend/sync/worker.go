@@ -9,7 +9,6 @@ import (
 
 	"github.com/failsafe-go/failsafe-go"
 	"github.com/failsafe-go/failsafe-go/circuitbreaker"
-	"github.com/failsafe-go/failsafe-go/ratelimiter"
 	"github.com/failsafe-go/failsafe-go/retrypolicy"
 
 	"github.com/karitham/waifubot/anilist"
@@ -33,12 +32,11 @@ type Service struct {
 	maxID         atomic.Int64
 }
 
-// NewService creates a sync Service with rate-limiting (5 req/min) and retry
-// policies (3 retries, exponential backoff) for AniList calls.
+// NewService creates a sync Service with circuit-breaking and retry policies
+// (3 retries, exponential backoff) for AniList calls. Its calls are made with
+// anilist.PriorityBackground, so the client's scheduler paces them behind
+// interactive traffic.
 func NewService(store catalog.Store, fetcher anilist.CharacterFetcher, maxIDProvider MaxIDProvider) *Service {
-	rl := ratelimiter.NewSmoothBuilder[[]collection.MediaCharacter](5, time.Minute).
-		WithMaxWaitTime(2 * time.Minute).
-		Build()
 	cb := circuitbreaker.NewBuilder[[]collection.MediaCharacter]().
 		WithFailureThreshold(5).
 		WithSuccessThreshold(2).
@@ -54,7 +52,7 @@ func NewService(store catalog.Store, fetcher anilist.CharacterFetcher, maxIDProv
 		store:         store,
 		anilist:       fetcher,
 		maxIDProvider: maxIDProvider,
-		executor:      failsafe.With(rl, cb, rp),
+		executor:      failsafe.With(cb, rp),
 		maxID:         atomic.Int64{},
 	}
 	s.maxID.Store(1_000_000) // fallback bound, updated by refreshMaxID
@@ -106,8 +104,8 @@ func (s *Service) buildDiscoveryPool(ctx context.Context) []int64 {
 	return pool
 }
 
-// processBatch fetches a batch of IDs from AniList, upserts valid results,
-// and marks missing IDs as inactive.
+// processBatch fetches a batch of IDs from AniList, upserts valid results
+// along with their media, and marks missing IDs as inactive.
 func (s *Service) processBatch(ctx context.Context, ids []int64) error {
 	chars, err := s.fetchCharacters(ctx, ids)
 	if err != nil {
@@ -115,17 +113,26 @@ func (s *Service) processBatch(ctx context.Context, ids []int64) error {
 	}
 
 	var upsertFails int
+	upserted := make([]collection.MediaCharacter, 0, len(chars))
 	for _, c := range chars {
 		if err := s.store.UpsertCharacter(ctx, catalog.Character{
-			ID:         c.ID,
-			Name:       c.Name,
-			Image:      c.ImageURL,
-			MediaTitle: c.MediaTitle,
-			Favorites:  c.Favorites,
+			ID:          c.ID,
+			Name:        c.Name,
+			Image:       c.ImageURL,
+			MediaTitle:  c.MediaTitle,
+			Favorites:   c.Favorites,
+			Aliases:     c.Aliases,
+			Description: c.Description,
 		}); err != nil {
 			slog.Error("failed to upsert character", "character_id", c.ID, "error", err)
 			upsertFails++
+			continue
 		}
+		upserted = append(upserted, c)
+	}
+
+	if err := s.linkMedia(ctx, upserted); err != nil {
+		slog.Error("failed to sync character media", "error", err)
 	}
 
 	if len(chars) < len(ids) {
@@ -144,6 +151,49 @@ func (s *Service) processBatch(ctx context.Context, ids []int64) error {
 	return nil
 }
 
+// linkMedia stores the media of chars and replaces each character's media links.
+// A character whose links fail keeps its previous ones until the next pass.
+func (s *Service) linkMedia(ctx context.Context, chars []collection.MediaCharacter) error {
+	media := batchMedia(chars)
+	if err := s.store.UpsertMedia(ctx, media); err != nil {
+		return fmt.Errorf("error upserting %d media: %w", len(media), err)
+	}
+
+	for _, c := range chars {
+		ids := make([]int64, len(c.Media))
+		for i, m := range c.Media {
+			ids[i] = m.ID
+		}
+		if err := s.store.SetCharacterMedia(ctx, c.ID, ids); err != nil {
+			slog.Error("failed to link character media", "character_id", c.ID, "error", err)
+		}
+	}
+	return nil
+}
+
+// batchMedia returns every media of chars once, in first-seen order.
+func batchMedia(chars []collection.MediaCharacter) []catalog.Media {
+	seen := make(map[int64]struct{})
+	var media []catalog.Media
+	for _, c := range chars {
+		for _, m := range c.Media {
+			if _, ok := seen[m.ID]; ok {
+				continue
+			}
+			seen[m.ID] = struct{}{}
+			media = append(media, catalog.Media{
+				ID:         m.ID,
+				Title:      m.Title,
+				Type:       m.Type,
+				CoverImage: m.CoverImageURL,
+				Popularity: m.Popularity,
+				MalID:      m.MalID,
+			})
+		}
+	}
+	return media
+}
+
 // missingIDs returns IDs from requested that are not present in found.
 // Pure function: no I/O, no side effects.
 func missingIDs(requested []int64, found []collection.MediaCharacter) []int64 {
@@ -171,9 +221,10 @@ func (s *Service) markMissingInactive(ctx context.Context, requested []int64, fo
 	return s.store.MarkCharactersInactive(ctx, missing)
 }
 
-// fetchCharacters fetches characters via the Executor (rate-limited + retry) when available,
+// fetchCharacters fetches characters via the Executor (circuit breaker + retry) when available,
 // falling back to a direct call when Executor is nil (e.g. in tests).
 func (s *Service) fetchCharacters(ctx context.Context, ids []int64) ([]collection.MediaCharacter, error) {
+	ctx = anilist.WithPriority(ctx, anilist.PriorityBackground)
 	if s.executor != nil {
 		return s.executor.WithContext(ctx).Get(func() ([]collection.MediaCharacter, error) {
 			return s.anilist.CharactersByIDs(ctx, ids)
@@ -277,7 +328,7 @@ func (s *Service) Run(ctx context.Context) {
 // refreshMaxID fetches the current max character ID from AniList and stores it.
 // On error, keeps the current bound and logs a warning.
 func (s *Service) refreshMaxID(ctx context.Context) {
-	v, err := s.maxIDProvider.MaxCharacterID(ctx)
+	v, err := s.maxIDProvider.MaxCharacterID(anilist.WithPriority(ctx, anilist.PriorityBackground))
 	if err != nil {
 		slog.Warn("failed to fetch max character id, keeping current bound", "error", err)
 		return
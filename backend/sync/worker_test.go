@@ -107,6 +107,53 @@ func TestSync(t *testing.T) {
 	}
 }
 
+func TestProcessBatchMedia(t *testing.T) {
+	shared := collection.Media{ID: 10, Title: "Fate/Zero", Type: "ANIME", Popularity: 300}
+	other := collection.Media{ID: 20, Title: "Fate/stay night", Type: "ANIME", Popularity: 200}
+
+	var media []catalog.Media
+	links := make(map[int64][]int64)
+	store := &collectiontest.MockStore{
+		UpsertCharacterFunc: func(_ context.Context, c catalog.Character) error {
+			if c.ID == 3 {
+				return errors.New("db error")
+			}
+			return nil
+		},
+		UpsertMediaFunc: func(_ context.Context, m []catalog.Media) error {
+			media = append(media, m...)
+			return nil
+		},
+		SetCharacterMediaFunc: func(_ context.Context, charID int64, mediaIDs []int64) error {
+			links[charID] = mediaIDs
+			return nil
+		},
+	}
+	fetcher := &mockFetcher{
+		CharactersByIDsFunc: func(_ context.Context, _ []int64) ([]collection.MediaCharacter, error) {
+			return []collection.MediaCharacter{
+				{ID: 1, Name: "Saber", Media: []collection.Media{shared, other}},
+				{ID: 2, Name: "Kiritsugu", Media: []collection.Media{shared}},
+				{ID: 3, Name: "Broken", Media: []collection.Media{{ID: 30, Title: "Unlinked"}}},
+				{ID: 4, Name: "Nobody"},
+			}, nil
+		},
+	}
+
+	svc := &Service{store: store, anilist: fetcher}
+	require.NoError(t, svc.processBatch(t.Context(), []int64{1, 2, 3, 4}))
+
+	assert.Equal(t, []catalog.Media{
+		{ID: 10, Title: "Fate/Zero", Type: "ANIME", Popularity: 300},
+		{ID: 20, Title: "Fate/stay night", Type: "ANIME", Popularity: 200},
+	}, media, "media are stored once, only for upserted characters")
+	assert.Equal(t, map[int64][]int64{
+		1: {10, 20},
+		2: {10},
+		4: {},
+	}, links)
+}
+
 func TestBuildDiscoveryPool(t *testing.T) {
 	tests := []struct {
 		name      string
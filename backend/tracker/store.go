@@ -0,0 +1,40 @@
+package tracker
+
+import (
+	"context"
+
+	"github.com/karitham/waifubot/storage/trackerstore"
+)
+
+type store struct {
+	q trackerstore.Querier
+}
+
+func NewStore(q trackerstore.Querier) Store {
+	return &store{q: q}
+}
+
+func (s *store) CharacterIDs(ctx context.Context, p Provider, externalIDs []int64) (map[int64]int64, error) {
+	rows, err := s.q.GetCharacterIDs(ctx, trackerstore.GetCharacterIDsParams{
+		Provider:    string(p),
+		ExternalIds: externalIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[int64]int64, len(rows))
+	for _, r := range rows {
+		ids[r.ExternalID] = r.CharacterID
+	}
+	return ids, nil
+}
+
+func (s *store) PutCharacterIDs(ctx context.Context, p Provider, ids map[int64]int64) error {
+	params := trackerstore.PutCharacterIDsParams{Provider: string(p)}
+	for external, id := range ids {
+		params.ExternalIds = append(params.ExternalIds, external)
+		params.CharacterIds = append(params.CharacterIds, id)
+	}
+	return s.q.PutCharacterIDs(ctx, params)
+}
@@ -0,0 +1,215 @@
+// Package tracker lets the bot search anime trackers other than AniList while
+// keeping the AniList IDs the catalog and collections are keyed on.
+package tracker
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/karitham/waifubot/catalog"
+	"github.com/karitham/waifubot/collection"
+)
+
+// Provider names a tracker backend.
+type Provider string
+
+const (
+	AniList     Provider = "anilist"
+	MyAnimeList Provider = "mal"
+)
+
+// Providers lists every Provider, for validating configuration.
+var Providers = []Provider{AniList, MyAnimeList}
+
+// Service is a tracker backend. IDs in its answers are the tracker's own.
+type Service interface {
+	Anime(ctx context.Context, name string) ([]collection.Media, error)
+	Manga(ctx context.Context, name string) ([]collection.Media, error)
+	User(ctx context.Context, name string) ([]collection.TrackerUser, error)
+	Character(ctx context.Context, name string) ([]collection.MediaCharacter, error)
+	SearchMedia(ctx context.Context, search string) ([]collection.Media, error)
+	GetMediaCharacters(ctx context.Context, mediaID int64) ([]collection.MediaCharacter, error)
+}
+
+// MangaCharacterLister is implemented by backends whose anime and manga IDs
+// overlap, where GetMediaCharacters only takes anime IDs.
+type MangaCharacterLister interface {
+	GetMangaCharacters(ctx context.Context, mangaID int64) ([]collection.MediaCharacter, error)
+}
+
+// Catalog is the part of the catalog tracker IDs are mapped against.
+type Catalog interface {
+	GetMedia(ctx context.Context, mediaID int64) (catalog.Media, error)
+	MediaByMalID(ctx context.Context, malID int64, mediaType string) (catalog.Media, error)
+	CharactersByMedia(ctx context.Context, mediaID int64) ([]catalog.Character, error)
+}
+
+// Store remembers which catalog character a tracker's character is.
+type Store interface {
+	// CharacterIDs maps the known external IDs to catalog character IDs.
+	CharacterIDs(ctx context.Context, p Provider, externalIDs []int64) (map[int64]int64, error)
+	// PutCharacterIDs stores the external ID to catalog character ID pairs of ids.
+	PutCharacterIDs(ctx context.Context, p Provider, ids map[int64]int64) error
+}
+
+// Mapped is a Service answering with AniList IDs in front of a backend that
+// uses MyAnimeList IDs. Media are matched through the MyAnimeList IDs AniList
+// records, and characters by name among the characters of a matched media.
+// Anything without a match in the catalog has an ID of 0.
+type Mapped struct {
+	provider Provider
+	svc      Service
+	catalog  Catalog
+	store    Store
+}
+
+var _ Service = (*Mapped)(nil)
+
+// Map returns a Mapped in front of svc, remembering its characters under p.
+func Map(p Provider, svc Service, cat Catalog, store Store) *Mapped {
+	return &Mapped{provider: p, svc: svc, catalog: cat, store: store}
+}
+
+func (m *Mapped) Anime(ctx context.Context, name string) ([]collection.Media, error) {
+	media, err := m.svc.Anime(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return m.mapMedia(ctx, media), nil
+}
+
+func (m *Mapped) Manga(ctx context.Context, name string) ([]collection.Media, error) {
+	media, err := m.svc.Manga(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return m.mapMedia(ctx, media), nil
+}
+
+func (m *Mapped) User(ctx context.Context, name string) ([]collection.TrackerUser, error) {
+	return m.svc.User(ctx, name)
+}
+
+// SearchMedia only returns the media in the catalog, its answers are used as catalog IDs.
+func (m *Mapped) SearchMedia(ctx context.Context, search string) ([]collection.Media, error) {
+	media, err := m.svc.SearchMedia(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(m.mapMedia(ctx, media), func(m collection.Media) bool {
+		return m.ID == 0
+	}), nil
+}
+
+func (m *Mapped) Character(ctx context.Context, name string) ([]collection.MediaCharacter, error) {
+	chars, err := m.svc.Character(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	external := make([]int64, len(chars))
+	for i, c := range chars {
+		external[i] = c.ID
+	}
+	ids, err := m.store.CharacterIDs(ctx, m.provider, external)
+	if err != nil {
+		slog.WarnContext(ctx, "error reading tracker character ids", "provider", m.provider, "error", err)
+	}
+	for i := range chars {
+		chars[i].ID = ids[chars[i].ID]
+	}
+	return chars, nil
+}
+
+// GetMediaCharacters returns the characters of the catalog media mediaID that
+// the backend also lists, and remembers them for Character.
+func (m *Mapped) GetMediaCharacters(ctx context.Context, mediaID int64) ([]collection.MediaCharacter, error) {
+	media, err := m.catalog.GetMedia(ctx, mediaID)
+	if errors.Is(err, collection.ErrNotFound) || (err == nil && media.MalID == 0) {
+		return nil, collection.ErrMediaNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var chars []collection.MediaCharacter
+	if lister, ok := m.svc.(MangaCharacterLister); ok && media.Type == "MANGA" {
+		chars, err = lister.GetMangaCharacters(ctx, media.MalID)
+	} else {
+		chars, err = m.svc.GetMediaCharacters(ctx, media.MalID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	local, err := m.catalog.CharactersByMedia(ctx, mediaID)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]int64, len(local))
+	for _, c := range local {
+		byName[nameKey(c.Name)] = c.ID
+	}
+	// Aliases only match names no character has, MyAnimeList often uses one
+	// AniList keeps as an alias.
+	for _, c := range local {
+		for _, alias := range c.Aliases {
+			if _, ok := byName[nameKey(alias)]; !ok {
+				byName[nameKey(alias)] = c.ID
+			}
+		}
+	}
+
+	ids := make(map[int64]int64)
+	matched := make([]collection.MediaCharacter, 0, len(chars))
+	for _, c := range chars {
+		id, ok := byName[nameKey(c.Name)]
+		if !ok {
+			continue
+		}
+		ids[c.ID] = id
+		c.ID = id
+		c.MediaTitle = media.Title
+		matched = append(matched, c)
+	}
+
+	if len(ids) > 0 {
+		if err := m.store.PutCharacterIDs(ctx, m.provider, ids); err != nil {
+			slog.WarnContext(ctx, "error storing tracker character ids", "provider", m.provider, "error", err)
+		}
+	}
+	return matched, nil
+}
+
+// mapMedia replaces the IDs of media with their catalog IDs, keeping the
+// backend's in MalID.
+func (m *Mapped) mapMedia(ctx context.Context, media []collection.Media) []collection.Media {
+	for i, med := range media {
+		media[i].MalID = med.ID
+		media[i].ID = 0
+
+		local, err := m.catalog.MediaByMalID(ctx, med.ID, med.Type)
+		if err != nil {
+			if !errors.Is(err, collection.ErrNotFound) {
+				slog.WarnContext(ctx, "error mapping tracker media", "provider", m.provider, "mal_id", med.ID, "error", err)
+			}
+			continue
+		}
+		media[i].ID = local.ID
+	}
+	return media
+}
+
+// nameKey makes names that only differ in order, case or punctuation equal,
+// so "Emiya, Kiritsugu" matches "Kiritsugu Emiya".
+func nameKey(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	slices.Sort(words)
+	return strings.Join(words, " ")
+}
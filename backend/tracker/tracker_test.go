@@ -0,0 +1,143 @@
+package tracker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karitham/waifubot/catalog"
+	"github.com/karitham/waifubot/collection"
+	"github.com/karitham/waifubot/collection/collectiontest"
+)
+
+type memStore map[int64]int64
+
+func (s memStore) CharacterIDs(_ context.Context, _ Provider, externalIDs []int64) (map[int64]int64, error) {
+	ids := make(map[int64]int64)
+	for _, id := range externalIDs {
+		if local, ok := s[id]; ok {
+			ids[id] = local
+		}
+	}
+	return ids, nil
+}
+
+func (s memStore) PutCharacterIDs(_ context.Context, _ Provider, ids map[int64]int64) error {
+	for external, id := range ids {
+		s[external] = id
+	}
+	return nil
+}
+
+type mangaBackend struct {
+	collectiontest.MockAnimeService
+	mangaIDs []int64
+}
+
+func (b *mangaBackend) GetMangaCharacters(_ context.Context, mangaID int64) ([]collection.MediaCharacter, error) {
+	b.mangaIDs = append(b.mangaIDs, mangaID)
+	return nil, nil
+}
+
+// fateCatalog knows Fate/Zero (AniList 10087, MyAnimeList 10087) and the manga (AniList 98, MyAnimeList 33887).
+func fateCatalog() *collectiontest.MockStore {
+	media := map[int64]catalog.Media{
+		10087: {ID: 10087, Title: "Fate/Zero", Type: "ANIME", MalID: 10087},
+		98:    {ID: 98, Title: "Fate/Zero", Type: "MANGA", MalID: 33887},
+		7:     {ID: 7, Title: "Unknown on MyAnimeList", Type: "ANIME"},
+	}
+	return &collectiontest.MockStore{
+		GetMediaFunc: func(_ context.Context, id int64) (catalog.Media, error) {
+			if m, ok := media[id]; ok {
+				return m, nil
+			}
+			return catalog.Media{}, collection.ErrNotFound
+		},
+		MediaByMalIDFunc: func(_ context.Context, malID int64, mediaType string) (catalog.Media, error) {
+			for _, m := range media {
+				if m.MalID == malID && m.Type == mediaType {
+					return m, nil
+				}
+			}
+			return catalog.Media{}, collection.ErrNotFound
+		},
+		CharactersByMediaFunc: func(context.Context, int64) ([]catalog.Character, error) {
+			return []catalog.Character{
+				{ID: 497, Name: "Artoria Pendragon", Aliases: []string{"Saber"}},
+				{ID: 1, Name: "Kiritsugu Emiya"},
+			}, nil
+		},
+	}
+}
+
+func TestMapped_Media(t *testing.T) {
+	backend := &mangaBackend{MockAnimeService: collectiontest.MockAnimeService{
+		AnimeFunc: func(context.Context, string) ([]collection.Media, error) {
+			return []collection.Media{{ID: 10087, Title: "Fate/Zero", Type: "ANIME"}, {ID: 5, Title: "Not in the catalog", Type: "ANIME"}}, nil
+		},
+		SearchMediaFunc: func(context.Context, string) ([]collection.Media, error) {
+			return []collection.Media{{ID: 33887, Type: "MANGA"}, {ID: 33887, Type: "ANIME"}, {ID: 5, Type: "ANIME"}}, nil
+		},
+	}}
+	m := Map(MyAnimeList, backend, fateCatalog(), memStore{})
+
+	anime, err := m.Anime(t.Context(), "fate")
+	require.NoError(t, err)
+	assert.Equal(t, []collection.Media{
+		{ID: 10087, MalID: 10087, Title: "Fate/Zero", Type: "ANIME"},
+		{ID: 0, MalID: 5, Title: "Not in the catalog", Type: "ANIME"},
+	}, anime)
+
+	media, err := m.SearchMedia(t.Context(), "fate")
+	require.NoError(t, err)
+	assert.Equal(t, []collection.Media{{ID: 98, MalID: 33887, Type: "MANGA"}}, media,
+		"searches only return catalog media, matched by type")
+}
+
+func TestMapped_Characters(t *testing.T) {
+	backend := &mangaBackend{MockAnimeService: collectiontest.MockAnimeService{
+		GetMediaCharactersFunc: func(_ context.Context, id int64) ([]collection.MediaCharacter, error) {
+			assert.Equal(t, int64(10087), id)
+			return []collection.MediaCharacter{
+				{ID: 3235, Name: "Emiya, Kiritsugu"},
+				{ID: 497, Name: "Saber"},
+				{ID: 999, Name: "Not in the catalog"},
+			}, nil
+		},
+		CharacterFunc: func(context.Context, string) ([]collection.MediaCharacter, error) {
+			return []collection.MediaCharacter{{ID: 3235, Name: "Kiritsugu Emiya"}, {ID: 999}}, nil
+		},
+	}}
+	store := memStore{}
+	m := Map(MyAnimeList, backend, fateCatalog(), store)
+
+	chars, err := m.GetMediaCharacters(t.Context(), 10087)
+	require.NoError(t, err)
+	assert.Equal(t, []collection.MediaCharacter{
+		{ID: 1, Name: "Emiya, Kiritsugu", MediaTitle: "Fate/Zero"},
+		{ID: 497, Name: "Saber", MediaTitle: "Fate/Zero"},
+	}, chars)
+	assert.Equal(t, memStore{3235: 1, 497: 497}, store)
+
+	found, err := m.Character(t.Context(), "kiritsugu")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), found[0].ID, "characters seen in a media are mapped")
+	assert.Equal(t, int64(0), found[1].ID)
+
+	_, err = m.GetMediaCharacters(t.Context(), 98)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{33887}, backend.mangaIDs, "manga are listed with their manga ID")
+
+	_, err = m.GetMediaCharacters(t.Context(), 7)
+	require.ErrorIs(t, err, collection.ErrMediaNotFound)
+	_, err = m.GetMediaCharacters(t.Context(), 404)
+	require.ErrorIs(t, err, collection.ErrMediaNotFound)
+}
+
+func TestNameKey(t *testing.T) {
+	assert.Equal(t, nameKey("Kiritsugu Emiya"), nameKey("Emiya, Kiritsugu"))
+	assert.Equal(t, nameKey("Lelouch Lamperouge"), nameKey("lelouch  LAMPEROUGE"))
+	assert.NotEqual(t, nameKey("Rin Tohsaka"), nameKey("Sakura Matou"))
+}
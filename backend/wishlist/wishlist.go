@@ -2,6 +2,8 @@ package wishlist
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/karitham/waifubot/collection"
 )
@@ -38,32 +40,41 @@ type Store interface {
 	GetUsersWantingCharacter(ctx context.Context, charID int64, guildID, excludeUserID uint64) ([]uint64, error)
 }
 
-// MediaService defines the interface for media operations.
-type MediaService interface {
-	SearchMedia(ctx context.Context, search string) ([]collection.Media, error)
-	GetMediaCharacters(ctx context.Context, mediaId int64) ([]collection.MediaCharacter, error)
-}
+var (
+	// ErrAlreadyOwned is returned when a user wishes for a character they own.
+	ErrAlreadyOwned = errors.New("character already owned")
+	// ErrOwnershipCheck wraps the error of checking whether the user owns the character.
+	ErrOwnershipCheck = errors.New("checking ownership")
+)
 
-// AddMediaToWishlist adds all characters from a media to the user's wishlist, filtering out owned characters.
-func AddMediaToWishlist(ctx context.Context, wishlistStore Store, mediaService MediaService, store collection.Store, userID uint64, mediaID int64) (int, error) {
-	characters, err := mediaService.GetMediaCharacters(ctx, mediaID)
+// AddCharacterToWishlist adds a catalog character the user doesn't own to their wishlist.
+func AddCharacterToWishlist(ctx context.Context, wishlistStore Store, store collection.Store, userID uint64, charID int64) (collection.Character, error) {
+	has, _, err := collection.CheckOwnership(ctx, store, userID, charID)
 	if err != nil {
-		return 0, err
+		return collection.Character{}, fmt.Errorf("%w: %w", ErrOwnershipCheck, err)
+	}
+	if has {
+		return collection.Character{}, ErrAlreadyOwned
 	}
 
-	if len(characters) == 0 {
-		return 0, nil
+	char, err := store.GetCharacterByID(ctx, charID)
+	if err != nil {
+		return collection.Character{}, err
 	}
 
-	for _, char := range characters {
-		_ = store.UpsertCharacter(ctx, collection.Character{
-			ID:         char.ID,
-			Name:       char.Name,
-			Image:      char.ImageURL,
-			MediaTitle: char.MediaTitle,
-			Favorites:  char.Favorites,
-		})
+	return char, wishlistStore.AddCharactersToWishlist(ctx, userID, []int64{charID})
+}
+
+// AddMediaToWishlist adds all characters from a media to the user's wishlist, filtering out owned characters.
+func AddMediaToWishlist(ctx context.Context, wishlistStore Store, source collection.MediaCharacterSource, store collection.Store, userID uint64, mediaID int64) (int, error) {
+	media, err := collection.MediaCharacters(ctx, store, source, mediaID)
+	if errors.Is(err, collection.ErrMediaNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
 	}
+	characters := media.Characters
 
 	ownedIDs, err := store.GetCollectionIDs(ctx, userID)
 	if err != nil {
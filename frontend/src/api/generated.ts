@@ -33,6 +33,8 @@ export type Profile = {
     quote?: string;
     /** User's token balance */
     tokens: number;
+    /** Value of the user's collection, computed from the favorites of its characters */
+    collection_value: number;
     /** Anilist user URL */
     anilist_url?: string;
     /** Discord username */
@@ -56,6 +58,16 @@ export type UserIdResponse = {
     /** User ID */
     id: string;
 };
+export type Achievement = {
+    /** Stable achievement identifier */
+    id: string;
+    /** Badge name */
+    name: string;
+    /** What it takes to unlock the achievement */
+    description: string;
+    /** When the user unlocked it */
+    unlocked_at: string;
+};
 export type UserProfile = {
     /** User ID */
     id: string;
@@ -71,12 +83,22 @@ export type UserProfile = {
     discord_avatar?: string;
     /** User's favorite character (may be null if no favorite set) */
     favorite?: Character;
+    /** Achievements the user unlocked */
+    achievements: Achievement[];
+};
+export type ValuePoint = {
+    /** Day the value was recorded for (UTC) */
+    day: string;
+    /** Value of the collection */
+    value: number;
 };
 export type CollectionResponse = {
     /** List of characters in user's collection */
     characters: Character[];
-    /** Total number of characters in collection */
+    /** Total number of characters in collection matching the filters, across every page */
     total: number;
+    /** Cursor of the next page, absent on the last one */
+    next_cursor?: string;
 };
 export type WishlistResponse = {
     /** List of characters in wishlist */
@@ -84,6 +106,166 @@ export type WishlistResponse = {
     /** Total number of characters in wishlist */
     total: number;
 };
+export type BackupCharacter = {
+    /** Character ID */
+    id: number;
+    /** Character name */
+    name?: string;
+    /** Character image URL */
+    image?: string;
+    /** Media the character is from */
+    media_title?: string;
+    /** Number of favorites on the character */
+    favorites?: number;
+    /** How a collected character was acquired */
+    source?: string;
+    /** When a collected character was acquired */
+    acquired_at?: string;
+};
+export type BackupUser = {
+    /** Discord user ID */
+    user_id: string;
+    /** Token balance */
+    tokens: number;
+    /** Profile quote */
+    quote?: string;
+    /** ID of the character shown on the profile */
+    favorite?: number;
+    /** Linked Anilist user page */
+    anilist_url?: string;
+    collection: BackupCharacter[];
+    wishlist: BackupCharacter[];
+};
+export type Backup = {
+    /** Version of the backup format */
+    version: number;
+    /** When the backup was made */
+    exported_at?: string;
+    users: BackupUser[];
+};
+export type SeriesCompletion = {
+    /** AniList anime or manga ID */
+    media_id: number;
+    /** Series title */
+    title: string;
+    /** Number of characters in the series */
+    total: number;
+    /** Number of those characters the user owns */
+    owned: number;
+    /** Share of the series owned, from 0 to 100 */
+    percent: number;
+    /** Characters the user doesn't own, most favorited first */
+    missing: Character[];
+};
+export type OwnershipEvent = {
+    /** Event ID */
+    id: number;
+    /** How the character changed hands */
+    kind: Kind;
+    /** Previous owner, absent when the character entered the game */
+    from_user_id?: string;
+    /** New owner, absent when the character was sold */
+    to_user_id?: string;
+    /** Tokens paid for the character */
+    tokens: number;
+    /** Trade or auction the event belongs to */
+    reference_id?: number;
+    /** When the event happened */
+    date: string;
+};
+export type CharacterHistory = {
+    character: Character;
+    events: OwnershipEvent[];
+};
+export type Media = {
+    /** AniList anime or manga ID */
+    id: number;
+    /** Media title */
+    title: string;
+    /** ANIME or MANGA */
+    "type": string;
+    /** Cover image URL */
+    cover_image: string;
+    /** Number of AniList users with the media in their list */
+    popularity: number;
+};
+export type CatalogCharacter = {
+    /** Character ID */
+    id: number;
+    /** Character name */
+    name: string;
+    /** Character image URL */
+    image: string;
+    /** Title of the character's most popular media */
+    media_title: string;
+    /** Number of favorites on the character */
+    favorites: number;
+    rarity: RarityTier;
+    /** Whether the character can still be rolled and dropped */
+    active: boolean;
+    /** Number of players owning the character */
+    owners: number;
+    /** Number of players wishing for the character */
+    wishlisted: number;
+    /** AniList's markdown description. Only set when getting a single character. */
+    description?: string;
+    /** Media the character appears in, most popular first. Only set when getting a single character. */
+    media?: Media[];
+    /** When the character first entered a collection. Only set when getting a single character that was ever claimed. */
+    first_claimed?: string;
+};
+export type MediaCharacters = {
+    /** AniList anime or manga ID */
+    media_id: number;
+    /** Media title */
+    title: string;
+    /** Characters of the media, most favorited first */
+    characters: Character[];
+};
+export type LeaderboardEntry = {
+    /** Position on the leaderboard, tied collectors share it */
+    rank: number;
+    /** User ID */
+    user_id: string;
+    /** Discord username */
+    discord_username: string;
+    /** Discord avatar URL */
+    discord_avatar?: string;
+    /** The collector's score in the category */
+    value: number;
+};
+export type Leaderboard = {
+    category: LeaderboardCategory;
+    /** Guild whose members are ranked, absent for the global leaderboard */
+    guild_id?: string;
+    entries: LeaderboardEntry[];
+    /** When the rankings were last computed */
+    refreshed_at: string;
+};
+export type Session = {
+    /** Discord user ID */
+    user_id: string;
+    /** When the session ends */
+    expires_at: string;
+};
+export type ProfileUpdate = {
+    /** New personal quote, empty to clear it */
+    quote?: string;
+    /** New Anilist user URL */
+    anilist_url?: string;
+};
+export type FavoriteUpdate = {
+    /** Character ID */
+    character_id: number;
+};
+export type ActivityEvent = {
+    event: OwnershipEvent;
+    character: Character;
+    /** Discord username of the previous owner */
+    from_username?: string;
+    /** Discord username of the new owner */
+    to_username?: string;
+};
 /**
  * Get user profile
  */
@@ -181,10 +363,39 @@ export function getProfileV1(userId: string, opts?: Oazapfts.RequestOpts) {
         ...opts
     }));
 }
+/**
+ * Get collection value history
+ */
+export function getValueHistory(userId: string, { days }: {
+    days?: number;
+} = {}, opts?: Oazapfts.RequestOpts) {
+    return oazapfts.ok(oazapfts.fetchJson<{
+        status: 200;
+        data: ValuePoint[];
+    } | {
+        status: 400;
+        data: Error;
+    }>(`/api/v1/profile/${encodeURIComponent(userId)}/value-history${QS.query(QS.explode({
+        days
+    }))}`, {
+        ...opts
+    }));
+}
 /**
  * Get user collection
  */
-export function getCollectionV1(userId: string, opts?: Oazapfts.RequestOpts) {
+export function getCollectionV1(userId: string, { rarity, media, source, $from, to, search, sort, order, cursor, limit }: {
+    rarity?: RarityTier;
+    media?: string;
+    source?: Source;
+    $from?: string;
+    to?: string;
+    search?: string;
+    sort?: Sort;
+    order?: Order;
+    cursor?: string;
+    limit?: number;
+} = {}, opts?: Oazapfts.RequestOpts) {
     return oazapfts.ok(oazapfts.fetchJson<{
         status: 200;
         data: CollectionResponse;
@@ -194,7 +405,35 @@ export function getCollectionV1(userId: string, opts?: Oazapfts.RequestOpts) {
     } | {
         status: 404;
         data: Error;
-    }>(`/api/v1/collection/${encodeURIComponent(userId)}`, {
+    }>(`/api/v1/collection/${encodeURIComponent(userId)}${QS.query(QS.explode({
+        rarity,
+        media,
+        source,
+        "from": $from,
+        to,
+        search,
+        sort,
+        order,
+        cursor,
+        limit
+    }))}`, {
+        ...opts
+    }));
+}
+/**
+ * Get series completion
+ */
+export function getSeriesCompletion(userId: string, mediaId: number, opts?: Oazapfts.RequestOpts) {
+    return oazapfts.ok(oazapfts.fetchJson<{
+        status: 200;
+        data: SeriesCompletion;
+    } | {
+        status: 400;
+        data: Error;
+    } | {
+        status: 404;
+        data: Error;
+    }>(`/api/v1/collection/${encodeURIComponent(userId)}/series/${encodeURIComponent(mediaId)}`, {
         ...opts
     }));
 }
@@ -215,6 +454,272 @@ export function getWishlist(userId: string, opts?: Oazapfts.RequestOpts) {
         ...opts
     }));
 }
+/**
+ * Export user backup
+ */
+export function exportUser(userId: string, { format }: {
+    format?: Format;
+} = {}, opts?: Oazapfts.RequestOpts) {
+    return oazapfts.ok(oazapfts.fetchJson<{
+        status: 200;
+        data: Backup;
+    } | {
+        status: 400;
+        data: Error;
+    } | {
+        status: 404;
+        data: Error;
+    }>(`/api/v1/export/${encodeURIComponent(userId)}${QS.query(QS.explode({
+        format
+    }))}`, {
+        ...opts
+    }));
+}
+/**
+ * Get character ownership history
+ */
+export function getCharacterHistory(characterId: number, { limit }: {
+    limit?: number;
+} = {}, opts?: Oazapfts.RequestOpts) {
+    return oazapfts.ok(oazapfts.fetchJson<{
+        status: 200;
+        data: CharacterHistory;
+    } | {
+        status: 400;
+        data: Error;
+    } | {
+        status: 404;
+        data: Error;
+    }>(`/api/v1/character/${encodeURIComponent(characterId)}/history${QS.query(QS.explode({
+        limit
+    }))}`, {
+        ...opts
+    }));
+}
+/**
+ * Search characters
+ */
+export function searchCharacters({ search }: {
+    search: string;
+}, opts?: Oazapfts.RequestOpts) {
+    return oazapfts.ok(oazapfts.fetchJson<{
+        status: 200;
+        data: CatalogCharacter[];
+    }>(`/api/v1/characters${QS.query(QS.explode({
+        search
+    }))}`, {
+        ...opts
+    }));
+}
+/**
+ * Get character
+ */
+export function getCatalogCharacter(characterId: number, opts?: Oazapfts.RequestOpts) {
+    return oazapfts.ok(oazapfts.fetchJson<{
+        status: 200;
+        data: CatalogCharacter;
+    } | {
+        status: 400;
+        data: Error;
+    } | {
+        status: 404;
+        data: Error;
+    }>(`/api/v1/characters/${encodeURIComponent(characterId)}`, {
+        ...opts
+    }));
+}
+/**
+ * Search media
+ */
+export function searchMedia({ search }: {
+    search: string;
+}, opts?: Oazapfts.RequestOpts) {
+    return oazapfts.ok(oazapfts.fetchJson<{
+        status: 200;
+        data: Media[];
+    }>(`/api/v1/media${QS.query(QS.explode({
+        search
+    }))}`, {
+        ...opts
+    }));
+}
+/**
+ * Get media characters
+ */
+export function getMediaCharacters(mediaId: number, opts?: Oazapfts.RequestOpts) {
+    return oazapfts.ok(oazapfts.fetchJson<{
+        status: 200;
+        data: MediaCharacters;
+    } | {
+        status: 400;
+        data: Error;
+    } | {
+        status: 404;
+        data: Error;
+    }>(`/api/v1/media/${encodeURIComponent(mediaId)}/characters`, {
+        ...opts
+    }));
+}
+/**
+ * Get leaderboard
+ */
+export function getLeaderboard(category: LeaderboardCategory, { guildId, limit }: {
+    guildId?: string;
+    limit?: number;
+} = {}, opts?: Oazapfts.RequestOpts) {
+    return oazapfts.ok(oazapfts.fetchJson<{
+        status: 200;
+        data: Leaderboard;
+    } | {
+        status: 400;
+        data: Error;
+    }>(`/api/v1/leaderboard/${encodeURIComponent(category)}${QS.query(QS.explode({
+        guild_id: guildId,
+        limit
+    }))}`, {
+        ...opts
+    }));
+}
+/**
+ * Log in with Discord
+ */
+export function login(opts?: Oazapfts.RequestOpts) {
+    return oazapfts.ok(oazapfts.fetchJson<{
+        status: 302;
+    } | {
+        status: 404;
+        data: Error;
+    }>("/api/v1/auth/login", {
+        ...opts
+    }));
+}
+/**
+ * Finish a Discord login
+ */
+export function loginCallback(code: string, state: string, opts?: Oazapfts.RequestOpts) {
+    return oazapfts.ok(oazapfts.fetchJson<{
+        status: 302;
+    } | {
+        status: 400;
+        data: Error;
+    } | {
+        status: 404;
+        data: Error;
+    }>(`/api/v1/auth/callback${QS.query(QS.explode({
+        code,
+        state
+    }))}`, {
+        ...opts
+    }));
+}
+/**
+ * Log out
+ */
+export function logout(opts?: Oazapfts.RequestOpts) {
+    return oazapfts.ok(oazapfts.fetchJson<{
+        status: 204;
+    } | {
+        status: 401;
+        data: Error;
+    }>("/api/v1/auth/logout", {
+        ...opts,
+        method: "POST"
+    }));
+}
+/**
+ * Get current session
+ */
+export function getSession(opts?: Oazapfts.RequestOpts) {
+    return oazapfts.ok(oazapfts.fetchJson<{
+        status: 200;
+        data: Session;
+    } | {
+        status: 401;
+        data: Error;
+    }>("/api/v1/auth/session", {
+        ...opts
+    }));
+}
+/**
+ * Edit own profile
+ */
+export function updateProfile(profileUpdate: ProfileUpdate, opts?: Oazapfts.RequestOpts) {
+    return oazapfts.ok(oazapfts.fetchJson<{
+        status: 200;
+        data: UserProfile;
+    } | {
+        status: 400;
+        data: Error;
+    } | {
+        status: 401;
+        data: Error;
+    }>("/api/v1/me/profile", oazapfts.json({
+        ...opts,
+        method: "PATCH",
+        body: profileUpdate
+    })));
+}
+/**
+ * Set own favorite
+ */
+export function setFavorite(favoriteUpdate: FavoriteUpdate, opts?: Oazapfts.RequestOpts) {
+    return oazapfts.ok(oazapfts.fetchJson<{
+        status: 204;
+    } | {
+        status: 400;
+        data: Error;
+    } | {
+        status: 401;
+        data: Error;
+    } | {
+        status: 404;
+        data: Error;
+    }>("/api/v1/me/favorite", oazapfts.json({
+        ...opts,
+        method: "PUT",
+        body: favoriteUpdate
+    })));
+}
+/**
+ * Add to own wishlist
+ */
+export function addToWishlist(characterId: number, opts?: Oazapfts.RequestOpts) {
+    return oazapfts.ok(oazapfts.fetchJson<{
+        status: 204;
+    } | {
+        status: 400;
+        data: Error;
+    } | {
+        status: 401;
+        data: Error;
+    } | {
+        status: 404;
+        data: Error;
+    } | {
+        status: 409;
+        data: Error;
+    }>(`/api/v1/me/wishlist/${encodeURIComponent(characterId)}`, {
+        ...opts,
+        method: "PUT"
+    }));
+}
+/**
+ * Remove from own wishlist
+ */
+export function removeFromWishlist(characterId: number, opts?: Oazapfts.RequestOpts) {
+    return oazapfts.ok(oazapfts.fetchJson<{
+        status: 204;
+    } | {
+        status: 400;
+        data: Error;
+    } | {
+        status: 401;
+        data: Error;
+    }>(`/api/v1/me/wishlist/${encodeURIComponent(characterId)}`, {
+        ...opts,
+        method: "DELETE"
+    }));
+}
 export enum Type {
     Roll = "ROLL",
     Claim = "CLAIM",
@@ -223,4 +728,49 @@ export enum Type {
     SeriesRoll = "SERIES_ROLL",
     Trade = "TRADE"
 }
-
+export enum Kind {
+    Roll = "roll",
+    Claim = "claim",
+    SeriesRoll = "series_roll",
+    Give = "give",
+    Sell = "sell",
+    Trade = "trade",
+    Auction = "auction",
+    Admin = "admin"
+}
+export enum LeaderboardCategory {
+    Collection = "collection",
+    Rarity = "rarity",
+    Legendary = "legendary",
+    Tokens = "tokens",
+    Series = "series"
+}
+export enum RarityTier {
+    Common = "common",
+    Uncommon = "uncommon",
+    Rare = "rare",
+    Legendary = "legendary"
+}
+export enum Source {
+    Roll = "ROLL",
+    Claim = "CLAIM",
+    Give = "GIVE",
+    Old = "OLD",
+    SeriesRoll = "SERIES_ROLL",
+    Trade = "TRADE"
+}
+export enum Sort {
+    Date = "date",
+    Rarity = "rarity",
+    Media = "media",
+    Name = "name",
+    Id = "id"
+}
+export enum Order {
+    Asc = "asc",
+    Desc = "desc"
+}
+export enum Format {
+    Json = "json",
+    Csv = "csv"
+}
@@ -8,20 +8,17 @@ import FilterBar from "./FilterBar";
 // same semantics so we can exercise the full state wiring.
 vi.mock("@solidjs/router", () => import("../../hooks/router-mock"));
 
-vi.mock("../../api/anilist", () => ({
-	searchMedia: vi.fn(async () => ({
-		data: {
-			Page: {
-				media: [
-					{
-						id: "12345",
-						title: { romaji: "Fate/Zero" },
-						coverImage: { large: "https://img.example/fz.jpg" },
-					},
-				],
-			},
+vi.mock("../../api/generated", async (importOriginal) => ({
+	...(await importOriginal<typeof import("../../api/generated")>()),
+	searchMedia: vi.fn(async () => [
+		{
+			id: 12345,
+			title: "Fate/Zero",
+			type: "ANIME",
+			cover_image: "https://img.example/fz.jpg",
+			popularity: 300,
 		},
-	})),
+	]),
 }));
 
 const fireInput = (el: HTMLInputElement, value: string) => {
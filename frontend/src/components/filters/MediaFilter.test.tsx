@@ -3,28 +3,27 @@ import { createSignal } from "solid-js";
 import { render } from "solid-js/web";
 import MediaFilter, { type MediaOption } from "./MediaFilter";
 
-vi.mock("../../api/anilist", () => ({
-	searchMedia: vi.fn(async () => ({
-		data: {
-			Page: {
-				media: [
-					{
-						id: "12345",
-						title: { romaji: "Fate/Zero" },
-						coverImage: { large: "https://img.example/fz.jpg" },
-					},
-					{
-						id: "67890",
-						title: { romaji: "Fate/stay night" },
-						coverImage: { large: "https://img.example/fsn.jpg" },
-					},
-				],
-			},
+vi.mock("../../api/generated", async (importOriginal) => ({
+	...(await importOriginal<typeof import("../../api/generated")>()),
+	searchMedia: vi.fn(async () => [
+		{
+			id: 12345,
+			title: "Fate/Zero",
+			type: "ANIME",
+			cover_image: "https://img.example/fz.jpg",
+			popularity: 300,
 		},
-	})),
+		{
+			id: 67890,
+			title: "Fate/stay night",
+			type: "ANIME",
+			cover_image: "https://img.example/fsn.jpg",
+			popularity: 200,
+		},
+	]),
 }));
 
-import { searchMedia } from "../../api/anilist";
+import { searchMedia } from "../../api/generated";
 
 const fireInput = (el: HTMLInputElement, value: string) => {
 	el.value = value;
@@ -81,7 +80,7 @@ describe("MediaFilter", () => {
 		fireInput(input!, "Fate");
 		await vi.advanceTimersByTimeAsync(600);
 
-		expect(searchMedia).toHaveBeenCalledWith("Fate", 10);
+		expect(searchMedia).toHaveBeenCalledWith({ search: "Fate" });
 
 		// Kobalte portals to body — find the option items
 		const items = Array.from(document.body.querySelectorAll('[role="option"]'));
@@ -93,7 +92,7 @@ describe("MediaFilter", () => {
 		await vi.advanceTimersByTimeAsync(0);
 
 		expect(value()).toEqual({
-			value: "12345",
+			value: 12345,
 			label: "Fate/Zero",
 			image: "https://img.example/fz.jpg",
 		});
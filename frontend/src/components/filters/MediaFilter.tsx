@@ -3,7 +3,7 @@ import {
 	type SearchRootItemComponentProps,
 } from "@kobalte/core/search";
 import { type Component, createEffect, createSignal, on, Show } from "solid-js";
-import { type Media, searchMedia } from "../../api/anilist";
+import { type Media, searchMedia } from "../../api/generated";
 
 export type MediaOption = {
 	value: string | number;
@@ -16,9 +16,11 @@ export type MediaFilterProps = {
 	value?: MediaOption | null;
 };
 
-/** AniList is rate-limited and round-trips are slow — be patient with keystrokes. */
+/** Every keystroke is a round-trip to the API — be patient with keystrokes. */
 const SEARCH_DEBOUNCE_MS = 250;
 
+const SEARCH_RESULTS = 10;
+
 type SearchStatus =
 	| { kind: "idle" }
 	| { kind: "searching" }
@@ -27,8 +29,8 @@ type SearchStatus =
 
 const toOption = (media: Media): MediaOption => ({
 	value: media.id,
-	label: media.title.romaji,
-	image: media.coverImage.large,
+	label: media.title,
+	image: media.cover_image || undefined,
 });
 
 const SearchItem: Component<SearchRootItemComponentProps<MediaOption>> = (
@@ -101,9 +103,9 @@ const MediaSearch = (props: {
 
 				setStatus({ kind: "searching" });
 				try {
-					const result = await searchMedia(value, 10);
+					const result = await searchMedia({ search: value });
 					if (seq !== requestSeq) return; // superseded by a newer search
-					const found = result?.data.Page.media.map(toOption) ?? [];
+					const found = result.slice(0, SEARCH_RESULTS).map(toOption);
 					setOptions(found);
 					setStatus(
 						found.length > 0 ? { kind: "idle" } : { kind: "no-results" },
@@ -180,7 +182,7 @@ const MediaSearch = (props: {
 };
 
 /**
- * Media filter: search AniList media by title, select one, show it as a
+ * Media filter: search the catalog's media by title, select one, show it as a
  * removable chip. Holds no selection state of its own — the selection
  * lives in the URL via the parent.
  */
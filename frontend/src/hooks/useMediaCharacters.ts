@@ -1,27 +1,20 @@
 import { createEffect, createResource, on } from "solid-js";
-import { getMediaCharacters } from "../api/anilist";
 import type { Character } from "../api/generated";
-import { Type } from "../api/generated";
+import { getMediaCharacters, Type } from "../api/generated";
 import type { MediaOption } from "../components/filters/MediaFilter";
 
 const fetchCharacters = async (
 	media: MediaOption,
 ): Promise<Character[] | undefined> => {
-	const result = await getMediaCharacters(String(media.value));
+	const result = await getMediaCharacters(Number(media.value));
 	if (!result) {
 		console.error("no media characters found");
 		return undefined;
 	}
 
-	return result.map(
-		(c): Character => ({
-			id: parseInt(c.id, 10),
-			name: c.name.full,
-			image: c.image.large,
-			date: new Date().toISOString(),
-			type: Type.Roll,
-			favorites: c.favourites ?? 0,
-		}),
+	const date = new Date().toISOString();
+	return result.characters.map(
+		(c): Character => ({ ...c, date, type: Type.Roll }),
 	);
 };
 